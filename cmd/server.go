@@ -50,6 +50,7 @@ const (
 	BlockedExtraArgsFlag             = "blocked-extra-args"
 	AllowForkPRsFlag                 = "allow-fork-prs"
 	AtlantisURLFlag                  = "atlantis-url"
+	AuditLogFileFlag                 = "audit-log-file"
 	AutoDiscoverModeFlag             = "autodiscover-mode"
 	AutomergeFlag                    = "automerge"
 	AutomergeMethodFlag              = "automerge-method"
@@ -66,6 +67,7 @@ const (
 	CheckoutDepthFlag                = "checkout-depth"
 	CheckoutStrategyFlag             = "checkout-strategy"
 	ConfigFlag                       = "config"
+	CostPricingFileFlag              = "cost-pricing-file"
 	DataDirFlag                      = "data-dir"
 	DefaultTFDistributionFlag        = "default-tf-distribution"
 	DefaultTFVersionFlag             = "default-tf-version"
@@ -80,6 +82,7 @@ const (
 	DiscardApprovalOnPlanFlag        = "discard-approval-on-plan"
 	EmojiReaction                    = "emoji-reaction"
 	EnableDiffMarkdownFormat         = "enable-diff-markdown-format"
+	EnableLockQueueFlag              = "enable-lock-queue"
 	EnablePolicyChecksFlag           = "enable-policy-checks"
 	EnableRegExpCmdFlag              = "enable-regexp-cmd"
 	EnableProfilingAPI               = "enable-profiling-api"
@@ -99,6 +102,7 @@ const (
 	GHOrganizationFlag               = "gh-org"
 	GHWebhookSecretFlag              = "gh-webhook-secret"               // nolint: gosec
 	GHAllowMergeableBypassApply      = "gh-allow-mergeable-bypass-apply" // nolint: gosec
+	GHCheckRunsFlag                  = "gh-check-runs"
 	GiteaBaseURLFlag                 = "gitea-base-url"
 	GiteaTokenFlag                   = "gitea-token"
 	GiteaUserFlag                    = "gitea-user"
@@ -141,6 +145,8 @@ const (
 	SilenceVCSStatusNoProjectsFlag   = "silence-vcs-status-no-projects"
 	SilenceAllowlistErrorsFlag       = "silence-allowlist-errors"
 	SkipCloneNoChanges               = "skip-clone-no-changes"
+	SlackRemediationAllowlistFlag    = "slack-remediation-allowlist"
+	SlackSigningSecretFlag           = "slack-signing-secret"
 	SlackTokenFlag                   = "slack-token"
 	SSLCertFileFlag                  = "ssl-cert-file"
 	SSLKeyFileFlag                   = "ssl-key-file"
@@ -148,6 +154,9 @@ const (
 	TFDistributionFlag               = "tf-distribution" // deprecated for DefaultTFDistributionFlag
 	TFDownloadFlag                   = "tf-download"
 	TFDownloadURLFlag                = "tf-download-url"
+	TFPluginCacheMaxSizeFlag         = "tf-plugin-cache-max-size-mb"
+	TFProviderMirrorDirFlag          = "tf-provider-mirror-dir"
+	TracingOTLPEndpointFlag          = "tracing-otlp-endpoint"
 	UseTFPluginCache                 = "use-tf-plugin-cache"
 	VarFileAllowlistFlag             = "var-file-allowlist"
 	VCSStatusName                    = "vcs-status-name"
@@ -164,6 +173,9 @@ const (
 	WebPasswordFlag                  = "web-password"
 	EnableDriftDetectionFlag         = "enable-drift-detection"
 	EnableDriftRemediationFlag       = "enable-drift-remediation"
+	DriftRemediationWorkersFlag      = "drift-remediation-workers"
+	DriftRemediationRetentionDays    = "drift-remediation-retention-days"
+	DriftRemediationMaxResults       = "drift-remediation-max-results"
 	WebsocketCheckOrigin             = "websocket-check-origin"
 
 	// NOTE: Must manually set these as defaults in the setDefaults function.
@@ -178,6 +190,9 @@ const (
 	DefaultCheckoutDepth                = 0
 	DefaultBitbucketBaseURL             = bitbucketcloud.BaseURL
 	DefaultDataDir                      = "~/.atlantis"
+	DefaultDriftRemediationWorkers      = 2
+	DefaultDriftRemediationRetention    = 90
+	DefaultDriftRemediationMaxResults   = 1000
 	DefaultEmojiReaction                = ""
 	DefaultExecutableName               = "atlantis"
 	DefaultMarkdownTemplateOverridesDir = "~/.markdown_templates"
@@ -243,10 +258,15 @@ var stringFlags = map[string]stringFlag{
 	AtlantisURLFlag: {
 		description: "URL that Atlantis can be reached at. Defaults to http://$(hostname):$port where $port is from --" + PortFlag + ". Supports a base path ex. https://example.com/basepath.",
 	},
+	AuditLogFileFlag: {
+		description: "Path of a file to record privileged actions, such as applies, unlocks and API token use, to as JSON lines." +
+			" Required to query the audit log with GET /api/audit.",
+	},
 	AutoDiscoverModeFlag: {
 		description: "Auto discover mode controls whether projects in a repo are discovered by Atlantis. Defaults to 'auto' which " +
 			"means projects will be discovered when no explicit projects are defined in repo config. Also supports 'enabled' (always " +
-			"discover projects) and 'disabled' (never discover projects).",
+			"discover projects), 'disabled' (never discover projects) and 'terragrunt' (discover projects from terragrunt.hcl files " +
+			"and run them through terragrunt).",
 		defaultValue: DefaultAutoDiscoverMode,
 	},
 	AutomergeMethodFlag: {
@@ -301,6 +321,9 @@ var stringFlags = map[string]stringFlag{
 	ConfigFlag: {
 		description: "Path to yaml config file where flag values can also be set.",
 	},
+	CostPricingFileFlag: {
+		description: "Path to a YAML pricing table used by the cost_estimate workflow step to estimate how much a plan changes a project's monthly cost.",
+	},
 	DataDirFlag: {
 		description:  "Path to directory to store Atlantis data.",
 		defaultValue: DefaultDataDir,
@@ -458,6 +481,12 @@ var stringFlags = map[string]stringFlag{
 			"all repos: '*' (not secure), an entire hostname: 'internalgithub.com/*' or an organization: 'github.com/runatlantis/*'." +
 			" For Bitbucket Server, {owner} is the name of the project (not the key).",
 	},
+	SlackRemediationAllowlistFlag: {
+		description: "Comma separated list of Slack user IDs allowed to use the Plan fix and Apply fix buttons on drift messages.",
+	},
+	SlackSigningSecretFlag: {
+		description: "Signing secret of the Slack app. Enables Plan fix and Apply fix buttons on Slack drift messages and the /slack/interactions endpoint that receives them.",
+	},
 	SlackTokenFlag: {
 		description: "API token for Slack notifications.",
 	},
@@ -475,6 +504,14 @@ var stringFlags = map[string]stringFlag{
 		description:  "Base URL to download Terraform versions from.",
 		defaultValue: DefaultTFDownloadURL,
 	},
+	TracingOTLPEndpointFlag: {
+		description: "OTLP/HTTP endpoint to export OpenTelemetry traces of webhook and API requests to, ex. http://localhost:4318." +
+			" If not set, tracing is disabled.",
+	},
+	TFProviderMirrorDirFlag: {
+		description: "Directory of provider packages to serve over the provider network mirror protocol at /providers/. " +
+			"Use the layout written by 'terraform providers mirror'.",
+	},
 	TFEHostnameFlag: {
 		description:  "Hostname of your Terraform Enterprise installation. If using Terraform Cloud no need to set.",
 		defaultValue: DefaultTFEHostname,
@@ -566,6 +603,10 @@ var boolFlags = map[string]boolFlag{
 		description:  "Enable atlantis to run user defined policy checks.  This is explicitly disabled for TFE/TFC backends since plan files are inaccessible.",
 		defaultValue: false,
 	},
+	EnableLockQueueFlag: {
+		description:  "Queue pull requests that try to plan a project locked by another pull request. When the lock is released, the next queued pull request takes the lock and is planned again automatically.",
+		defaultValue: false,
+	},
 	EnableRegExpCmdFlag: {
 		description:  "Enable Atlantis to use regular expressions on plan/apply commands when \"-p\" flag is passed with it.",
 		defaultValue: false,
@@ -586,6 +627,11 @@ var boolFlags = map[string]boolFlag{
 		description:  "Feature flag to enable functionality to allow mergeable check to ignore apply required check",
 		defaultValue: false,
 	},
+	GHCheckRunsFlag: {
+		description: "Report each project's plan, policy check and apply as a GitHub check run instead of a commit status." +
+			" Check runs include the plan summary and policy failures, and offer Re-plan and Apply buttons. Requires --" + GHAppIDFlag + ".",
+		defaultValue: false,
+	},
 	GitlabStatusRetryEnabledFlag: {
 		description:  "Enable enhanced retry logic for GitLab pipeline status updates with exponential backoff.",
 		defaultValue: false,
@@ -712,6 +758,18 @@ var intFlags = map[string]intFlag{
 		description:  "If non-zero, the maximum number of comments to split command output into before truncating.",
 		defaultValue: DefaultMaxCommentsPerCommand,
 	},
+	DriftRemediationWorkersFlag: {
+		description:  "Max number of drift remediations executed concurrently. Additional requests are queued.",
+		defaultValue: DefaultDriftRemediationWorkers,
+	},
+	DriftRemediationRetentionDays: {
+		description:  "Number of days to keep finished drift remediation results in the locking database.",
+		defaultValue: DefaultDriftRemediationRetention,
+	},
+	DriftRemediationMaxResults: {
+		description:  "Max number of finished drift remediation results to keep per repository. Older results are removed first.",
+		defaultValue: DefaultDriftRemediationMaxResults,
+	},
 	GiteaPageSizeFlag: {
 		description:  "Optional value that specifies the number of results per page to expect from Gitea.",
 		defaultValue: DefaultGiteaPageSize,
@@ -732,6 +790,12 @@ var intFlags = map[string]intFlag{
 		description:  "The Redis Port for when using a Locking DB type of 'redis'.",
 		defaultValue: DefaultRedisPort,
 	},
+	TFPluginCacheMaxSizeFlag: {
+		description: fmt.Sprintf("Used only if --%s=true.", UseTFPluginCache) +
+			" Max size in megabytes of the provider cache. Least recently used providers are removed when it's exceeded." +
+			" 0 means unlimited.",
+		defaultValue: 0,
+	},
 }
 
 var int64Flags = map[string]int64Flag{
@@ -1001,6 +1065,15 @@ func (s *ServerCmd) setDefaults(c *server.UserConfig, v *viper.Viper) {
 	if c.BitbucketBaseURL == "" {
 		c.BitbucketBaseURL = DefaultBitbucketBaseURL
 	}
+	if c.DriftRemediationWorkers == 0 {
+		c.DriftRemediationWorkers = DefaultDriftRemediationWorkers
+	}
+	if c.DriftRemediationRetentionDays == 0 {
+		c.DriftRemediationRetentionDays = DefaultDriftRemediationRetention
+	}
+	if c.DriftRemediationMaxResults == 0 {
+		c.DriftRemediationMaxResults = DefaultDriftRemediationMaxResults
+	}
 	if c.EmojiReaction == "" {
 		c.EmojiReaction = DefaultEmojiReaction
 	}
@@ -1160,6 +1233,10 @@ func (s *ServerCmd) validate(userConfig server.UserConfig) error {
 		return fmt.Errorf("cannot use --%s and --%s at the same time", RepoConfigFlag, RepoConfigJSONFlag)
 	}
 
+	if userConfig.EnableLockQueue && userConfig.DisableRepoLocking {
+		return fmt.Errorf("cannot use --%s and --%s at the same time", EnableLockQueueFlag, DisableRepoLockingFlag)
+	}
+
 	// Warn if any tokens have newlines.
 	for name, token := range map[string]string{
 		GHTokenFlag:                userConfig.GithubToken,
@@ -1177,6 +1254,14 @@ func (s *ServerCmd) validate(userConfig server.UserConfig) error {
 		}
 	}
 
+	if userConfig.GithubCheckRuns && userConfig.GithubAppID == 0 {
+		return fmt.Errorf("if setting --%s, must set --%s", GHCheckRunsFlag, GHAppIDFlag)
+	}
+
+	if userConfig.SlackSigningSecret != "" && userConfig.SlackToken == "" {
+		return fmt.Errorf("if setting --%s, must set --%s", SlackSigningSecretFlag, SlackTokenFlag)
+	}
+
 	if userConfig.TFEHostname != DefaultTFEHostname && userConfig.TFEToken == "" {
 		return fmt.Errorf("if setting --%s, must set --%s", TFEHostnameFlag, TFETokenFlag)
 	}
@@ -1206,6 +1291,10 @@ func (s *ServerCmd) validate(userConfig server.UserConfig) error {
 		return fmt.Errorf("invalid --%s: %w", WebhookHttpHeaders, err)
 	}
 
+	if userConfig.TFPluginCacheMaxSizeMB < 0 {
+		return fmt.Errorf("--%s cannot be negative", TFPluginCacheMaxSizeFlag)
+	}
+
 	return nil
 }
 
@@ -54,6 +54,7 @@ var testFlags = map[string]any{
 	ADWebhookPasswordFlag:            "ad-wh-pass",
 	ADWebhookUserFlag:                "ad-wh-user",
 	AtlantisURLFlag:                  "url",
+	AuditLogFileFlag:                 "/path/to/audit.log",
 	AutoplanModules:                  false,
 	AutoplanModulesFromProjects:      "",
 	AllowCommandsFlag:                "version,plan,apply,unlock,import,approve_policies",
@@ -70,6 +71,7 @@ var testFlags = map[string]any{
 	BitbucketUserFlag:                "bitbucket-user",
 	BitbucketWebhookSecretFlag:       "bitbucket-secret",
 	CheckoutStrategyFlag:             CheckoutStrategyMerge,
+	CostPricingFileFlag:              "pricing.yaml",
 	CheckoutDepthFlag:                0,
 	DataDirFlag:                      "/path",
 	DefaultTFDistributionFlag:        "terraform",
@@ -83,6 +85,7 @@ var testFlags = map[string]any{
 	ExecutableName:                   "atlantis",
 	FailOnPreWorkflowHookError:       false,
 	GHAllowMergeableBypassApply:      false,
+	GHCheckRunsFlag:                  false,
 	GHHostnameFlag:                   "ghhostname",
 	GHTeamAllowlistFlag:              "",
 	GHTokenFlag:                      "token",
@@ -141,6 +144,8 @@ var testFlags = map[string]any{
 	SilenceAllowlistErrorsFlag:       true,
 	SilenceVCSStatusNoPlans:          true,
 	SkipCloneNoChanges:               true,
+	SlackRemediationAllowlistFlag:    "U012AB3CD,U045EF6GH",
+	SlackSigningSecretFlag:           "slack-signing-secret",
 	SlackTokenFlag:                   "slack-token",
 	SSLCertFileFlag:                  "cert-file",
 	SSLKeyFileFlag:                   "key-file",
@@ -148,6 +153,9 @@ var testFlags = map[string]any{
 	TFDistributionFlag:               "terraform",
 	TFDownloadFlag:                   true,
 	TFDownloadURLFlag:                "https://my-hostname.com",
+	TFPluginCacheMaxSizeFlag:         1024,
+	TFProviderMirrorDirFlag:          "/path/to/mirror",
+	TracingOTLPEndpointFlag:          "http://localhost:4318",
 	TFEHostnameFlag:                  "my-hostname",
 	TFELocalExecutionModeFlag:        true,
 	TFETokenFlag:                     "my-token",
@@ -167,9 +175,13 @@ var testFlags = map[string]any{
 	DisableUnlockLabelFlag:           "do-not-unlock",
 	EnablePolicyChecksFlag:           false,
 	EnableRegExpCmdFlag:              false,
+	EnableLockQueueFlag:              false,
 	EnableDiffMarkdownFormat:         false,
 	EnableDriftDetectionFlag:         true,
 	EnableDriftRemediationFlag:       true,
+	DriftRemediationWorkersFlag:      4,
+	DriftRemediationRetentionDays:    30,
+	DriftRemediationMaxResults:       50,
 	EnableProfilingAPI:               false,
 }
 
@@ -1128,6 +1140,19 @@ func TestExecute_RepoCfgFlags(t *testing.T) {
 	ErrEquals(t, "cannot use --repo-config and --repo-config-json at the same time", err)
 }
 
+// Can't queue for locks when repo locking is disabled.
+func TestExecute_LockQueueWithoutRepoLocking(t *testing.T) {
+	c := setup(map[string]any{
+		GHUserFlag:             "user",
+		GHTokenFlag:            "token",
+		RepoAllowlistFlag:      "github.com",
+		EnableLockQueueFlag:    true,
+		DisableRepoLockingFlag: true,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "cannot use --enable-lock-queue and --disable-repo-locking at the same time", err)
+}
+
 // Can't use both --tfe-hostname flag without --tfe-token.
 func TestExecute_TFEHostnameOnly(t *testing.T) {
 	c := setup(map[string]any{
@@ -1140,6 +1165,28 @@ func TestExecute_TFEHostnameOnly(t *testing.T) {
 	ErrEquals(t, "if setting --tfe-hostname, must set --tfe-token", err)
 }
 
+func TestExecute_GHCheckRunsWithoutApp(t *testing.T) {
+	c := setup(map[string]any{
+		GHUserFlag:        "user",
+		GHTokenFlag:       "token",
+		RepoAllowlistFlag: "github.com",
+		GHCheckRunsFlag:   true,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "if setting --gh-check-runs, must set --gh-app-id", err)
+}
+
+func TestExecute_TFPluginCacheMaxSizeNegative(t *testing.T) {
+	c := setup(map[string]any{
+		GHUserFlag:               "user",
+		GHTokenFlag:              "token",
+		RepoAllowlistFlag:        "github.com",
+		TFPluginCacheMaxSizeFlag: -1,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--tf-plugin-cache-max-size-mb cannot be negative", err)
+}
+
 // Must set allow or whitelist.
 func TestExecute_AllowAndWhitelist(t *testing.T) {
 	c := setup(map[string]any{
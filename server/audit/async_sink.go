@@ -0,0 +1,84 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// AsyncSinkBufferSize is how many events an AsyncSink holds while they wait
+// to be sent.
+const AsyncSinkBufferSize = 1000
+
+// AsyncSink writes events to another sink in the background, so that a slow
+// sink, such as a webhook, doesn't hold up the action being audited. If its
+// buffer is full, events are dropped and Write returns an error.
+type AsyncSink struct {
+	sink   Sink
+	logger logging.SimpleLogging
+	events chan Event
+	done   chan struct{}
+	// mu guards closed so that Write never sends on a closed channel.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncSink returns a sink that writes events to sink in the background,
+// holding up to bufferSize events while they wait.
+func NewAsyncSink(logger logging.SimpleLogging, sink Sink, bufferSize int) *AsyncSink {
+	a := &AsyncSink{
+		sink:   sink,
+		logger: logger,
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *AsyncSink) run() {
+	defer close(a.done)
+	for event := range a.events {
+		if err := a.sink.Write(event); err != nil {
+			a.logger.Err("writing %s audit event: %s", event.Action, err)
+		}
+	}
+}
+
+// Write queues event to be sent. It returns an error if the event was
+// dropped.
+func (a *AsyncSink) Write(event Event) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return fmt.Errorf("dropping %s audit event: sink is closed", event.Action)
+	}
+	select {
+	case a.events <- event:
+		return nil
+	default:
+		return fmt.Errorf("dropping %s audit event: %d events are already waiting to be sent", event.Action, cap(a.events))
+	}
+}
+
+// Close stops accepting events and waits until the queued events are sent or
+// ctx is done.
+func (a *AsyncSink) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.events)
+	}
+	a.mu.Unlock()
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("sending queued audit events: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+// blockingSink records events, waiting on release before each write.
+type blockingSink struct {
+	release chan struct{}
+	mu      sync.Mutex
+	events  []audit.Event
+}
+
+func (b *blockingSink) Write(event audit.Event) error {
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func TestAsyncSink_CloseSendsQueuedEvents(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	close(sink.release)
+	l := audit.NewLog(logging.NewNoopLogger(t), audit.NewAsyncSink(logging.NewNoopLogger(t), sink, 10))
+
+	l.Record(audit.Event{Action: audit.ActionPlan})
+	l.Record(audit.Event{Action: audit.ActionApply})
+	Ok(t, l.Close(context.Background()))
+
+	Equals(t, 2, len(sink.events))
+	Equals(t, audit.ActionPlan, sink.events[0].Action)
+	Equals(t, audit.ActionApply, sink.events[1].Action)
+}
+
+func TestAsyncSink_WriteDoesNotBlock(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	a := audit.NewAsyncSink(logging.NewNoopLogger(t), sink, 1)
+
+	// The first event is taken by the writer and blocks it, the second fills
+	// the buffer. Which one is pending doesn't matter: the buffer holds one.
+	Ok(t, a.Write(audit.Event{Action: audit.ActionPlan}))
+	var dropped int
+	for range 2 {
+		if err := a.Write(audit.Event{Action: audit.ActionApply}); err != nil {
+			ErrContains(t, "events are already waiting to be sent", err)
+			dropped++
+		}
+	}
+	Assert(t, dropped > 0, "expected an event to be dropped")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ErrContains(t, "sending queued audit events", a.Close(ctx))
+
+	close(sink.release)
+	Ok(t, a.Close(context.Background()))
+	ErrContains(t, "sink is closed", a.Write(audit.Event{Action: audit.ActionPlan}))
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit records privileged actions, such as applies, unlocks and
+// global apply lock changes, as a stream of structured events.
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// Action is a privileged action recorded in the audit log.
+type Action string
+
+const (
+	ActionPlan            Action = "plan"
+	ActionApply           Action = "apply"
+	ActionApprovePolicies Action = "approve_policies"
+	ActionImport          Action = "import"
+	ActionStateRm         Action = "state_rm"
+	// ActionUnlock is the release of a project lock or of every lock held by
+	// a pull request.
+	ActionUnlock Action = "unlock"
+	// ActionApplyLock and ActionApplyUnlock toggle the global apply lock.
+	ActionApplyLock   Action = "apply_lock"
+	ActionApplyUnlock Action = "apply_unlock"
+	// ActionDriftRemediation is a request to remediate drift.
+	ActionDriftRemediation Action = "drift_remediation"
+	// ActionDriftRemediationCancel is a request to cancel a remediation.
+	ActionDriftRemediationCancel Action = "drift_remediation_cancel"
+	// ActionAPIToken is a request authenticated with the API secret, an API
+	// token or an OIDC token, or one that failed to authenticate.
+	ActionAPIToken Action = "api_token"
+)
+
+// Outcome is how a recorded action ended.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure means the action ran but didn't succeed, e.g. an apply
+	// requirement wasn't met.
+	OutcomeFailure Outcome = "failure"
+	// OutcomeError means the action errored.
+	OutcomeError Outcome = "error"
+	// OutcomeDenied means the caller wasn't allowed to take the action.
+	OutcomeDenied Outcome = "denied"
+)
+
+// Sources record how an action was requested.
+const (
+	SourceComment  = "comment"
+	SourceAutoplan = "autoplan"
+	SourceAPI      = "api"
+	SourceUI       = "ui"
+	SourceSlack    = "slack"
+)
+
+// Event is a single privileged action.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Action Action    `json:"action"`
+	// Actor is the VCS user, API token or OIDC subject that took the action.
+	Actor string `json:"actor"`
+	// Source is how the action was requested, e.g. SourceComment.
+	Source     string  `json:"source"`
+	Repo       string  `json:"repo,omitempty"`
+	Pull       int     `json:"pull,omitempty"`
+	Project    string  `json:"project,omitempty"`
+	Dir        string  `json:"dir,omitempty"`
+	Workspace  string  `json:"workspace,omitempty"`
+	CommitSHA  string  `json:"commit_sha,omitempty"`
+	PlanDigest string  `json:"plan_digest,omitempty"`
+	Outcome    Outcome `json:"outcome"`
+	// Error describes why the action failed, errored or was denied.
+	Error string `json:"error,omitempty"`
+	// Details has context specific to the action, e.g. the lock ID for
+	// ActionUnlock or the request path for ActionAPIToken.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Sink stores or forwards audit events.
+type Sink interface {
+	Write(event Event) error
+}
+
+// Querier is a Sink whose events can be read back.
+type Querier interface {
+	// Query returns the events matching q, newest first.
+	Query(q Query) ([]Event, error)
+}
+
+// DefaultQueryLimit is how many events Query returns if no limit is given.
+const DefaultQueryLimit = 100
+
+// MaxQueryLimit is the most events Query returns.
+const MaxQueryLimit = 1000
+
+// Query filters audit events. Empty fields match every event.
+type Query struct {
+	Action  Action
+	Actor   string
+	Repo    string
+	Pull    int
+	Project string
+	Outcome Outcome
+	Since   time.Time
+	Until   time.Time
+	// Limit is the most events to return, newest first.
+	Limit int
+}
+
+// Matches returns true if event matches every filter set in q.
+func (q Query) Matches(event Event) bool {
+	switch {
+	case q.Action != "" && event.Action != q.Action:
+		return false
+	case q.Actor != "" && event.Actor != q.Actor:
+		return false
+	case q.Repo != "" && event.Repo != q.Repo:
+		return false
+	case q.Pull != 0 && event.Pull != q.Pull:
+		return false
+	case q.Project != "" && event.Project != q.Project:
+		return false
+	case q.Outcome != "" && event.Outcome != q.Outcome:
+		return false
+	case !q.Since.IsZero() && event.Time.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && event.Time.After(q.Until):
+		return false
+	}
+	return true
+}
+
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return min(q.Limit, MaxQueryLimit)
+}
+
+// ErrNotQueryable is returned by Log.Query if none of its sinks can be
+// queried.
+var ErrNotQueryable = errors.New("no audit sink supports queries")
+
+// Log sends audit events to its sinks. A nil *Log discards events, so
+// callers don't need to check whether auditing is enabled.
+type Log struct {
+	Sinks  []Sink
+	Logger logging.SimpleLogging
+}
+
+// NewLog returns a Log that writes to sinks, or nil if there are none.
+func NewLog(logger logging.SimpleLogging, sinks ...Sink) *Log {
+	if len(sinks) == 0 {
+		return nil
+	}
+	return &Log{Sinks: sinks, Logger: logger}
+}
+
+// Record sends event to every sink, setting its time if it isn't set.
+// Errors are logged rather than returned so that a sink being unavailable
+// doesn't block the action being audited.
+func (l *Log) Record(event Event) {
+	if l == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	for _, s := range l.Sinks {
+		if err := s.Write(event); err != nil {
+			l.Logger.Err("writing %s audit event: %s", event.Action, err)
+		}
+	}
+}
+
+// Close waits for sinks that send events in the background, such as
+// AsyncSink, to send the events they hold, until ctx is done.
+func (l *Log) Close(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	var errs []error
+	for _, s := range l.Sinks {
+		if closer, ok := s.(interface{ Close(context.Context) error }); ok {
+			errs = append(errs, closer.Close(ctx))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Query returns the events matching q from the first sink that supports
+// queries.
+func (l *Log) Query(q Query) ([]Event, error) {
+	if l != nil {
+		for _, s := range l.Sinks {
+			if querier, ok := s.(Querier); ok {
+				return querier.Query(q)
+			}
+		}
+	}
+	return nil, ErrNotQueryable
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestNilLog(t *testing.T) {
+	var l *audit.Log
+	Assert(t, audit.NewLog(logging.NewNoopLogger(t)) == nil, "expected nil log without sinks")
+	l.Record(audit.Event{Action: audit.ActionApply})
+	_, err := l.Query(audit.Query{})
+	Assert(t, errors.Is(err, audit.ErrNotQueryable), "expected ErrNotQueryable, got %v", err)
+}
+
+func TestFileSink_Query(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.log")
+	sink, err := audit.NewFileSink(path)
+	Ok(t, err)
+	l := audit.NewLog(logging.NewNoopLogger(t), sink)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, e := range []audit.Event{
+		{Action: audit.ActionPlan, Actor: "alice", Repo: "owner/repo", Pull: 1, Outcome: audit.OutcomeSuccess},
+		{Action: audit.ActionApply, Actor: "alice", Repo: "owner/repo", Pull: 1, Outcome: audit.OutcomeError},
+		{Action: audit.ActionApply, Actor: "bob", Repo: "owner/repo", Pull: 2, Outcome: audit.OutcomeSuccess},
+		{Action: audit.ActionUnlock, Actor: "bob", Repo: "owner/other", Pull: 3, Outcome: audit.OutcomeSuccess},
+	} {
+		e.Time = start.Add(time.Duration(i) * time.Hour)
+		l.Record(e)
+	}
+	// A partially written line is skipped.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	Ok(t, err)
+	_, err = f.WriteString("{\"action\":\n")
+	Ok(t, err)
+	Ok(t, f.Close())
+
+	events, err := l.Query(audit.Query{})
+	Ok(t, err)
+	Equals(t, 4, len(events))
+	Equals(t, audit.ActionUnlock, events[0].Action)
+
+	events, err = l.Query(audit.Query{Action: audit.ActionApply})
+	Ok(t, err)
+	Equals(t, 2, len(events))
+	Equals(t, "bob", events[0].Actor)
+	Equals(t, "alice", events[1].Actor)
+
+	events, err = l.Query(audit.Query{Repo: "owner/repo", Outcome: audit.OutcomeSuccess})
+	Ok(t, err)
+	Equals(t, 2, len(events))
+
+	events, err = l.Query(audit.Query{Since: start.Add(time.Hour), Until: start.Add(2 * time.Hour)})
+	Ok(t, err)
+	Equals(t, 2, len(events))
+	Equals(t, 2, events[0].Pull)
+	Equals(t, 1, events[1].Pull)
+
+	events, err = l.Query(audit.Query{Limit: 1})
+	Ok(t, err)
+	Equals(t, 1, len(events))
+	Equals(t, 3, events[0].Pull)
+}
+
+func TestLog_RecordSetsTime(t *testing.T) {
+	sink, err := audit.NewFileSink(filepath.Join(t.TempDir(), "audit.log"))
+	Ok(t, err)
+	l := audit.NewLog(logging.NewNoopLogger(t), sink)
+
+	before := time.Now().UTC()
+	l.Record(audit.Event{Action: audit.ActionApplyLock, Actor: "ui"})
+	events, err := l.Query(audit.Query{})
+	Ok(t, err)
+	Equals(t, 1, len(events))
+	Assert(t, !events[0].Time.Before(before.Truncate(time.Second)), "expected time to be set")
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// maxEventSize is the longest line FileSink reads back. Events are small, so
+// this only guards against a corrupt file.
+const maxEventSize = 1024 * 1024
+
+// FileSink appends events to a file as JSON lines and supports queries by
+// scanning it.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink creates path's directory and the file if they don't exist and
+// returns a sink that appends to it.
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("creating audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	return &FileSink{path: path}, nil
+}
+
+func (f *FileSink) Write(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding audit event: %w", err)
+	}
+	data = append(data, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close() // nolint: errcheck
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return file.Close()
+}
+
+// Query scans the whole file, so it gets slower as the file grows. Rotate
+// the file with an external tool if that matters.
+func (f *FileSink) Query(q Query) ([]Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer file.Close()
+
+	// Keep the newest limit matches as the file is read oldest first.
+	limit := q.limit()
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// Skip lines that were partially written, e.g. when the disk
+			// filled up.
+			continue
+		}
+		if !q.Matches(event) {
+			continue
+		}
+		if len(events) == limit {
+			events = events[1:]
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	slices.Reverse(events)
+	return events, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/audit"
+)
+
+// ListAuditEvents handles GET /api/audit requests. It lists audit events,
+// newest first.
+// Query parameters, all optional:
+//   - action: only list events for this action, e.g. apply
+//   - actor: only list events taken by this user or API caller
+//   - repository: only list events for this repository (owner/repo)
+//   - pr: only list events for this pull request
+//   - project: only list events for this project
+//   - outcome: only list events with this outcome, e.g. denied
+//   - since, until: only list events in this time range (RFC 3339)
+//   - limit: maximum number of events to return (default: 100, max: 1000)
+//
+// This is an authenticated endpoint that requires the audit:read scope.
+func (a *APIController) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	if middleware.RequireScope(w, r, APIScopeAuditRead) == nil {
+		return
+	}
+
+	params := r.URL.Query()
+	q := audit.Query{
+		Action:  audit.Action(params.Get("action")),
+		Actor:   params.Get("actor"),
+		Repo:    params.Get("repository"),
+		Project: params.Get("project"),
+		Outcome: audit.Outcome(params.Get("outcome")),
+	}
+	for _, param := range []struct {
+		name string
+		dest *int
+	}{{"pr", &q.Pull}, {"limit", &q.Limit}} {
+		value := params.Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || parsed <= 0 {
+			responder.ValidationFailed(w, r, fmt.Sprintf("invalid %s parameter", param.name),
+				ValidationError{Field: param.name, Message: "must be a positive integer"})
+			return
+		}
+		*param.dest = parsed
+	}
+	for _, param := range []struct {
+		name string
+		dest *time.Time
+	}{{"since", &q.Since}, {"until", &q.Until}} {
+		value := params.Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			responder.ValidationFailed(w, r, fmt.Sprintf("invalid %s parameter", param.name),
+				ValidationError{Field: param.name, Message: "must be an RFC 3339 timestamp"})
+			return
+		}
+		*param.dest = parsed
+	}
+
+	events, err := a.AuditLog.Query(q)
+	if errors.Is(err, audit.ErrNotQueryable) {
+		responder.ServiceUnavailable(w, r, "querying the audit log requires --audit-log-file")
+		return
+	}
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("querying audit log: %w", err))
+		return
+	}
+	responder.Success(w, r, http.StatusOK, NewAuditEventListAPI(events))
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/core/locking"
+	lockingmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// setupAudit returns an API controller that records to an audit log file.
+func setupAudit(t *testing.T) *controllers.APIController {
+	ac, _, _ := setup(t)
+	sink, err := audit.NewFileSink(filepath.Join(t.TempDir(), "audit.log"))
+	Ok(t, err)
+	ac.AuditLog = audit.NewLog(ac.Logger, sink)
+	ac.APITokens = []*controllers.APIToken{
+		newTestAPIToken(t, "auditor", "auditor-secret", "*", "audit:read"),
+		newTestAPIToken(t, "ops", "ops-secret", "*", "apply-lock:write"),
+	}
+	return ac
+}
+
+func TestAPIController_ListAuditEvents(t *testing.T) {
+	ac := setupAudit(t)
+	applyLocker := lockingmocks.NewMockApplyLocker(gomock.NewController(t))
+	ac.ApplyLocker = applyLocker
+	applyLocker.EXPECT().LockApply().Return(locking.ApplyCommandLock{Locked: true, Time: time.Now()}, nil)
+
+	w := httptest.NewRecorder()
+	ac.LockApply(w, lockRequest("POST", url.Values{}, "ops-secret"))
+	Equals(t, http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	ac.LockApply(w, lockRequest("POST", url.Values{}, "wrong-secret"))
+	Equals(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{"action": {"apply_lock"}}, "auditor-secret"))
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.AuditEventListAPI
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, 1, result.TotalCount)
+	Equals(t, audit.ActionApplyLock, result.Events[0].Action)
+	Equals(t, "ops", result.Events[0].Actor)
+	Equals(t, audit.SourceAPI, result.Events[0].Source)
+	Equals(t, audit.OutcomeSuccess, result.Events[0].Outcome)
+
+	// Authentication attempts are recorded, including the failed one.
+	w = httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{"action": {"api_token"}, "outcome": {"denied"}}, "auditor-secret"))
+	Equals(t, http.StatusOK, w.Code)
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, 1, result.TotalCount)
+	Equals(t, "", result.Events[0].Actor)
+	Equals(t, "invalid or missing API token", result.Events[0].Error)
+	Equals(t, "apply-lock:write", result.Events[0].Details["scope"])
+
+	w = httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{"action": {"api_token"}, "limit": {"2"}}, "auditor-secret"))
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, 2, result.TotalCount)
+	// Newest first: this request, then the previous query.
+	Equals(t, "auditor", result.Events[0].Actor)
+	Equals(t, "GET", result.Events[0].Details["method"])
+	Equals(t, "audit:read", result.Events[0].Details["scope"])
+	Assert(t, !result.Events[0].Time.Before(result.Events[1].Time), "expected newest event first")
+}
+
+func TestAPIController_ListAuditEventsErrors(t *testing.T) {
+	ac := setupAudit(t)
+
+	w := httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{}, "ops-secret"))
+	ResponseContains(t, w, http.StatusForbidden, `is missing the \"audit:read\" scope`)
+
+	w = httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{"since": {"yesterday"}}, "auditor-secret"))
+	ResponseContains(t, w, http.StatusBadRequest, "must be an RFC 3339 timestamp")
+
+	w = httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{"pr": {"-1"}}, "auditor-secret"))
+	ResponseContains(t, w, http.StatusBadRequest, "must be a positive integer")
+
+	ac.AuditLog = nil
+	w = httptest.NewRecorder()
+	ac.ListAuditEvents(w, lockRequest("GET", url.Values{}, "auditor-secret"))
+	ResponseContains(t, w, http.StatusServiceUnavailable, "requires --audit-log-file")
+}
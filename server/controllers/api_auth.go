@@ -0,0 +1,293 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// APIScope is a permission that can be granted to an API token.
+type APIScope string
+
+const (
+	// APIScopePlan allows POST /api/plan.
+	APIScopePlan APIScope = "plan"
+	// APIScopeApply allows POST /api/apply.
+	APIScopeApply APIScope = "apply"
+	// APIScopeDriftRead allows reading drift status and remediation results.
+	APIScopeDriftRead APIScope = "drift:read"
+	// APIScopeDriftDetect allows POST /api/drift/detect.
+	APIScopeDriftDetect APIScope = "drift:detect"
+	// APIScopeDriftRemediate allows starting and cancelling drift remediations.
+	APIScopeDriftRemediate APIScope = "drift:remediate"
+	// APIScopeLocksRead allows listing and reading locks.
+	APIScopeLocksRead APIScope = "locks:read"
+	// APIScopeLocksWrite allows deleting locks.
+	APIScopeLocksWrite APIScope = "locks:write"
+	// APIScopeApplyLockWrite allows toggling the global apply lock, which
+	// affects every repository.
+	APIScopeApplyLockWrite APIScope = "apply-lock:write"
+	// APIScopePoliciesRead allows reading policy exceptions.
+	APIScopePoliciesRead APIScope = "policies:read"
+	// APIScopeAuditRead allows reading the audit log, which covers every
+	// repository.
+	APIScopeAuditRead APIScope = "audit:read"
+	// APIScopeCacheRead allows reading provider cache and mirror stats.
+	APIScopeCacheRead APIScope = "cache:read"
+)
+
+// APIScopes lists every scope an API token can be granted.
+var APIScopes = []APIScope{
+	APIScopePlan,
+	APIScopeApply,
+	APIScopeDriftRead,
+	APIScopeDriftDetect,
+	APIScopeDriftRemediate,
+	APIScopeLocksRead,
+	APIScopeLocksWrite,
+	APIScopeApplyLockWrite,
+	APIScopePoliciesRead,
+	APIScopeAuditRead,
+	APIScopeCacheRead,
+}
+
+// Authentication methods recorded on models.APICaller.
+const (
+	apiSecretAuthMethod = "api_secret"
+	apiTokenAuthMethod  = "api_token"
+)
+
+// apiGrant is what a caller authenticated by an API token or OIDC token may
+// do.
+type apiGrant struct {
+	scopes []APIScope
+	repos  *events.RepoAllowlistChecker
+	// refs restricts the refs the caller may plan and apply. Nil allows
+	// every ref.
+	refs *regexp.Regexp
+}
+
+// newAPIGrant validates scopes and the repos pattern of the credential
+// described by owner.
+func newAPIGrant(owner string, scopes []string, repos string) (apiGrant, error) {
+	if len(scopes) == 0 {
+		return apiGrant{}, fmt.Errorf("%s: at least one scope is required", owner)
+	}
+	var grant apiGrant
+	for _, s := range scopes {
+		scope := APIScope(strings.TrimSpace(s))
+		if !slices.Contains(APIScopes, scope) {
+			return apiGrant{}, fmt.Errorf("%s: unknown scope %q", owner, s)
+		}
+		grant.scopes = append(grant.scopes, scope)
+	}
+	if strings.TrimSpace(repos) == "" {
+		return apiGrant{}, fmt.Errorf("%s: repos must not be empty", owner)
+	}
+	var err error
+	grant.repos, err = events.NewRepoAllowlistChecker(repos)
+	if err != nil {
+		return apiGrant{}, fmt.Errorf("%s: parsing repos: %w", owner, err)
+	}
+	return grant, nil
+}
+
+// APIToken is a named API token limited to a set of scopes and repositories.
+// Only the SHA-256 digest of the token is kept.
+type APIToken struct {
+	Name string
+	hash []byte
+	apiGrant
+}
+
+// NewAPIToken returns a token named name whose secret hashes to tokenSHA256,
+// a hex encoded SHA-256 digest. repos is a pattern in the --repo-allowlist
+// format restricting which repositories the token can act on.
+func NewAPIToken(name string, tokenSHA256 string, scopes []string, repos string) (*APIToken, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("API token name must not be empty")
+	}
+	hash, err := hex.DecodeString(strings.TrimSpace(tokenSHA256))
+	if err != nil || len(hash) != sha256.Size {
+		return nil, fmt.Errorf("API token %q: token-sha256 must be a hex encoded SHA-256 digest", name)
+	}
+	grant, err := newAPIGrant(fmt.Sprintf("API token %q", name), scopes, repos)
+	if err != nil {
+		return nil, err
+	}
+	return &APIToken{Name: name, hash: hash, apiGrant: grant}, nil
+}
+
+// ValidateAPITokens returns an error if two tokens share a name or a secret.
+func ValidateAPITokens(tokens []*APIToken) error {
+	names := make(map[string]struct{}, len(tokens))
+	hashes := make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		if _, ok := names[t.Name]; ok {
+			return fmt.Errorf("API token name %q is used more than once", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if _, ok := hashes[string(t.hash)]; ok {
+			return fmt.Errorf("API token %q has the same token-sha256 as another token", t.Name)
+		}
+		hashes[string(t.hash)] = struct{}{}
+	}
+	return nil
+}
+
+// HasScope returns true if the credential was granted scope.
+func (g apiGrant) HasScope(scope APIScope) bool {
+	return slices.Contains(g.scopes, scope)
+}
+
+// APIPrincipal is an authenticated API caller.
+type APIPrincipal struct {
+	// Caller identifies the principal on remediation results.
+	Caller *models.APICaller
+	// grant is nil when the caller used the shared API secret, which grants
+	// every scope on every allowlisted repository.
+	grant *apiGrant
+}
+
+// HasScope returns true if the principal may use endpoints requiring scope.
+func (p *APIPrincipal) HasScope(scope APIScope) bool {
+	return p.grant == nil || p.grant.HasScope(scope)
+}
+
+// CanAccessRepo returns true if the principal may act on repo. It doesn't
+// replace the server's --repo-allowlist check.
+func (p *APIPrincipal) CanAccessRepo(repo models.Repo) bool {
+	return p.CanAccessRepoName(repo.FullName, repo.VCSHost.Hostname)
+}
+
+// CanAccessRepoName is CanAccessRepo for callers that only have the
+// repository's name and host.
+func (p *APIPrincipal) CanAccessRepoName(repoFullName string, vcsHostname string) bool {
+	return p.grant == nil || p.grant.repos.IsAllowlisted(repoFullName, vcsHostname)
+}
+
+// CanUseRef returns true if the principal may run commands against ref.
+func (p *APIPrincipal) CanUseRef(ref string) bool {
+	return p.grant == nil || p.grant.refs == nil || p.grant.refs.MatchString(ref)
+}
+
+// String describes the principal in error messages.
+func (p *APIPrincipal) String() string {
+	if p.Caller.AuthMethod == oidcAuthMethod {
+		return fmt.Sprintf("OIDC token %q", p.Caller.Name)
+	}
+	return fmt.Sprintf("API token %q", p.Caller.Name)
+}
+
+// User returns the user commands run on behalf of the principal are
+// attributed to, e.g. on locks.
+func (p *APIPrincipal) User() models.User {
+	return models.User{Username: p.Caller.Name}
+}
+
+// authenticate identifies the caller of r from the X-Atlantis-Token header,
+// or an OIDC bearer token if OIDC issuers are configured, and checks that it
+// was granted scope. Each attempt is recorded in the audit log.
+func (m *APIMiddleware) authenticate(r *http.Request, scope APIScope) (*APIPrincipal, int, *APIError) {
+	if !m.Enabled() {
+		return nil, http.StatusServiceUnavailable, NewAPIError(ErrCodeServiceUnavailable, "API is disabled")
+	}
+
+	var principal *APIPrincipal
+	if bearer, ok := bearerToken(r); ok && len(m.OIDCIssuers) > 0 {
+		var code int
+		var apiErr *APIError
+		principal, code, apiErr = m.oidcPrincipal(r, bearer)
+		if apiErr != nil {
+			m.recordAuthentication(r, scope, nil, apiErr)
+			return nil, code, apiErr
+		}
+	} else {
+		principal = m.principal(r, r.Header.Get(atlantisTokenHeader))
+		if principal == nil {
+			apiErr := NewAPIError(ErrCodeUnauthorized, "invalid or missing API token")
+			m.recordAuthentication(r, scope, nil, apiErr)
+			return nil, http.StatusUnauthorized, apiErr
+		}
+	}
+	if !principal.HasScope(scope) {
+		apiErr := NewAPIError(ErrCodeForbidden,
+			fmt.Sprintf("%s is missing the %q scope", principal, scope))
+		m.recordAuthentication(r, scope, principal, apiErr)
+		return nil, http.StatusForbidden, apiErr
+	}
+	m.recordAuthentication(r, scope, principal, nil)
+	return principal, http.StatusOK, nil
+}
+
+// recordAuthentication records an API request's authentication in the audit
+// log. principal is nil if the caller couldn't be identified.
+func (m *APIMiddleware) recordAuthentication(r *http.Request, scope APIScope, principal *APIPrincipal, apiErr *APIError) {
+	event := audit.Event{
+		Action:  audit.ActionAPIToken,
+		Source:  audit.SourceAPI,
+		Outcome: audit.OutcomeSuccess,
+		Details: map[string]string{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"scope":       string(scope),
+			"remote_addr": r.RemoteAddr,
+		},
+	}
+	if principal != nil {
+		event.Actor = principal.Caller.Name
+		event.Details["auth_method"] = principal.Caller.AuthMethod
+	}
+	if apiErr != nil {
+		event.Outcome = audit.OutcomeDenied
+		event.Error = apiErr.Message
+	}
+	m.AuditLog.Record(event)
+}
+
+// apiAuditEvent fills in the caller and outcome of an audit event for an
+// action taken through the API.
+func apiAuditEvent(principal *APIPrincipal, event audit.Event, err error) audit.Event {
+	event.Actor = principal.Caller.Name
+	event.Source = audit.SourceAPI
+	return auditEvent(event, err)
+}
+
+// principal returns the caller presenting token, or nil if it matches neither
+// the API secret nor a configured token. Comparisons are constant-time to
+// prevent timing attacks.
+func (m *APIMiddleware) principal(r *http.Request, token string) *APIPrincipal {
+	if len(m.APISecret) > 0 && subtle.ConstantTimeCompare([]byte(token), m.APISecret) == 1 {
+		return &APIPrincipal{Caller: m.Caller(r)}
+	}
+	if token == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(token))
+	for _, t := range m.APITokens {
+		if subtle.ConstantTimeCompare(sum[:], t.hash) == 1 {
+			return &APIPrincipal{
+				Caller: &models.APICaller{
+					Name:       t.Name,
+					AuthMethod: apiTokenAuthMethod,
+					RemoteAddr: r.RemoteAddr,
+				},
+				grant: &t.apiGrant,
+			}
+		}
+	}
+	return nil
+}
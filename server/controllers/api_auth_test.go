@@ -0,0 +1,235 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	driftmocks "github.com/runatlantis/atlantis/server/core/drift/mocks"
+	. "github.com/runatlantis/atlantis/server/core/locking/mocks"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newTestAPIToken(t *testing.T, name string, secret string, repos string, scopes ...string) *controllers.APIToken {
+	t.Helper()
+	sum := sha256.Sum256([]byte(secret))
+	token, err := controllers.NewAPIToken(name, hex.EncodeToString(sum[:]), scopes, repos)
+	Ok(t, err)
+	return token
+}
+
+func TestNewAPIToken(t *testing.T) {
+	sum := sha256.Sum256([]byte("secret"))
+	validHash := hex.EncodeToString(sum[:])
+	cases := []struct {
+		description string
+		name        string
+		hash        string
+		scopes      []string
+		repos       string
+		expErr      string
+	}{
+		{
+			description: "valid",
+			name:        "ci",
+			hash:        validHash,
+			scopes:      []string{"plan", "drift:read"},
+			repos:       "github.com/owner/*",
+		},
+		{
+			description: "missing name",
+			hash:        validHash,
+			scopes:      []string{"plan"},
+			repos:       "*",
+			expErr:      "API token name must not be empty",
+		},
+		{
+			description: "plain text token",
+			name:        "ci",
+			hash:        "secret",
+			scopes:      []string{"plan"},
+			repos:       "*",
+			expErr:      `API token "ci": token-sha256 must be a hex encoded SHA-256 digest`,
+		},
+		{
+			description: "no scopes",
+			name:        "ci",
+			hash:        validHash,
+			repos:       "*",
+			expErr:      `API token "ci": at least one scope is required`,
+		},
+		{
+			description: "unknown scope",
+			name:        "ci",
+			hash:        validHash,
+			scopes:      []string{"plan", "admin"},
+			repos:       "*",
+			expErr:      `API token "ci": unknown scope "admin"`,
+		},
+		{
+			description: "missing repos",
+			name:        "ci",
+			hash:        validHash,
+			scopes:      []string{"plan"},
+			expErr:      `API token "ci": repos must not be empty`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			token, err := controllers.NewAPIToken(c.name, c.hash, c.scopes, c.repos)
+			if c.expErr != "" {
+				ErrEquals(t, c.expErr, err)
+				return
+			}
+			Ok(t, err)
+			Equals(t, c.name, token.Name)
+		})
+	}
+}
+
+func TestValidateAPITokens(t *testing.T) {
+	ci := newTestAPIToken(t, "ci", "secret1", "*", "plan")
+	Ok(t, controllers.ValidateAPITokens([]*controllers.APIToken{ci, newTestAPIToken(t, "drift", "secret2", "*", "drift:read")}))
+	ErrEquals(t, `API token name "ci" is used more than once`,
+		controllers.ValidateAPITokens([]*controllers.APIToken{ci, newTestAPIToken(t, "ci", "secret2", "*", "plan")}))
+	ErrEquals(t, `API token "drift" has the same token-sha256 as another token`,
+		controllers.ValidateAPITokens([]*controllers.APIToken{ci, newTestAPIToken(t, "drift", "secret1", "*", "plan")}))
+}
+
+func TestAPIController_PlanWithAPITokenRunsAsToken(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	ac.APISecret = nil
+	ac.APITokens = []*controllers.APIToken{newTestAPIToken(t, "ci-pipeline", "ci-secret", "gitlab.com/owner/*", "plan")}
+
+	body, _ := json.Marshal(controllers.APIRequest{Repository: "owner/repo", Ref: "main", Type: "Gitlab", Projects: []string{"default"}})
+	req, _ := http.NewRequest("POST", "", bytes.NewBuffer(body))
+	req.Header.Set(atlantisTokenHeader, "ci-secret")
+	w := httptest.NewRecorder()
+	ac.Plan(w, req)
+
+	ResponseContains(t, w, http.StatusOK, "")
+	ctx, _ := projectCommandBuilder.VerifyWasCalledOnce().BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]()).GetCapturedArguments()
+	Equals(t, models.User{Username: "ci-pipeline"}, ctx.User)
+}
+
+func TestAPIController_APITokenScopes(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	ac.APITokens = []*controllers.APIToken{newTestAPIToken(t, "ci-pipeline", "ci-secret", "*", "plan")}
+
+	cases := []struct {
+		description string
+		token       string
+		expCode     int
+		expBody     string
+	}{
+		{
+			description: "missing scope",
+			token:       "ci-secret",
+			expCode:     http.StatusForbidden,
+			expBody:     `API token \"ci-pipeline\" is missing the \"apply\" scope`,
+		},
+		{
+			description: "unknown token",
+			token:       "other-secret",
+			expCode:     http.StatusUnauthorized,
+			expBody:     "did not match expected secret",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			body, _ := json.Marshal(controllers.APIRequest{Repository: "owner/repo", Ref: "main", Type: "Gitlab", Projects: []string{"default"}})
+			req, _ := http.NewRequest("POST", "", bytes.NewBuffer(body))
+			req.Header.Set(atlantisTokenHeader, c.token)
+			w := httptest.NewRecorder()
+			ac.Apply(w, req)
+			ResponseContains(t, w, c.expCode, c.expBody)
+		})
+	}
+	projectCommandBuilder.VerifyWasCalled(Never()).BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())
+}
+
+func TestAPIController_APITokenRepoNotAllowed(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	ac.APITokens = []*controllers.APIToken{newTestAPIToken(t, "team-a", "team-a-secret", "github.com/team-a/*", "plan", "drift:read")}
+	driftStorage := driftmocks.NewMockStorage()
+	ac.DriftStorage = driftStorage
+
+	body, _ := json.Marshal(controllers.APIRequest{Repository: "team-b/repo", Ref: "main", Type: "Github", Projects: []string{"default"}})
+	req, _ := http.NewRequest("POST", "", bytes.NewBuffer(body))
+	req.Header.Set(atlantisTokenHeader, "team-a-secret")
+	w := httptest.NewRecorder()
+	ac.Plan(w, req)
+	ResponseContains(t, w, http.StatusForbidden, `API token \"team-a\" is not allowed to access repo team-b/repo`)
+	projectCommandBuilder.VerifyWasCalled(Never()).BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())
+
+	req, _ = http.NewRequest("GET", "?repository=team-b/repo&type=Github", nil)
+	req.Header.Set(atlantisTokenHeader, "team-a-secret")
+	w = httptest.NewRecorder()
+	ac.DriftStatus(w, req)
+	Equals(t, http.StatusForbidden, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	Equals(t, controllers.ErrCodeForbidden, parseAPIError(t, response).Code)
+	driftStorage.VerifyWasCalled(Never()).Get(Any[string](), Any[drift.GetOptions]())
+}
+
+func TestAPIController_ListLocksWithAPITokens(t *testing.T) {
+	ac, _, _ := setup(t)
+	ac.APITokens = []*controllers.APIToken{
+		newTestAPIToken(t, "team-a", "team-a-secret", "github.com/team-a/*", "locks:read"),
+		newTestAPIToken(t, "planner", "planner-secret", "*", "plan"),
+	}
+	githubRepo := models.Repo{VCSHost: models.VCSHost{Hostname: "github.com"}}
+	ac.Locker.(*MockLocker).EXPECT().List().Return(map[string]models.ProjectLock{
+		"a": {Project: models.Project{RepoFullName: "team-a/repo"}, Pull: models.PullRequest{BaseRepo: githubRepo}},
+		"b": {Project: models.Project{RepoFullName: "team-b/repo"}, Pull: models.PullRequest{BaseRepo: githubRepo}},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "", nil)
+	w := httptest.NewRecorder()
+	ac.ListLocks(w, req)
+	Equals(t, http.StatusUnauthorized, w.Code)
+
+	req.Header.Set(atlantisTokenHeader, "planner-secret")
+	w = httptest.NewRecorder()
+	ac.ListLocks(w, req)
+	Equals(t, http.StatusForbidden, w.Code)
+
+	req.Header.Set(atlantisTokenHeader, "team-a-secret")
+	w = httptest.NewRecorder()
+	ac.ListLocks(w, req)
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.ListLocksResult
+	Ok(t, json.NewDecoder(w.Body).Decode(&result))
+	Equals(t, 1, len(result.Locks))
+	Equals(t, "a", result.Locks[0].Name)
+}
+
+func TestAPIController_APISecretStillGrantsEveryScope(t *testing.T) {
+	ac, _, _ := setup(t)
+	ac.APITokens = []*controllers.APIToken{newTestAPIToken(t, "team-a", "team-a-secret", "github.com/team-a/*", "plan")}
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Get(Any[string](), Any[drift.GetOptions]())).ThenReturn(nil, nil)
+	ac.DriftStorage = driftStorage
+
+	req, _ := http.NewRequest("GET", "?repository=team-b/repo&type=Github", nil)
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.DriftStatus(w, req)
+	Equals(t, http.StatusOK, w.Code)
+	Assert(t, strings.Contains(w.Body.String(), `"repository":"team-b/repo"`), "unexpected body: %s", w.Body.String())
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
+)
+
+// GetCacheStats handles GET /api/cache requests. It reports the provider
+// cache's size and hit rate, and how many requests the provider network
+// mirror has served.
+//
+// This is an authenticated endpoint that requires the cache:read scope.
+func (a *APIController) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	if middleware.RequireScope(w, r, APIScopeCacheRead) == nil {
+		return
+	}
+
+	if a.ProviderCache == nil && a.ProviderMirror == nil {
+		responder.ServiceUnavailable(w, r, "the provider cache and provider mirror are disabled")
+		return
+	}
+
+	var cacheStats *providercache.Stats
+	if a.ProviderCache != nil {
+		stats, err := a.ProviderCache.Stats()
+		if err != nil {
+			responder.InternalError(w, r, fmt.Errorf("reading provider cache stats: %w", err))
+			return
+		}
+		cacheStats = &stats
+	}
+	var mirrorStats *providercache.MirrorStats
+	if a.ProviderMirror != nil {
+		stats := a.ProviderMirror.Stats()
+		mirrorStats = &stats
+	}
+	responder.Success(w, r, http.StatusOK, NewCacheStatsAPI(cacheStats, mirrorStats))
+}
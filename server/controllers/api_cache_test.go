@@ -0,0 +1,55 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestAPIController_GetCacheStats(t *testing.T) {
+	ac, _, _ := setup(t)
+	ac.APITokens = []*controllers.APIToken{
+		newTestAPIToken(t, "monitoring", "monitoring-secret", "*", "cache:read"),
+		newTestAPIToken(t, "ops", "ops-secret", "*", "apply-lock:write"),
+	}
+
+	w := httptest.NewRecorder()
+	ac.GetCacheStats(w, lockRequest("GET", url.Values{}, "ops-secret"))
+	ResponseContains(t, w, http.StatusForbidden, `is missing the \"cache:read\" scope`)
+
+	w = httptest.NewRecorder()
+	ac.GetCacheStats(w, lockRequest("GET", url.Values{}, "monitoring-secret"))
+	ResponseContains(t, w, http.StatusServiceUnavailable, "disabled")
+
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+	cache.SetMaxSize(1024 * 1024)
+	ac.ProviderCache = cache
+	w = httptest.NewRecorder()
+	ac.GetCacheStats(w, lockRequest("GET", url.Values{}, "monitoring-secret"))
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.CacheStatsAPI
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Assert(t, result.ProviderCache != nil, "expected provider cache stats")
+	Equals(t, int64(1024*1024), result.ProviderCache.MaxSizeBytes)
+	Equals(t, 0, result.ProviderCache.Entries)
+	Assert(t, result.ProviderMirror == nil, "expected no provider mirror stats")
+
+	mirror := providercache.NewMirror(t.TempDir())
+	mirror.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/registry.terraform.io/hashicorp/null/index.json", nil))
+	ac.ProviderMirror = mirror
+	w = httptest.NewRecorder()
+	ac.GetCacheStats(w, lockRequest("GET", url.Values{}, "monitoring-secret"))
+	Equals(t, http.StatusOK, w.Code)
+	result = controllers.CacheStatsAPI{}
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, &controllers.ProviderMirrorStatsAPI{Requests: 1, NotFound: 1}, result.ProviderMirror)
+}
@@ -4,7 +4,6 @@
 package controllers
 
 import (
-	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,25 +22,35 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/drift"
 	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
 	"github.com/runatlantis/atlantis/server/events"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/vcs"
 	"github.com/runatlantis/atlantis/server/events/webhooks"
 	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
 	tally "github.com/uber-go/tally/v4"
 )
 
 const atlantisTokenHeader = "X-Atlantis-Token"
+const atlantisUserHeader = "X-Atlantis-User"
 
 var nonPRPullCounter atomic.Int64
 
 var apiErrorURLCredentialRE = regexp.MustCompile(`(?i)(https?://)([^\s/@]+:)?[^\s/@]+@`)
 
 type APIController struct {
-	APISecret                       []byte
+	APISecret []byte
+	// APITokens are named API tokens with limited scopes and repositories.
+	// They are accepted alongside APISecret.
+	APITokens []*APIToken
+	// OIDCIssuers accept OIDC tokens from CI jobs in place of APISecret.
+	OIDCIssuers                     []*OIDCIssuer
 	Locker                          locking.Locker `validate:"required"`
 	DriftStorage                    drift.Storage
 	RemediationService              drift.RemediationService
@@ -74,11 +83,32 @@ type APIController struct {
 	// LivePullHeadFetcher is optional for tests. In production it is used for
 	// PR-backed API requests to seed live PR identity data such as base branch.
 	LivePullHeadFetcher events.LivePullHeadFetcher
+	// DriftMetrics counts drift detection runs. Nil when drift detection is
+	// disabled.
+	DriftMetrics *drift.Metrics
 	// DriftWebhookSender sends webhook notifications when drift is detected.
 	// Nil when no drift webhooks are configured.
 	DriftWebhookSender *webhooks.DriftWebhookSender
 	// SilenceVCSStatusNoProjects is whether API should set commit status if no projects are found
 	SilenceVCSStatusNoProjects bool
+	// DeleteLockCommand releases locks for the lock endpoints. Nil disables
+	// deleting locks through the API.
+	DeleteLockCommand events.DeleteLockCommand
+	// Database records discarded plans when locks are deleted and stores the
+	// policy exceptions listed by the policy exceptions endpoint.
+	Database db.Database
+	// ApplyLocker manages the global apply lock. Nil disables the apply lock
+	// endpoints.
+	ApplyLocker locking.ApplyLocker
+	// AuditLog records API authentication, lock and drift remediation
+	// actions and is queried by the audit endpoint. It may be nil.
+	AuditLog *audit.Log
+	// ProviderCache is the Terraform provider cache reported by the cache
+	// endpoint. Nil when --use-tf-plugin-cache is false.
+	ProviderCache *providercache.Cache
+	// ProviderMirror is the provider network mirror reported by the cache
+	// endpoint. Nil when --tf-provider-mirror-dir isn't set.
+	ProviderMirror *providercache.Mirror
 
 	// apiMiddleware provides common authentication and response utilities.
 	// Initialized lazily via getAPIMiddleware() with sync.Once for thread safety.
@@ -97,7 +127,7 @@ type driftFullDetectionLockKey struct {
 // getAPIMiddleware returns the APIMiddleware, initializing it lazily with sync.Once.
 func (a *APIController) getAPIMiddleware() *APIMiddleware {
 	a.apiMiddlewareOnce.Do(func() {
-		a.apiMiddleware = NewAPIMiddleware(a.APISecret, a.Logger)
+		a.apiMiddleware = NewAPIMiddleware(a.APISecret, a.APITokens, a.OIDCIssuers, a.AuditLog, a.Logger)
 	})
 	return a.apiMiddleware
 }
@@ -279,10 +309,14 @@ func sanitizeRepoCloneURL(message string, repo models.Repo) string {
 }
 
 func (a *APIController) Plan(w http.ResponseWriter, r *http.Request) {
+	traceCtx, span := tracing.StartRequest(r, "APIController.Plan")
+	defer span.End()
+	r = r.WithContext(traceCtx)
+
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
-	request, ctx, code, err := a.apiParseAndValidate(r)
+	request, ctx, code, err := a.apiParseAndValidate(r, APIScopePlan)
 	if err != nil {
 		a.apiReportLegacyError(w, code, err)
 		return
@@ -312,10 +346,14 @@ func (a *APIController) Plan(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *APIController) Apply(w http.ResponseWriter, r *http.Request) {
+	traceCtx, span := tracing.StartRequest(r, "APIController.Apply")
+	defer span.End()
+	r = r.WithContext(traceCtx)
+
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
-	request, ctx, code, err := a.apiParseAndValidate(r)
+	request, ctx, code, err := a.apiParseAndValidate(r, APIScopeApply)
 	if err != nil {
 		a.apiReportLegacyError(w, code, err)
 		return
@@ -379,10 +417,21 @@ type ListLocksResult struct {
 	Locks []LockDetail
 }
 
+// ListLocks returns every project lock. It is unauthenticated unless API or
+// OIDC tokens are configured, in which case callers need the locks:read scope
+// and only see locks on repositories they can access.
 func (a *APIController) ListLocks(w http.ResponseWriter, r *http.Request) {
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
+	principal := &APIPrincipal{}
+	if middleware.Scoped() {
+		principal = middleware.RequireScope(w, r, APIScopeLocksRead)
+		if principal == nil {
+			return
+		}
+	}
+
 	locks, err := a.Locker.List()
 	if err != nil {
 		a.apiReportLegacyError(w, http.StatusInternalServerError, err)
@@ -391,6 +440,9 @@ func (a *APIController) ListLocks(w http.ResponseWriter, r *http.Request) {
 
 	result := ListLocksResult{}
 	for name, lock := range locks {
+		if !principal.CanAccessRepoName(lock.Project.RepoFullName, lock.Pull.BaseRepo.VCSHost.Hostname) {
+			continue
+		}
 		result.Locks = append(result.Locks, LockDetail{
 			Name:            name,
 			ProjectName:     lock.Project.ProjectName,
@@ -408,7 +460,7 @@ func (a *APIController) ListLocks(w http.ResponseWriter, r *http.Request) {
 }
 
 // DriftStatus returns cached drift detection results for a repository.
-// This is an authenticated endpoint that requires the API secret.
+// This is an authenticated endpoint that requires the API secret or an API token.
 // Query parameters:
 //   - repository: required, the full repository name (owner/repo)
 //   - type: required, the VCS provider type
@@ -421,7 +473,8 @@ func (a *APIController) DriftStatus(w http.ResponseWriter, r *http.Request) {
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
-	if !middleware.RequireAuth(w, r) {
+	principal := middleware.RequireScope(w, r, APIScopeDriftRead)
+	if principal == nil {
 		return
 	}
 
@@ -474,6 +527,10 @@ func (a *APIController) DriftStatus(w http.ResponseWriter, r *http.Request) {
 		responder.Forbidden(w, r, "repository is not in the allowlist")
 		return
 	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return
+	}
 
 	pathFilter := r.URL.Query().Get("path")
 	if pathFilter != "" {
@@ -923,12 +980,16 @@ func seedPullStatusFromPlanResult(ctx *command.Context, result *command.Result)
 		}
 		switch projectResult.Command {
 		case command.Plan:
+			planDigest, planCommit := projectResult.PlanIntegrity()
 			upsertProjectStatus(ctx.PullStatus, models.ProjectStatus{
 				Workspace:    projectResult.Workspace,
 				RepoRelDir:   projectResult.RepoRelDir,
 				ProjectName:  projectResult.ProjectName,
 				PolicyStatus: projectResult.PolicyStatus(),
 				Status:       projectResult.PlanStatus(),
+				PlanDigest:   planDigest,
+				PlanCommit:   planCommit,
+				CostEstimate: projectResult.PlanCostEstimate(),
 			})
 		case command.PolicyCheck, command.ApprovePolicies:
 			upsertProjectPolicyStatus(ctx.PullStatus, projectResult)
@@ -976,6 +1037,9 @@ func upsertProjectStatus(pullStatus *models.PullStatus, status models.ProjectSta
 			status.ProjectName == project.ProjectName {
 			project.Status = status.Status
 			project.PolicyStatus = mergePolicyStatuses(project.PolicyStatus, status.PolicyStatus)
+			project.PlanDigest = status.PlanDigest
+			project.PlanCommit = status.PlanCommit
+			project.CostEstimate = status.CostEstimate
 			return
 		}
 	}
@@ -1005,15 +1069,19 @@ func mergePolicyStatuses(existing []models.PolicySetStatus, incoming []models.Po
 	return existing
 }
 
-func (a *APIController) apiParseAndValidate(r *http.Request) (*APIRequest, *command.Context, int, error) {
-	if len(a.APISecret) == 0 {
-		return nil, nil, http.StatusServiceUnavailable, fmt.Errorf("ignoring request since API is disabled")
-	}
-
-	// Validate the secret token using constant-time comparison to prevent timing attacks
-	secret := r.Header.Get(atlantisTokenHeader)
-	if subtle.ConstantTimeCompare([]byte(secret), a.APISecret) != 1 {
-		return nil, nil, http.StatusUnauthorized, fmt.Errorf("header %s did not match expected secret", atlantisTokenHeader)
+func (a *APIController) apiParseAndValidate(r *http.Request, scope APIScope) (*APIRequest, *command.Context, int, error) {
+	principal, code, apiErr := a.getAPIMiddleware().authenticate(r, scope)
+	switch code {
+	case http.StatusOK:
+	case http.StatusServiceUnavailable:
+		return nil, nil, code, fmt.Errorf("ignoring request since API is disabled")
+	case http.StatusUnauthorized:
+		if _, ok := bearerToken(r); ok && len(a.OIDCIssuers) > 0 {
+			return nil, nil, code, errors.New(apiErr.Message)
+		}
+		return nil, nil, code, fmt.Errorf("header %s did not match expected secret", atlantisTokenHeader)
+	default:
+		return nil, nil, code, errors.New(apiErr.Message)
 	}
 
 	// Parse the JSON payload
@@ -1033,7 +1101,8 @@ func (a *APIController) apiParseAndValidate(r *http.Request) (*APIRequest, *comm
 	if err != nil {
 		return nil, nil, http.StatusBadRequest, err
 	}
-	cloneURL, err := a.VCSClient.GetCloneURL(a.Logger, VCSHostType, request.Repository)
+	logger := tracing.Logger(a.Logger, r.Context())
+	cloneURL, err := a.VCSClient.GetCloneURL(logger, VCSHostType, request.Repository)
 	if err != nil {
 		return nil, nil, http.StatusInternalServerError, err
 	}
@@ -1047,6 +1116,12 @@ func (a *APIController) apiParseAndValidate(r *http.Request) (*APIRequest, *comm
 	if !a.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
 		return nil, nil, http.StatusForbidden, fmt.Errorf("repo not allowlisted")
 	}
+	if !principal.CanAccessRepo(baseRepo) {
+		return nil, nil, http.StatusForbidden, fmt.Errorf("%s is not allowed to access repo %s", principal, baseRepo.FullName)
+	}
+	if !principal.CanUseRef(request.Ref) {
+		return nil, nil, http.StatusForbidden, fmt.Errorf("%s is not allowed to use ref %s", principal, request.Ref)
+	}
 
 	pullNum := request.PR
 	syntheticNonPR := request.PR <= 0
@@ -1067,10 +1142,11 @@ func (a *APIController) apiParseAndValidate(r *http.Request) (*APIRequest, *comm
 		HardenedNonPRRefCheckout: syntheticNonPR,
 	}
 	ctx := &command.Context{
+		User:                      principal.User(),
 		HeadRepo:                  baseRepo,
 		Pull:                      pull,
 		Scope:                     a.Scope,
-		Log:                       a.Logger,
+		Log:                       logger,
 		API:                       true,
 		SkipPRModifiedFiles:       syntheticNonPR,
 		SkipPRRequirements:        syntheticNonPR,
@@ -1115,13 +1191,14 @@ func (a *APIController) populatePullStatus(ctx *command.Context) {
 
 // Remediate handles POST /api/drift/remediate requests.
 // It executes drift remediation (plan or apply) for the specified projects.
-// This is an authenticated endpoint that requires the API secret.
+// This is an authenticated endpoint that requires the API secret or an API token.
 func (a *APIController) Remediate(w http.ResponseWriter, r *http.Request) {
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
 	// Authenticate
-	if !middleware.RequireAuth(w, r) {
+	principal := middleware.RequireScope(w, r, APIScopeDriftRemediate)
+	if principal == nil {
 		return
 	}
 
@@ -1184,22 +1261,38 @@ func (a *APIController) Remediate(w http.ResponseWriter, r *http.Request) {
 		responder.Forbidden(w, r, "repository is not in the allowlist")
 		return
 	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return
+	}
+	if !principal.CanUseRef(request.Ref) {
+		responder.Forbidden(w, r, "caller is not allowed to use this ref")
+		return
+	}
 	executionRef := request.Ref
 	request.Ref = apiRequestStorageRef(request.Ref)
 	request.ExecutionRef = executionRef
 	request.BaseBranch = apiRequestBaseBranch(executionRef, request.BaseBranch)
 	request.StorageRepository = baseRepo.ID()
+	request.RequestedBy = principal.Caller
 
 	// Create executor that bridges to existing plan/apply infrastructure
 	executor := &apiRemediationExecutor{
 		controller: a,
 		baseRepo:   baseRepo,
 		baseBranch: request.BaseBranch,
+		user:       principal.User(),
 		logger:     a.Logger,
 	}
 
-	// Execute remediation
-	result, err := a.RemediationService.Remediate(request, executor)
+	// Queue remediation. The service runs it in the background, so the
+	// response normally reports a running status and the client polls
+	// GET /api/drift/remediate/{id} for progress.
+	result, err := a.remediate(request, executor)
+	if errors.Is(err, drift.ErrRemediationQueueFull) {
+		responder.ServiceUnavailable(w, r, err.Error())
+		return
+	}
 	if err != nil {
 		responder.InternalError(w, r, err)
 		return
@@ -1209,6 +1302,8 @@ func (a *APIController) Remediate(w http.ResponseWriter, r *http.Request) {
 	apiResult := NewRemediationResultAPI(result)
 
 	switch result.Status {
+	case models.RemediationStatusPending, models.RemediationStatusRunning:
+		responder.Success(w, r, http.StatusAccepted, apiResult)
 	case models.RemediationStatusFailed:
 		if len(result.Projects) == 0 && result.Error != "" {
 			responder.InternalError(w, r, errors.New(result.Error))
@@ -1222,13 +1317,94 @@ func (a *APIController) Remediate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// RunRemediation validates request and queues it on the remediation service
+// like POST /api/drift/remediate, for callers outside the HTTP API such as
+// Slack interactions. request.RequestedBy should identify the caller.
+func (a *APIController) RunRemediation(request models.RemediationRequest) (*models.RemediationResult, error) {
+	if a.RemediationService == nil {
+		return nil, errors.New("drift remediation is not enabled")
+	}
+	if validationErrors := request.Validate(); len(validationErrors) > 0 {
+		return nil, fmt.Errorf("invalid remediation request: %s: %s", validationErrors[0].Field, validationErrors[0].Message)
+	}
+	request.ApplyDefaults()
+	if request.Action == models.RemediationAutoApply && !a.EnableDriftRemediation {
+		return nil, errors.New("drift remediation apply is not enabled")
+	}
+	VCSHostType, err := models.NewVCSHostType(request.Type)
+	if err != nil {
+		return nil, err
+	}
+	cloneURL, err := a.VCSClient.GetCloneURL(a.Logger, VCSHostType, request.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clone URL: %w", err)
+	}
+	baseRepo, err := a.Parser.ParseAPIPlanRequest(VCSHostType, request.Repository, cloneURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repository: %w", err)
+	}
+	if !a.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
+		return nil, fmt.Errorf("repository %s is not in the allowlist", baseRepo.FullName)
+	}
+	executionRef := request.Ref
+	request.Ref = apiRequestStorageRef(request.Ref)
+	request.ExecutionRef = executionRef
+	request.BaseBranch = apiRequestBaseBranch(executionRef, request.BaseBranch)
+	request.StorageRepository = baseRepo.ID()
+
+	executor := &apiRemediationExecutor{
+		controller: a,
+		baseRepo:   baseRepo,
+		baseBranch: request.BaseBranch,
+		logger:     a.Logger,
+	}
+	if request.RequestedBy != nil {
+		executor.user = models.User{Username: request.RequestedBy.Name}
+	}
+	return a.remediate(request, executor)
+}
+
+// remediate queues request on the remediation service and records it in the
+// audit log.
+func (a *APIController) remediate(request models.RemediationRequest, executor drift.RemediationExecutor) (*models.RemediationResult, error) {
+	result, err := a.RemediationService.Remediate(request, executor)
+	event := auditEvent(audit.Event{
+		Action: audit.ActionDriftRemediation,
+		Source: audit.SourceAPI,
+		Repo:   request.Repository,
+		Details: map[string]string{
+			"action": string(request.Action),
+			"ref":    request.ExecutionRef,
+		},
+	}, err)
+	if request.RequestedBy != nil {
+		event.Actor = request.RequestedBy.Name
+		switch request.RequestedBy.AuthMethod {
+		case "slack":
+			event.Source = audit.SourceSlack
+		case "web":
+			event.Source = audit.SourceUI
+		}
+	}
+	if len(request.Projects) > 0 {
+		event.Details["projects"] = strings.Join(request.Projects, ",")
+	}
+	if result != nil {
+		event.Details["remediation_id"] = result.ID
+	}
+	a.AuditLog.Record(event)
+	return result, err
+}
+
 // apiRemediationExecutor implements drift.RemediationExecutor using the API controller's
 // existing plan/apply infrastructure.
 type apiRemediationExecutor struct {
 	controller *APIController
 	baseRepo   models.Repo
 	baseBranch string
-	logger     logging.SimpleLogging
+	// user is who commands are run on behalf of, e.g. on locks.
+	user   models.User
+	logger logging.SimpleLogging
 }
 
 // ExecutePlan runs a plan for the given project using the API infrastructure.
@@ -1261,6 +1437,7 @@ func (e *apiRemediationExecutor) ExecutePlan(repository, ref, vcsType, projectNa
 			BaseRepo:                 e.baseRepo,
 			HardenedNonPRRefCheckout: true,
 		},
+		User:                      e.user,
 		Scope:                     e.controller.Scope,
 		Log:                       e.logger,
 		API:                       true,
@@ -1336,6 +1513,7 @@ func (e *apiRemediationExecutor) ExecuteApplyProjects(repository, ref, vcsType s
 			BaseRepo:                 e.baseRepo,
 			HardenedNonPRRefCheckout: true,
 		},
+		User:                      e.user,
 		Scope:                     e.controller.Scope,
 		Log:                       e.logger,
 		API:                       true,
@@ -1430,6 +1608,7 @@ func (e *apiRemediationExecutor) ExecuteApply(repository, ref, vcsType, projectN
 			BaseRepo:                 e.baseRepo,
 			HardenedNonPRRefCheckout: true,
 		},
+		User:                      e.user,
 		Scope:                     e.controller.Scope,
 		Log:                       e.logger,
 		API:                       true,
@@ -1715,13 +1894,14 @@ func applyProjectRemediationOutput(result command.ProjectResult) string {
 
 // GetRemediationResult handles GET /api/drift/remediate/{id} requests.
 // It retrieves a specific remediation result by ID.
-// This is an authenticated endpoint that requires the API secret.
+// This is an authenticated endpoint that requires the API secret or an API token.
 func (a *APIController) GetRemediationResult(w http.ResponseWriter, r *http.Request) {
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
 	// Authenticate
-	if !middleware.RequireAuth(w, r) {
+	principal := middleware.RequireScope(w, r, APIScopeDriftRead)
+	if principal == nil {
 		return
 	}
 
@@ -1731,6 +1911,87 @@ func (a *APIController) GetRemediationResult(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	id, baseRepo, repository, ok := a.parseRemediationResultRequest(w, r, principal)
+	if !ok {
+		return
+	}
+
+	// Get the result only after the requested repository has been authorized.
+	result, err := a.RemediationService.GetResult(id)
+	if err != nil {
+		responder.NotFound(w, r, fmt.Sprintf("remediation result not found: %v", err))
+		return
+	}
+	if !remediationResultMatchesRepo(result, baseRepo.ID(), repository) {
+		responder.NotFound(w, r, "remediation result not found")
+		return
+	}
+
+	// Convert to API DTO and return
+	apiResult := NewRemediationResultAPI(result)
+	responder.Success(w, r, http.StatusOK, apiResult)
+}
+
+// CancelRemediation handles DELETE /api/drift/remediate/{id} requests.
+// It cancels a queued or running remediation. Projects that have not started
+// are skipped; a project that is already executing runs to completion.
+// This is an authenticated endpoint that requires the API secret or an API token.
+func (a *APIController) CancelRemediation(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	// Authenticate
+	principal := middleware.RequireScope(w, r, APIScopeDriftRemediate)
+	if principal == nil {
+		return
+	}
+
+	// Check if remediation service is configured
+	if a.RemediationService == nil {
+		responder.ServiceUnavailable(w, r, "drift remediation is not enabled")
+		return
+	}
+
+	id, baseRepo, repository, ok := a.parseRemediationResultRequest(w, r, principal)
+	if !ok {
+		return
+	}
+
+	// Look the result up first so a caller can only cancel remediations for
+	// a repository they have been authorized for.
+	result, err := a.RemediationService.GetResult(id)
+	if err != nil || !remediationResultMatchesRepo(result, baseRepo.ID(), repository) {
+		responder.NotFound(w, r, "remediation result not found")
+		return
+	}
+
+	result, err = a.RemediationService.Cancel(id)
+	a.AuditLog.Record(apiAuditEvent(principal, audit.Event{
+		Action:  audit.ActionDriftRemediationCancel,
+		Repo:    baseRepo.FullName,
+		Details: map[string]string{"remediation_id": id},
+	}, err))
+	switch {
+	case errors.Is(err, drift.ErrRemediationNotFound):
+		responder.NotFound(w, r, "remediation result not found")
+		return
+	case errors.Is(err, drift.ErrRemediationNotCancellable):
+		responder.Error(w, r, http.StatusConflict, NewAPIError(ErrCodeConflict, err.Error()))
+		return
+	case err != nil:
+		responder.InternalError(w, r, err)
+		return
+	}
+
+	responder.Success(w, r, http.StatusOK, NewRemediationResultAPI(result))
+}
+
+// parseRemediationResultRequest extracts the remediation ID and the
+// allowlisted repository from a /api/drift/remediate/{id} request. It writes
+// an error response and returns false if the request is invalid.
+func (a *APIController) parseRemediationResultRequest(w http.ResponseWriter, r *http.Request, principal *APIPrincipal) (string, models.Repo, string, bool) {
+	responder := a.getAPIMiddleware().Responder
+
 	// Get the ID from the gorilla/mux path variable.
 	// Route registered as /api/drift/remediate/{id}.
 	id := mux.Vars(r)["id"]
@@ -1742,43 +2003,29 @@ func (a *APIController) GetRemediationResult(w http.ResponseWriter, r *http.Requ
 	if id == "" {
 		responder.ValidationFailed(w, r, "missing required parameter",
 			ValidationError{Field: "id", Message: "id parameter is required"})
-		return
+		return "", models.Repo{}, "", false
 	}
 
 	repository := r.URL.Query().Get("repository")
 	if repository == "" {
 		responder.ValidationFailed(w, r, "missing required parameter",
 			ValidationError{Field: "repository", Message: "repository parameter is required"})
-		return
+		return "", models.Repo{}, "", false
 	}
 	vcsType := r.URL.Query().Get("type")
 	if vcsType == "" {
 		responder.ValidationFailed(w, r, "missing required parameter",
 			ValidationError{Field: "type", Message: "type parameter is required"})
-		return
+		return "", models.Repo{}, "", false
 	}
-	baseRepo, ok := a.parseAllowlistedRepo(w, r, repository, vcsType)
+	baseRepo, ok := a.parseAllowlistedRepo(w, r, principal, repository, vcsType)
 	if !ok {
-		return
-	}
-
-	// Get the result only after the requested repository has been authorized.
-	result, err := a.RemediationService.GetResult(id)
-	if err != nil {
-		responder.NotFound(w, r, fmt.Sprintf("remediation result not found: %v", err))
-		return
+		return "", models.Repo{}, "", false
 	}
-	if !remediationResultMatchesRepo(result, baseRepo.ID(), repository) {
-		responder.NotFound(w, r, "remediation result not found")
-		return
-	}
-
-	// Convert to API DTO and return
-	apiResult := NewRemediationResultAPI(result)
-	responder.Success(w, r, http.StatusOK, apiResult)
+	return id, baseRepo, repository, true
 }
 
-func (a *APIController) parseAllowlistedRepo(w http.ResponseWriter, r *http.Request, repository, vcsType string) (models.Repo, bool) {
+func (a *APIController) parseAllowlistedRepo(w http.ResponseWriter, r *http.Request, principal *APIPrincipal, repository, vcsType string) (models.Repo, bool) {
 	responder := a.getAPIMiddleware().Responder
 	VCSHostType, err := models.NewVCSHostType(vcsType)
 	if err != nil {
@@ -1810,6 +2057,10 @@ func (a *APIController) parseAllowlistedRepo(w http.ResponseWriter, r *http.Requ
 		responder.Forbidden(w, r, "repository is not in the allowlist")
 		return models.Repo{}, false
 	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return models.Repo{}, false
+	}
 	return baseRepo, true
 }
 
@@ -1827,13 +2078,14 @@ func remediationResultMatchesRepo(result *models.RemediationResult, storageRepos
 //   - type: required, the VCS provider type
 //   - limit: optional, maximum number of results to return (default: 10)
 //
-// This is an authenticated endpoint that requires the API secret.
+// This is an authenticated endpoint that requires the API secret or an API token.
 func (a *APIController) ListRemediationResults(w http.ResponseWriter, r *http.Request) {
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
 	// Authenticate
-	if !middleware.RequireAuth(w, r) {
+	principal := middleware.RequireScope(w, r, APIScopeDriftRead)
+	if principal == nil {
 		return
 	}
 
@@ -1886,6 +2138,10 @@ func (a *APIController) ListRemediationResults(w http.ResponseWriter, r *http.Re
 		responder.Forbidden(w, r, "repository is not in the allowlist")
 		return
 	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return
+	}
 
 	// Parse limit (default: 10)
 	limit := 10
@@ -1903,16 +2159,48 @@ func (a *APIController) ListRemediationResults(w http.ResponseWriter, r *http.Re
 		limit = min(parsedLimit, 100)
 	}
 
+	opts := drift.RemediationListOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+		Status: models.RemediationStatus(r.URL.Query().Get("status")),
+	}
+	if opts.Status != "" && !opts.Status.IsValid() {
+		responder.ValidationFailed(w, r, "invalid status parameter",
+			ValidationError{Field: "status", Message: "must be one of: pending, running, success, failed, partial, cancelled"})
+		return
+	}
+	for _, param := range []struct {
+		name string
+		dest *time.Time
+	}{{"since", &opts.StartedAfter}, {"until", &opts.StartedBefore}} {
+		value := r.URL.Query().Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			responder.ValidationFailed(w, r, fmt.Sprintf("invalid %s parameter", param.name),
+				ValidationError{Field: param.name, Message: "must be an RFC 3339 timestamp"})
+			return
+		}
+		*param.dest = parsed
+	}
+
 	// Get results
-	results, err := a.RemediationService.ListResults(baseRepo.ID(), limit)
+	page, err := a.RemediationService.ListResults(baseRepo.ID(), opts)
+	if errors.Is(err, drift.ErrInvalidRemediationCursor) {
+		responder.ValidationFailed(w, r, "invalid cursor parameter",
+			ValidationError{Field: "cursor", Message: err.Error()})
+		return
+	}
 	if err != nil {
 		responder.InternalError(w, r, err)
 		return
 	}
 
 	// Convert to API DTOs
-	apiResults := make([]RemediationResultAPI, 0, len(results))
-	for _, r := range results {
+	apiResults := make([]RemediationResultAPI, 0, len(page.Results))
+	for _, r := range page.Results {
 		apiResults = append(apiResults, NewRemediationResultAPI(r))
 	}
 
@@ -1921,6 +2209,7 @@ func (a *APIController) ListRemediationResults(w http.ResponseWriter, r *http.Re
 		Repository: repository,
 		Count:      len(apiResults),
 		Results:    apiResults,
+		NextCursor: page.NextCursor,
 	}
 
 	responder.Success(w, r, http.StatusOK, response)
@@ -2007,6 +2296,7 @@ func newProjectDriftFromResult(pr command.ProjectResult, ref, baseBranch, resolv
 		projectDrift.Drift = models.DriftSummary{HasDrift: false}
 	} else if pr.PlanSuccess != nil {
 		projectDrift.Drift = models.NewDriftSummaryFromPlanSuccess(pr.PlanSuccess)
+		projectDrift.PlanOutput = pr.PlanSuccess.TerraformOutput
 	}
 
 	return projectDrift
@@ -2067,13 +2357,14 @@ func (a *APIController) reconcileDriftStorage(repository, ref, baseBranch string
 
 // DetectDrift handles POST /api/drift/detect requests.
 // It triggers drift detection by running plans for the specified projects.
-// This is an authenticated endpoint that requires the API secret.
+// This is an authenticated endpoint that requires the API secret or an API token.
 func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 	middleware := a.getAPIMiddleware()
 	responder := middleware.Responder
 
 	// Authenticate
-	if !middleware.RequireAuth(w, r) {
+	principal := middleware.RequireScope(w, r, APIScopeDriftDetect)
+	if principal == nil {
 		return
 	}
 
@@ -2129,6 +2420,71 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 		responder.Forbidden(w, r, "repository is not in the allowlist")
 		return
 	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return
+	}
+	if !principal.CanUseRef(request.Ref) {
+		responder.Forbidden(w, r, "caller is not allowed to use this ref")
+		return
+	}
+	detectionResult, err := a.runDriftDetection(request, baseRepo, principal.User())
+	if err != nil {
+		if errors.Is(err, events.ErrTeamAllowlistDenied) {
+			responder.Forbidden(w, r, err.Error())
+			return
+		}
+		responder.InternalError(w, r, err)
+		return
+	}
+
+	// Convert to API DTO and return
+	apiResult := NewDriftDetectionResultAPI(detectionResult)
+
+	code := http.StatusOK
+	if driftDetectionHasErrors(detectionResult) {
+		code = http.StatusMultiStatus // 207 - some projects may have failed
+	}
+	responder.Success(w, r, code, apiResult)
+}
+
+// RunDriftDetection runs drift detection for request outside of an HTTP
+// request, e.g. on a drift_detection schedule. Results are stored and drift
+// webhooks are sent exactly as for POST /api/drift/detect.
+func (a *APIController) RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error) {
+	if a.DriftStorage == nil {
+		return nil, errors.New("drift detection is not enabled")
+	}
+	if validationErrors := request.Validate(); len(validationErrors) > 0 {
+		return nil, fmt.Errorf("invalid drift detection request: %s: %s", validationErrors[0].Field, validationErrors[0].Message)
+	}
+	VCSHostType, err := models.NewVCSHostType(request.Type)
+	if err != nil {
+		return nil, err
+	}
+	cloneURL, err := a.VCSClient.GetCloneURL(a.Logger, VCSHostType, request.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clone URL: %w", err)
+	}
+	baseRepo, err := a.Parser.ParseAPIPlanRequest(VCSHostType, request.Repository, cloneURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repository: %w", err)
+	}
+	if !a.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
+		return nil, fmt.Errorf("repository %s is not in the allowlist", baseRepo.FullName)
+	}
+	return a.runDriftDetection(request, baseRepo, models.User{})
+}
+
+// runDriftDetection plans the requested projects of an already validated and
+// allowlisted request on behalf of user, stores the resulting drift and sends
+// drift webhooks.
+func (a *APIController) runDriftDetection(request models.DriftDetectionRequest, baseRepo models.Repo, user models.User) (detectionResult *models.DriftDetectionResult, err error) {
+	if a.DriftMetrics != nil {
+		defer func() {
+			a.DriftMetrics.DetectionRun(baseRepo.ID(), err != nil || driftDetectionHasErrors(detectionResult))
+		}()
+	}
 	normalizedRef := apiRequestStorageRef(request.Ref)
 	normalizedBaseBranch := apiRequestBaseBranch(request.Ref, request.BaseBranch)
 	fullDetection := len(request.Projects) == 0 && len(request.Paths) == 0
@@ -2158,6 +2514,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 
 	// Build the command context
 	ctx := &command.Context{
+		User:     user,
 		HeadRepo: baseRepo,
 		Pull: models.PullRequest{
 			Num:                      nextNonPRPullNum(), // Synthetic non-PR workflow ID.
@@ -2181,8 +2538,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 
 	// Setup working directory
 	if err := a.apiSetup(ctx, command.Plan); err != nil {
-		responder.InternalError(w, r, fmt.Errorf("setup failed: %w", err))
-		return
+		return nil, fmt.Errorf("setup failed: %w", err)
 	}
 	defer a.cleanupNonPRWorkingDir(ctx)
 
@@ -2193,8 +2549,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 	if err := a.PreWorkflowHooksCommandRunner.RunPreHooks(ctx, preHookCmd); err != nil {
 		preHookFailed = true
 		if a.FailOnPreWorkflowHookError {
-			responder.InternalError(w, r, fmt.Errorf("pre-workflow hook failed: %w", err))
-			return
+			return nil, fmt.Errorf("pre-workflow hook failed: %w", err)
 		}
 		a.Logger.Warn("pre-workflow hook error (continuing): %v", err)
 	}
@@ -2202,21 +2557,17 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 
 	result, err := a.apiPlan(apiRequest, ctx)
 	if err != nil {
-		if errors.Is(err, events.ErrTeamAllowlistDenied) {
-			responder.Forbidden(w, r, err.Error())
-			return
-		}
-		responder.InternalError(w, r, err)
-		return
+		return nil, err
 	}
 	defer a.Locker.UnlockByPull(ctx.HeadRepo.FullName, ctx.Pull.Num) // nolint: errcheck
 
 	// Process results and store drift data
-	detectionResult := models.NewDriftDetectionResult(request.Repository)
+	detectionResult = models.NewDriftDetectionResult(request.Repository)
 	detectedProjects := map[driftProjectIdentity]struct{}{}
 	storeFailed := false
 	projectDrifts := driftProjectsFromCommandResult(result, normalizedRef, normalizedBaseBranch, ctx.Pull.HeadCommit, detectionResult.ID)
 	for _, projectDrift := range projectDrifts {
+		projectDrift.VCSType = request.Type
 		detectedProjects[newDriftProjectIdentity(projectDrift)] = struct{}{}
 		if err := a.DriftStorage.Store(baseRepo.ID(), projectDrift); err != nil {
 			storeFailed = true
@@ -2236,19 +2587,14 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 	// no-drift heartbeat results.
 	if a.DriftWebhookSender != nil && !driftDetectionHasErrors(detectionResult) {
 		webhookResult := convertToDriftWebhookResult(detectionResult, normalizedRef)
+		webhookResult.BaseBranch = normalizedBaseBranch
+		webhookResult.VCSType = request.Type
 		if err := a.DriftWebhookSender.Send(a.Logger, webhookResult); err != nil {
 			a.Logger.Warn("failed to send drift webhook: %v", err)
 		}
 	}
 
-	// Convert to API DTO and return
-	apiResult := NewDriftDetectionResultAPI(detectionResult)
-
-	code := http.StatusOK
-	if driftDetectionHasErrors(detectionResult) {
-		code = http.StatusMultiStatus // 207 - some projects may have failed
-	}
-	responder.Success(w, r, code, apiResult)
+	return detectionResult, nil
 }
 
 // convertToDriftWebhookResult converts a DriftDetectionResult to a webhook DriftResult.
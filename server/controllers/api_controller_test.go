@@ -5,6 +5,7 @@ package controllers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -35,6 +36,7 @@ import (
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/metrics/metricstest"
 	. "github.com/runatlantis/atlantis/testing"
+	tally "github.com/uber-go/tally/v4"
 	"go.uber.org/mock/gomock"
 )
 
@@ -2738,7 +2740,7 @@ func TestAPIController_DriftReadEndpointsRejectNonAllowlistedRepos(t *testing.T)
 
 			Equals(t, http.StatusForbidden, w.Code)
 			driftStorage.VerifyWasCalled(Never()).Get(Any[string](), Any[drift.GetOptions]())
-			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.RemediationListOptions]())
 		})
 	}
 }
@@ -2775,7 +2777,7 @@ func TestAPIController_RemediateReadEndpointsRejectInvalidRepositoryBeforeRead(t
 			Equals(t, http.StatusBadRequest, w.Code)
 			vcsClient.VerifyWasCalled(Never()).GetCloneURL(Any[logging.SimpleLogging](), Any[models.VCSHostType](), Any[string]())
 			remediationService.VerifyWasCalled(Never()).GetResult(Any[string]())
-			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.RemediationListOptions]())
 		})
 	}
 }
@@ -2848,6 +2850,50 @@ func TestAPIController_RemediateRejectsNonAllowlistedRepoBeforeMutation(t *testi
 	projectCommandRunner.VerifyWasCalled(Never()).Apply(Any[command.ProjectContext]())
 }
 
+func TestAPIController_RunRemediation(t *testing.T) {
+	ac, _, _ := setup(t)
+	remediationService := driftmocks.NewMockRemediationService()
+	ac.RemediationService = remediationService
+	var captured models.RemediationRequest
+	When(remediationService.Remediate(Any[models.RemediationRequest](), Any[drift.RemediationExecutor]())).
+		Then(func(args []Param) ReturnValues {
+			captured = args[0].(models.RemediationRequest)
+			return ReturnValues{&models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusRunning}, nil}
+		})
+
+	result, err := ac.RunRemediation(models.RemediationRequest{
+		Repository:  "Repo",
+		Ref:         "refs/heads/main",
+		Type:        "Gitlab",
+		Projects:    []string{"app"},
+		RequestedBy: &models.APICaller{Name: "U123", AuthMethod: "slack"},
+	})
+
+	Ok(t, err)
+	Equals(t, "rem-1", result.ID)
+	Equals(t, "main", captured.Ref)
+	Equals(t, "refs/heads/main", captured.ExecutionRef)
+	Equals(t, models.RemediationPlanOnly, captured.Action)
+	Equals(t, &models.APICaller{Name: "U123", AuthMethod: "slack"}, captured.RequestedBy)
+	Assert(t, captured.StorageRepository != "", "expected storage repository to be set")
+}
+
+func TestAPIController_RunRemediation_Rejected(t *testing.T) {
+	ac, _, _ := setup(t)
+	remediationService := driftmocks.NewMockRemediationService()
+	ac.RemediationService = remediationService
+
+	_, err := ac.RunRemediation(models.RemediationRequest{Repository: "Repo", Ref: "main", Type: "Gitlab", Action: models.RemediationAutoApply})
+	ErrEquals(t, "drift remediation apply is not enabled", err)
+
+	repoAllowlistChecker, err := events.NewRepoAllowlistChecker("github.com/allowed/repo")
+	Ok(t, err)
+	ac.RepoAllowlistChecker = repoAllowlistChecker
+	_, err = ac.RunRemediation(models.RemediationRequest{Repository: "Repo", Ref: "main", Type: "Gitlab"})
+	ErrContains(t, "is not in the allowlist", err)
+	remediationService.VerifyWasCalled(Never()).Remediate(Any[models.RemediationRequest](), Any[drift.RemediationExecutor]())
+}
+
 func TestAPIController_Remediate_InvalidRepositoryReturnsBadRequest(t *testing.T) {
 	ac, _, _ := setup(t)
 	remediationService := driftmocks.NewMockRemediationService()
@@ -2928,6 +2974,8 @@ func TestAPIController_Remediate(t *testing.T) {
 
 	req, _ := http.NewRequest("POST", "", bytes.NewBuffer(body))
 	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	req.Header.Set("X-Atlantis-User", "jdoe")
+	req.RemoteAddr = "10.0.0.1:1234"
 	w := httptest.NewRecorder()
 	ac.Remediate(w, req)
 
@@ -2944,6 +2992,7 @@ func TestAPIController_Remediate(t *testing.T) {
 	Equals(t, "refs/heads/main", capturedRequest.ExecutionRef)
 	Equals(t, "main", capturedRequest.BaseBranch)
 	Equals(t, "github.com/owner/repo", capturedRequest.StorageRepository)
+	Equals(t, &models.APICaller{Name: "jdoe", AuthMethod: "api_secret", RemoteAddr: "10.0.0.1:1234"}, capturedRequest.RequestedBy)
 }
 
 func TestAPIController_Remediate_ProjectFailuresReturnNon2xx(t *testing.T) {
@@ -3011,6 +3060,60 @@ func TestAPIController_Remediate_ProjectFailuresReturnNon2xx(t *testing.T) {
 	Assert(t, apiErr.Details != nil, "expected failed remediation details")
 }
 
+func TestAPIController_Remediate_RunningReturnsAccepted(t *testing.T) {
+	RegisterMockTestingT(t)
+	gmockCtrl := gomock.NewController(t)
+	logger := logging.NewNoopLogger(t)
+	locker := NewMockLocker(gmockCtrl)
+	parser := NewMockEventParsing()
+	vcsClient := NewMockClient()
+	repoAllowlistChecker, _ := events.NewRepoAllowlistChecker("*")
+
+	remediationService := driftmocks.NewMockRemediationService()
+	When(remediationService.Remediate(Any[models.RemediationRequest](), Any[drift.RemediationExecutor]())).ThenReturn(&models.RemediationResult{
+		ID:         "test-id",
+		Repository: "owner/repo",
+		Ref:        "main",
+		Action:     models.RemediationPlanOnly,
+		Status:     models.RemediationStatusRunning,
+	}, nil)
+
+	When(vcsClient.GetCloneURL(Any[logging.SimpleLogging](), Any[models.VCSHostType](), Eq("owner/repo"))).ThenReturn("https://github.com/owner/repo.git", nil)
+	When(parser.ParseAPIPlanRequest(Any[models.VCSHostType](), Eq("owner/repo"), Any[string]())).ThenReturn(models.Repo{
+		FullName: "owner/repo",
+		VCSHost:  models.VCSHost{Hostname: "github.com"},
+	}, nil)
+
+	ac := controllers.APIController{
+		APISecret:            []byte(atlantisToken),
+		Logger:               logger,
+		Locker:               locker,
+		Parser:               parser,
+		VCSClient:            vcsClient,
+		RepoAllowlistChecker: repoAllowlistChecker,
+		RemediationService:   remediationService,
+	}
+
+	body, _ := json.Marshal(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Action:     models.RemediationPlanOnly,
+	})
+
+	req, _ := http.NewRequest("POST", "", bytes.NewBuffer(body))
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.Remediate(w, req)
+
+	Equals(t, http.StatusAccepted, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	var result controllers.RemediationResultAPI
+	parseAPIResponse(t, response, &result)
+	Equals(t, "test-id", result.ID)
+	Equals(t, "running", result.Status)
+}
+
 func TestAPIController_Remediate_ServiceFailureWithoutProjectsReturnsInternalError(t *testing.T) {
 	RegisterMockTestingT(t)
 	gmockCtrl := gomock.NewController(t)
@@ -3067,7 +3170,8 @@ func TestAPIController_Remediate_ServiceFailureWithoutProjectsReturnsInternalErr
 
 func TestAPIController_RemediateProjectSelectorsUseExactMatching(t *testing.T) {
 	ac, projectCommandBuilder, projectCommandRunner := setup(t)
-	ac.RemediationService = drift.NewInMemoryRemediationService(drift.NewInMemoryStorage())
+	remediationService := drift.NewInMemoryRemediationService(drift.NewInMemoryStorage())
+	ac.RemediationService = remediationService
 	workingDir := ac.WorkingDir.(*MockWorkingDir)
 	When(workingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[string]())).
 		ThenReturn(t.TempDir(), nil)
@@ -3103,7 +3207,15 @@ func TestAPIController_RemediateProjectSelectorsUseExactMatching(t *testing.T) {
 	w := httptest.NewRecorder()
 	ac.Remediate(w, req)
 
-	Equals(t, http.StatusOK, w.Code)
+	Equals(t, http.StatusAccepted, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	var accepted controllers.RemediationResultAPI
+	parseAPIResponse(t, response, &accepted)
+	waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := remediationService.Wait(waitCtx, accepted.ID)
+	Ok(t, err)
+	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Assert(t, capturedCtx != nil, "expected project command builder context")
 	Assert(t, capturedCtx.ExactProjectNameMatching, "remediation API project selectors must use exact matching")
 	Assert(t, capturedCmd != nil, "expected remediation project selector")
@@ -3603,6 +3715,80 @@ func TestAPIController_GetRemediationResult_NoService(t *testing.T) {
 	Equals(t, controllers.ErrCodeServiceUnavailable, apiErr.Code)
 }
 
+func TestAPIController_CancelRemediation(t *testing.T) {
+	ac, _, _ := setup(t)
+	remediationService := driftmocks.NewMockRemediationService()
+	When(remediationService.GetResult(Eq("test-id"))).ThenReturn(&models.RemediationResult{
+		ID:                "test-id",
+		Repository:        "owner/repo",
+		StorageRepository: "github.com/owner/repo",
+		Ref:               "main",
+		Status:            models.RemediationStatusRunning,
+	}, nil)
+	When(remediationService.Cancel(Eq("test-id"))).ThenReturn(&models.RemediationResult{
+		ID:                "test-id",
+		Repository:        "owner/repo",
+		StorageRepository: "github.com/owner/repo",
+		Ref:               "main",
+		Status:            models.RemediationStatusCancelled,
+	}, nil)
+	ac.RemediationService = remediationService
+
+	req, _ := http.NewRequest("DELETE", "/api/drift/remediate/test-id?repository=owner/repo&type=Github", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "test-id"})
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.CancelRemediation(w, req)
+
+	Equals(t, http.StatusOK, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	var result controllers.RemediationResultAPI
+	parseAPIResponse(t, response, &result)
+	Equals(t, "cancelled", result.Status)
+}
+
+func TestAPIController_CancelRemediation_AlreadyCompleted(t *testing.T) {
+	ac, _, _ := setup(t)
+	remediationService := driftmocks.NewMockRemediationService()
+	When(remediationService.GetResult(Eq("test-id"))).ThenReturn(&models.RemediationResult{
+		ID:                "test-id",
+		Repository:        "owner/repo",
+		StorageRepository: "github.com/owner/repo",
+		Status:            models.RemediationStatusSuccess,
+	}, nil)
+	When(remediationService.Cancel(Eq("test-id"))).ThenReturn(nil, drift.ErrRemediationNotCancellable)
+	ac.RemediationService = remediationService
+
+	req, _ := http.NewRequest("DELETE", "/api/drift/remediate/test-id?repository=owner/repo&type=Github", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "test-id"})
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.CancelRemediation(w, req)
+
+	Equals(t, http.StatusConflict, w.Code)
+}
+
+func TestAPIController_CancelRemediation_RejectsMismatchedRepo(t *testing.T) {
+	ac, _, _ := setup(t)
+	remediationService := driftmocks.NewMockRemediationService()
+	When(remediationService.GetResult(Eq("test-id"))).ThenReturn(&models.RemediationResult{
+		ID:                "test-id",
+		Repository:        "other/repo",
+		StorageRepository: "github.com/other/repo",
+		Status:            models.RemediationStatusRunning,
+	}, nil)
+	ac.RemediationService = remediationService
+
+	req, _ := http.NewRequest("DELETE", "/api/drift/remediate/test-id?repository=owner/repo&type=Github", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "test-id"})
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.CancelRemediation(w, req)
+
+	Equals(t, http.StatusNotFound, w.Code)
+	remediationService.VerifyWasCalled(Never()).Cancel(Any[string]())
+}
+
 // Phase 5: ListRemediationResults tests
 
 func TestAPIController_ListRemediationResults(t *testing.T) {
@@ -3630,7 +3816,7 @@ func TestAPIController_ListRemediationResults(t *testing.T) {
 			FailureCount:  1,
 		},
 	}
-	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(10))).ThenReturn(mockResults, nil)
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(drift.RemediationListOptions{Limit: 10}))).ThenReturn(drift.RemediationPage{Results: mockResults}, nil)
 	ac.RemediationService = remediationService
 
 	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github", nil)
@@ -3658,7 +3844,7 @@ func TestAPIController_ListRemediationResults_WithLimit(t *testing.T) {
 			Status:     models.RemediationStatusSuccess,
 		},
 	}
-	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(5))).ThenReturn(mockResults, nil)
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(drift.RemediationListOptions{Limit: 5}))).ThenReturn(drift.RemediationPage{Results: mockResults}, nil)
 	ac.RemediationService = remediationService
 
 	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&limit=5", nil)
@@ -3669,6 +3855,91 @@ func TestAPIController_ListRemediationResults_WithLimit(t *testing.T) {
 	Equals(t, http.StatusOK, w.Code)
 }
 
+func TestAPIController_ListRemediationResults_Filters(t *testing.T) {
+	ac, _, _ := setup(t)
+
+	remediationService := driftmocks.NewMockRemediationService()
+	expOpts := drift.RemediationListOptions{
+		Limit:         20,
+		Cursor:        "abc",
+		Status:        models.RemediationStatusFailed,
+		StartedAfter:  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		StartedBefore: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+	}
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(expOpts))).ThenReturn(drift.RemediationPage{
+		Results: []*models.RemediationResult{
+			{
+				ID:          "result-1",
+				Repository:  "owner/repo",
+				Status:      models.RemediationStatusFailed,
+				RequestedBy: &models.APICaller{Name: "jdoe", AuthMethod: "api_secret"},
+			},
+		},
+		NextCursor: "next",
+	}, nil)
+	ac.RemediationService = remediationService
+
+	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&limit=20&cursor=abc&status=failed&since=2025-01-01T00:00:00Z&until=2025-02-01T00:00:00Z", nil)
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.ListRemediationResults(w, req)
+
+	Equals(t, http.StatusOK, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	var listResponse controllers.RemediationListAPI
+	parseAPIResponse(t, response, &listResponse)
+	Equals(t, 1, listResponse.Count)
+	Equals(t, "next", listResponse.NextCursor)
+	Equals(t, "jdoe", listResponse.Results[0].RequestedBy.Name)
+	Equals(t, "api_secret", listResponse.Results[0].RequestedBy.AuthMethod)
+}
+
+func TestAPIController_ListRemediationResults_InvalidFilters(t *testing.T) {
+	cases := map[string]string{
+		"status=done":      "status",
+		"since=yesterday":  "since",
+		"until=2025-01-01": "until",
+	}
+	for query, field := range cases {
+		t.Run(query, func(t *testing.T) {
+			ac, _, _ := setup(t)
+			remediationService := driftmocks.NewMockRemediationService()
+			ac.RemediationService = remediationService
+
+			req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&"+query, nil)
+			req.Header.Set(atlantisTokenHeader, atlantisToken)
+			w := httptest.NewRecorder()
+			ac.ListRemediationResults(w, req)
+
+			Equals(t, http.StatusBadRequest, w.Code)
+			response, _ := io.ReadAll(w.Result().Body)
+			apiErr := parseAPIError(t, response)
+			Equals(t, controllers.ErrCodeValidation, apiErr.Code)
+			Assert(t, strings.Contains(fmt.Sprintf("%v", apiErr.Details), field), "expected %s validation error, got %#v", field, apiErr.Details)
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.RemediationListOptions]())
+		})
+	}
+}
+
+func TestAPIController_ListRemediationResults_InvalidCursor(t *testing.T) {
+	ac, _, _ := setup(t)
+
+	remediationService := driftmocks.NewMockRemediationService()
+	When(remediationService.ListResults(Any[string](), Any[drift.RemediationListOptions]())).ThenReturn(drift.RemediationPage{}, drift.ErrInvalidRemediationCursor)
+	ac.RemediationService = remediationService
+
+	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&cursor=bogus", nil)
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.ListRemediationResults(w, req)
+
+	Equals(t, http.StatusBadRequest, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	apiErr := parseAPIError(t, response)
+	Equals(t, controllers.ErrCodeValidation, apiErr.Code)
+	Assert(t, strings.Contains(fmt.Sprintf("%v", apiErr.Details), "cursor"), "expected cursor validation error, got %#v", apiErr.Details)
+}
+
 func TestAPIController_ListRemediationResults_InvalidLimit(t *testing.T) {
 	cases := []string{"5abc", "5+6", "abc", "", "0", "-1"}
 	for _, limit := range cases {
@@ -3686,7 +3957,7 @@ func TestAPIController_ListRemediationResults_InvalidLimit(t *testing.T) {
 			response, _ := io.ReadAll(w.Result().Body)
 			apiErr := parseAPIError(t, response)
 			Equals(t, controllers.ErrCodeValidation, apiErr.Code)
-			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.RemediationListOptions]())
 		})
 	}
 }
@@ -3716,7 +3987,7 @@ func TestAPIController_ListRemediationResults_MissingType(t *testing.T) {
 	response, _ := io.ReadAll(w.Result().Body)
 	apiErr := parseAPIError(t, response)
 	Equals(t, controllers.ErrCodeValidation, apiErr.Code)
-	remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+	remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.RemediationListOptions]())
 }
 
 func TestAPIController_ListRemediationResults_MissingRepository(t *testing.T) {
@@ -3750,7 +4021,7 @@ func TestAPIController_ListRemediationResults_Empty(t *testing.T) {
 	ac, _, _ := setup(t)
 
 	remediationService := driftmocks.NewMockRemediationService()
-	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(10))).ThenReturn([]*models.RemediationResult{}, nil)
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(drift.RemediationListOptions{Limit: 10}))).ThenReturn(drift.RemediationPage{Results: []*models.RemediationResult{}}, nil)
 	ac.RemediationService = remediationService
 
 	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github", nil)
@@ -3804,6 +4075,75 @@ func TestAPIController_DetectDrift(t *testing.T) {
 	Equals(t, "Repo", result.Repository)
 }
 
+func TestAPIController_RunDriftDetection(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		ThenReturn([]command.ProjectContext{{CommandName: command.Plan}}, nil)
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Store(Any[string](), Any[models.ProjectDrift]())).ThenReturn(nil)
+	ac.DriftStorage = driftStorage
+
+	result, err := ac.RunDriftDetection(models.DriftDetectionRequest{
+		Repository: "Repo",
+		Ref:        "main",
+		Type:       "Gitlab",
+		Projects:   []string{"default"},
+	})
+	Ok(t, err)
+	Equals(t, "Repo", result.Repository)
+	Equals(t, 1, result.TotalProjects)
+	driftStorage.VerifyWasCalledOnce().Store(Any[string](), Any[models.ProjectDrift]())
+}
+
+func TestAPIController_RunDriftDetectionCountsRuns(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		ThenReturn([]command.ProjectContext{{CommandName: command.Plan}}, nil)
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Store(Any[string](), Any[models.ProjectDrift]())).ThenReturn(errors.New("storage unavailable"))
+	ac.DriftStorage = driftStorage
+	scope := tally.NewTestScope("", nil)
+	ac.DriftMetrics = drift.NewMetrics(scope)
+
+	_, err := ac.RunDriftDetection(models.DriftDetectionRequest{
+		Repository: "Repo",
+		Ref:        "main",
+		Type:       "Gitlab",
+		Projects:   []string{"default"},
+	})
+	Ok(t, err)
+
+	counts := map[string]int64{}
+	for _, counter := range scope.Snapshot().Counters() {
+		Equals(t, "gitlab.com/Repo", counter.Tags()["repository"])
+		counts[counter.Name()] = counter.Value()
+	}
+	Equals(t, map[string]int64{"drift.detection.runs": 1, "drift.detection.errors": 1}, counts)
+}
+
+func TestAPIController_RunDriftDetectionRejectsNonAllowlistedRepo(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	repoAllowlistChecker, err := events.NewRepoAllowlistChecker("github.com/allowed/repo")
+	Ok(t, err)
+	ac.RepoAllowlistChecker = repoAllowlistChecker
+	ac.DriftStorage = driftmocks.NewMockStorage()
+
+	_, err = ac.RunDriftDetection(models.DriftDetectionRequest{
+		Repository: "Repo",
+		Ref:        "main",
+		Type:       "Gitlab",
+	})
+	ErrContains(t, "is not in the allowlist", err)
+	projectCommandBuilder.VerifyWasCalled(Never()).BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())
+}
+
+func TestAPIController_RunDriftDetectionRequiresDriftStorage(t *testing.T) {
+	ac, _, _ := setup(t)
+
+	_, err := ac.RunDriftDetection(models.DriftDetectionRequest{Repository: "Repo", Ref: "main", Type: "Gitlab"})
+	ErrEquals(t, "drift detection is not enabled", err)
+}
+
 func TestAPIController_DetectDrift_TeamAllowlistDeniedReturnsForbidden(t *testing.T) {
 	ac, projectCommandBuilder, _ := setup(t)
 	driftStorage := driftmocks.NewMockStorage()
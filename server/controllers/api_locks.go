@@ -0,0 +1,266 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// lockIDQueryParam is the query parameter identifying a lock. Lock IDs
+// contain slashes and dots, so they aren't path parameters.
+const lockIDQueryParam = "id"
+
+// GetLock handles GET /api/lock?id= requests. It returns the project lock
+// with the given ID.
+// This is an authenticated endpoint that requires the locks:read scope.
+func (a *APIController) GetLock(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	principal := middleware.RequireScope(w, r, APIScopeLocksRead)
+	if principal == nil {
+		return
+	}
+
+	id, lock, ok := a.findAccessibleLock(w, r, principal)
+	if !ok {
+		return
+	}
+	responder.Success(w, r, http.StatusOK, NewLockDetailAPI(id, *lock))
+}
+
+// DeleteLock handles DELETE /api/lock?id= requests. Like deleting a lock in
+// the web UI, it discards the project's plan and comments on the pull request
+// that held the lock.
+// This is an authenticated endpoint that requires the locks:write scope.
+func (a *APIController) DeleteLock(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	principal := middleware.RequireScope(w, r, APIScopeLocksWrite)
+	if principal == nil {
+		return
+	}
+	if a.DeleteLockCommand == nil || a.Database == nil {
+		responder.ServiceUnavailable(w, r, "deleting locks is not enabled")
+		return
+	}
+
+	// Look the lock up first so callers can only delete locks on
+	// repositories they have access to.
+	id, _, ok := a.findAccessibleLock(w, r, principal)
+	if !ok {
+		return
+	}
+	lock, err := a.DeleteLockCommand.DeleteLock(a.Logger, id)
+	if err != nil {
+		a.AuditLog.Record(apiAuditEvent(principal, audit.Event{
+			Action:  audit.ActionUnlock,
+			Details: map[string]string{"lock_id": id},
+		}, err))
+		responder.InternalError(w, r, fmt.Errorf("deleting lock %q: %w", id, err))
+		return
+	}
+	if lock == nil {
+		responder.NotFound(w, r, "lock not found")
+		return
+	}
+	a.AuditLog.Record(lockAuditEvent(apiAuditEvent(principal, audit.Event{Action: audit.ActionUnlock}, nil), id, *lock))
+	discardLockedPlan(a.Logger, a.Database, a.VCSClient, *lock, fmt.Sprintf("the Atlantis API by `%s`", principal.Caller.Name))
+	responder.Success(w, r, http.StatusOK, NewLockDetailAPI(id, *lock))
+}
+
+// ReleasePullLocks handles DELETE /api/locks requests. It releases every lock
+// held by a pull request, discarding the plans and commenting on the pull
+// request for each one.
+// Query parameters:
+//   - repository: required, the full repository name (owner/repo)
+//   - type: required, the VCS provider type
+//   - pr: required, the pull request number
+//
+// This is an authenticated endpoint that requires the locks:write scope.
+func (a *APIController) ReleasePullLocks(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	principal := middleware.RequireScope(w, r, APIScopeLocksWrite)
+	if principal == nil {
+		return
+	}
+	if a.DeleteLockCommand == nil || a.Database == nil {
+		responder.ServiceUnavailable(w, r, "deleting locks is not enabled")
+		return
+	}
+
+	repository := r.URL.Query().Get("repository")
+	if repository == "" {
+		responder.ValidationFailed(w, r, "missing required parameter",
+			ValidationError{Field: "repository", Message: "repository parameter is required"})
+		return
+	}
+	vcsType := r.URL.Query().Get("type")
+	if vcsType == "" {
+		responder.ValidationFailed(w, r, "missing required parameter",
+			ValidationError{Field: "type", Message: "type parameter is required"})
+		return
+	}
+	pullNum, err := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("pr")))
+	if err != nil || pullNum <= 0 {
+		responder.ValidationFailed(w, r, "invalid pr parameter",
+			ValidationError{Field: "pr", Message: "must be a positive integer"})
+		return
+	}
+	VCSHostType, err := models.NewVCSHostType(vcsType)
+	if err != nil {
+		responder.ValidationFailed(w, r, "invalid VCS type",
+			ValidationError{Field: "type", Message: err.Error()})
+		return
+	}
+	cloneURL, err := a.VCSClient.GetCloneURL(a.Logger, VCSHostType, repository)
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("failed to get clone URL: %w", err))
+		return
+	}
+	baseRepo, err := a.Parser.ParseAPIPlanRequest(VCSHostType, repository, cloneURL)
+	if err != nil {
+		responder.ValidationFailed(w, r, fmt.Sprintf("failed to parse repository: %v", err))
+		return
+	}
+	if !a.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
+		responder.Forbidden(w, r, "repository is not in the allowlist")
+		return
+	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return
+	}
+
+	locks, err := a.DeleteLockCommand.ReleaseLocksByPull(a.Logger, baseRepo.FullName, pullNum)
+	via := fmt.Sprintf("the Atlantis API by `%s`", principal.Caller.Name)
+	released := make(map[string]models.ProjectLock, len(locks))
+	for _, lock := range locks {
+		discardLockedPlan(a.Logger, a.Database, a.VCSClient, lock, via)
+		id := models.GenerateLockKey(lock.Project, lock.Workspace)
+		released[id] = lock
+		a.AuditLog.Record(lockAuditEvent(apiAuditEvent(principal, audit.Event{Action: audit.ActionUnlock}, nil), id, lock))
+	}
+	if err != nil {
+		a.AuditLog.Record(apiAuditEvent(principal, audit.Event{
+			Action: audit.ActionUnlock,
+			Repo:   baseRepo.FullName,
+			Pull:   pullNum,
+		}, err))
+		responder.InternalError(w, r, fmt.Errorf("releasing locks for %s#%d: %w", baseRepo.FullName, pullNum, err))
+		return
+	}
+	responder.Success(w, r, http.StatusOK, NewListLocksResultAPI(released))
+}
+
+// findAccessibleLock looks up the lock identified by the id query parameter.
+// It writes an error response and returns false if the lock doesn't exist or
+// is on a repository principal can't access.
+func (a *APIController) findAccessibleLock(w http.ResponseWriter, r *http.Request, principal *APIPrincipal) (string, *models.ProjectLock, bool) {
+	responder := a.getAPIMiddleware().Responder
+
+	id := r.URL.Query().Get(lockIDQueryParam)
+	if id == "" {
+		responder.ValidationFailed(w, r, "missing required parameter",
+			ValidationError{Field: lockIDQueryParam, Message: "id parameter is required"})
+		return "", nil, false
+	}
+	lock, err := a.Locker.GetLock(id)
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("getting lock %q: %w", id, err))
+		return "", nil, false
+	}
+	// Locks on repositories the caller can't access are reported as missing
+	// so their existence isn't disclosed.
+	if lock == nil || !principal.CanAccessRepoName(lock.Project.RepoFullName, lock.Pull.BaseRepo.VCSHost.Hostname) {
+		responder.NotFound(w, r, "lock not found")
+		return "", nil, false
+	}
+	return id, lock, true
+}
+
+// GetApplyLock handles GET /api/apply/lock requests. It returns whether apply
+// commands are locked globally.
+// This is an authenticated endpoint that requires the locks:read scope.
+func (a *APIController) GetApplyLock(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	if middleware.RequireScope(w, r, APIScopeLocksRead) == nil {
+		return
+	}
+	if a.ApplyLocker == nil {
+		responder.ServiceUnavailable(w, r, "the global apply lock is not enabled")
+		return
+	}
+
+	lock, err := a.ApplyLocker.CheckApplyLock()
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("checking apply lock: %w", err))
+		return
+	}
+	responder.Success(w, r, http.StatusOK, NewApplyLockAPI(lock))
+}
+
+// LockApply handles POST /api/apply/lock requests. It locks apply commands
+// globally, as the "Disable Apply Commands" button in the web UI does. If the
+// lock already exists it is a no-op.
+// This is an authenticated endpoint that requires the apply-lock:write scope.
+func (a *APIController) LockApply(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	principal := middleware.RequireScope(w, r, APIScopeApplyLockWrite)
+	if principal == nil {
+		return
+	}
+	if a.ApplyLocker == nil {
+		responder.ServiceUnavailable(w, r, "the global apply lock is not enabled")
+		return
+	}
+
+	lock, err := a.ApplyLocker.LockApply()
+	a.AuditLog.Record(apiAuditEvent(principal, audit.Event{Action: audit.ActionApplyLock}, err))
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("creating apply lock: %w", err))
+		return
+	}
+	a.Logger.Info("global apply lock acquired by %s", principal.Caller.Name)
+	responder.Success(w, r, http.StatusOK, NewApplyLockAPI(lock))
+}
+
+// UnlockApply handles DELETE /api/apply/lock requests. It releases the global
+// apply lock. If there's no lock it is a no-op.
+// This is an authenticated endpoint that requires the apply-lock:write scope.
+func (a *APIController) UnlockApply(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	principal := middleware.RequireScope(w, r, APIScopeApplyLockWrite)
+	if principal == nil {
+		return
+	}
+	if a.ApplyLocker == nil {
+		responder.ServiceUnavailable(w, r, "the global apply lock is not enabled")
+		return
+	}
+
+	err := a.ApplyLocker.UnlockApply()
+	a.AuditLog.Record(apiAuditEvent(principal, audit.Event{Action: audit.ActionApplyUnlock}, err))
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("deleting apply lock: %w", err))
+		return
+	}
+	a.Logger.Info("global apply lock released by %s", principal.Caller.Name)
+	responder.Success(w, r, http.StatusOK, ApplyLockAPI{})
+}
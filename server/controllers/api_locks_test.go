@@ -0,0 +1,250 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/controllers"
+	dbmocks "github.com/runatlantis/atlantis/server/core/db/mocks"
+	"github.com/runatlantis/atlantis/server/core/locking"
+	lockingmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
+	eventmocks "github.com/runatlantis/atlantis/server/events/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
+)
+
+const testLockID = "team-a/repo/./default"
+
+func testProjectLock(repoFullName string) models.ProjectLock {
+	baseRepo := models.Repo{FullName: repoFullName, VCSHost: models.VCSHost{Hostname: "github.com", Type: models.Github}}
+	return models.ProjectLock{
+		Project:   models.Project{RepoFullName: repoFullName, Path: "."},
+		Workspace: "default",
+		Pull:      models.PullRequest{Num: 7, BaseRepo: baseRepo},
+		User:      models.User{Username: "alice"},
+		Time:      time.Now(),
+	}
+}
+
+// setupLocks returns an API controller with lock management enabled.
+func setupLocks(t *testing.T) (*controllers.APIController, *eventmocks.MockDeleteLockCommand, *dbmocks.MockDatabase) {
+	ac, _, _ := setup(t)
+	deleteLockCommand := eventmocks.NewMockDeleteLockCommand()
+	database := dbmocks.NewMockDatabase(gomock.NewController(t))
+	ac.DeleteLockCommand = deleteLockCommand
+	ac.Database = database
+	ac.APITokens = []*controllers.APIToken{
+		newTestAPIToken(t, "team-a", "team-a-secret", "github.com/team-a/*", "locks:read", "locks:write"),
+		newTestAPIToken(t, "reader", "reader-secret", "*", "locks:read"),
+	}
+	return ac, deleteLockCommand, database
+}
+
+func lockRequest(method string, query url.Values, token string) *http.Request {
+	req, _ := http.NewRequest(method, "?"+query.Encode(), nil)
+	req.Header.Set(atlantisTokenHeader, token)
+	return req
+}
+
+func TestAPIController_GetLock(t *testing.T) {
+	ac, _, _ := setupLocks(t)
+	lock := testProjectLock("team-a/repo")
+	ac.Locker.(*lockingmocks.MockLocker).EXPECT().GetLock(testLockID).Return(&lock, nil)
+
+	w := httptest.NewRecorder()
+	ac.GetLock(w, lockRequest("GET", url.Values{"id": {testLockID}}, "reader-secret"))
+
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.LockDetailAPI
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, testLockID, result.ID)
+	Equals(t, "team-a/repo", result.Repository)
+	Equals(t, 7, result.PullRequestID)
+}
+
+func TestAPIController_GetLockErrors(t *testing.T) {
+	otherRepoLock := testProjectLock("team-b/repo")
+	cases := []struct {
+		description string
+		query       url.Values
+		lock        *models.ProjectLock
+		lockErr     error
+		expCode     int
+	}{
+		{
+			description: "missing id",
+			query:       url.Values{},
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "no lock",
+			query:       url.Values{"id": {testLockID}},
+			expCode:     http.StatusNotFound,
+		},
+		{
+			description: "lock on inaccessible repo",
+			query:       url.Values{"id": {testLockID}},
+			lock:        &otherRepoLock,
+			expCode:     http.StatusNotFound,
+		},
+		{
+			description: "locker error",
+			query:       url.Values{"id": {testLockID}},
+			lockErr:     errors.New("db error"),
+			expCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			ac, _, _ := setupLocks(t)
+			if c.query.Has("id") {
+				ac.Locker.(*lockingmocks.MockLocker).EXPECT().GetLock(testLockID).Return(c.lock, c.lockErr)
+			}
+
+			w := httptest.NewRecorder()
+			ac.GetLock(w, lockRequest("GET", c.query, "team-a-secret"))
+
+			Equals(t, c.expCode, w.Code)
+		})
+	}
+}
+
+func TestAPIController_DeleteLock(t *testing.T) {
+	ac, deleteLockCommand, database := setupLocks(t)
+	lock := testProjectLock("team-a/repo")
+	ac.Locker.(*lockingmocks.MockLocker).EXPECT().GetLock(testLockID).Return(&lock, nil)
+	When(deleteLockCommand.DeleteLock(Any[logging.SimpleLogging](), Eq(testLockID))).ThenReturn(&lock, nil)
+	database.EXPECT().UpdateProjectStatus(lock.Pull, "default", ".", models.DiscardedPlanStatus).Return(nil)
+
+	w := httptest.NewRecorder()
+	ac.DeleteLock(w, lockRequest("DELETE", url.Values{"id": {testLockID}}, "team-a-secret"))
+
+	Equals(t, http.StatusOK, w.Code)
+	ac.VCSClient.(*vcsmocks.MockClient).VerifyWasCalledOnce().CreateComment(Any[logging.SimpleLogging](), Eq(lock.Pull.BaseRepo), Eq(7),
+		Eq("**Warning**: The plan for dir: `.` workspace: `default` was **discarded** via the Atlantis API by `team-a`.\n\n"+
+			"To `apply` this plan you must run `plan` again."), Eq(""))
+}
+
+func TestAPIController_DeleteLockDenied(t *testing.T) {
+	ac, deleteLockCommand, _ := setupLocks(t)
+	lock := testProjectLock("team-b/repo")
+
+	w := httptest.NewRecorder()
+	ac.DeleteLock(w, lockRequest("DELETE", url.Values{"id": {testLockID}}, "reader-secret"))
+	ResponseContains(t, w, http.StatusForbidden, `API token \"reader\" is missing the \"locks:write\" scope`)
+
+	ac.Locker.(*lockingmocks.MockLocker).EXPECT().GetLock(testLockID).Return(&lock, nil)
+	w = httptest.NewRecorder()
+	ac.DeleteLock(w, lockRequest("DELETE", url.Values{"id": {testLockID}}, "team-a-secret"))
+	Equals(t, http.StatusNotFound, w.Code)
+
+	deleteLockCommand.VerifyWasCalled(Never()).DeleteLock(Any[logging.SimpleLogging](), Any[string]())
+}
+
+func TestAPIController_ReleasePullLocks(t *testing.T) {
+	ac, deleteLockCommand, database := setupLocks(t)
+	lock := testProjectLock("team-a/repo")
+	stagingLock := testProjectLock("team-a/repo")
+	stagingLock.Workspace = "staging"
+	When(deleteLockCommand.ReleaseLocksByPull(Any[logging.SimpleLogging](), Eq("team-a/repo"), Eq(7))).
+		ThenReturn([]models.ProjectLock{lock, stagingLock}, nil)
+	database.EXPECT().UpdateProjectStatus(lock.Pull, gomock.Any(), ".", models.DiscardedPlanStatus).Return(nil).Times(2)
+
+	w := httptest.NewRecorder()
+	ac.ReleasePullLocks(w, lockRequest("DELETE", url.Values{"repository": {"team-a/repo"}, "type": {"Github"}, "pr": {"7"}}, "team-a-secret"))
+
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.ListLocksResultAPI
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, 2, result.TotalCount)
+	ac.VCSClient.(*vcsmocks.MockClient).VerifyWasCalled(Times(2)).CreateComment(Any[logging.SimpleLogging](), Eq(lock.Pull.BaseRepo), Eq(7), Any[string](), Eq(""))
+}
+
+func TestAPIController_ReleasePullLocksErrors(t *testing.T) {
+	cases := []struct {
+		description string
+		query       url.Values
+		expCode     int
+	}{
+		{
+			description: "missing repository",
+			query:       url.Values{"type": {"Github"}, "pr": {"7"}},
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "invalid pr",
+			query:       url.Values{"repository": {"team-a/repo"}, "type": {"Github"}, "pr": {"abc"}},
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "inaccessible repo",
+			query:       url.Values{"repository": {"team-b/repo"}, "type": {"Github"}, "pr": {"7"}},
+			expCode:     http.StatusForbidden,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			ac, deleteLockCommand, _ := setupLocks(t)
+
+			w := httptest.NewRecorder()
+			ac.ReleasePullLocks(w, lockRequest("DELETE", c.query, "team-a-secret"))
+
+			Equals(t, c.expCode, w.Code)
+			deleteLockCommand.VerifyWasCalled(Never()).ReleaseLocksByPull(Any[logging.SimpleLogging](), Any[string](), Any[int]())
+		})
+	}
+}
+
+func TestAPIController_ApplyLock(t *testing.T) {
+	ac, _, _ := setup(t)
+	applyLocker := lockingmocks.NewMockApplyLocker(gomock.NewController(t))
+	ac.ApplyLocker = applyLocker
+	ac.APITokens = []*controllers.APIToken{
+		newTestAPIToken(t, "ops", "ops-secret", "*", "locks:read", "apply-lock:write"),
+		newTestAPIToken(t, "team-a", "team-a-secret", "github.com/team-a/*", "locks:read", "locks:write"),
+	}
+	lockedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	applyLocker.EXPECT().LockApply().Return(locking.ApplyCommandLock{Locked: true, Time: lockedAt}, nil)
+	w := httptest.NewRecorder()
+	ac.LockApply(w, lockRequest("POST", url.Values{}, "ops-secret"))
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.ApplyLockAPI
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, true, result.Locked)
+	Equals(t, lockedAt, *result.LockedAt)
+
+	w = httptest.NewRecorder()
+	ac.LockApply(w, lockRequest("POST", url.Values{}, "team-a-secret"))
+	ResponseContains(t, w, http.StatusForbidden, `is missing the \"apply-lock:write\" scope`)
+
+	applyLocker.EXPECT().CheckApplyLock().Return(locking.ApplyCommandLock{Locked: true, Time: lockedAt}, nil)
+	w = httptest.NewRecorder()
+	ac.GetApplyLock(w, lockRequest("GET", url.Values{}, "team-a-secret"))
+	ResponseContains(t, w, http.StatusOK, `"locked":true`)
+
+	applyLocker.EXPECT().UnlockApply().Return(nil)
+	w = httptest.NewRecorder()
+	ac.UnlockApply(w, lockRequest("DELETE", url.Values{}, "ops-secret"))
+	ResponseContains(t, w, http.StatusOK, `"locked":false`)
+}
+
+func TestAPIController_ApplyLockDisabled(t *testing.T) {
+	ac, _, _ := setup(t)
+
+	w := httptest.NewRecorder()
+	ac.LockApply(w, lockRequest("POST", url.Values{}, atlantisToken))
+
+	ResponseContains(t, w, http.StatusServiceUnavailable, "the global apply lock is not enabled")
+}
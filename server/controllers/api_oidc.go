@@ -0,0 +1,320 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// Claims matched by OIDC rules. GitHub Actions and GitLab CI both issue
+// ref and environment claims; repository is GitHub's name for the repo.
+const (
+	oidcRepositoryClaim  = "repository"
+	oidcRefClaim         = "ref"
+	oidcEnvironmentClaim = "environment"
+)
+
+const (
+	oidcAuthMethod = "oidc"
+	// oidcLeeway allows for clock skew between Atlantis and the issuer.
+	oidcLeeway = time.Minute
+	// jwksMaxAge is how long fetched signing keys are used before they're
+	// fetched again.
+	jwksMaxAge = time.Hour
+	// jwksMinRefresh limits how often an unknown key ID causes a fetch.
+	jwksMinRefresh = time.Minute
+)
+
+// oidcSigningMethods are the JWT algorithms accepted for OIDC tokens. Only
+// asymmetric algorithms make sense with keys published in a JWKS.
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// OIDCRule grants callers presenting an OIDC token whose claims match
+// Repository, Ref and Environment access to the API. The claim patterns are
+// regular expressions that must match the whole claim; empty patterns match
+// any value.
+type OIDCRule struct {
+	Repository  string
+	Ref         string
+	Environment string
+	// Scopes are the API scopes granted by the rule.
+	Scopes []string
+	// Repos is a pattern in the --repo-allowlist format restricting the
+	// repositories the caller may act on.
+	Repos string
+	// Refs is a regular expression restricting the refs the caller may plan
+	// and apply. Empty allows every ref.
+	Refs string
+}
+
+type oidcRule struct {
+	claims map[string]*regexp.Regexp
+	grant  apiGrant
+}
+
+// OIDCIssuer validates OIDC tokens, such as the ones GitHub Actions and
+// GitLab CI issue to jobs, and maps their claims to API access.
+type OIDCIssuer struct {
+	Issuer   string
+	Audience string
+	keys     *jwksCache
+	rules    []oidcRule
+}
+
+// NewOIDCIssuer returns an issuer accepting tokens from issuer for audience,
+// signed by a key from the JWKS in jwksFile or at jwksURL. Exactly one of
+// jwksFile and jwksURL must be set. A JWKS file is read immediately so
+// configuration errors surface on startup.
+func NewOIDCIssuer(issuer string, audience string, jwksFile string, jwksURL string, rules []OIDCRule) (*OIDCIssuer, error) {
+	if issuer == "" {
+		return nil, errors.New("OIDC issuer must not be empty")
+	}
+	if audience == "" {
+		return nil, fmt.Errorf("OIDC issuer %q: audience must not be empty", issuer)
+	}
+	if (jwksFile == "") == (jwksURL == "") {
+		return nil, fmt.Errorf("OIDC issuer %q: exactly one of jwks-file and jwks-url must be set", issuer)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("OIDC issuer %q: at least one rule is required", issuer)
+	}
+
+	i := &OIDCIssuer{Issuer: issuer, Audience: audience}
+	for n, r := range rules {
+		owner := fmt.Sprintf("OIDC issuer %q rule %d", issuer, n+1)
+		// Anyone can get a token from a public issuer like GitHub Actions,
+		// so rules must at least pin the repository it was issued to.
+		if r.Repository == "" {
+			return nil, fmt.Errorf("%s: repository must not be empty", owner)
+		}
+		rule := oidcRule{claims: make(map[string]*regexp.Regexp)}
+		for claim, pattern := range map[string]string{oidcRepositoryClaim: r.Repository, oidcRefClaim: r.Ref, oidcEnvironmentClaim: r.Environment} {
+			if pattern == "" {
+				continue
+			}
+			re, err := compileFullMatch(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s: parsing %s: %w", owner, claim, err)
+			}
+			rule.claims[claim] = re
+		}
+		var err error
+		rule.grant, err = newAPIGrant(owner, r.Scopes, r.Repos)
+		if err != nil {
+			return nil, err
+		}
+		if r.Refs != "" {
+			rule.grant.refs, err = compileFullMatch(r.Refs)
+			if err != nil {
+				return nil, fmt.Errorf("%s: parsing refs: %w", owner, err)
+			}
+		}
+		i.rules = append(i.rules, rule)
+	}
+
+	if jwksFile != "" {
+		i.keys = newJWKSCache(func() ([]byte, error) { return os.ReadFile(jwksFile) }) // nolint: gosec
+		if err := i.keys.refresh(); err != nil {
+			return nil, fmt.Errorf("OIDC issuer %q: %w", issuer, err)
+		}
+	} else {
+		client := &http.Client{Timeout: 10 * time.Second}
+		i.keys = newJWKSCache(func() ([]byte, error) { return fetchJWKS(client, jwksURL) })
+	}
+	return i, nil
+}
+
+func compileFullMatch(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// verify returns the claims of raw if it's a valid token from the issuer.
+func (i *OIDCIssuer) verify(raw string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return i.keys.key(kid)
+		},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(i.Issuer),
+		jwt.WithAudience(i.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(oidcLeeway),
+	)
+	return claims, err
+}
+
+// grant returns the grant of the first rule matching claims, or nil.
+func (i *OIDCIssuer) grant(claims jwt.MapClaims) *apiGrant {
+	for n := range i.rules {
+		if i.rules[n].matches(claims) {
+			return &i.rules[n].grant
+		}
+	}
+	return nil
+}
+
+func (r oidcRule) matches(claims jwt.MapClaims) bool {
+	for claim, re := range r.claims {
+		value, ok := claims[claim].(string)
+		if !ok || !re.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// oidcPrincipal authenticates the caller presenting the bearer token raw.
+func (m *APIMiddleware) oidcPrincipal(r *http.Request, raw string) (*APIPrincipal, int, *APIError) {
+	unverified, _, err := jwt.NewParser().ParseUnverified(raw, jwt.MapClaims{})
+	if err != nil {
+		m.Logger.Debug("rejecting API bearer token: %s", err)
+		return nil, http.StatusUnauthorized, NewAPIError(ErrCodeUnauthorized, "invalid OIDC token")
+	}
+	iss, _ := unverified.Claims.GetIssuer()
+	var issuer *OIDCIssuer
+	for _, i := range m.OIDCIssuers {
+		if i.Issuer == iss {
+			issuer = i
+			break
+		}
+	}
+	if issuer == nil {
+		m.Logger.Debug("rejecting API bearer token from unknown issuer %q", iss)
+		return nil, http.StatusUnauthorized, NewAPIError(ErrCodeUnauthorized, "invalid OIDC token")
+	}
+
+	claims, err := issuer.verify(raw)
+	if err != nil {
+		m.Logger.Debug("rejecting API bearer token from %q: %s", iss, err)
+		return nil, http.StatusUnauthorized, NewAPIError(ErrCodeUnauthorized, "invalid OIDC token")
+	}
+	grant := issuer.grant(claims)
+	if grant == nil {
+		return nil, http.StatusForbidden, NewAPIError(ErrCodeForbidden, "OIDC token does not match any rule")
+	}
+	name, _ := claims.GetSubject()
+	if name == "" {
+		name = oidcAuthMethod
+	}
+	return &APIPrincipal{
+		Caller: &models.APICaller{
+			Name:       name,
+			AuthMethod: oidcAuthMethod,
+			RemoteAddr: r.RemoteAddr,
+		},
+		grant: grant,
+	}, http.StatusOK, nil
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// jwksCache holds the signing keys of an issuer. Keys are loaded again when
+// they're older than jwksMaxAge or a token names an unknown key, so key
+// rotations are picked up.
+type jwksCache struct {
+	load func() ([]byte, error)
+	now  func() time.Time
+
+	mu       sync.Mutex
+	keys     jose.JSONWebKeySet
+	loadedAt time.Time
+}
+
+func newJWKSCache(load func() ([]byte, error)) *jwksCache {
+	return &jwksCache{load: load, now: time.Now}
+}
+
+// key returns the public key with ID kid. Tokens without a kid can only be
+// verified when the JWKS has a single key.
+func (c *jwksCache) key(kid string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := c.lookup(kid)
+	age := c.now().Sub(c.loadedAt)
+	if (key == nil && age >= jwksMinRefresh) || age >= jwksMaxAge {
+		if err := c.refreshLocked(); err != nil {
+			if key != nil {
+				return key, nil
+			}
+			return nil, err
+		}
+		key = c.lookup(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no signing key with kid %q", kid)
+	}
+	return key, nil
+}
+
+func (c *jwksCache) lookup(kid string) any {
+	keys := c.keys.Keys
+	if kid != "" {
+		keys = c.keys.Key(kid)
+	} else if len(keys) != 1 {
+		return nil
+	}
+	for _, k := range keys {
+		if k.Use != "enc" && k.IsPublic() {
+			return k.Key
+		}
+	}
+	return nil
+}
+
+func (c *jwksCache) refresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshLocked()
+}
+
+func (c *jwksCache) refreshLocked() error {
+	// Record the attempt even if it fails so a broken JWKS endpoint isn't
+	// hammered by every request.
+	c.loadedAt = c.now()
+	data, err := c.load()
+	if err != nil {
+		return fmt.Errorf("loading JWKS: %w", err)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("parsing JWKS: %w", err)
+	}
+	c.keys = keys
+	return nil
+}
+
+func fetchJWKS(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
@@ -0,0 +1,286 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	driftmocks "github.com/runatlantis/atlantis/server/core/drift/mocks"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+const (
+	testOIDCIssuer   = "https://token.actions.githubusercontent.com"
+	testOIDCAudience = "https://atlantis.example.com"
+	testOIDCKeyID    = "key-1"
+)
+
+func newTestOIDCKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Ok(t, err)
+	return key
+}
+
+func testJWKS(t *testing.T, key *rsa.PrivateKey) []byte {
+	t.Helper()
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &key.PublicKey,
+		KeyID:     testOIDCKeyID,
+		Algorithm: "RS256",
+		Use:       "sig",
+	}}})
+	Ok(t, err)
+	return jwks
+}
+
+func writeTestJWKS(t *testing.T, key *rsa.PrivateKey) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	Ok(t, os.WriteFile(path, testJWKS(t, key), 0600))
+	return path
+}
+
+// signTestOIDCToken returns a GitHub Actions style token for my-org/infra on
+// main, with claims overridden by overrides.
+func signTestOIDCToken(t *testing.T, key *rsa.PrivateKey, overrides jwt.MapClaims) string {
+	t.Helper()
+	claims := jwt.MapClaims{
+		"iss":         testOIDCIssuer,
+		"aud":         testOIDCAudience,
+		"sub":         "repo:my-org/infra:environment:production",
+		"repository":  "my-org/infra",
+		"ref":         "refs/heads/main",
+		"environment": "production",
+		"iat":         time.Now().Unix(),
+		"exp":         time.Now().Add(5 * time.Minute).Unix(),
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testOIDCKeyID
+	signed, err := token.SignedString(key)
+	Ok(t, err)
+	return signed
+}
+
+var testOIDCRules = []controllers.OIDCRule{{
+	Repository:  "my-org/infra",
+	Ref:         "refs/heads/main",
+	Environment: "production",
+	Scopes:      []string{"plan", "drift:read"},
+	Repos:       "gitlab.com/my-org/infra",
+	Refs:        "main",
+}}
+
+func TestNewOIDCIssuer(t *testing.T) {
+	jwksFile := writeTestJWKS(t, newTestOIDCKey(t))
+	invalidJWKSFile := filepath.Join(t.TempDir(), "invalid.json")
+	Ok(t, os.WriteFile(invalidJWKSFile, []byte("not json"), 0600))
+
+	cases := []struct {
+		description string
+		audience    string
+		jwksFile    string
+		jwksURL     string
+		rules       []controllers.OIDCRule
+		expErr      string
+	}{
+		{
+			description: "valid",
+			audience:    testOIDCAudience,
+			jwksFile:    jwksFile,
+			rules:       testOIDCRules,
+		},
+		{
+			description: "missing audience",
+			jwksFile:    jwksFile,
+			rules:       testOIDCRules,
+			expErr:      `OIDC issuer "https://token.actions.githubusercontent.com": audience must not be empty`,
+		},
+		{
+			description: "both jwks sources",
+			audience:    testOIDCAudience,
+			jwksFile:    jwksFile,
+			jwksURL:     "https://example.com/jwks",
+			rules:       testOIDCRules,
+			expErr:      `OIDC issuer "https://token.actions.githubusercontent.com": exactly one of jwks-file and jwks-url must be set`,
+		},
+		{
+			description: "no rules",
+			audience:    testOIDCAudience,
+			jwksFile:    jwksFile,
+			expErr:      `OIDC issuer "https://token.actions.githubusercontent.com": at least one rule is required`,
+		},
+		{
+			description: "rule without repository",
+			audience:    testOIDCAudience,
+			jwksFile:    jwksFile,
+			rules:       []controllers.OIDCRule{{Ref: "refs/heads/main", Scopes: []string{"plan"}, Repos: "*"}},
+			expErr:      `OIDC issuer "https://token.actions.githubusercontent.com" rule 1: repository must not be empty`,
+		},
+		{
+			description: "invalid refs regex",
+			audience:    testOIDCAudience,
+			jwksFile:    jwksFile,
+			rules:       []controllers.OIDCRule{{Repository: "my-org/infra", Scopes: []string{"plan"}, Repos: "*", Refs: "("}},
+			expErr:      "OIDC issuer \"https://token.actions.githubusercontent.com\" rule 1: parsing refs: error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			description: "invalid jwks file",
+			audience:    testOIDCAudience,
+			jwksFile:    invalidJWKSFile,
+			rules:       testOIDCRules,
+			expErr:      `OIDC issuer "https://token.actions.githubusercontent.com": parsing JWKS: invalid character 'o' in literal null (expecting 'u')`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			_, err := controllers.NewOIDCIssuer(testOIDCIssuer, c.audience, c.jwksFile, c.jwksURL, c.rules)
+			if c.expErr != "" {
+				ErrEquals(t, c.expErr, err)
+				return
+			}
+			Ok(t, err)
+		})
+	}
+}
+
+func planWithBearer(t *testing.T, ac *controllers.APIController, bearer string, ref string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, _ := json.Marshal(controllers.APIRequest{Repository: "my-org/infra", Ref: ref, Type: "Gitlab", Projects: []string{"default"}})
+	req, _ := http.NewRequest("POST", "", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	w := httptest.NewRecorder()
+	ac.Plan(w, req)
+	return w
+}
+
+func TestAPIController_PlanWithOIDCToken(t *testing.T) {
+	key := newTestOIDCKey(t)
+	issuer, err := controllers.NewOIDCIssuer(testOIDCIssuer, testOIDCAudience, writeTestJWKS(t, key), "", testOIDCRules)
+	Ok(t, err)
+	ac, projectCommandBuilder, _ := setup(t)
+	ac.APISecret = nil
+	ac.OIDCIssuers = []*controllers.OIDCIssuer{issuer}
+
+	w := planWithBearer(t, ac, signTestOIDCToken(t, key, nil), "main")
+
+	ResponseContains(t, w, http.StatusOK, "")
+	ctx, _ := projectCommandBuilder.VerifyWasCalledOnce().BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]()).GetCapturedArguments()
+	Equals(t, models.User{Username: "repo:my-org/infra:environment:production"}, ctx.User)
+}
+
+func TestAPIController_PlanWithOIDCTokenRejected(t *testing.T) {
+	key := newTestOIDCKey(t)
+	issuer, err := controllers.NewOIDCIssuer(testOIDCIssuer, testOIDCAudience, writeTestJWKS(t, key), "", testOIDCRules)
+	Ok(t, err)
+
+	cases := []struct {
+		description string
+		token       string
+		ref         string
+		expCode     int
+		expBody     string
+	}{
+		{
+			description: "wrong audience",
+			token:       signTestOIDCToken(t, key, jwt.MapClaims{"aud": "https://other.example.com"}),
+			expCode:     http.StatusUnauthorized,
+			expBody:     "invalid OIDC token",
+		},
+		{
+			description: "expired",
+			token:       signTestOIDCToken(t, key, jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}),
+			expCode:     http.StatusUnauthorized,
+			expBody:     "invalid OIDC token",
+		},
+		{
+			description: "unknown issuer",
+			token:       signTestOIDCToken(t, key, jwt.MapClaims{"iss": "https://gitlab.example.com"}),
+			expCode:     http.StatusUnauthorized,
+			expBody:     "invalid OIDC token",
+		},
+		{
+			description: "signed by another key",
+			token:       signTestOIDCToken(t, newTestOIDCKey(t), nil),
+			expCode:     http.StatusUnauthorized,
+			expBody:     "invalid OIDC token",
+		},
+		{
+			description: "other repository",
+			token:       signTestOIDCToken(t, key, jwt.MapClaims{"repository": "my-org/infra-fork"}),
+			expCode:     http.StatusForbidden,
+			expBody:     "OIDC token does not match any rule",
+		},
+		{
+			description: "other environment",
+			token:       signTestOIDCToken(t, key, jwt.MapClaims{"environment": "staging"}),
+			expCode:     http.StatusForbidden,
+			expBody:     "OIDC token does not match any rule",
+		},
+		{
+			description: "disallowed ref",
+			token:       signTestOIDCToken(t, key, nil),
+			ref:         "feature",
+			expCode:     http.StatusForbidden,
+			expBody:     `OIDC token \"repo:my-org/infra:environment:production\" is not allowed to use ref feature`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			ac, projectCommandBuilder, _ := setup(t)
+			ac.OIDCIssuers = []*controllers.OIDCIssuer{issuer}
+			ref := c.ref
+			if ref == "" {
+				ref = "main"
+			}
+
+			w := planWithBearer(t, ac, c.token, ref)
+
+			ResponseContains(t, w, c.expCode, c.expBody)
+			projectCommandBuilder.VerifyWasCalled(Never()).BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())
+		})
+	}
+}
+
+func TestAPIController_OIDCTokenWithJWKSURL(t *testing.T) {
+	key := newTestOIDCKey(t)
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(testJWKS(t, key)) // nolint: errcheck
+	}))
+	defer jwksServer.Close()
+	issuer, err := controllers.NewOIDCIssuer(testOIDCIssuer, testOIDCAudience, "", jwksServer.URL, testOIDCRules)
+	Ok(t, err)
+	ac, _, _ := setup(t)
+	ac.OIDCIssuers = []*controllers.OIDCIssuer{issuer}
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Get(Any[string](), Any[drift.GetOptions]())).ThenReturn(nil, nil)
+	ac.DriftStorage = driftStorage
+
+	req, _ := http.NewRequest("GET", "?repository=my-org/infra&type=Gitlab", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestOIDCToken(t, key, nil))
+	w := httptest.NewRecorder()
+	ac.DriftStatus(w, req)
+
+	Equals(t, http.StatusOK, w.Code)
+}
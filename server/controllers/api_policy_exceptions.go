@@ -0,0 +1,91 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// ListPolicyExceptions handles GET /api/policies/exceptions requests. It lists
+// the policy exceptions granted with approve_policies --expires, including
+// expired ones, so they can be audited.
+// Query parameters:
+//   - repository: required, the full repository name (owner/repo)
+//   - type: required, the VCS provider type
+//   - pr: optional, only list the exceptions granted on this pull request
+//
+// This is an authenticated endpoint that requires the policies:read scope.
+func (a *APIController) ListPolicyExceptions(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	principal := middleware.RequireScope(w, r, APIScopePoliciesRead)
+	if principal == nil {
+		return
+	}
+	if a.Database == nil {
+		responder.ServiceUnavailable(w, r, "policy exceptions are not enabled")
+		return
+	}
+
+	repository := r.URL.Query().Get("repository")
+	if repository == "" {
+		responder.ValidationFailed(w, r, "missing required parameter",
+			ValidationError{Field: "repository", Message: "repository parameter is required"})
+		return
+	}
+	vcsType := r.URL.Query().Get("type")
+	if vcsType == "" {
+		responder.ValidationFailed(w, r, "missing required parameter",
+			ValidationError{Field: "type", Message: "type parameter is required"})
+		return
+	}
+	pullNum := 0
+	if pr := r.URL.Query().Get("pr"); pr != "" {
+		var err error
+		pullNum, err = strconv.Atoi(strings.TrimSpace(pr))
+		if err != nil || pullNum <= 0 {
+			responder.ValidationFailed(w, r, "invalid pr parameter",
+				ValidationError{Field: "pr", Message: "must be a positive integer"})
+			return
+		}
+	}
+	VCSHostType, err := models.NewVCSHostType(vcsType)
+	if err != nil {
+		responder.ValidationFailed(w, r, "invalid VCS type",
+			ValidationError{Field: "type", Message: err.Error()})
+		return
+	}
+	cloneURL, err := a.VCSClient.GetCloneURL(a.Logger, VCSHostType, repository)
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("failed to get clone URL: %w", err))
+		return
+	}
+	baseRepo, err := a.Parser.ParseAPIPlanRequest(VCSHostType, repository, cloneURL)
+	if err != nil {
+		responder.ValidationFailed(w, r, fmt.Sprintf("failed to parse repository: %v", err))
+		return
+	}
+	if !a.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
+		responder.Forbidden(w, r, "repository is not in the allowlist")
+		return
+	}
+	if !principal.CanAccessRepo(baseRepo) {
+		responder.Forbidden(w, r, "caller is not allowed to access this repository")
+		return
+	}
+
+	exceptions, err := a.Database.GetPolicyExceptions(baseRepo.FullName, pullNum)
+	if err != nil {
+		responder.InternalError(w, r, fmt.Errorf("getting policy exceptions for %s: %w", baseRepo.FullName, err))
+		return
+	}
+	responder.Success(w, r, http.StatusOK, NewPolicyExceptionListAPI(exceptions, time.Now()))
+}
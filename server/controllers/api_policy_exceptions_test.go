@@ -0,0 +1,126 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/controllers"
+	dbmocks "github.com/runatlantis/atlantis/server/core/db/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// setupPolicyExceptions returns an API controller with a database for the
+// policy exceptions endpoint.
+func setupPolicyExceptions(t *testing.T) (*controllers.APIController, *dbmocks.MockDatabase) {
+	ac, _, _ := setup(t)
+	database := dbmocks.NewMockDatabase(gomock.NewController(t))
+	ac.Database = database
+	ac.APITokens = []*controllers.APIToken{
+		newTestAPIToken(t, "auditor", "auditor-secret", "github.com/team-a/*", "policies:read"),
+		newTestAPIToken(t, "reader", "reader-secret", "*", "locks:read"),
+	}
+	return ac, database
+}
+
+func TestAPIController_ListPolicyExceptions(t *testing.T) {
+	ac, database := setupPolicyExceptions(t)
+	granted := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+	exception := models.PolicyException{
+		Project:       models.NewProject("team-a/repo", "infra", ""),
+		Workspace:     "default",
+		PullNum:       7,
+		PolicySetName: "policies",
+		Hashes:        []string{"abc"},
+		Approver:      "alice",
+		Reason:        "accepted risk",
+		Time:          granted,
+		Expires:       granted.Add(24 * time.Hour),
+	}
+	active := exception
+	active.Expires = granted.Add(14 * 24 * time.Hour)
+	database.EXPECT().GetPolicyExceptions("team-a/repo", 7).Return([]models.PolicyException{exception, active}, nil)
+
+	w := httptest.NewRecorder()
+	ac.ListPolicyExceptions(w, lockRequest("GET", url.Values{"repository": {"team-a/repo"}, "type": {"Github"}, "pr": {"7"}}, "auditor-secret"))
+
+	Equals(t, http.StatusOK, w.Code)
+	var result controllers.PolicyExceptionListAPI
+	parseAPIResponse(t, w.Body.Bytes(), &result)
+	Equals(t, 2, result.TotalCount)
+	Equals(t, controllers.PolicyExceptionAPI{
+		Repository:    "team-a/repo",
+		Path:          "infra",
+		Workspace:     "default",
+		PullRequestID: 7,
+		PolicySet:     "policies",
+		Hashes:        []string{"abc"},
+		ApprovedBy:    "alice",
+		Reason:        "accepted risk",
+		ApprovedAt:    granted,
+		ExpiresAt:     granted.Add(24 * time.Hour),
+		Expired:       true,
+	}, result.Exceptions[0])
+	Equals(t, false, result.Exceptions[1].Expired)
+}
+
+func TestAPIController_ListPolicyExceptionsAllPulls(t *testing.T) {
+	ac, database := setupPolicyExceptions(t)
+	database.EXPECT().GetPolicyExceptions("team-a/repo", 0).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	ac.ListPolicyExceptions(w, lockRequest("GET", url.Values{"repository": {"team-a/repo"}, "type": {"Github"}}, "auditor-secret"))
+
+	ResponseContains(t, w, http.StatusOK, `"exceptions":[]`)
+}
+
+func TestAPIController_ListPolicyExceptionsErrors(t *testing.T) {
+	cases := []struct {
+		description string
+		query       url.Values
+		token       string
+		expCode     int
+	}{
+		{
+			description: "missing scope",
+			query:       url.Values{"repository": {"team-a/repo"}, "type": {"Github"}},
+			token:       "reader-secret",
+			expCode:     http.StatusForbidden,
+		},
+		{
+			description: "missing repository",
+			query:       url.Values{"type": {"Github"}},
+			token:       "auditor-secret",
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "invalid pr",
+			query:       url.Values{"repository": {"team-a/repo"}, "type": {"Github"}, "pr": {"abc"}},
+			token:       "auditor-secret",
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "inaccessible repo",
+			query:       url.Values{"repository": {"team-b/repo"}, "type": {"Github"}},
+			token:       "auditor-secret",
+			expCode:     http.StatusForbidden,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			ac, _ := setupPolicyExceptions(t)
+
+			w := httptest.NewRecorder()
+			ac.ListPolicyExceptions(w, lockRequest("GET", c.query, c.token))
+
+			Equals(t, c.expCode, w.Code)
+		})
+	}
+}
@@ -4,13 +4,15 @@
 package controllers
 
 import (
-	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/logging"
 )
 
@@ -198,35 +200,67 @@ func (a *APIResponder) writeJSON(w http.ResponseWriter, code int, data any) {
 // APIMiddleware provides common middleware functions for API endpoints.
 type APIMiddleware struct {
 	APISecret []byte
+	// APITokens are named tokens with limited scopes, accepted alongside the
+	// API secret.
+	APITokens []*APIToken
+	// OIDCIssuers accept OIDC tokens passed as bearer tokens, e.g. from CI
+	// jobs.
+	OIDCIssuers []*OIDCIssuer
+	// AuditLog records every authentication attempt. It may be nil.
+	AuditLog  *audit.Log
 	Logger    logging.SimpleLogging
 	Responder *APIResponder
 }
 
 // NewAPIMiddleware creates a new APIMiddleware.
-func NewAPIMiddleware(apiSecret []byte, logger logging.SimpleLogging) *APIMiddleware {
+func NewAPIMiddleware(apiSecret []byte, apiTokens []*APIToken, oidcIssuers []*OIDCIssuer, auditLog *audit.Log, logger logging.SimpleLogging) *APIMiddleware {
 	return &APIMiddleware{
-		APISecret: apiSecret,
-		Logger:    logger,
-		Responder: NewAPIResponder(logger),
+		APISecret:   apiSecret,
+		APITokens:   apiTokens,
+		OIDCIssuers: oidcIssuers,
+		AuditLog:    auditLog,
+		Logger:      logger,
+		Responder:   NewAPIResponder(logger),
 	}
 }
 
-// RequireAuth is middleware that validates the API secret token.
-// Returns true if authentication passed, false if it failed (response already sent).
-func (m *APIMiddleware) RequireAuth(w http.ResponseWriter, r *http.Request) bool {
-	if len(m.APISecret) == 0 {
-		m.Responder.Error(w, r, http.StatusServiceUnavailable,
-			NewAPIError(ErrCodeServiceUnavailable, "API is disabled"))
-		return false
-	}
+// Enabled returns true if an API secret, API token or OIDC issuer is
+// configured.
+func (m *APIMiddleware) Enabled() bool {
+	return len(m.APISecret) > 0 || m.Scoped()
+}
+
+// Scoped returns true if callers with limited access, i.e. API tokens or
+// OIDC tokens, are configured.
+func (m *APIMiddleware) Scoped() bool {
+	return len(m.APITokens) > 0 || len(m.OIDCIssuers) > 0
+}
 
-	secret := r.Header.Get(atlantisTokenHeader)
-	if subtle.ConstantTimeCompare([]byte(secret), m.APISecret) != 1 {
-		m.Responder.Unauthorized(w, r, "invalid or missing API token")
-		return false
+// RequireScope is middleware that authenticates the caller and checks that it
+// was granted scope. Returns the caller if authentication passed, nil if it
+// failed (response already sent).
+func (m *APIMiddleware) RequireScope(w http.ResponseWriter, r *http.Request, scope APIScope) *APIPrincipal {
+	principal, code, apiErr := m.authenticate(r, scope)
+	if apiErr != nil {
+		m.Responder.Error(w, r, code, apiErr)
+		return nil
 	}
+	return principal
+}
 
-	return true
+// Caller returns the identity of a caller authenticated with the shared API
+// secret. The secret doesn't identify anyone, so callers may name themselves with the
+// X-Atlantis-User header; otherwise the name is "api".
+func (m *APIMiddleware) Caller(r *http.Request) *models.APICaller {
+	name := strings.TrimSpace(r.Header.Get(atlantisUserHeader))
+	if name == "" {
+		name = "api"
+	}
+	return &models.APICaller{
+		Name:       name,
+		AuthMethod: apiSecretAuthMethod,
+		RemoteAddr: r.RemoteAddr,
+	}
 }
 
 // SetJSONContentType sets the Content-Type header for JSON responses.
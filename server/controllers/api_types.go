@@ -6,6 +6,9 @@ package controllers
 import (
 	"time"
 
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 )
@@ -338,6 +341,18 @@ type RemediationResultAPI struct {
 	Summary RemediationSummaryAPI `json:"summary"`
 	// Error contains any top-level error.
 	Error string `json:"error,omitempty"`
+	// RequestedBy identifies the API caller that requested the remediation.
+	RequestedBy *APICallerAPI `json:"requested_by,omitempty"`
+}
+
+// APICallerAPI is the API representation of the caller that made a request.
+type APICallerAPI struct {
+	// Name is the caller's identity.
+	Name string `json:"name"`
+	// AuthMethod is how the caller authenticated.
+	AuthMethod string `json:"auth_method"`
+	// RemoteAddr is the network address the request came from.
+	RemoteAddr string `json:"remote_addr,omitempty"`
 }
 
 // RemediationProjectAPI is the API representation of a project remediation result.
@@ -380,6 +395,8 @@ type RemediationListAPI struct {
 	Count int `json:"count"`
 	// Results contains the remediation results.
 	Results []RemediationResultAPI `json:"results"`
+	// NextCursor fetches the next page of results. Empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // NewRemediationResultAPI converts an internal RemediationResult to its API representation.
@@ -399,6 +416,13 @@ func NewRemediationResultAPI(rr *models.RemediationResult) RemediationResultAPI
 	if rr.CompletedAt != nil {
 		result.CompletedAt = rr.CompletedAt
 	}
+	if rr.RequestedBy != nil {
+		result.RequestedBy = &APICallerAPI{
+			Name:       rr.RequestedBy.Name,
+			AuthMethod: rr.RequestedBy.AuthMethod,
+			RemoteAddr: rr.RequestedBy.RemoteAddr,
+		}
+	}
 
 	for _, p := range rr.Projects {
 		apiProject := RemediationProjectAPI{
@@ -487,19 +511,181 @@ func NewListLocksResultAPI(locks map[string]models.ProjectLock) ListLocksResultA
 	}
 
 	for name, lock := range locks {
-		result.Locks = append(result.Locks, LockDetailAPI{
-			ID:             name,
-			ProjectName:    lock.Project.ProjectName,
-			Repository:     lock.Project.RepoFullName,
-			Path:           lock.Project.Path,
-			Workspace:      lock.Workspace,
-			PullRequestID:  lock.Pull.Num,
-			PullRequestURL: lock.Pull.URL,
-			LockedBy:       lock.User.Username,
-			LockedAt:       lock.Time,
-		})
+		result.Locks = append(result.Locks, NewLockDetailAPI(name, lock))
 	}
 
 	result.TotalCount = len(result.Locks)
 	return result
 }
+
+// NewLockDetailAPI creates a LockDetailAPI for the lock with ID id.
+func NewLockDetailAPI(id string, lock models.ProjectLock) LockDetailAPI {
+	return LockDetailAPI{
+		ID:             id,
+		ProjectName:    lock.Project.ProjectName,
+		Repository:     lock.Project.RepoFullName,
+		Path:           lock.Project.Path,
+		Workspace:      lock.Workspace,
+		PullRequestID:  lock.Pull.Num,
+		PullRequestURL: lock.Pull.URL,
+		LockedBy:       lock.User.Username,
+		LockedAt:       lock.Time,
+	}
+}
+
+// ApplyLockAPI is the API representation of the global apply lock.
+type ApplyLockAPI struct {
+	// Locked is true when apply commands are locked, either by the global
+	// apply lock or because apply is disabled.
+	Locked bool `json:"locked"`
+	// LockedAt is when the global apply lock was acquired.
+	LockedAt *time.Time `json:"locked_at"`
+}
+
+// NewApplyLockAPI creates an ApplyLockAPI from the apply lock status.
+func NewApplyLockAPI(lock locking.ApplyCommandLock) ApplyLockAPI {
+	result := ApplyLockAPI{Locked: lock.Locked}
+	if !lock.Time.IsZero() {
+		result.LockedAt = &lock.Time
+	}
+	return result
+}
+
+// PolicyExceptionAPI is the API representation of a policy exception.
+type PolicyExceptionAPI struct {
+	// ProjectName is the name of the project the exception applies to.
+	ProjectName string `json:"project_name"`
+	// Repository is the full repository name (owner/repo).
+	Repository string `json:"repository"`
+	// Path is the relative path to the project within the repository.
+	Path string `json:"path"`
+	// Workspace is the Terraform workspace.
+	Workspace string `json:"workspace"`
+	// PullRequestID is the PR number the exception was granted on.
+	PullRequestID int `json:"pull_request_id"`
+	// PolicySet is the name of the policy set the exception applies to.
+	PolicySet string `json:"policy_set"`
+	// Hashes are the hashes of the failing policy items the exception covers.
+	Hashes []string `json:"hashes"`
+	// ApprovedBy is the policy owner who granted the exception.
+	ApprovedBy string `json:"approved_by"`
+	// Reason is the justification given for the exception.
+	Reason string `json:"reason"`
+	// ApprovedAt is when the exception was granted.
+	ApprovedAt time.Time `json:"approved_at"`
+	// ExpiresAt is when the exception stops applying.
+	ExpiresAt time.Time `json:"expires_at"`
+	// Expired is true if the exception no longer applies.
+	Expired bool `json:"expired"`
+}
+
+// PolicyExceptionListAPI is the API response for listing policy exceptions.
+type PolicyExceptionListAPI struct {
+	// Exceptions contains the policy exceptions, including expired ones.
+	Exceptions []PolicyExceptionAPI `json:"exceptions"`
+	// TotalCount is the total number of policy exceptions.
+	TotalCount int `json:"total_count"`
+}
+
+// NewPolicyExceptionListAPI creates a PolicyExceptionListAPI from the stored
+// exceptions, marking the ones that have expired at now.
+func NewPolicyExceptionListAPI(exceptions []models.PolicyException, now time.Time) PolicyExceptionListAPI {
+	result := PolicyExceptionListAPI{
+		Exceptions: make([]PolicyExceptionAPI, 0, len(exceptions)),
+	}
+
+	for _, e := range exceptions {
+		result.Exceptions = append(result.Exceptions, PolicyExceptionAPI{
+			ProjectName:   e.Project.ProjectName,
+			Repository:    e.Project.RepoFullName,
+			Path:          e.Project.Path,
+			Workspace:     e.Workspace,
+			PullRequestID: e.PullNum,
+			PolicySet:     e.PolicySetName,
+			Hashes:        e.Hashes,
+			ApprovedBy:    e.Approver,
+			Reason:        e.Reason,
+			ApprovedAt:    e.Time,
+			ExpiresAt:     e.Expires,
+			Expired:       e.Expired(now),
+		})
+	}
+
+	result.TotalCount = len(result.Exceptions)
+	return result
+}
+
+// AuditEventListAPI is the API response for listing audit events.
+type AuditEventListAPI struct {
+	// Events contains the matching audit events, newest first. They're
+	// returned as is because the event format is already public: it's what
+	// the audit log file and webhooks receive.
+	Events []audit.Event `json:"events"`
+	// TotalCount is the number of events returned.
+	TotalCount int `json:"total_count"`
+}
+
+// NewAuditEventListAPI creates an AuditEventListAPI from events.
+func NewAuditEventListAPI(events []audit.Event) AuditEventListAPI {
+	if events == nil {
+		events = []audit.Event{}
+	}
+	return AuditEventListAPI{Events: events, TotalCount: len(events)}
+}
+
+// CacheStatsAPI is the API response for provider cache and mirror stats.
+type CacheStatsAPI struct {
+	// ProviderCache is nil when the provider cache is disabled.
+	ProviderCache *ProviderCacheStatsAPI `json:"provider_cache,omitempty"`
+	// ProviderMirror is nil when the provider network mirror is disabled.
+	ProviderMirror *ProviderMirrorStatsAPI `json:"provider_mirror,omitempty"`
+}
+
+// ProviderCacheStatsAPI is the API representation of the provider cache's
+// size and how often terraform init found providers in it since Atlantis
+// started.
+type ProviderCacheStatsAPI struct {
+	// MaxSizeBytes is zero if the cache isn't limited.
+	MaxSizeBytes int64 `json:"max_size_bytes"`
+	SizeBytes    int64 `json:"size_bytes"`
+	// Entries is the number of provider packages in the cache.
+	Entries int   `json:"entries"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	// HitRate is hits / (hits + misses), or zero before the first init.
+	HitRate float64 `json:"hit_rate"`
+	// Added is the number of provider packages added to the cache.
+	Added     int64 `json:"added"`
+	Evictions int64 `json:"evictions"`
+}
+
+// ProviderMirrorStatsAPI is the API representation of the provider network
+// mirror's request counts since Atlantis started.
+type ProviderMirrorStatsAPI struct {
+	Requests int64 `json:"requests"`
+	NotFound int64 `json:"not_found"`
+}
+
+// NewCacheStatsAPI creates a CacheStatsAPI. Either argument may be nil.
+func NewCacheStatsAPI(cache *providercache.Stats, mirror *providercache.MirrorStats) CacheStatsAPI {
+	var result CacheStatsAPI
+	if cache != nil {
+		result.ProviderCache = &ProviderCacheStatsAPI{
+			MaxSizeBytes: cache.MaxSizeBytes,
+			SizeBytes:    cache.SizeBytes,
+			Entries:      cache.Entries,
+			Hits:         cache.Hits,
+			Misses:       cache.Misses,
+			HitRate:      cache.HitRate,
+			Added:        cache.Added,
+			Evictions:    cache.Evictions,
+		}
+	}
+	if mirror != nil {
+		result.ProviderMirror = &ProviderMirrorStatsAPI{
+			Requests: mirror.Requests,
+			NotFound: mirror.NotFound,
+		}
+	}
+	return result
+}
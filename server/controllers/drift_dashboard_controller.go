@@ -0,0 +1,260 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/controllers/web_templates"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// Drift dashboard status filter values.
+const (
+	driftFilterStatusDrift = "drift"
+	driftFilterStatusClean = "clean"
+	driftFilterStatusError = "error"
+)
+
+// DriftDashboardController renders the drift dashboard web pages.
+type DriftDashboardController struct {
+	AtlantisVersion   string                       `validate:"required"`
+	AtlantisURL       *url.URL                     `validate:"required"`
+	Logger            logging.SimpleLogging        `validate:"required"`
+	DriftStorage      drift.Storage                `validate:"required"`
+	DashboardTemplate web_templates.TemplateWriter `validate:"required"`
+	PlanTemplate      web_templates.TemplateWriter `validate:"required"`
+	// Remediator queues plan-only remediations requested from the dashboard.
+	// Nil disables remediation from the dashboard.
+	Remediator RemediationRunner
+}
+
+// Get is the GET /drift route. It renders the stored drift results, filtered
+// by the repository, project, workspace and status query parameters.
+func (d *DriftDashboardController) Get(w http.ResponseWriter, r *http.Request) {
+	all, err := d.DriftStorage.GetAll()
+	if err != nil {
+		d.respond(w, logging.Error, http.StatusServiceUnavailable, "Could not retrieve drift results: %s", err)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := web_templates.DriftFilterData{
+		Repository: query.Get("repository"),
+		Project:    query.Get("project"),
+		Workspace:  query.Get("workspace"),
+		Status:     query.Get("status"),
+	}
+
+	data := web_templates.DriftDashboardData{
+		Filter:              filter,
+		RemediationEnabled:  d.Remediator != nil,
+		QueuedRemediationID: query.Get("queued"),
+		AtlantisVersion:     d.AtlantisVersion,
+		CleanedBasePath:     d.AtlantisURL.Path,
+	}
+	for repository, drifts := range all {
+		if len(drifts) == 0 {
+			continue
+		}
+		data.RepositoryNames = append(data.RepositoryNames, repository)
+		if filter.Repository != "" && filter.Repository != repository {
+			continue
+		}
+		repo := web_templates.DriftRepoData{Repository: repository}
+		for _, pd := range drifts {
+			if !matchesDriftFilter(pd, filter) {
+				continue
+			}
+			repo.Projects = append(repo.Projects, d.driftProjectData(repository, pd))
+			if pd.Error != "" {
+				repo.ProjectsWithError++
+			} else if pd.Drift.HasDrift {
+				repo.ProjectsWithDrift++
+			}
+		}
+		if len(repo.Projects) == 0 {
+			continue
+		}
+		slices.SortFunc(repo.Projects, compareDriftProjectData)
+		data.Repos = append(data.Repos, repo)
+	}
+	slices.Sort(data.RepositoryNames)
+	slices.SortFunc(data.Repos, func(a, b web_templates.DriftRepoData) int {
+		return strings.Compare(a.Repository, b.Repository)
+	})
+
+	if err := d.DashboardTemplate.Execute(w, data); err != nil {
+		d.Logger.Err("%s", err.Error())
+	}
+}
+
+// GetPlan is the GET /drift/plan route. It renders the output of the plan
+// that produced a drift result.
+func (d *DriftDashboardController) GetPlan(w http.ResponseWriter, r *http.Request) {
+	repository := r.URL.Query().Get("repository")
+	pd, ok := d.findProject(w, repository, r.URL.Query().Get("key"))
+	if !ok {
+		return
+	}
+	err := d.PlanTemplate.Execute(w, web_templates.DriftPlanData{
+		Repository:           repository,
+		ProjectName:          pd.ProjectName,
+		Path:                 pd.Path,
+		Workspace:            pd.Workspace,
+		Ref:                  pd.Ref,
+		LastCheckedFormatted: pd.LastChecked.Format("2006-01-02 15:04:05"),
+		PlanOutput:           pd.PlanOutput,
+		AtlantisVersion:      d.AtlantisVersion,
+		CleanedBasePath:      d.AtlantisURL.Path,
+	})
+	if err != nil {
+		d.Logger.Err("%s", err.Error())
+	}
+}
+
+// Remediate is the POST /drift/remediate route. It queues a plan-only
+// remediation for a drift result and redirects back to the dashboard.
+func (d *DriftDashboardController) Remediate(w http.ResponseWriter, r *http.Request) {
+	if d.Remediator == nil {
+		d.respond(w, logging.Warn, http.StatusNotFound, "Drift remediation from the web UI is not enabled")
+		return
+	}
+	// Browsers send cached basic auth credentials with cross-site form posts,
+	// so reject requests that didn't come from the dashboard itself.
+	if err := http.NewCrossOriginProtection().Check(r); err != nil {
+		d.respond(w, logging.Warn, http.StatusForbidden, "Rejected drift remediation request: %s", err)
+		return
+	}
+
+	repository := r.FormValue("repository")
+	pd, ok := d.findProject(w, repository, r.FormValue("key"))
+	if !ok {
+		return
+	}
+	_, fullName, _ := strings.Cut(repository, "/")
+	if pd.VCSType == "" || fullName == "" {
+		d.respond(w, logging.Warn, http.StatusBadRequest, "Drift result for %s in %s predates remediation from the web UI; run drift detection again", pd.Path, repository)
+		return
+	}
+
+	request := models.RemediationRequest{
+		Repository: fullName,
+		Ref:        pd.Ref,
+		BaseBranch: pd.BaseBranch,
+		Type:       pd.VCSType,
+		Action:     models.RemediationPlanOnly,
+		Paths: []models.DriftDetectionPath{{
+			Directory: pd.Path,
+			Workspace: pd.Workspace,
+		}},
+		RequestedBy: &models.APICaller{AuthMethod: "web"},
+	}
+	if user, _, ok := r.BasicAuth(); ok {
+		request.RequestedBy.Name = user
+	}
+	if pd.ProjectName != "" {
+		request.Projects = []string{pd.ProjectName}
+	}
+	result, err := d.Remediator.RunRemediation(request)
+	if err != nil {
+		d.respond(w, logging.Warn, http.StatusBadRequest, "Could not start drift remediation: %s", err)
+		return
+	}
+	d.Logger.Info("queued drift remediation %s for %s from the web UI", result.ID, repository)
+	http.Redirect(w, r, d.AtlantisURL.Path+"/drift?"+url.Values{"queued": {result.ID}}.Encode(), http.StatusSeeOther)
+}
+
+// findProject returns the drift result of repository identified by key, a
+// drift.StorageKey. It responds with an error if there is no such result.
+func (d *DriftDashboardController) findProject(w http.ResponseWriter, repository, key string) (models.ProjectDrift, bool) {
+	if repository == "" || key == "" {
+		d.respond(w, logging.Warn, http.StatusBadRequest, "repository and key are required")
+		return models.ProjectDrift{}, false
+	}
+	drifts, err := d.DriftStorage.Get(repository, drift.GetOptions{})
+	if err != nil {
+		d.respond(w, logging.Error, http.StatusServiceUnavailable, "Could not retrieve drift results: %s", err)
+		return models.ProjectDrift{}, false
+	}
+	for _, pd := range drifts {
+		if drift.StorageKey(pd) == key {
+			return pd, true
+		}
+	}
+	d.respond(w, logging.Info, http.StatusNotFound, "No drift result found for %s", repository)
+	return models.ProjectDrift{}, false
+}
+
+func (d *DriftDashboardController) driftProjectData(repository string, pd models.ProjectDrift) web_templates.DriftProjectData {
+	key := drift.StorageKey(pd)
+	data := web_templates.DriftProjectData{
+		ProjectName:          pd.ProjectName,
+		Path:                 pd.Path,
+		Workspace:            pd.Workspace,
+		Ref:                  pd.Ref,
+		HasDrift:             pd.Drift.HasDrift,
+		ToAdd:                pd.Drift.ToAdd,
+		ToChange:             pd.Drift.ToChange,
+		ToDestroy:            pd.Drift.ToDestroy,
+		Error:                pd.Error,
+		LastChecked:          pd.LastChecked,
+		LastCheckedFormatted: pd.LastChecked.Format("2006-01-02 15:04:05"),
+	}
+	if pd.PlanOutput != "" {
+		data.PlanPath = "/drift/plan?" + url.Values{"repository": {repository}, "key": {key}}.Encode()
+	}
+	if d.Remediator != nil && pd.VCSType != "" {
+		data.RemediationKey = key
+	}
+	return data
+}
+
+func (d *DriftDashboardController) respond(w http.ResponseWriter, lvl logging.LogLevel, responseCode int, format string, args ...any) {
+	response := fmt.Sprintf(format, args...)
+	d.Logger.Log(lvl, response)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(responseCode)
+	fmt.Fprintln(w, response) // #nosec G705 -- response body is served as text/plain, not interpreted as HTML
+}
+
+func matchesDriftFilter(pd models.ProjectDrift, filter web_templates.DriftFilterData) bool {
+	if filter.Project != "" {
+		needle := strings.ToLower(filter.Project)
+		if !strings.Contains(strings.ToLower(pd.ProjectName), needle) && !strings.Contains(strings.ToLower(pd.Path), needle) {
+			return false
+		}
+	}
+	if filter.Workspace != "" && pd.Workspace != filter.Workspace {
+		return false
+	}
+	switch filter.Status {
+	case driftFilterStatusDrift:
+		return pd.Error == "" && pd.Drift.HasDrift
+	case driftFilterStatusClean:
+		return pd.Error == "" && !pd.Drift.HasDrift
+	case driftFilterStatusError:
+		return pd.Error != ""
+	}
+	return true
+}
+
+func compareDriftProjectData(a, b web_templates.DriftProjectData) int {
+	if c := strings.Compare(a.ProjectName, b.ProjectName); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Path, b.Path); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Workspace, b.Workspace); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Ref, b.Ref)
+}
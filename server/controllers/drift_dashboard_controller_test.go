@@ -0,0 +1,289 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/controllers/web_templates"
+	tMocks "github.com/runatlantis/atlantis/server/controllers/web_templates/mocks"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+var dashboardLastChecked = time.Date(2025, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+var dashboardVPCDrift = models.ProjectDrift{
+	ProjectName: "vpc",
+	Path:        "modules/vpc",
+	Workspace:   "default",
+	Ref:         "main",
+	BaseBranch:  "main",
+	VCSType:     "Github",
+	Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1, ToChange: 2, ToDestroy: 3},
+	LastChecked: dashboardLastChecked,
+	PlanOutput:  "Plan: 1 to add, 2 to change, 3 to destroy.",
+}
+
+func newTestDriftDashboard(t *testing.T, remediator controllers.RemediationRunner) (*controllers.DriftDashboardController, *tMocks.MockTemplateWriter, *tMocks.MockTemplateWriter) {
+	t.Helper()
+	RegisterMockTestingT(t)
+	storage := drift.NewInMemoryStorage()
+	Ok(t, storage.Store("github.com/owner/repo", dashboardVPCDrift))
+	Ok(t, storage.Store("github.com/owner/repo", models.ProjectDrift{
+		Path:        "db",
+		Workspace:   "default",
+		Ref:         "main",
+		Error:       "terraform init failed",
+		LastChecked: dashboardLastChecked,
+	}))
+	Ok(t, storage.Store("github.com/owner/other", models.ProjectDrift{
+		Path:        ".",
+		Workspace:   "staging",
+		Ref:         "main",
+		LastChecked: dashboardLastChecked,
+	}))
+	atlantisURL, err := url.Parse("https://example.com/basepath")
+	Ok(t, err)
+	dashboardTemplate := tMocks.NewMockTemplateWriter()
+	planTemplate := tMocks.NewMockTemplateWriter()
+	return &controllers.DriftDashboardController{
+		AtlantisVersion:   "1.0.0",
+		AtlantisURL:       atlantisURL,
+		Logger:            logging.NewNoopLogger(t),
+		DriftStorage:      storage,
+		DashboardTemplate: dashboardTemplate,
+		PlanTemplate:      planTemplate,
+		Remediator:        remediator,
+	}, dashboardTemplate, planTemplate
+}
+
+func dashboardVPCProjectData() web_templates.DriftProjectData {
+	key := drift.StorageKey(dashboardVPCDrift)
+	return web_templates.DriftProjectData{
+		ProjectName:          "vpc",
+		Path:                 "modules/vpc",
+		Workspace:            "default",
+		Ref:                  "main",
+		HasDrift:             true,
+		ToAdd:                1,
+		ToChange:             2,
+		ToDestroy:            3,
+		LastChecked:          dashboardLastChecked,
+		LastCheckedFormatted: "2025-03-04 05:06:07",
+		PlanPath:             "/drift/plan?" + url.Values{"repository": {"github.com/owner/repo"}, "key": {key}}.Encode(),
+	}
+}
+
+func TestDriftDashboardController_Get(t *testing.T) {
+	dc, tmpl, _ := newTestDriftDashboard(t, nil)
+	req, _ := http.NewRequest("GET", "/drift", nil)
+	w := httptest.NewRecorder()
+
+	dc.Get(w, req)
+
+	tmpl.VerifyWasCalledOnce().Execute(w, web_templates.DriftDashboardData{
+		Repos: []web_templates.DriftRepoData{
+			{
+				Repository: "github.com/owner/other",
+				Projects: []web_templates.DriftProjectData{{
+					Path:                 ".",
+					Workspace:            "staging",
+					Ref:                  "main",
+					LastChecked:          dashboardLastChecked,
+					LastCheckedFormatted: "2025-03-04 05:06:07",
+				}},
+			},
+			{
+				Repository: "github.com/owner/repo",
+				Projects: []web_templates.DriftProjectData{
+					{
+						Path:                 "db",
+						Workspace:            "default",
+						Ref:                  "main",
+						Error:                "terraform init failed",
+						LastChecked:          dashboardLastChecked,
+						LastCheckedFormatted: "2025-03-04 05:06:07",
+					},
+					dashboardVPCProjectData(),
+				},
+				ProjectsWithDrift: 1,
+				ProjectsWithError: 1,
+			},
+		},
+		RepositoryNames: []string{"github.com/owner/other", "github.com/owner/repo"},
+		AtlantisVersion: "1.0.0",
+		CleanedBasePath: "/basepath",
+	})
+}
+
+func TestDriftDashboardController_GetFilters(t *testing.T) {
+	cases := []struct {
+		query    string
+		expPaths []string
+	}{
+		{query: "repository=github.com/owner/other", expPaths: []string{"."}},
+		{query: "status=drift", expPaths: []string{"modules/vpc"}},
+		{query: "status=error", expPaths: []string{"db"}},
+		{query: "status=clean", expPaths: []string{"."}},
+		{query: "project=VPC", expPaths: []string{"modules/vpc"}},
+		{query: "workspace=staging", expPaths: []string{"."}},
+		{query: "repository=github.com/owner/repo&workspace=staging"},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			dc, tmpl, _ := newTestDriftDashboard(t, nil)
+			req, _ := http.NewRequest("GET", "/drift?"+c.query, nil)
+			w := httptest.NewRecorder()
+
+			dc.Get(w, req)
+
+			_, captured := tmpl.VerifyWasCalledOnce().Execute(Any[*httptest.ResponseRecorder](), Any[any]()).GetCapturedArguments()
+			data := captured.(web_templates.DriftDashboardData)
+			var paths []string
+			for _, repo := range data.Repos {
+				for _, p := range repo.Projects {
+					paths = append(paths, p.Path)
+				}
+			}
+			Equals(t, c.expPaths, paths)
+			Equals(t, []string{"github.com/owner/other", "github.com/owner/repo"}, data.RepositoryNames)
+		})
+	}
+}
+
+func TestDriftDashboardController_GetOffersRemediation(t *testing.T) {
+	dc, tmpl, _ := newTestDriftDashboard(t, &fakeRemediationRunner{})
+	req, _ := http.NewRequest("GET", "/drift?repository=github.com/owner/repo&status=drift&queued=abc", nil)
+	w := httptest.NewRecorder()
+
+	dc.Get(w, req)
+
+	expProject := dashboardVPCProjectData()
+	expProject.RemediationKey = drift.StorageKey(dashboardVPCDrift)
+	tmpl.VerifyWasCalledOnce().Execute(w, web_templates.DriftDashboardData{
+		Repos: []web_templates.DriftRepoData{{
+			Repository:        "github.com/owner/repo",
+			Projects:          []web_templates.DriftProjectData{expProject},
+			ProjectsWithDrift: 1,
+		}},
+		RepositoryNames:     []string{"github.com/owner/other", "github.com/owner/repo"},
+		Filter:              web_templates.DriftFilterData{Repository: "github.com/owner/repo", Status: "drift"},
+		RemediationEnabled:  true,
+		QueuedRemediationID: "abc",
+		AtlantisVersion:     "1.0.0",
+		CleanedBasePath:     "/basepath",
+	})
+}
+
+func TestDriftDashboardController_GetPlan(t *testing.T) {
+	dc, _, tmpl := newTestDriftDashboard(t, nil)
+	query := url.Values{"repository": {"github.com/owner/repo"}, "key": {drift.StorageKey(dashboardVPCDrift)}}
+	req, _ := http.NewRequest("GET", "/drift/plan?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+
+	dc.GetPlan(w, req)
+
+	tmpl.VerifyWasCalledOnce().Execute(w, web_templates.DriftPlanData{
+		Repository:           "github.com/owner/repo",
+		ProjectName:          "vpc",
+		Path:                 "modules/vpc",
+		Workspace:            "default",
+		Ref:                  "main",
+		LastCheckedFormatted: "2025-03-04 05:06:07",
+		PlanOutput:           "Plan: 1 to add, 2 to change, 3 to destroy.",
+		AtlantisVersion:      "1.0.0",
+		CleanedBasePath:      "/basepath",
+	})
+}
+
+func TestDriftDashboardController_GetPlanNotFound(t *testing.T) {
+	dc, _, _ := newTestDriftDashboard(t, nil)
+	req, _ := http.NewRequest("GET", "/drift/plan?repository=github.com/owner/repo&key=missing", nil)
+	w := httptest.NewRecorder()
+
+	dc.GetPlan(w, req)
+
+	ResponseContains(t, w, http.StatusNotFound, "No drift result found for github.com/owner/repo")
+}
+
+func newDriftRemediateRequest(t *testing.T, key string) *http.Request {
+	t.Helper()
+	form := url.Values{"repository": {"github.com/owner/repo"}, "key": {key}}
+	req, err := http.NewRequest("POST", "https://example.com/drift/remediate", strings.NewReader(form.Encode()))
+	Ok(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Sec-Fetch-Site", "same-origin")
+	req.SetBasicAuth("admin", "password")
+	return req
+}
+
+func TestDriftDashboardController_Remediate(t *testing.T) {
+	runner := &fakeRemediationRunner{result: &models.RemediationResult{ID: "abc", Status: models.RemediationStatusRunning}}
+	dc, _, _ := newTestDriftDashboard(t, runner)
+	w := httptest.NewRecorder()
+
+	dc.Remediate(w, newDriftRemediateRequest(t, drift.StorageKey(dashboardVPCDrift)))
+
+	Equals(t, http.StatusSeeOther, w.Code)
+	Equals(t, "/basepath/drift?queued=abc", w.Header().Get("Location"))
+	Equals(t, []models.RemediationRequest{{
+		Repository:  "owner/repo",
+		Ref:         "main",
+		BaseBranch:  "main",
+		Type:        "Github",
+		Action:      models.RemediationPlanOnly,
+		Projects:    []string{"vpc"},
+		Paths:       []models.DriftDetectionPath{{Directory: "modules/vpc", Workspace: "default"}},
+		RequestedBy: &models.APICaller{Name: "admin", AuthMethod: "web"},
+	}}, runner.requests)
+}
+
+func TestDriftDashboardController_RemediateRejectsCrossOrigin(t *testing.T) {
+	runner := &fakeRemediationRunner{}
+	dc, _, _ := newTestDriftDashboard(t, runner)
+	req := newDriftRemediateRequest(t, drift.StorageKey(dashboardVPCDrift))
+	req.Header.Set("Sec-Fetch-Site", "cross-site")
+	w := httptest.NewRecorder()
+
+	dc.Remediate(w, req)
+
+	Equals(t, http.StatusForbidden, w.Code)
+	Equals(t, 0, len(runner.requests))
+}
+
+func TestDriftDashboardController_RemediateRequiresVCSType(t *testing.T) {
+	runner := &fakeRemediationRunner{}
+	dc, _, _ := newTestDriftDashboard(t, runner)
+	legacy := dashboardVPCDrift
+	legacy.Path = "legacy"
+	legacy.VCSType = ""
+	Ok(t, dc.DriftStorage.Store("github.com/owner/repo", legacy))
+	w := httptest.NewRecorder()
+
+	dc.Remediate(w, newDriftRemediateRequest(t, drift.StorageKey(legacy)))
+
+	ResponseContains(t, w, http.StatusBadRequest, "run drift detection again")
+	Equals(t, 0, len(runner.requests))
+}
+
+func TestDriftDashboardController_RemediateDisabled(t *testing.T) {
+	dc, _, _ := newTestDriftDashboard(t, nil)
+	req, _ := http.NewRequest("POST", "/drift/remediate", bytes.NewBuffer(nil))
+	w := httptest.NewRecorder()
+
+	dc.Remediate(w, req)
+
+	ResponseContains(t, w, http.StatusNotFound, "not enabled")
+}
@@ -5,6 +5,7 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"github.com/runatlantis/atlantis/server/events/vcs/common"
 	"github.com/runatlantis/atlantis/server/events/vcs/gitea"
 	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
 	tally "github.com/uber-go/tally/v4"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
@@ -99,13 +101,16 @@ type VCSEventsController struct {
 
 // Post handles POST webhook requests.
 func (e *VCSEventsController) Post(w http.ResponseWriter, r *http.Request) {
+	traceCtx, span := tracing.StartRequest(r, "VCSEventsController.Post")
+	defer span.End()
+
 	if r.Header.Get(giteaHeader) != "" {
 		if !e.supportsHost(models.Gitea) {
 			e.respond(w, logging.Debug, http.StatusBadRequest, "Ignoring request since not configured to support Gitea")
 			return
 		}
 		e.Logger.Debug("handling Gitea post")
-		e.handleGiteaPost(w, r)
+		e.handleGiteaPost(traceCtx, w, r)
 		return
 	} else if r.Header.Get(githubHeader) != "" {
 		if !e.supportsHost(models.Github) {
@@ -113,7 +118,7 @@ func (e *VCSEventsController) Post(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		e.Logger.Debug("handling GitHub post")
-		e.handleGithubPost(w, r)
+		e.handleGithubPost(traceCtx, w, r)
 		return
 	} else if r.Header.Get(gitlabHeader) != "" {
 		if !e.supportsHost(models.Gitlab) {
@@ -121,7 +126,7 @@ func (e *VCSEventsController) Post(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		e.Logger.Debug("handling GitLab post")
-		e.handleGitlabPost(w, r)
+		e.handleGitlabPost(traceCtx, w, r)
 		return
 	} else if r.Header.Get(bitbucketEventTypeHeader) != "" {
 		// Bitbucket Cloud and Server use the same event type header but they
@@ -132,7 +137,7 @@ func (e *VCSEventsController) Post(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			e.Logger.Debug("handling Bitbucket Cloud post")
-			e.handleBitbucketCloudPost(w, r)
+			e.handleBitbucketCloudPost(traceCtx, w, r)
 			return
 		} else if r.Header.Get(bitbucketServerRequestIDHeader) != "" {
 			if !e.supportsHost(models.BitbucketServer) {
@@ -140,7 +145,7 @@ func (e *VCSEventsController) Post(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			e.Logger.Debug("handling Bitbucket Server post")
-			e.handleBitbucketServerPost(w, r)
+			e.handleBitbucketServerPost(traceCtx, w, r)
 			return
 		}
 	} else if r.Header.Get(azuredevopsHeader) != "" || r.Header.Get(azuredevopsServerHeader) != "" {
@@ -149,7 +154,7 @@ func (e *VCSEventsController) Post(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		e.Logger.Debug("handling AzureDevops post")
-		e.handleAzureDevopsPost(w, r)
+		e.handleAzureDevopsPost(traceCtx, w, r)
 		return
 	}
 	e.respond(w, logging.Debug, http.StatusBadRequest, "Ignoring request")
@@ -166,7 +171,7 @@ type HTTPResponse struct {
 	err  HTTPError
 }
 
-func (e *VCSEventsController) handleGithubPost(w http.ResponseWriter, r *http.Request) {
+func (e *VCSEventsController) handleGithubPost(traceCtx context.Context, w http.ResponseWriter, r *http.Request) {
 	// Validate the request against the optional webhook secret.
 	payload, err := e.GithubRequestValidator.Validate(r, e.GithubWebhookSecret)
 	if err != nil {
@@ -175,7 +180,7 @@ func (e *VCSEventsController) handleGithubPost(w http.ResponseWriter, r *http.Re
 	}
 
 	githubReqID := "X-Github-Delivery=" + html.EscapeString(r.Header.Get("X-Github-Delivery"))
-	logger := e.Logger.With("gh-request-id", githubReqID)
+	logger := tracing.Logger(e.Logger.With("gh-request-id", githubReqID), traceCtx)
 	scope := e.Scope.SubScope("github_event")
 
 	logger.Debug("request valid")
@@ -193,6 +198,10 @@ func (e *VCSEventsController) handleGithubPost(w http.ResponseWriter, r *http.Re
 		resp = e.HandleGithubPullRequestEvent(logger, event, githubReqID)
 		scope = scope.SubScope(fmt.Sprintf("pr_%s", *event.Action))
 		scope = common.SetGitScopeTags(scope, event.GetRepo().GetFullName(), event.GetNumber())
+	case *github.CheckRunEvent:
+		resp = e.HandleGithubCheckRunEvent(logger, event, githubReqID)
+		scope = scope.SubScope(fmt.Sprintf("check_run_%s", event.GetAction()))
+		scope = common.SetGitScopeTags(scope, event.GetRepo().GetFullName(), checkRunPullNum(event.GetCheckRun()))
 	default:
 		resp = HTTPResponse{
 			body: fmt.Sprintf("Ignoring unsupported event %s", githubReqID),
@@ -214,7 +223,7 @@ func (e *VCSEventsController) handleGithubPost(w http.ResponseWriter, r *http.Re
 	fmt.Fprintln(w, resp.body)
 }
 
-func (e *VCSEventsController) handleBitbucketCloudPost(w http.ResponseWriter, r *http.Request) {
+func (e *VCSEventsController) handleBitbucketCloudPost(traceCtx context.Context, w http.ResponseWriter, r *http.Request) {
 	eventType := r.Header.Get(bitbucketEventTypeHeader)
 	reqID := r.Header.Get(bitbucketCloudRequestIDHeader)
 	sig := r.Header.Get(bitbucketSignatureHeader)
@@ -230,21 +239,22 @@ func (e *VCSEventsController) handleBitbucketCloudPost(w http.ResponseWriter, r
 			return
 		}
 	}
+	logger := tracing.Logger(e.Logger, traceCtx)
 	switch eventType {
 	case bitbucketcloud.PullCreatedHeader, bitbucketcloud.PullUpdatedHeader, bitbucketcloud.PullFulfilledHeader, bitbucketcloud.PullRejectedHeader:
 		e.Logger.Debug("handling as pull request state changed event")
-		e.handleBitbucketCloudPullRequestEvent(e.Logger, w, eventType, body, reqID)
+		e.handleBitbucketCloudPullRequestEvent(logger, w, eventType, body, reqID)
 		return
 	case bitbucketcloud.PullCommentCreatedHeader:
 		e.Logger.Debug("handling as comment created event")
-		e.HandleBitbucketCloudCommentEvent(w, body, reqID)
+		e.HandleBitbucketCloudCommentEvent(logger, w, body, reqID)
 		return
 	default:
 		e.respond(w, logging.Debug, http.StatusOK, "Ignoring unsupported event type %s %s=%s", eventType, bitbucketCloudRequestIDHeader, reqID)
 	}
 }
 
-func (e *VCSEventsController) handleBitbucketServerPost(w http.ResponseWriter, r *http.Request) {
+func (e *VCSEventsController) handleBitbucketServerPost(traceCtx context.Context, w http.ResponseWriter, r *http.Request) {
 	eventType := r.Header.Get(bitbucketEventTypeHeader)
 	reqID := r.Header.Get(bitbucketServerRequestIDHeader)
 	sig := r.Header.Get(bitbucketSignatureHeader)
@@ -266,21 +276,22 @@ func (e *VCSEventsController) handleBitbucketServerPost(w http.ResponseWriter, r
 			return
 		}
 	}
+	logger := tracing.Logger(e.Logger, traceCtx)
 	switch eventType {
 	case bitbucketserver.PullCreatedHeader, bitbucketserver.PullFromRefUpdatedHeader, bitbucketserver.PullMergedHeader, bitbucketserver.PullDeclinedHeader, bitbucketserver.PullDeletedHeader:
 		e.Logger.Debug("handling as pull request state changed event")
-		e.handleBitbucketServerPullRequestEvent(e.Logger, w, eventType, body, reqID)
+		e.handleBitbucketServerPullRequestEvent(logger, w, eventType, body, reqID)
 		return
 	case bitbucketserver.PullCommentCreatedHeader:
 		e.Logger.Debug("handling as comment created event")
-		e.HandleBitbucketServerCommentEvent(w, body, reqID)
+		e.HandleBitbucketServerCommentEvent(logger, w, body, reqID)
 		return
 	default:
 		e.respond(w, logging.Debug, http.StatusOK, "Ignoring unsupported event type %s %s=%s", eventType, bitbucketServerRequestIDHeader, reqID)
 	}
 }
 
-func (e *VCSEventsController) handleAzureDevopsPost(w http.ResponseWriter, r *http.Request) {
+func (e *VCSEventsController) handleAzureDevopsPost(traceCtx context.Context, w http.ResponseWriter, r *http.Request) {
 	// Validate the request against the optional basic auth username and password.
 	payload, err := e.AzureDevopsRequestValidator.Validate(r, e.AzureDevopsWebhookBasicUser, e.AzureDevopsWebhookBasicPassword)
 	if err != nil {
@@ -301,19 +312,20 @@ func (e *VCSEventsController) handleAzureDevopsPost(w http.ResponseWriter, r *ht
 		e.respond(w, logging.Error, http.StatusBadRequest, "Failed parsing webhook: %v %s", err, azuredevopsReqID)
 		return
 	}
+	logger := tracing.Logger(e.Logger, traceCtx)
 	switch event.PayloadType {
 	case azuredevops.PullRequestCommentedEvent:
 		e.Logger.Debug("handling as pull request commented event")
-		e.HandleAzureDevopsPullRequestCommentedEvent(w, event, azuredevopsReqID)
+		e.HandleAzureDevopsPullRequestCommentedEvent(logger, w, event, azuredevopsReqID)
 	case azuredevops.PullRequestEvent:
 		e.Logger.Debug("handling as pull request event")
-		e.HandleAzureDevopsPullRequestEvent(w, event, azuredevopsReqID)
+		e.HandleAzureDevopsPullRequestEvent(logger, w, event, azuredevopsReqID)
 	default:
 		e.respond(w, logging.Debug, http.StatusOK, "Ignoring unsupported event: %v %s", event.PayloadType, azuredevopsReqID)
 	}
 }
 
-func (e *VCSEventsController) handleGiteaPost(w http.ResponseWriter, r *http.Request) {
+func (e *VCSEventsController) handleGiteaPost(traceCtx context.Context, w http.ResponseWriter, r *http.Request) {
 	signature := r.Header.Get(giteaSignatureHeader)
 	eventType := r.Header.Get(giteaEventTypeHeader)
 	reqID := r.Header.Get(giteaRequestIDHeader)
@@ -333,7 +345,7 @@ func (e *VCSEventsController) handleGiteaPost(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	logger := e.Logger.With("gitea-request-id", reqID)
+	logger := tracing.Logger(e.Logger.With("gitea-request-id", reqID), traceCtx)
 
 	// Log the event type for debugging purposes
 	logger.Debug("Received Gitea event %s with ID %s", eventType, reqID)
@@ -341,7 +353,7 @@ func (e *VCSEventsController) handleGiteaPost(w http.ResponseWriter, r *http.Req
 	// Depending on the event type, handle the event appropriately
 	switch eventType {
 	case "pull_request_comment":
-		e.HandleGiteaPullRequestCommentEvent(w, body, reqID)
+		e.HandleGiteaPullRequestCommentEvent(logger, w, body, reqID)
 	case "pull_request", "pull_request_sync":
 		logger.Debug("Handling as pull_request")
 		e.handleGiteaPullRequestEvent(logger, w, body, reqID)
@@ -385,7 +397,7 @@ func (e *VCSEventsController) handleGiteaPullRequestEvent(logger logging.SimpleL
 }
 
 // HandleGiteaPullRequestCommentEvent handles comment events from Gitea where Atlantis commands can come from.
-func (e *VCSEventsController) HandleGiteaPullRequestCommentEvent(w http.ResponseWriter, body []byte, reqID string) {
+func (e *VCSEventsController) HandleGiteaPullRequestCommentEvent(logger logging.SimpleLogging, w http.ResponseWriter, body []byte, reqID string) {
 	var event gitea.GiteaIssueCommentPayload
 	if err := json.Unmarshal(body, &event); err != nil {
 		e.Logger.Err("Failed to unmarshal Gitea comment payload: %v", err)
@@ -397,7 +409,7 @@ func (e *VCSEventsController) HandleGiteaPullRequestCommentEvent(w http.Response
 	baseRepo, user, pullNum, _ := e.Parser.ParseGiteaIssueCommentEvent(event)
 	// Since we're lacking headRepo and maybePull details, we'll pass nil
 	// This follows the same approach as the GitHub client for handling comment events without full PR details
-	response := e.handleCommentEvent(e.Logger, baseRepo, nil, nil, user, pullNum, event.Comment.Body, event.Comment.ID, models.Gitea)
+	response := e.handleCommentEvent(logger, baseRepo, nil, nil, user, pullNum, event.Comment.Body, event.Comment.ID, models.Gitea)
 
 	e.respond(w, logging.Debug, http.StatusOK, "%s", response.body)
 }
@@ -432,14 +444,91 @@ func (e *VCSEventsController) HandleGithubCommentEvent(event *github.IssueCommen
 	return e.handleCommentEvent(logger, baseRepo, nil, nil, user, pullNum, comment.GetBody(), comment.GetID(), models.Github)
 }
 
+// HandleGithubCheckRunEvent runs the command behind a button clicked on one of
+// the check runs Atlantis creates when --gh-check-runs is set. It's exported to
+// make testing easier.
+func (e *VCSEventsController) HandleGithubCheckRunEvent(logger logging.SimpleLogging, event *github.CheckRunEvent, githubReqID string) HTTPResponse {
+	if event.GetAction() != "requested_action" || event.GetRequestedAction() == nil {
+		return HTTPResponse{
+			body: fmt.Sprintf("Ignoring check run event since action was not requested_action %s", githubReqID),
+		}
+	}
+	pullNum := checkRunPullNum(event.GetCheckRun())
+	if pullNum == 0 {
+		return HTTPResponse{
+			body: fmt.Sprintf("Ignoring check run event since the check run is not on a pull request %s", githubReqID),
+		}
+	}
+
+	cmd, baseRepo, user, err := e.parseGithubCheckRunEvent(event)
+	if err != nil {
+		wrapped := fmt.Errorf("parsing event: %s: %w", githubReqID, err)
+		return HTTPResponse{
+			body: wrapped.Error(),
+			err: HTTPError{
+				code: http.StatusBadRequest,
+				err:  wrapped,
+			},
+		}
+	}
+
+	logger = logger.WithHistory(
+		"repo", baseRepo.FullName,
+		"pull", pullNum,
+	)
+	if !e.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
+		e.commentNotAllowlisted(baseRepo, pullNum)
+
+		err := errors.New("repo not allowlisted")
+		return HTTPResponse{
+			body: err.Error(),
+			err: HTTPError{
+				err:        err,
+				code:       http.StatusForbidden,
+				isSilenced: e.SilenceAllowlistErrors,
+			},
+		}
+	}
+
+	logger.Info("Running check run action '%v' for user '%v'.", cmd.Name, user.Username)
+	e.runCommentCommand(logger, baseRepo, nil, nil, user, pullNum, cmd)
+	return HTTPResponse{
+		body: "Processing...",
+	}
+}
+
+func (e *VCSEventsController) parseGithubCheckRunEvent(event *github.CheckRunEvent) (*events.CommentCommand, models.Repo, models.User, error) {
+	cmd, err := events.ParseCheckRunAction(event.GetRequestedAction().Identifier, event.GetCheckRun().GetExternalID())
+	if err != nil {
+		return nil, models.Repo{}, models.User{}, err
+	}
+	if event.GetSender().GetLogin() == "" {
+		return nil, models.Repo{}, models.User{}, errors.New("sender.login is null")
+	}
+	baseRepo, err := e.Parser.ParseGithubRepo(event.GetRepo())
+	if err != nil {
+		return nil, models.Repo{}, models.User{}, err
+	}
+	return cmd, baseRepo, models.User{Username: event.GetSender().GetLogin()}, nil
+}
+
+// checkRunPullNum returns the number of the pull request checkRun belongs to,
+// or 0 if it doesn't belong to one.
+func checkRunPullNum(checkRun *github.CheckRun) int {
+	if checkRun == nil || len(checkRun.PullRequests) == 0 {
+		return 0
+	}
+	return checkRun.PullRequests[0].GetNumber()
+}
+
 // HandleBitbucketCloudCommentEvent handles comment events from Bitbucket.
-func (e *VCSEventsController) HandleBitbucketCloudCommentEvent(w http.ResponseWriter, body []byte, reqID string) {
+func (e *VCSEventsController) HandleBitbucketCloudCommentEvent(logger logging.SimpleLogging, w http.ResponseWriter, body []byte, reqID string) {
 	pull, baseRepo, headRepo, user, comment, err := e.Parser.ParseBitbucketCloudPullCommentEvent(body)
 	if err != nil {
 		e.respond(w, logging.Error, http.StatusBadRequest, "Error parsing pull data: %s %s=%s", err, bitbucketCloudRequestIDHeader, reqID)
 		return
 	}
-	resp := e.handleCommentEvent(e.Logger, baseRepo, &headRepo, &pull, user, pull.Num, comment, -1, models.BitbucketCloud)
+	resp := e.handleCommentEvent(logger, baseRepo, &headRepo, &pull, user, pull.Num, comment, -1, models.BitbucketCloud)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
@@ -454,13 +543,13 @@ func (e *VCSEventsController) HandleBitbucketCloudCommentEvent(w http.ResponseWr
 }
 
 // HandleBitbucketServerCommentEvent handles comment events from Bitbucket.
-func (e *VCSEventsController) HandleBitbucketServerCommentEvent(w http.ResponseWriter, body []byte, reqID string) {
+func (e *VCSEventsController) HandleBitbucketServerCommentEvent(logger logging.SimpleLogging, w http.ResponseWriter, body []byte, reqID string) {
 	pull, baseRepo, headRepo, user, comment, err := e.Parser.ParseBitbucketServerPullCommentEvent(body)
 	if err != nil {
 		e.respond(w, logging.Error, http.StatusBadRequest, "Error parsing pull data: %s %s=%s", err, bitbucketCloudRequestIDHeader, reqID)
 		return
 	}
-	resp := e.handleCommentEvent(e.Logger, baseRepo, &headRepo, &pull, user, pull.Num, comment, -1, models.BitbucketCloud)
+	resp := e.handleCommentEvent(logger, baseRepo, &headRepo, &pull, user, pull.Num, comment, -1, models.BitbucketCloud)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
@@ -490,7 +579,7 @@ func (e *VCSEventsController) handleBitbucketCloudPullRequestEvent(logger loggin
 	)
 
 	logger.Info("Handling Bitbucket Cloud Pull Request '%s' event", pullEventType.String())
-	resp := e.handlePullRequestEvent(e.Logger, baseRepo, headRepo, pull, user, pullEventType)
+	resp := e.handlePullRequestEvent(logger, baseRepo, headRepo, pull, user, pullEventType)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
@@ -519,7 +608,7 @@ func (e *VCSEventsController) handleBitbucketServerPullRequestEvent(logger loggi
 	)
 
 	logger.Info("Handling Bitbucket Server Pull Request '%s' event", pullEventType.String())
-	resp := e.handlePullRequestEvent(e.Logger, baseRepo, headRepo, pull, user, pullEventType)
+	resp := e.handlePullRequestEvent(logger, baseRepo, headRepo, pull, user, pullEventType)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
@@ -590,10 +679,10 @@ func (e *VCSEventsController) handlePullRequestEvent(logger logging.SimpleLoggin
 		// We use a goroutine so that this function returns and the connection is
 		// closed.
 		if !e.TestingMode {
-			go e.CommandRunner.RunAutoplanCommand(baseRepo, headRepo, pull, user)
+			go e.CommandRunner.RunAutoplanCommand(logging.Context(logger), baseRepo, headRepo, pull, user)
 		} else {
 			// When testing we want to wait for everything to complete.
-			e.CommandRunner.RunAutoplanCommand(baseRepo, headRepo, pull, user)
+			e.CommandRunner.RunAutoplanCommand(logging.Context(logger), baseRepo, headRepo, pull, user)
 		}
 		return HTTPResponse{
 			body: "Processing...",
@@ -624,7 +713,7 @@ func (e *VCSEventsController) handlePullRequestEvent(logger logging.SimpleLoggin
 	return HTTPResponse{}
 }
 
-func (e *VCSEventsController) handleGitlabPost(w http.ResponseWriter, r *http.Request) {
+func (e *VCSEventsController) handleGitlabPost(traceCtx context.Context, w http.ResponseWriter, r *http.Request) {
 	event, err := e.GitlabRequestParserValidator.ParseAndValidate(r, e.GitlabWebhookSecret)
 	if err != nil {
 		e.respond(w, logging.Warn, http.StatusBadRequest, "%s", err.Error())
@@ -632,12 +721,13 @@ func (e *VCSEventsController) handleGitlabPost(w http.ResponseWriter, r *http.Re
 	}
 	e.Logger.Debug("request valid")
 
+	logger := tracing.Logger(e.Logger, traceCtx)
 	switch event := event.(type) {
 	case gitlab.MergeCommentEvent:
 		e.Logger.Debug("handling as comment event")
-		e.HandleGitlabCommentEvent(w, event)
+		e.HandleGitlabCommentEvent(logger, w, event)
 	case gitlab.MergeEvent:
-		e.HandleGitlabMergeRequestEvent(e.Logger, w, event)
+		e.HandleGitlabMergeRequestEvent(logger, w, event)
 	case gitlab.CommitCommentEvent:
 		e.Logger.Debug("comments on commits are not supported, only comments on merge requests")
 		e.respond(w, logging.Debug, http.StatusOK, "Ignoring comment on commit event")
@@ -649,14 +739,14 @@ func (e *VCSEventsController) handleGitlabPost(w http.ResponseWriter, r *http.Re
 
 // HandleGitlabCommentEvent handles comment events from GitLab where Atlantis
 // commands can come from. It's exported to make testing easier.
-func (e *VCSEventsController) HandleGitlabCommentEvent(w http.ResponseWriter, event gitlab.MergeCommentEvent) {
+func (e *VCSEventsController) HandleGitlabCommentEvent(logger logging.SimpleLogging, w http.ResponseWriter, event gitlab.MergeCommentEvent) {
 	// todo: can gitlab return the pull request here too?
 	baseRepo, headRepo, commentID, user, err := e.Parser.ParseGitlabMergeRequestCommentEvent(event)
 	if err != nil {
 		e.respond(w, logging.Error, http.StatusBadRequest, "Error parsing webhook: %s", err)
 		return
 	}
-	resp := e.handleCommentEvent(e.Logger, baseRepo, &headRepo, nil, user, event.MergeRequest.IID, event.ObjectAttributes.Note, int64(commentID), models.Gitlab)
+	resp := e.handleCommentEvent(logger, baseRepo, &headRepo, nil, user, event.MergeRequest.IID, event.ObjectAttributes.Note, int64(commentID), models.Gitlab)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
@@ -735,18 +825,22 @@ func (e *VCSEventsController) handleCommentEvent(logger logging.SimpleLogging, b
 	} else {
 		logger.Info("Running comment command '%v' for user '%v'.", parseResult.Command.Name, user.Username)
 	}
+	e.runCommentCommand(logger, baseRepo, maybeHeadRepo, maybePull, user, pullNum, parseResult.Command)
+
+	return HTTPResponse{
+		body: "Processing...",
+	}
+}
+
+func (e *VCSEventsController) runCommentCommand(logger logging.SimpleLogging, baseRepo models.Repo, maybeHeadRepo *models.Repo, maybePull *models.PullRequest, user models.User, pullNum int, cmd *events.CommentCommand) {
 	if !e.TestingMode {
 		// Respond with success and then actually execute the command asynchronously.
 		// We use a goroutine so that this function returns and the connection is
 		// closed.
-		go e.CommandRunner.RunCommentCommand(baseRepo, maybeHeadRepo, maybePull, user, pullNum, parseResult.Command)
+		go e.CommandRunner.RunCommentCommand(logging.Context(logger), baseRepo, maybeHeadRepo, maybePull, user, pullNum, cmd)
 	} else {
 		// When testing we want to wait for everything to complete.
-		e.CommandRunner.RunCommentCommand(baseRepo, maybeHeadRepo, maybePull, user, pullNum, parseResult.Command)
-	}
-
-	return HTTPResponse{
-		body: "Processing...",
+		e.CommandRunner.RunCommentCommand(logging.Context(logger), baseRepo, maybeHeadRepo, maybePull, user, pullNum, cmd)
 	}
 }
 
@@ -784,7 +878,7 @@ func (e *VCSEventsController) HandleGitlabMergeRequestEvent(logger logging.Simpl
 // commands can come from. It's exported to make testing easier.
 // Sometimes we may want data from the parent azuredevops.Event struct, so we handle type checking here.
 // Requires Resource Version 2.0 of the Pull Request Commented On webhook payload.
-func (e *VCSEventsController) HandleAzureDevopsPullRequestCommentedEvent(w http.ResponseWriter, event *azuredevops.Event, azuredevopsReqID string) {
+func (e *VCSEventsController) HandleAzureDevopsPullRequestCommentedEvent(logger logging.SimpleLogging, w http.ResponseWriter, event *azuredevops.Event, azuredevopsReqID string) {
 	resource, ok := event.Resource.(*azuredevops.GitPullRequestWithComment)
 	if !ok || event.PayloadType != azuredevops.PullRequestCommentedEvent {
 		e.respond(w, logging.Error, http.StatusBadRequest, "Event.Resource is nil or received bad event type %v; %s", event.Resource, azuredevopsReqID)
@@ -820,7 +914,7 @@ func (e *VCSEventsController) HandleAzureDevopsPullRequestCommentedEvent(w http.
 		e.respond(w, logging.Error, http.StatusBadRequest, "Error parsing pull request repository field: %s; %s", err, azuredevopsReqID)
 		return
 	}
-	resp := e.handleCommentEvent(e.Logger, baseRepo, nil, nil, user, resource.PullRequest.GetPullRequestID(), string(strippedComment), -1, models.AzureDevops)
+	resp := e.handleCommentEvent(logger, baseRepo, nil, nil, user, resource.PullRequest.GetPullRequestID(), string(strippedComment), -1, models.AzureDevops)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
@@ -837,7 +931,7 @@ func (e *VCSEventsController) HandleAzureDevopsPullRequestCommentedEvent(w http.
 // HandleAzureDevopsPullRequestEvent will delete any locks associated with the pull
 // request if the event is a pull request closed event. It's exported to make
 // testing easier.
-func (e *VCSEventsController) HandleAzureDevopsPullRequestEvent(w http.ResponseWriter, event *azuredevops.Event, azuredevopsReqID string) {
+func (e *VCSEventsController) HandleAzureDevopsPullRequestEvent(logger logging.SimpleLogging, w http.ResponseWriter, event *azuredevops.Event, azuredevopsReqID string) {
 	prText := event.Message.GetText()
 	ignoreEvents := []string{
 		"changed the reviewer list",
@@ -870,8 +964,8 @@ func (e *VCSEventsController) HandleAzureDevopsPullRequestEvent(w http.ResponseW
 		e.respond(w, logging.Error, http.StatusBadRequest, "Error parsing pull data: %s %s", err, azuredevopsReqID)
 		return
 	}
-	e.Logger.Info("identified event as type %q", pullEventType.String())
-	resp := e.handlePullRequestEvent(e.Logger, baseRepo, headRepo, pull, user, pullEventType)
+	logger.Info("identified event as type %q", pullEventType.String())
+	resp := e.handlePullRequestEvent(logger, baseRepo, headRepo, pull, user, pullEventType)
 
 	//TODO: move this to the outer most function similar to github
 	lvl := logging.Debug
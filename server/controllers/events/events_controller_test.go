@@ -6,6 +6,7 @@ package events_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -395,7 +396,7 @@ func TestPost_GitlabCommentSuccess(t *testing.T) {
 	e.Post(w, req)
 	ResponseContains(t, w, http.StatusOK, "Processing...")
 
-	cr.VerifyWasCalledOnce().RunCommentCommand(models.Repo{}, &models.Repo{}, nil, models.User{}, 0, &cmd)
+	cr.VerifyWasCalledOnce().RunCommentCommand(Any[context.Context](), Eq(models.Repo{}), Eq(&models.Repo{}), Eq[*models.PullRequest](nil), Eq(models.User{}), Eq(0), Eq(&cmd))
 }
 
 func TestPost_GithubCommentSuccess(t *testing.T) {
@@ -414,7 +415,7 @@ func TestPost_GithubCommentSuccess(t *testing.T) {
 	e.Post(w, req)
 	ResponseContains(t, w, http.StatusOK, "Processing...")
 
-	cr.VerifyWasCalledOnce().RunCommentCommand(baseRepo, nil, nil, user, 1, &cmd)
+	cr.VerifyWasCalledOnce().RunCommentCommand(Any[context.Context](), Eq(baseRepo), Eq[*models.Repo](nil), Eq[*models.PullRequest](nil), Eq(user), Eq(1), Eq(&cmd))
 }
 
 func TestPost_GithubCommentReaction(t *testing.T) {
@@ -451,6 +452,61 @@ func TestPost_GilabCommentReaction(t *testing.T) {
 	vcsClient.VerifyWasCalledOnce().ReactToComment(Any[logging.SimpleLogging](), Eq(models.Repo{}), Eq(0), Eq(int64(0)), Eq("eyes"))
 }
 
+func TestPost_GithubCheckRunNotRequestedAction(t *testing.T) {
+	t.Log("when the event is a github check run event but not a requested action we ignore it")
+	e, v, _, _, _, cr, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "rerequested", "check_run": {"external_id": "project=app", "pull_requests": [{"number": 1}]}}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Ignoring check run event since action was not requested_action")
+	cr.VerifyWasCalled(Never()).RunCommentCommand(Any[context.Context](), Any[models.Repo](), Any[*models.Repo](), Any[*models.PullRequest](), Any[models.User](), Any[int](), Any[*events.CommentCommand]())
+}
+
+func TestPost_GithubCheckRunNotOnPullRequest(t *testing.T) {
+	t.Log("when the check run doesn't belong to a pull request we ignore it")
+	e, v, _, _, _, cr, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "requested_action", "requested_action": {"identifier": "plan"}, "check_run": {"external_id": "project=app"}}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Ignoring check run event since the check run is not on a pull request")
+	cr.VerifyWasCalled(Never()).RunCommentCommand(Any[context.Context](), Any[models.Repo](), Any[*models.Repo](), Any[*models.PullRequest](), Any[models.User](), Any[int](), Any[*events.CommentCommand]())
+}
+
+func TestPost_GithubCheckRunInvalidAction(t *testing.T) {
+	t.Log("when the requested action isn't one Atlantis offers we return a 400")
+	e, v, _, _, _, _, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "requested_action", "requested_action": {"identifier": "destroy"}, "sender": {"login": "user"}, "check_run": {"external_id": "project=app", "pull_requests": [{"number": 1}]}}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusBadRequest, `unknown check run action "destroy"`)
+}
+
+func TestPost_GithubCheckRunRequestedAction(t *testing.T) {
+	t.Log("when a check run button is clicked we run the command for its project")
+	e, v, _, _, p, cr, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "requested_action", "requested_action": {"identifier": "apply"}, "sender": {"login": "user"}, "check_run": {"external_id": "dir=modules%2Fvpc&workspace=default", "pull_requests": [{"number": 7}]}}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	baseRepo := models.Repo{FullName: "owner/repo"}
+	When(p.ParseGithubRepo(Any[*github.Repository]())).ThenReturn(baseRepo, nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Processing...")
+
+	cr.VerifyWasCalledOnce().RunCommentCommand(Any[context.Context](), Eq(baseRepo), Eq[*models.Repo](nil), Eq[*models.PullRequest](nil), Eq(models.User{Username: "user"}), Eq(7),
+		Eq(&events.CommentCommand{Name: command.Apply, RepoRelDir: "modules/vpc", Workspace: "default"}))
+}
+
 func TestPost_GithubPullRequestInvalid(t *testing.T) {
 	t.Log("when the event is a github pull request with invalid data we return a 400")
 	e, v, _, _, p, _, _, _, _ := setup(t)
@@ -905,8 +961,8 @@ func TestPost_BBServerPullClosed(t *testing.T) {
 				},
 			}
 			pullCleaner.VerifyWasCalledOnce().CleanUpPull(
-				logger,
-				expRepo, models.PullRequest{
+				Any[logging.SimpleLogging](),
+				Eq(expRepo), Eq(models.PullRequest{
 					Num:        10,
 					HeadCommit: "2d9fb6b9a46eafb1dcef7b008d1a429d45ca742c",
 					URL:        "https://bbserver.com/projects/PROJ/repos/repository/pull-requests/10",
@@ -915,7 +971,7 @@ func TestPost_BBServerPullClosed(t *testing.T) {
 					Author:     "admin",
 					State:      models.OpenPullState,
 					BaseRepo:   expRepo,
-				})
+				}))
 		})
 	}
 }
@@ -976,7 +1032,7 @@ func TestPost_PullOpenedOrUpdated(t *testing.T) {
 			w := httptest.NewRecorder()
 			e.Post(w, req)
 			ResponseContains(t, w, http.StatusOK, "Processing...")
-			cr.VerifyWasCalledOnce().RunAutoplanCommand(models.Repo{}, models.Repo{}, models.PullRequest{State: models.ClosedPullState}, models.User{})
+			cr.VerifyWasCalledOnce().RunAutoplanCommand(Any[context.Context](), Eq(models.Repo{}), Eq(models.Repo{}), Eq(models.PullRequest{State: models.ClosedPullState}), Eq(models.User{}))
 		})
 	}
 }
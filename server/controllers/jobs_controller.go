@@ -12,6 +12,7 @@ import (
 	"github.com/runatlantis/atlantis/server/controllers/web_templates"
 	"github.com/runatlantis/atlantis/server/controllers/websocket"
 	"github.com/runatlantis/atlantis/server/core/db"
+	"github.com/runatlantis/atlantis/server/jobs"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/metrics"
 	tally "github.com/uber-go/tally/v4"
@@ -38,6 +39,19 @@ type JobsController struct {
 	WsMux                    *websocket.Multiplexor       `validate:"required"`
 	KeyGenerator             JobIDKeyGenerator
 	StatsScope               tally.Scope `validate:"required"`
+	// JobRegistry holds the output of jobs that are still in memory.
+	JobRegistry websocket.PartitionRegistry
+	// JobLogSink serves the output of jobs that are no longer in
+	// JobRegistry. It's nil if job output isn't archived.
+	JobLogSink jobs.JobLogSink
+	// JobTraces looks up the trace ID shown on the page of a job that's in
+	// memory. It can be nil.
+	JobTraces JobTraceIDGetter
+}
+
+// JobTraceIDGetter returns the ID of the trace a job ran in.
+type JobTraceIDGetter interface {
+	GetJobTraceID(jobID string) string
 }
 
 func (j *JobsController) getProjectJobs(w http.ResponseWriter, r *http.Request) error {
@@ -52,11 +66,30 @@ func (j *JobsController) getProjectJobs(w http.ResponseWriter, r *http.Request)
 		AtlantisVersion: j.AtlantisVersion,
 		ProjectPath:     jobID,
 		CleanedBasePath: j.AtlantisURL.Path,
+		TraceID:         j.jobTraceID(jobID),
 	}
 
 	return j.ProjectJobsTemplate.Execute(w, viewData)
 }
 
+// jobTraceID returns the ID of the trace the job ran in, or "" if it's not
+// known. Only the metadata of an archived log is read, not the job's output.
+func (j *JobsController) jobTraceID(jobID string) string {
+	if j.JobTraces != nil {
+		if traceID := j.JobTraces.GetJobTraceID(jobID); traceID != "" {
+			return traceID
+		}
+	}
+	if j.JobLogSink == nil || j.JobRegistry == nil || j.JobRegistry.IsKeyExists(jobID) {
+		return ""
+	}
+	traceID, err := j.JobLogSink.TraceID(jobID)
+	if err != nil {
+		j.Logger.Warn("loading trace ID of archived job %s: %s", jobID, err)
+	}
+	return traceID
+}
+
 func (j *JobsController) GetProjectJobs(w http.ResponseWriter, r *http.Request) {
 	errorCounter := j.StatsScope.SubScope("getprojectjobs").Counter(metrics.ExecutionErrorMetric)
 	err := j.getProjectJobs(w, r)
@@ -67,7 +100,16 @@ func (j *JobsController) GetProjectJobs(w http.ResponseWriter, r *http.Request)
 }
 
 func (j *JobsController) getProjectJobsWS(w http.ResponseWriter, r *http.Request) error {
-	err := j.WsMux.Handle(w, r)
+	archived, err := j.archivedJobLog(r)
+	if err != nil {
+		j.respond(w, logging.Error, http.StatusInternalServerError, "%s", err.Error())
+		return err
+	}
+	if archived != nil {
+		return j.WsMux.WriteLines(w, r, archived.Lines)
+	}
+
+	err = j.WsMux.Handle(w, r)
 
 	if err != nil {
 		j.respond(w, logging.Error, http.StatusInternalServerError, "%s", err.Error())
@@ -77,6 +119,23 @@ func (j *JobsController) getProjectJobsWS(w http.ResponseWriter, r *http.Request
 	return nil
 }
 
+// archivedJobLog returns the archived log of the job in r if the job's output
+// is no longer in memory, or nil if it is or there's no archive.
+func (j *JobsController) archivedJobLog(r *http.Request) (*jobs.JobLog, error) {
+	if j.JobLogSink == nil || j.JobRegistry == nil {
+		return nil, nil
+	}
+	jobID, err := j.KeyGenerator.Generate(r)
+	if err != nil || j.JobRegistry.IsKeyExists(jobID) {
+		return nil, nil
+	}
+	log, err := j.JobLogSink.Get(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("loading archived log for job %s: %w", jobID, err)
+	}
+	return log, nil
+}
+
 func (j *JobsController) GetProjectJobsWS(w http.ResponseWriter, r *http.Request) {
 	jobsMetric := j.StatsScope.SubScope("getprojectjobs")
 	errorCounter := jobsMetric.Counter(metrics.ExecutionErrorMetric)
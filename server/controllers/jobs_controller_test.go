@@ -0,0 +1,107 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	gorillawebsocket "github.com/gorilla/websocket"
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/controllers/web_templates"
+	tMocks "github.com/runatlantis/atlantis/server/controllers/web_templates/mocks"
+	"github.com/runatlantis/atlantis/server/controllers/websocket"
+	"github.com/runatlantis/atlantis/server/jobs"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/metrics/metricstest"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+// emptyJobRegistry has no jobs in memory.
+type emptyJobRegistry struct{}
+
+func (emptyJobRegistry) Register(_ string, buffer chan string) { close(buffer) }
+
+func (emptyJobRegistry) Deregister(_ string, _ chan string) {}
+
+func (emptyJobRegistry) IsKeyExists(_ string) bool { return false }
+
+func TestJobsController_GetProjectJobsWS_Archived(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	sink, err := jobs.NewLocalJobLogSink(t.TempDir())
+	Ok(t, err)
+	Ok(t, sink.Archive(jobs.JobLog{JobID: "1234", Lines: []string{"line 1", "line 2"}}))
+
+	jc := &controllers.JobsController{
+		Logger:      logger,
+		WsMux:       websocket.NewMultiplexor(logger, controllers.JobIDKeyGenerator{}, emptyJobRegistry{}, false),
+		StatsScope:  metricstest.NewLoggingScope(t, logger, "atlantis"),
+		JobRegistry: emptyJobRegistry{},
+		JobLogSink:  sink,
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/jobs/{job-id}/ws", jc.GetProjectJobsWS)
+	s := httptest.NewServer(router)
+	defer s.Close()
+	wsURL := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL+"/jobs/1234/ws", nil)
+	Ok(t, err)
+	defer conn.Close()
+	Ok(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var lines []string
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		lines = append(lines, string(msg))
+	}
+	Equals(t, []string{"\rline 1\n", "\rline 2\n"}, lines)
+
+	// A job that was never archived still fails.
+	_, resp, err := gorillawebsocket.DefaultDialer.Dial(wsURL+"/jobs/5678/ws", nil)
+	Assert(t, err != nil, "expected unknown job to fail")
+	Equals(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+// traceOnlyJobLogSink only serves trace IDs, so loading a job's output from it
+// fails.
+type traceOnlyJobLogSink struct {
+	jobs.JobLogSink
+	traceIDs map[string]string
+}
+
+func (s traceOnlyJobLogSink) TraceID(jobID string) (string, error) {
+	return s.traceIDs[jobID], nil
+}
+
+func TestJobsController_GetProjectJobs_ArchivedTraceID(t *testing.T) {
+	RegisterMockTestingT(t)
+	logger := logging.NewNoopLogger(t)
+	tmpl := tMocks.NewMockTemplateWriter()
+	atlantisURL, err := url.Parse("https://example.com/basepath")
+	Ok(t, err)
+	jc := &controllers.JobsController{
+		AtlantisURL:         atlantisURL,
+		Logger:              logger,
+		ProjectJobsTemplate: tmpl,
+		StatsScope:          metricstest.NewLoggingScope(t, logger, "atlantis"),
+		JobRegistry:         emptyJobRegistry{},
+		JobLogSink:          traceOnlyJobLogSink{traceIDs: map[string]string{"1234": "4bf92f3577b34da6a3ce929d0e0e4736"}},
+	}
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/jobs/1234", nil), map[string]string{"job-id": "1234"})
+
+	jc.GetProjectJobs(httptest.NewRecorder(), req)
+
+	_, captured := tmpl.VerifyWasCalledOnce().Execute(Any[io.Writer](), Any[any]()).GetCapturedArguments()
+	Equals(t, "4bf92f3577b34da6a3ce929d0e0e4736", captured.(web_templates.ProjectJobData).TraceID)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/runatlantis/atlantis/server/controllers/web_templates"
 
 	"github.com/gorilla/mux"
+	"github.com/runatlantis/atlantis/server/audit"
 	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/events"
@@ -32,12 +33,17 @@ type LocksController struct {
 	WorkingDirLocker   events.WorkingDirLocker      `validate:"required"`
 	Database           db.Database                  `validate:"required"`
 	DeleteLockCommand  events.DeleteLockCommand     `validate:"required"`
+	// LockQueue is nil unless --enable-lock-queue is set.
+	LockQueue locking.LockQueue
+	// AuditLog records deleted locks and apply lock changes. It may be nil.
+	AuditLog *audit.Log
 }
 
 // LockApply handles creating a global apply lock.
 // If Lock already exists it will be a no-op
-func (l *LocksController) LockApply(w http.ResponseWriter, _ *http.Request) {
+func (l *LocksController) LockApply(w http.ResponseWriter, r *http.Request) {
 	lock, err := l.ApplyLocker.LockApply()
+	l.AuditLog.Record(uiAuditEvent(r, audit.ActionApplyLock, err))
 	if err != nil {
 		l.respond(w, logging.Error, http.StatusInternalServerError, "creating apply lock failed with: %s", err)
 		return
@@ -48,8 +54,9 @@ func (l *LocksController) LockApply(w http.ResponseWriter, _ *http.Request) {
 
 // UnlockApply handles releasing a global apply lock.
 // If Lock doesn't exists it will be a no-op
-func (l *LocksController) UnlockApply(w http.ResponseWriter, _ *http.Request) {
+func (l *LocksController) UnlockApply(w http.ResponseWriter, r *http.Request) {
 	err := l.ApplyLocker.UnlockApply()
+	l.AuditLog.Record(uiAuditEvent(r, audit.ActionApplyUnlock, err))
 	if err != nil {
 		l.respond(w, logging.Error, http.StatusInternalServerError, "deleting apply lock failed with: %s", err)
 		return
@@ -93,6 +100,22 @@ func (l *LocksController) GetLock(w http.ResponseWriter, r *http.Request) {
 		RepoOwner:       owner,
 		RepoName:        repo,
 	}
+	if l.LockQueue != nil {
+		queue, err := l.LockQueue.Queue(idUnencoded)
+		if err != nil {
+			l.respond(w, logging.Error, http.StatusInternalServerError, "Failed getting lock queue: %s", err)
+			return
+		}
+		for i, entry := range queue {
+			viewData.Queue = append(viewData.Queue, web_templates.QueuedPullData{
+				Position:        i + 1,
+				PullNum:         entry.Pull.Num,
+				PullRequestLink: entry.Pull.URL,
+				QueuedBy:        entry.User.Username,
+				TimeFormatted:   entry.Time.Format("2006-01-02 15:04:05"),
+			})
+		}
+	}
 
 	err = l.LockDetailTemplate.Execute(w, viewData)
 	if err != nil {
@@ -117,6 +140,9 @@ func (l *LocksController) DeleteLock(w http.ResponseWriter, r *http.Request) {
 
 	lock, err := l.DeleteLockCommand.DeleteLock(l.Logger, idUnencoded)
 	if err != nil {
+		event := uiAuditEvent(r, audit.ActionUnlock, err)
+		event.Details = map[string]string{"lock_id": idUnencoded}
+		l.AuditLog.Record(event)
 		l.respond(w, logging.Error, http.StatusInternalServerError, "deleting lock failed with: '%s'", err)
 		return
 	}
@@ -125,25 +151,64 @@ func (l *LocksController) DeleteLock(w http.ResponseWriter, r *http.Request) {
 		l.respond(w, logging.Info, http.StatusNotFound, "No lock found at id '%s'", idUnencoded)
 		return
 	}
+	l.AuditLog.Record(lockAuditEvent(uiAuditEvent(r, audit.ActionUnlock, nil), idUnencoded, *lock))
+
+	discardLockedPlan(l.Logger, l.Database, l.VCSClient, *lock, "the Atlantis UI")
+	l.respond(w, logging.Info, http.StatusOK, "Deleted lock id '%s'", id)
+}
 
+// discardLockedPlan marks the plan of a deleted lock as discarded and comments
+// back on its pull request. via names where the lock was deleted from.
+func discardLockedPlan(logger logging.SimpleLogging, database db.Database, vcsClient vcs.Client, lock models.ProjectLock, via string) {
 	// NOTE: Because BaseRepo was added to the PullRequest model later, previous
 	// installations of Atlantis will have locks in their DB that do not have
 	// this field on PullRequest. We skip commenting in this case.
-	if lock.Pull.BaseRepo != (models.Repo{}) {
-		if err := l.Database.UpdateProjectStatus(lock.Pull, lock.Workspace, lock.Project.Path, models.DiscardedPlanStatus); err != nil {
-			l.Logger.Err("unable to update project status: %s", err)
-		}
+	if lock.Pull.BaseRepo == (models.Repo{}) {
+		logger.Debug("skipping commenting on pull request and deleting workspace because BaseRepo field is empty")
+		return
+	}
+	if err := database.UpdateProjectStatus(lock.Pull, lock.Workspace, lock.Project.Path, models.DiscardedPlanStatus); err != nil {
+		logger.Err("unable to update project status: %s", err)
+	}
 
-		// Once the lock has been deleted, comment back on the pull request.
-		comment := fmt.Sprintf("**Warning**: The plan for dir: `%s` workspace: `%s` was **discarded** via the Atlantis UI.\n\n"+
-			"To `apply` this plan you must run `plan` again.", lock.Project.Path, lock.Workspace)
-		if err = l.VCSClient.CreateComment(l.Logger, lock.Pull.BaseRepo, lock.Pull.Num, comment, ""); err != nil {
-			l.Logger.Warn("failed commenting on pull request: %s", err)
-		}
-	} else {
-		l.Logger.Debug("skipping commenting on pull request and deleting workspace because BaseRepo field is empty")
+	// Once the lock has been deleted, comment back on the pull request.
+	comment := fmt.Sprintf("**Warning**: The plan for dir: `%s` workspace: `%s` was **discarded** via %s.\n\n"+
+		"To `apply` this plan you must run `plan` again.", lock.Project.Path, lock.Workspace, via)
+	if err := vcsClient.CreateComment(logger, lock.Pull.BaseRepo, lock.Pull.Num, comment, ""); err != nil {
+		logger.Warn("failed commenting on pull request: %s", err)
 	}
-	l.respond(w, logging.Info, http.StatusOK, "Deleted lock id '%s'", id)
+}
+
+// uiAuditEvent returns an audit event for an action taken in the web UI. The
+// actor is the basic auth user if web authentication is enabled.
+func uiAuditEvent(r *http.Request, action audit.Action, err error) audit.Event {
+	actor, _, ok := r.BasicAuth()
+	if !ok {
+		actor = audit.SourceUI
+	}
+	return auditEvent(audit.Event{Action: action, Actor: actor, Source: audit.SourceUI}, err)
+}
+
+// auditEvent sets event's outcome from err.
+func auditEvent(event audit.Event, err error) audit.Event {
+	event.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		event.Outcome = audit.OutcomeError
+		event.Error = err.Error()
+	}
+	return event
+}
+
+// lockAuditEvent adds the details of a deleted lock to event.
+func lockAuditEvent(event audit.Event, id string, lock models.ProjectLock) audit.Event {
+	event.Repo = lock.Project.RepoFullName
+	event.Pull = lock.Pull.Num
+	event.Project = lock.Project.ProjectName
+	event.Dir = lock.Project.Path
+	event.Workspace = lock.Workspace
+	event.CommitSHA = lock.Pull.HeadCommit
+	event.Details = map[string]string{"lock_id": id}
+	return event
 }
 
 // respond is a helper function to respond and log the response. lvl is the log
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -209,6 +210,42 @@ func TestGetLock_Success(t *testing.T) {
 	ResponseContains(t, w, http.StatusOK, "")
 }
 
+func TestGetLock_ShowsQueue(t *testing.T) {
+	RegisterMockTestingT(t)
+	ctrl := gomock.NewController(t)
+	l := mocks.NewMockLocker(ctrl)
+	l.EXPECT().GetLock("id").Return(&models.ProjectLock{
+		Project:   models.Project{RepoFullName: "owner/repo", Path: "path"},
+		Pull:      models.PullRequest{URL: "url", Author: "lkysow"},
+		Workspace: "workspace",
+	}, nil)
+	queuedAt := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	lockQueue := mocks.NewMockLockQueue(ctrl)
+	lockQueue.EXPECT().Queue("id").Return([]models.LockQueueEntry{
+		{Pull: models.PullRequest{Num: 2, URL: "url2"}, User: models.User{Username: "alice"}, Time: queuedAt},
+		{Pull: models.PullRequest{Num: 3, URL: "url3"}, User: models.User{Username: "bob"}, Time: queuedAt},
+	}, nil)
+	tmpl := tMocks.NewMockTemplateWriter()
+	atlantisURL, err := url.Parse("https://example.com")
+	Ok(t, err)
+	lc := controllers.LocksController{
+		Logger:             logging.NewNoopLogger(t),
+		Locker:             l,
+		LockQueue:          lockQueue,
+		LockDetailTemplate: tmpl,
+		AtlantisURL:        atlantisURL,
+	}
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req = mux.SetURLVars(req, map[string]string{"id": "id"})
+	w := httptest.NewRecorder()
+	lc.GetLock(w, req)
+	_, captured := tmpl.VerifyWasCalledOnce().Execute(Any[io.Writer](), Any[any]()).GetCapturedArguments()
+	Equals(t, []web_templates.QueuedPullData{
+		{Position: 1, PullNum: 2, PullRequestLink: "url2", QueuedBy: "alice", TimeFormatted: "2025-03-04 05:06:07"},
+		{Position: 2, PullNum: 3, PullRequestLink: "url3", QueuedBy: "bob", TimeFormatted: "2025-03-04 05:06:07"},
+	}, captured.(web_templates.LockDetailData).Queue)
+}
+
 func TestDeleteLock_NoLockID(t *testing.T) {
 	t.Log("If there is no lock ID in the request then we should get a 400")
 	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
@@ -308,7 +345,7 @@ func TestDeleteLock_UpdateProjectStatus(t *testing.T) {
 	Ok(t, err)
 	defer closeTestDatabase(t, database)
 	// Seed the DB with a successful plan for that project (that is later discarded).
-	_, err = database.UpdatePullWithResults(pull, []command.ProjectResult{
+	seeded, err := database.UpdatePullWithResults(pull, []command.ProjectResult{
 		{
 			Command:    command.Plan,
 			RepoRelDir: projectPath,
@@ -343,6 +380,7 @@ func TestDeleteLock_UpdateProjectStatus(t *testing.T) {
 			Workspace:  workspaceName,
 			RepoRelDir: projectPath,
 			Status:     models.DiscardedPlanStatus,
+			PlannedAt:  seeded.Projects[0].PlannedAt,
 		},
 	}, status.Projects)
 }
@@ -0,0 +1,353 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// slackInteractionMaxBodyBytes bounds the size of interaction payloads.
+	// Slack's payloads include the whole message, which is at most a few
+	// hundred kilobytes.
+	slackInteractionMaxBodyBytes = 1 << 20
+	// DefaultSlackRemediationPollInterval is how often a remediation started
+	// from Slack is checked so its message can be updated with the outcome.
+	DefaultSlackRemediationPollInterval = 10 * time.Second
+	// DefaultSlackRemediationTimeout is how long a remediation started from
+	// Slack is waited for before its message stops being updated.
+	DefaultSlackRemediationTimeout = 2 * time.Hour
+)
+
+// RemediationRunner queues drift remediation requests. It is implemented by
+// APIController.
+type RemediationRunner interface {
+	RunRemediation(request models.RemediationRequest) (*models.RemediationResult, error)
+}
+
+// SlackInteractionsController handles the interactive payloads Slack sends
+// when a user clicks a Plan fix or Apply fix button on a drift message.
+type SlackInteractionsController struct {
+	Logger logging.SimpleLogging
+	// SigningSecret is the Slack app's signing secret. Requests not signed
+	// with it are rejected.
+	SigningSecret string
+	// AllowedUsers are the Slack user IDs allowed to trigger remediation.
+	AllowedUsers []string
+	// AllowApply shows the Apply fix button again once a remediation
+	// finishes. Set it when drift remediation apply is enabled.
+	AllowApply         bool
+	Remediator         RemediationRunner
+	RemediationService drift.RemediationService
+	Slack              webhooks.SlackClient
+	// PollInterval is how often running remediations are checked. Defaults
+	// to DefaultSlackRemediationPollInterval.
+	PollInterval time.Duration
+	// Timeout bounds how long a running remediation is waited for. Defaults
+	// to DefaultSlackRemediationTimeout.
+	Timeout time.Duration
+
+	mu sync.Mutex
+	// messages tracks the drift messages with remediations in flight so
+	// concurrent updates to the same message don't overwrite each other.
+	messages map[string]*slackMessageState
+}
+
+type slackMessageState struct {
+	attachments []slack.Attachment
+	blocks      []slack.Block
+	pending     int
+}
+
+// slackDriftClick is a click on a drift message button.
+type slackDriftClick struct {
+	channel   string
+	timestamp string
+	user      string
+	key       string
+	action    models.RemediationAction
+	value     webhooks.DriftActionValue
+}
+
+// Post handles POST /slack/interactions. Slack requires interactions to be
+// acknowledged within 3 seconds, so the request is answered before any call
+// to Slack or the VCS host is made.
+func (c *SlackInteractionsController) Post(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, slackInteractionMaxBodyBytes))
+	if err != nil {
+		c.respond(w, logging.Warn, http.StatusBadRequest, "reading request body: %s", err)
+		return
+	}
+	if err := c.verify(r.Header, body); err != nil {
+		c.respond(w, logging.Warn, http.StatusUnauthorized, "invalid Slack request signature: %s", err)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		c.respond(w, logging.Warn, http.StatusBadRequest, "parsing request body: %s", err)
+		return
+	}
+	var payload slack.InteractionCallback
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		c.respond(w, logging.Warn, http.StatusBadRequest, "parsing Slack payload: %s", err)
+		return
+	}
+
+	click, ok, err := parseSlackDriftClick(payload)
+	if err != nil {
+		c.respond(w, logging.Warn, http.StatusBadRequest, "%s", err)
+		return
+	}
+	if !ok {
+		c.respond(w, logging.Debug, http.StatusOK, "ignoring Slack interaction")
+		return
+	}
+
+	if !slices.Contains(c.AllowedUsers, click.user) {
+		c.Logger.Warn("Slack user %s is not allowed to remediate drift in %s", click.user, click.value.Repository)
+		go func() {
+			if err := c.Slack.PostEphemeral(click.channel, click.user, "You are not allowed to remediate drift from Slack. Ask an Atlantis admin to add your Slack user ID to --slack-remediation-allowlist."); err != nil {
+				c.Logger.Warn("unable to notify Slack user %s: %s", click.user, err)
+			}
+		}()
+		c.respond(w, logging.Debug, http.StatusOK, "user not allowed")
+		return
+	}
+
+	go c.remediate(click, payload.Message)
+	c.respond(w, logging.Debug, http.StatusOK, "remediation requested")
+}
+
+// remediate queues the remediation for click and updates the message with
+// its outcome once it finishes.
+func (c *SlackInteractionsController) remediate(click slackDriftClick, message slack.Message) {
+	c.beginMessage(click, message)
+	verb := slackActionVerb(click.action)
+
+	request := models.RemediationRequest{
+		Repository: click.value.Repository,
+		Ref:        click.value.Ref,
+		BaseBranch: click.value.BaseBranch,
+		Type:       click.value.VCSType,
+		Action:     click.action,
+		Paths: []models.DriftDetectionPath{{
+			Directory: click.value.Path,
+			Workspace: click.value.Workspace,
+		}},
+		RequestedBy: &models.APICaller{Name: click.user, AuthMethod: "slack"},
+	}
+	if click.value.ProjectName != "" {
+		request.Projects = []string{click.value.ProjectName}
+	}
+	result, err := c.Remediator.RunRemediation(request)
+	if err != nil {
+		c.Logger.Warn("unable to start drift remediation requested from Slack: %s", err)
+		c.finishMessage(click, fmt.Sprintf(":x: %s requested by <@%s> could not be started: %s", verb, click.user, err))
+		return
+	}
+	c.Logger.Info("Slack user %s started drift remediation %s for %s", click.user, result.ID, click.value.Repository)
+	if result.Status.IsTerminal() {
+		c.finishMessage(click, slackRemediationOutcome(verb, click.user, result))
+		return
+	}
+	c.updateMessage(click, fmt.Sprintf(":hourglass_flowing_sand: %s requested by <@%s> is running (remediation `%s`)", verb, click.user, result.ID), false)
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSlackRemediationTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	id := result.ID
+	result, err = c.waitForRemediation(ctx, id)
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.Logger.Warn("drift remediation %s requested from Slack is still running after %s", id, timeout)
+		c.finishMessage(click, fmt.Sprintf(":warning: %s requested by <@%s> is still running after %s; check remediation `%s` through the API", verb, click.user, timeout, id))
+		return
+	}
+	if err != nil {
+		c.Logger.Warn("unable to get drift remediation %s: %s", id, err)
+		c.finishMessage(click, fmt.Sprintf(":warning: %s requested by <@%s>: status of remediation `%s` is unavailable", verb, click.user, id))
+		return
+	}
+	c.finishMessage(click, slackRemediationOutcome(verb, click.user, result))
+}
+
+// waitForRemediation polls the remediation with the given ID until it
+// finishes or ctx is done.
+func (c *SlackInteractionsController) waitForRemediation(ctx context.Context, id string) (*models.RemediationResult, error) {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultSlackRemediationPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		result, err := c.RemediationService.GetResult(id)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status.IsTerminal() {
+			return result, nil
+		}
+	}
+}
+
+// beginMessage records the message a click came from, unless it is already
+// tracked because another remediation from it is in flight.
+func (c *SlackInteractionsController) beginMessage(click slackDriftClick, message slack.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages == nil {
+		c.messages = map[string]*slackMessageState{}
+	}
+	key := click.channel + "/" + click.timestamp
+	state, ok := c.messages[key]
+	if !ok {
+		state = &slackMessageState{attachments: message.Attachments, blocks: message.Blocks.BlockSet}
+		c.messages[key] = state
+	}
+	state.pending++
+}
+
+// updateMessage sets the status line of the clicked project and updates the
+// message in Slack.
+func (c *SlackInteractionsController) updateMessage(click slackDriftClick, status string, showActions bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state := c.messages[click.channel+"/"+click.timestamp]
+	if state == nil {
+		return
+	}
+	state.blocks = webhooks.SetDriftProjectStatus(state.blocks, click.key, click.value, status, showActions, c.AllowApply)
+	if err := c.Slack.UpdateMessage(click.channel, click.timestamp, state.attachments, state.blocks); err != nil {
+		c.Logger.Warn("unable to update Slack drift message: %s", err)
+	}
+}
+
+// finishMessage sets the final status of the clicked project, shows its
+// buttons again and stops tracking the message if nothing else is in flight.
+func (c *SlackInteractionsController) finishMessage(click slackDriftClick, status string) {
+	c.updateMessage(click, status, true)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := click.channel + "/" + click.timestamp
+	if state := c.messages[key]; state != nil {
+		state.pending--
+		if state.pending <= 0 {
+			delete(c.messages, key)
+		}
+	}
+}
+
+func (c *SlackInteractionsController) verify(header http.Header, body []byte) error {
+	verifier, err := slack.NewSecretsVerifier(header, c.SigningSecret)
+	if err != nil {
+		return err
+	}
+	if _, err := verifier.Write(body); err != nil {
+		return err
+	}
+	return verifier.Ensure()
+}
+
+func (c *SlackInteractionsController) respond(w http.ResponseWriter, lvl logging.LogLevel, responseCode int, format string, args ...any) {
+	response := fmt.Sprintf(format, args...)
+	c.Logger.Log(lvl, response)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(responseCode)
+	fmt.Fprintln(w, response) // #nosec G705 -- response body is served as text/plain, not interpreted as HTML
+}
+
+// parseSlackDriftClick returns the drift button click in payload. It returns
+// false if payload isn't a drift button click.
+func parseSlackDriftClick(payload slack.InteractionCallback) (slackDriftClick, bool, error) {
+	if payload.Type != slack.InteractionTypeBlockActions {
+		return slackDriftClick{}, false, nil
+	}
+	for _, action := range payload.ActionCallback.BlockActions {
+		var remediationAction models.RemediationAction
+		switch action.ActionID {
+		case webhooks.DriftPlanActionID:
+			remediationAction = models.RemediationPlanOnly
+		case webhooks.DriftApplyActionID:
+			remediationAction = models.RemediationAutoApply
+		default:
+			continue
+		}
+		key, ok := webhooks.DriftActionProjectKey(action.BlockID)
+		if !ok {
+			return slackDriftClick{}, false, fmt.Errorf("unexpected block ID %q for drift action", action.BlockID)
+		}
+		value, err := webhooks.ParseDriftActionValue(action.Value)
+		if err != nil {
+			return slackDriftClick{}, false, err
+		}
+		timestamp := payload.Container.MessageTs
+		if timestamp == "" {
+			timestamp = payload.Message.Timestamp
+		}
+		channel := payload.Container.ChannelID
+		if channel == "" {
+			channel = payload.Channel.ID
+		}
+		return slackDriftClick{
+			channel:   channel,
+			timestamp: timestamp,
+			user:      payload.User.ID,
+			key:       key,
+			action:    remediationAction,
+			value:     value,
+		}, true, nil
+	}
+	return slackDriftClick{}, false, nil
+}
+
+func slackActionVerb(action models.RemediationAction) string {
+	if action == models.RemediationAutoApply {
+		return "Apply fix"
+	}
+	return "Plan fix"
+}
+
+func slackRemediationOutcome(verb, user string, result *models.RemediationResult) string {
+	switch result.Status {
+	case models.RemediationStatusSuccess:
+		return fmt.Sprintf(":white_check_mark: %s requested by <@%s> succeeded (remediation `%s`)", verb, user, result.ID)
+	case models.RemediationStatusCancelled:
+		return fmt.Sprintf(":no_entry_sign: %s requested by <@%s> was cancelled (remediation `%s`)", verb, user, result.ID)
+	default:
+		reason := result.Error
+		for _, p := range result.Projects {
+			if reason == "" && p.Error != "" {
+				reason = p.Error
+			}
+		}
+		if reason == "" {
+			reason = string(result.Status)
+		}
+		return fmt.Sprintf(":x: %s requested by <@%s> failed (remediation `%s`): %s", verb, user, result.ID, reason)
+	}
+}
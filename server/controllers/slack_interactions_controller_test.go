@@ -0,0 +1,263 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/controllers"
+	driftmocks "github.com/runatlantis/atlantis/server/core/drift/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	webhookmocks "github.com/runatlantis/atlantis/server/events/webhooks/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"github.com/slack-go/slack"
+)
+
+const slackSigningSecret = "slack-secret"
+
+type fakeRemediationRunner struct {
+	mu       sync.Mutex
+	requests []models.RemediationRequest
+	result   *models.RemediationResult
+	err      error
+	// release, if set, blocks RunRemediation until it's closed.
+	release chan struct{}
+}
+
+func (f *fakeRemediationRunner) RunRemediation(request models.RemediationRequest) (*models.RemediationResult, error) {
+	if f.release != nil {
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, request)
+	return f.result, f.err
+}
+
+func (f *fakeRemediationRunner) Requests() []models.RemediationRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.requests)
+}
+
+var slackDriftValue = webhooks.DriftActionValue{
+	Repository:  "owner/repo",
+	Ref:         "main",
+	VCSType:     "Github",
+	ProjectName: "vpc",
+	Path:        "modules/vpc",
+	Workspace:   "default",
+}
+
+func slackDriftPayload(t *testing.T, actionID, user string) string {
+	t.Helper()
+	value, err := json.Marshal(slackDriftValue)
+	Ok(t, err)
+	payload := map[string]any{
+		"type": "block_actions",
+		"user": map[string]any{"id": user},
+		"container": map[string]any{
+			"type":       "message",
+			"channel_id": "C123",
+			"message_ts": "1700000000.000100",
+		},
+		"message": map[string]any{
+			"ts": "1700000000.000100",
+			"blocks": []slack.Block{
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "vpc", false, false), nil, nil,
+					slack.SectionBlockOptionBlockID("atlantis_drift_project/0")),
+				webhooks.DriftActionsBlock("0", slackDriftValue, true),
+			},
+		},
+		"actions": []map[string]any{{
+			"action_id": actionID,
+			"block_id":  "atlantis_drift_actions/0",
+			"value":     string(value),
+		}},
+	}
+	encoded, err := json.Marshal(payload)
+	Ok(t, err)
+	return url.Values{"payload": {string(encoded)}}.Encode()
+}
+
+func signedSlackRequest(t *testing.T, body, secret string) *http.Request {
+	t.Helper()
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	req := httptest.NewRequest(http.MethodPost, "/slack/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newSlackInteractionsController(t *testing.T, runner controllers.RemediationRunner) (*controllers.SlackInteractionsController, *webhookmocks.MockSlackClient, *driftmocks.MockRemediationService) {
+	RegisterMockTestingT(t)
+	slackClient := webhookmocks.NewMockSlackClient()
+	service := driftmocks.NewMockRemediationService()
+	return &controllers.SlackInteractionsController{
+		Logger:             logging.NewNoopLogger(t),
+		SigningSecret:      slackSigningSecret,
+		AllowedUsers:       []string{"U_ALLOWED"},
+		Remediator:         runner,
+		RemediationService: service,
+		Slack:              slackClient,
+		AllowApply:         true,
+		PollInterval:       time.Millisecond,
+	}, slackClient, service
+}
+
+func lastStatusText(t *testing.T, blocks []slack.Block) string {
+	t.Helper()
+	for _, b := range blocks {
+		if b.ID() == "atlantis_drift_status/0" {
+			return b.(*slack.ContextBlock).ContextElements.Elements[0].(*slack.TextBlockObject).Text
+		}
+	}
+	t.Fatalf("no status block in %v", blocks)
+	return ""
+}
+
+func TestSlackInteractionsController_RejectsBadSignature(t *testing.T) {
+	runner := &fakeRemediationRunner{}
+	c, _, _ := newSlackInteractionsController(t, runner)
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftPlanActionID, "U_ALLOWED"), "wrong-secret"))
+
+	Equals(t, http.StatusUnauthorized, w.Code)
+	Equals(t, 0, len(runner.Requests()))
+}
+
+func TestSlackInteractionsController_RejectsUserNotAllowed(t *testing.T) {
+	runner := &fakeRemediationRunner{}
+	c, slackClient, _ := newSlackInteractionsController(t, runner)
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftApplyActionID, "U_OTHER"), slackSigningSecret))
+
+	Equals(t, http.StatusOK, w.Code)
+	Equals(t, 0, len(runner.Requests()))
+	slackClient.VerifyWasCalledEventually(Once(), time.Second).PostEphemeral(Eq("C123"), Eq("U_OTHER"), Any[string]())
+	slackClient.VerifyWasCalled(Never()).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]())
+}
+
+func TestSlackInteractionsController_RemediatesAndUpdatesMessage(t *testing.T) {
+	runner := &fakeRemediationRunner{result: &models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusRunning}}
+	c, slackClient, service := newSlackInteractionsController(t, runner)
+	When(service.GetResult("rem-1")).ThenReturn(&models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusSuccess}, nil)
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftApplyActionID, "U_ALLOWED"), slackSigningSecret))
+
+	Equals(t, http.StatusOK, w.Code)
+	slackClient.VerifyWasCalledEventually(Times(2), time.Second).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]())
+	Equals(t, 1, len(runner.Requests()))
+	Equals(t, models.RemediationRequest{
+		Repository:  "owner/repo",
+		Ref:         "main",
+		Type:        "Github",
+		Action:      models.RemediationAutoApply,
+		Projects:    []string{"vpc"},
+		Paths:       []models.DriftDetectionPath{{Directory: "modules/vpc", Workspace: "default"}},
+		RequestedBy: &models.APICaller{Name: "U_ALLOWED", AuthMethod: "slack"},
+	}, runner.Requests()[0])
+
+	channels, timestamps, _, blocks := slackClient.VerifyWasCalled(Times(2)).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]()).GetAllCapturedArguments()
+	Equals(t, []string{"C123", "C123"}, channels)
+	Equals(t, []string{"1700000000.000100", "1700000000.000100"}, timestamps)
+	Equals(t, ":hourglass_flowing_sand: Apply fix requested by <@U_ALLOWED> is running (remediation `rem-1`)", lastStatusText(t, blocks[0]))
+	Equals(t, ":white_check_mark: Apply fix requested by <@U_ALLOWED> succeeded (remediation `rem-1`)", lastStatusText(t, blocks[1]))
+	Equals(t, "atlantis_drift_actions/0", blocks[1][len(blocks[1])-1].ID())
+}
+
+func TestSlackInteractionsController_ReportsRemediationStartFailure(t *testing.T) {
+	runner := &fakeRemediationRunner{err: errors.New("drift remediation apply is not enabled")}
+	c, slackClient, _ := newSlackInteractionsController(t, runner)
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftApplyActionID, "U_ALLOWED"), slackSigningSecret))
+
+	Equals(t, http.StatusOK, w.Code)
+	_, _, _, blocks := slackClient.VerifyWasCalledEventually(Once(), time.Second).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]()).GetCapturedArguments()
+	Equals(t, ":x: Apply fix requested by <@U_ALLOWED> could not be started: drift remediation apply is not enabled", lastStatusText(t, blocks))
+}
+
+func TestSlackInteractionsController_IgnoresOtherInteractions(t *testing.T) {
+	runner := &fakeRemediationRunner{}
+	c, _, _ := newSlackInteractionsController(t, runner)
+
+	body := url.Values{"payload": {`{"type":"view_submission","user":{"id":"U_ALLOWED"}}`}}.Encode()
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, body, slackSigningSecret))
+
+	Equals(t, http.StatusOK, w.Code)
+	Equals(t, 0, len(runner.Requests()))
+}
+
+func TestSlackInteractionsController_AcksBeforeRemediating(t *testing.T) {
+	runner := &fakeRemediationRunner{
+		result:  &models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusSuccess},
+		release: make(chan struct{}),
+	}
+	c, slackClient, _ := newSlackInteractionsController(t, runner)
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftPlanActionID, "U_ALLOWED"), slackSigningSecret))
+
+	// The interaction is acknowledged while the remediation is still starting.
+	Equals(t, http.StatusOK, w.Code)
+	Equals(t, 0, len(runner.Requests()))
+	close(runner.release)
+	slackClient.VerifyWasCalledEventually(Once(), time.Second).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]())
+	Equals(t, 1, len(runner.Requests()))
+}
+
+func TestSlackInteractionsController_StopsWaitingAfterTimeout(t *testing.T) {
+	runner := &fakeRemediationRunner{result: &models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusRunning}}
+	c, slackClient, service := newSlackInteractionsController(t, runner)
+	c.Timeout = 20 * time.Millisecond
+	When(service.GetResult("rem-1")).ThenReturn(&models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusRunning}, nil)
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftPlanActionID, "U_ALLOWED"), slackSigningSecret))
+
+	Equals(t, http.StatusOK, w.Code)
+	_, _, _, blocks := slackClient.VerifyWasCalledEventually(Times(2), time.Second).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]()).GetAllCapturedArguments()
+	Equals(t, ":warning: Plan fix requested by <@U_ALLOWED> is still running after 20ms; check remediation `rem-1` through the API", lastStatusText(t, blocks[1]))
+}
+
+func TestSlackInteractionsController_HidesApplyWhenNotAllowed(t *testing.T) {
+	runner := &fakeRemediationRunner{result: &models.RemediationResult{ID: "rem-1", Status: models.RemediationStatusSuccess}}
+	c, slackClient, _ := newSlackInteractionsController(t, runner)
+	c.AllowApply = false
+
+	w := httptest.NewRecorder()
+	c.Post(w, signedSlackRequest(t, slackDriftPayload(t, webhooks.DriftPlanActionID, "U_ALLOWED"), slackSigningSecret))
+
+	Equals(t, http.StatusOK, w.Code)
+	_, _, _, blocks := slackClient.VerifyWasCalledEventually(Once(), time.Second).UpdateMessage(Any[string](), Any[string](), Any[[]slack.Attachment](), Any[[]slack.Block]()).GetCapturedArguments()
+	actions := blocks[len(blocks)-1].(*slack.ActionBlock)
+	Equals(t, "atlantis_drift_actions/0", actions.BlockID)
+	Equals(t, 1, len(actions.Elements.ElementSet))
+	Equals(t, webhooks.DriftPlanActionID, actions.Elements.ElementSet[0].(*slack.ButtonBlockElement).ActionID)
+}
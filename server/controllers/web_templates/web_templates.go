@@ -28,6 +28,8 @@ var templateFileNames = map[string]string{
 	"project-jobs":       "project-jobs.html.tmpl",
 	"project-jobs-error": "project-jobs-error.html.tmpl",
 	"github-app":         "github-app.html.tmpl",
+	"drift":              "drift.html.tmpl",
+	"drift-plan":         "drift-plan.html.tmpl",
 }
 
 // TemplateWriter is an interface over html/template that's used to enable
@@ -69,6 +71,9 @@ type IndexData struct {
 	// not using a path-based proxy, this will be an empty string. Never ends
 	// in a '/' (hence "cleaned").
 	CleanedBasePath string
+	// DriftDashboardEnabled is true when drift detection is enabled, to link
+	// to the drift dashboard.
+	DriftDashboardEnabled bool
 }
 
 var IndexTemplate = templates.Lookup(templateFileNames["index"])
@@ -87,6 +92,18 @@ type LockDetailData struct {
 	// not using a path-based proxy, this will be an empty string. Never ends
 	// in a '/' (hence "cleaned").
 	CleanedBasePath string
+	// Queue lists the pull requests waiting for the lock, in order.
+	Queue []QueuedPullData
+}
+
+// QueuedPullData holds the fields needed to display a pull request waiting
+// for a lock.
+type QueuedPullData struct {
+	Position        int
+	PullNum         int
+	PullRequestLink string
+	QueuedBy        string
+	TimeFormatted   string
 }
 
 var LockTemplate = templates.Lookup(templateFileNames["lock"])
@@ -96,6 +113,8 @@ type ProjectJobData struct {
 	AtlantisVersion string
 	ProjectPath     string
 	CleanedBasePath string
+	// TraceID is the ID of the trace the job ran in, if tracing is enabled.
+	TraceID string
 }
 
 var ProjectJobsTemplate = templates.Lookup(templateFileNames["project-jobs"])
@@ -120,3 +139,75 @@ type GithubSetupData struct {
 }
 
 var GithubAppSetupTemplate = templates.Lookup(templateFileNames["github-app"])
+
+// DriftProjectData holds the fields needed to display a project in the drift
+// dashboard.
+type DriftProjectData struct {
+	ProjectName          string
+	Path                 string
+	Workspace            string
+	Ref                  string
+	HasDrift             bool
+	ToAdd                int
+	ToChange             int
+	ToDestroy            int
+	Error                string
+	LastChecked          time.Time
+	LastCheckedFormatted string
+	// PlanPath is the URL of the plan that detected the drift, or empty if no
+	// plan output is stored.
+	PlanPath string
+	// RemediationKey identifies the project when requesting remediation. It
+	// is empty if the project can't be remediated from the dashboard.
+	RemediationKey string
+}
+
+// DriftRepoData holds the projects of a repository in the drift dashboard.
+type DriftRepoData struct {
+	Repository        string
+	Projects          []DriftProjectData
+	ProjectsWithDrift int
+	ProjectsWithError int
+}
+
+// DriftFilterData holds the filters applied to the drift dashboard.
+type DriftFilterData struct {
+	Repository string
+	Project    string
+	Workspace  string
+	Status     string
+}
+
+// DriftDashboardData holds the data for rendering the drift dashboard.
+type DriftDashboardData struct {
+	Repos []DriftRepoData
+	// RepositoryNames lists every repository with drift results, for the
+	// repository filter.
+	RepositoryNames []string
+	Filter          DriftFilterData
+	// RemediationEnabled is true when plan-only remediation can be requested
+	// from the dashboard.
+	RemediationEnabled bool
+	// QueuedRemediationID is the ID of a remediation that was just requested.
+	QueuedRemediationID string
+	AtlantisVersion     string
+	CleanedBasePath     string
+}
+
+var DriftTemplate = templates.Lookup(templateFileNames["drift"])
+
+// DriftPlanData holds the data for rendering the plan output of a drift
+// result.
+type DriftPlanData struct {
+	Repository           string
+	ProjectName          string
+	Path                 string
+	Workspace            string
+	Ref                  string
+	LastCheckedFormatted string
+	PlanOutput           string
+	AtlantisVersion      string
+	CleanedBasePath      string
+}
+
+var DriftPlanTemplate = templates.Lookup(templateFileNames["drift-plan"])
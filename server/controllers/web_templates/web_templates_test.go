@@ -62,6 +62,9 @@ func TestLockTemplate(t *testing.T) {
 		CleanedBasePath: "/path",
 		RepoOwner:       "repo owner",
 		RepoName:        "repo name",
+		Queue: []QueuedPullData{
+			{Position: 1, PullNum: 2, PullRequestLink: "https://example.com/2", QueuedBy: "queued by", TimeFormatted: "02-01-2006 15:04:05"},
+		},
 	})
 	Ok(t, err)
 }
@@ -71,12 +74,13 @@ func TestProjectJobsTemplate(t *testing.T) {
 		AtlantisVersion: "v0.0.0",
 		ProjectPath:     "project path",
 		CleanedBasePath: "/path",
+		TraceID:         "4bf92f3577b34da6a3ce929d0e0e4736",
 	})
 	Ok(t, err)
 }
 
 func TestProjectJobsErrorTemplate(t *testing.T) {
-	err := ProjectJobsTemplate.Execute(io.Discard, ProjectJobsError{
+	err := ProjectJobsErrorTemplate.Execute(io.Discard, ProjectJobsError{
 		AtlantisVersion: "v0.0.0",
 		ProjectPath:     "project path",
 		CleanedBasePath: "/path",
@@ -96,3 +100,55 @@ func TestGithubAppSetupTemplate(t *testing.T) {
 	})
 	Ok(t, err)
 }
+
+func TestDriftTemplate(t *testing.T) {
+	err := DriftTemplate.Execute(io.Discard, DriftDashboardData{
+		Repos: []DriftRepoData{
+			{
+				Repository: "github.com/owner/repo",
+				Projects: []DriftProjectData{
+					{
+						ProjectName:          "project name",
+						Path:                 "path",
+						Workspace:            "workspace",
+						Ref:                  "main",
+						HasDrift:             true,
+						ToAdd:                1,
+						LastChecked:          time.Now(),
+						LastCheckedFormatted: "2006-01-02 15:04:05",
+						PlanPath:             "/drift/plan?repository=repo&key=key",
+						RemediationKey:       "key",
+					},
+					{
+						Path:  "other",
+						Error: "error",
+					},
+				},
+				ProjectsWithDrift: 1,
+				ProjectsWithError: 1,
+			},
+		},
+		RepositoryNames:     []string{"github.com/owner/repo"},
+		Filter:              DriftFilterData{Repository: "github.com/owner/repo", Status: "drift"},
+		RemediationEnabled:  true,
+		QueuedRemediationID: "id",
+		AtlantisVersion:     "v0.0.0",
+		CleanedBasePath:     "/path",
+	})
+	Ok(t, err)
+}
+
+func TestDriftPlanTemplate(t *testing.T) {
+	err := DriftPlanTemplate.Execute(io.Discard, DriftPlanData{
+		Repository:           "github.com/owner/repo",
+		ProjectName:          "project name",
+		Path:                 "path",
+		Workspace:            "workspace",
+		Ref:                  "main",
+		LastCheckedFormatted: "2006-01-02 15:04:05",
+		PlanOutput:           "plan output",
+		AtlantisVersion:      "v0.0.0",
+		CleanedBasePath:      "/path",
+	})
+	Ok(t, err)
+}
@@ -84,3 +84,14 @@ func (m *Multiplexor) Handle(w http.ResponseWriter, r *http.Request) error {
 	}
 	return nil
 }
+
+// WriteLines writes lines to the websocket for r and then closes it. It's used
+// for partitions that are no longer in the registry, e.g. archived job logs.
+func (m *Multiplexor) WriteLines(w http.ResponseWriter, r *http.Request, lines []string) error {
+	buffer := make(chan string, len(lines))
+	for _, line := range lines {
+		buffer <- line
+	}
+	close(buffer)
+	return m.writer.Write(w, r, buffer)
+}
@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"path"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,17 +24,21 @@ import (
 
 // BoltDB is a database using BoltDB
 type BoltDB struct {
-	db                    *bolt.DB
-	locksBucketName       []byte
-	pullsBucketName       []byte
-	globalLocksBucketName []byte
+	db                         *bolt.DB
+	locksBucketName            []byte
+	pullsBucketName            []byte
+	globalLocksBucketName      []byte
+	lockQueuesBucketName       []byte
+	policyExceptionsBucketName []byte
 }
 
 const (
-	locksBucketName       = "runLocks"
-	pullsBucketName       = "pulls"
-	globalLocksBucketName = "globalLocks"
-	pullKeySeparator      = "::"
+	locksBucketName            = "runLocks"
+	pullsBucketName            = "pulls"
+	globalLocksBucketName      = "globalLocks"
+	lockQueuesBucketName       = "lockQueues"
+	policyExceptionsBucketName = "policyExceptions"
+	pullKeySeparator           = "::"
 )
 
 // New returns a valid locker. We need to be able to write to dataDir
@@ -61,6 +66,12 @@ func New(dataDir string) (*BoltDB, error) {
 		if _, err = tx.CreateBucketIfNotExists([]byte(globalLocksBucketName)); err != nil {
 			return fmt.Errorf("creating bucket %q: %w", globalLocksBucketName, err)
 		}
+		if _, err = tx.CreateBucketIfNotExists([]byte(lockQueuesBucketName)); err != nil {
+			return fmt.Errorf("creating bucket %q: %w", lockQueuesBucketName, err)
+		}
+		if _, err = tx.CreateBucketIfNotExists([]byte(policyExceptionsBucketName)); err != nil {
+			return fmt.Errorf("creating bucket %q: %w", policyExceptionsBucketName, err)
+		}
 		return nil
 	})
 	if err != nil {
@@ -120,20 +131,24 @@ func New(dataDir string) (*BoltDB, error) {
 	}
 
 	return &BoltDB{
-		db:                    db,
-		locksBucketName:       []byte(locksBucketName),
-		pullsBucketName:       []byte(pullsBucketName),
-		globalLocksBucketName: []byte(globalLocksBucketName),
+		db:                         db,
+		locksBucketName:            []byte(locksBucketName),
+		pullsBucketName:            []byte(pullsBucketName),
+		globalLocksBucketName:      []byte(globalLocksBucketName),
+		lockQueuesBucketName:       []byte(lockQueuesBucketName),
+		policyExceptionsBucketName: []byte(policyExceptionsBucketName),
 	}, nil
 }
 
 // NewWithDB is used for testing.
 func NewWithDB(db *bolt.DB, bucket string, globalBucket string) (*BoltDB, error) {
 	return &BoltDB{
-		db:                    db,
-		locksBucketName:       []byte(bucket),
-		pullsBucketName:       []byte(pullsBucketName),
-		globalLocksBucketName: []byte(globalBucket),
+		db:                         db,
+		locksBucketName:            []byte(bucket),
+		pullsBucketName:            []byte(pullsBucketName),
+		globalLocksBucketName:      []byte(globalBucket),
+		lockQueuesBucketName:       []byte(lockQueuesBucketName),
+		policyExceptionsBucketName: []byte(policyExceptionsBucketName),
 	}, nil
 }
 
@@ -402,6 +417,155 @@ func (b *BoltDB) GetLock(p models.Project, workspace string) (*models.ProjectLoc
 	return &lock, nil
 }
 
+// EnqueueLock adds entry to the end of the queue for its project and
+// workspace, unless its pull request is already queued. It returns the
+// 1-based position of the pull request in the queue.
+func (b *BoltDB) EnqueueLock(entry models.LockQueueEntry) (int, error) {
+	var position int
+	key := []byte(b.lockKey(entry.Project, entry.Workspace))
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.lockQueuesBucketName)
+		queue, err := b.getLockQueueFromBucket(bucket, key)
+		if err != nil {
+			return err
+		}
+		for i, queued := range queue {
+			if queued.Pull.Num == entry.Pull.Num {
+				position = i + 1
+				return nil
+			}
+		}
+		queue = append(queue, entry)
+		position = len(queue)
+		return b.writeLockQueueToBucket(bucket, key, queue)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return position, nil
+}
+
+// GetLockQueue returns the pull requests queued for the lock on project and
+// workspace, in order.
+func (b *BoltDB) GetLockQueue(project models.Project, workspace string) ([]models.LockQueueEntry, error) {
+	var queue []models.LockQueueEntry
+	key := []byte(b.lockKey(project, workspace))
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		queue, err = b.getLockQueueFromBucket(tx.Bucket(b.lockQueuesBucketName), key)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return queue, nil
+}
+
+// DequeueLock removes pullNum from the queue for project and workspace.
+func (b *BoltDB) DequeueLock(project models.Project, workspace string, pullNum int) error {
+	key := []byte(b.lockKey(project, workspace))
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.lockQueuesBucketName)
+		queue, err := b.getLockQueueFromBucket(bucket, key)
+		if err != nil {
+			return err
+		}
+		return b.writeLockQueueToBucket(bucket, key, slices.DeleteFunc(queue, func(e models.LockQueueEntry) bool {
+			return e.Pull.Num == pullNum
+		}))
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// DequeuePull removes pullNum from every queue in the repo.
+func (b *BoltDB) DequeuePull(repoFullName string, pullNum int) error {
+	prefix := []byte(repoFullName + "/")
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.lockQueuesBucketName)
+		updated := make(map[string][]models.LockQueueEntry)
+		c := bucket.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			queue, err := b.getLockQueueFromBucket(bucket, k)
+			if err != nil {
+				return err
+			}
+			remaining := slices.DeleteFunc(slices.Clone(queue), func(e models.LockQueueEntry) bool {
+				return e.Pull.Num == pullNum
+			})
+			if len(remaining) != len(queue) {
+				updated[string(k)] = remaining
+			}
+		}
+		// Keys can't be modified while iterating with a cursor.
+		for k, queue := range updated {
+			if err := b.writeLockQueueToBucket(bucket, []byte(k), queue); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// AddPolicyException records a policy exception.
+func (b *BoltDB) AddPolicyException(exception models.PolicyException) error {
+	key := b.policyExceptionsKey(exception.Project.RepoFullName, exception.PullNum)
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.policyExceptionsBucketName)
+		var exceptions []models.PolicyException
+		if serialized := bucket.Get(key); serialized != nil {
+			if err := json.Unmarshal(serialized, &exceptions); err != nil {
+				return fmt.Errorf("deserializing policy exceptions at %q: %w", string(key), err)
+			}
+		}
+		serialized, err := json.Marshal(append(exceptions, exception))
+		if err != nil {
+			return fmt.Errorf("serializing policy exceptions: %w", err)
+		}
+		return bucket.Put(key, serialized)
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// GetPolicyExceptions returns the policy exceptions granted on pullNum in the
+// repo, including expired ones, in the order they were granted. If pullNum is
+// 0, it returns the exceptions for every pull request in the repo.
+func (b *BoltDB) GetPolicyExceptions(repoFullName string, pullNum int) ([]models.PolicyException, error) {
+	prefix := b.policyExceptionsKey(repoFullName, pullNum)
+	if pullNum == 0 {
+		prefix = fmt.Appendf(nil, "%s%s", repoFullName, pullKeySeparator)
+	}
+	var exceptions []models.PolicyException
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(b.policyExceptionsBucketName).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			// Seeking "owner/repo::1" also finds "owner/repo::10".
+			if pullNum != 0 && !bytes.Equal(k, prefix) {
+				continue
+			}
+			var pullExceptions []models.PolicyException
+			if err := json.Unmarshal(v, &pullExceptions); err != nil {
+				return fmt.Errorf("deserializing policy exceptions at %q: %w", string(k), err)
+			}
+			exceptions = append(exceptions, pullExceptions...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return exceptions, nil
+}
+
 // UpdatePullWithResults updates pull's status with the latest project results.
 // It returns the new PullStatus object.
 func (b *BoltDB) UpdatePullWithResults(pull models.PullRequest, newResults []command.ProjectResult) (models.PullStatus, error) {
@@ -469,6 +633,11 @@ func (b *BoltDB) UpdatePullWithResults(pull models.PullRequest, newResults []com
 						res.ProjectName == proj.ProjectName {
 
 						proj.Status = res.PlanStatus()
+						if res.Command == command.Plan {
+							proj.PlanDigest, proj.PlanCommit = res.PlanIntegrity()
+							proj.CostEstimate = res.PlanCostEstimate()
+							proj.PlannedAt = time.Now()
+						}
 
 						// Updating only policy sets which are included in results; keeping the rest.
 						if len(proj.PolicyStatus) > 0 {
@@ -608,6 +777,35 @@ func (b *BoltDB) lockKey(p models.Project, workspace string) string {
 	return models.GenerateLockKey(p, workspace)
 }
 
+func (b *BoltDB) policyExceptionsKey(repoFullName string, pullNum int) []byte {
+	return fmt.Appendf(nil, "%s%s%d", repoFullName, pullKeySeparator, pullNum)
+}
+
+func (b *BoltDB) getLockQueueFromBucket(bucket *bolt.Bucket, key []byte) ([]models.LockQueueEntry, error) {
+	serialized := bucket.Get(key)
+	if serialized == nil {
+		return nil, nil
+	}
+	var queue []models.LockQueueEntry
+	if err := json.Unmarshal(serialized, &queue); err != nil {
+		return nil, fmt.Errorf("deserializing lock queue at %q: %w", string(key), err)
+	}
+	return queue, nil
+}
+
+// writeLockQueueToBucket stores queue at key, deleting the key once the queue
+// is empty.
+func (b *BoltDB) writeLockQueueToBucket(bucket *bolt.Bucket, key []byte, queue []models.LockQueueEntry) error {
+	if len(queue) == 0 {
+		return bucket.Delete(key)
+	}
+	serialized, err := json.Marshal(queue)
+	if err != nil {
+		return fmt.Errorf("serializing lock queue: %w", err)
+	}
+	return bucket.Put(key, serialized)
+}
+
 func (b *BoltDB) getPullFromBucket(bucket *bolt.Bucket, key []byte) (*models.PullStatus, error) {
 	serialized := bucket.Get(key)
 	if serialized == nil {
@@ -630,12 +828,21 @@ func (b *BoltDB) writePullToBucket(bucket *bolt.Bucket, key []byte, pull models.
 }
 
 func (b *BoltDB) projectResultToProject(p command.ProjectResult) models.ProjectStatus {
+	planDigest, planCommit := p.PlanIntegrity()
+	var plannedAt time.Time
+	if p.Command == command.Plan {
+		plannedAt = time.Now()
+	}
 	return models.ProjectStatus{
 		Workspace:    p.Workspace,
 		RepoRelDir:   p.RepoRelDir,
 		ProjectName:  p.ProjectName,
 		PolicyStatus: p.PolicyStatus(),
 		Status:       p.PlanStatus(),
+		PlanDigest:   planDigest,
+		PlanCommit:   planCommit,
+		PlannedAt:    plannedAt,
+		CostEstimate: p.PlanCostEstimate(),
 	}
 }
 
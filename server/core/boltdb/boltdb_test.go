@@ -659,7 +659,7 @@ func TestPullStatus_UpdateGet(t *testing.T) {
 			ProjectName: "",
 			Status:      models.ErroredPlanStatus,
 		},
-	}, status.Projects)
+	}, withoutPlannedAt(status.Projects))
 	b.Close()
 }
 
@@ -779,6 +779,51 @@ func TestPullStatus_UpdateProject(t *testing.T) {
 	b.Close()
 }
 
+// Test that the plan digest and commit are recorded by plans and kept by
+// later commands.
+func TestPullStatus_UpdateRecordsPlanIntegrity(t *testing.T) {
+	b := newTestDB2(t)
+	defer b.Close()
+
+	pull := models.PullRequest{
+		Num:        1,
+		HeadCommit: "sha",
+		BaseRepo: models.Repo{
+			FullName: "runatlantis/atlantis",
+			Owner:    "runatlantis",
+			Name:     "atlantis",
+		},
+	}
+	planResult := command.ProjectResult{
+		Command:    command.Plan,
+		RepoRelDir: ".",
+		Workspace:  "default",
+		ProjectCommandOutput: command.ProjectCommandOutput{
+			PlanSuccess: &models.PlanSuccess{PlanDigest: "digest1", PlanCommit: "sha"},
+		},
+	}
+	_, err := b.UpdatePullWithResults(pull, []command.ProjectResult{planResult})
+	Ok(t, err)
+
+	planResult.PlanSuccess = &models.PlanSuccess{PlanDigest: "digest2", PlanCommit: "sha", CostEstimate: &models.CostEstimate{Currency: "USD", MonthlyDelta: 5}}
+	_, err = b.UpdatePullWithResults(pull, []command.ProjectResult{planResult})
+	Ok(t, err)
+
+	status, err := b.UpdatePullWithResults(pull, []command.ProjectResult{{
+		Command:    command.Apply,
+		RepoRelDir: ".",
+		Workspace:  "default",
+		ProjectCommandOutput: command.ProjectCommandOutput{
+			Failure: "failure",
+		},
+	}})
+	Ok(t, err)
+	Equals(t, 1, len(status.Projects))
+	Equals(t, "digest2", status.Projects[0].PlanDigest)
+	Equals(t, "sha", status.Projects[0].PlanCommit)
+	Equals(t, &models.CostEstimate{Currency: "USD", MonthlyDelta: 5}, status.Projects[0].CostEstimate)
+}
+
 // Test that if we update an existing pull status and our new status is for a
 // different HeadSHA, that we just overwrite the old status.
 func TestPullStatus_UpdateNewCommit(t *testing.T) {
@@ -909,7 +954,7 @@ func TestPullStatus_UpdateSameCommitNewBaseBranch(t *testing.T) {
 			ProjectName: "",
 			Status:      models.PlannedPlanStatus,
 		},
-	}, maybeStatus.Projects)
+	}, withoutPlannedAt(maybeStatus.Projects))
 	b.Close()
 }
 
@@ -971,7 +1016,7 @@ func TestBoltDB_SameCommitBackfillBaseDoesNotPromoteLegacyOldBaseProjects(t *tes
 			ProjectName: "",
 			Status:      models.PlannedPlanStatus,
 		},
-	}, status.Projects)
+	}, withoutPlannedAt(status.Projects))
 
 	maybeStatus, err := b.GetPullStatus(pull)
 	Ok(t, err)
@@ -1100,7 +1145,7 @@ func TestPullStatus_UpdateMerge_Apply(t *testing.T) {
 				Workspace:  "default",
 				Status:     models.AppliedPlanStatus,
 			},
-		}, updateStatus.Projects)
+		}, withoutPlannedAt(updateStatus.Projects))
 	}
 	b.Close()
 }
@@ -1428,3 +1473,133 @@ func cleanupDB(db *bolt.DB) {
 	db.Close()           // nolint: errcheck
 	os.Remove(db.Path()) // nolint: errcheck
 }
+
+func TestPullStatus_RecordsPlannedAt(t *testing.T) {
+	b := newTestDB2(t)
+	pull := models.PullRequest{
+		Num:        1,
+		HeadCommit: "sha",
+		BaseRepo:   models.Repo{FullName: "runatlantis/atlantis", VCSHost: models.VCSHost{Hostname: "github.com"}},
+	}
+
+	before := time.Now()
+	status, err := b.UpdatePullWithResults(pull, []command.ProjectResult{
+		{
+			Command:    command.Plan,
+			RepoRelDir: ".",
+			Workspace:  "default",
+			ProjectCommandOutput: command.ProjectCommandOutput{
+				PlanSuccess: &models.PlanSuccess{},
+			},
+		},
+	})
+	Ok(t, err)
+	plannedAt := status.Projects[0].PlannedAt
+	Assert(t, !plannedAt.Before(before), "expected PlannedAt to be set, got %s", plannedAt)
+
+	// Applying doesn't count as plan activity.
+	status, err = b.UpdatePullWithResults(pull, []command.ProjectResult{
+		{
+			Command:    command.Apply,
+			RepoRelDir: ".",
+			Workspace:  "default",
+			ProjectCommandOutput: command.ProjectCommandOutput{
+				ApplySuccess: "applied",
+			},
+		},
+	})
+	Ok(t, err)
+	Assert(t, status.Projects[0].PlannedAt.Equal(plannedAt), "expected PlannedAt to be unchanged, got %s", status.Projects[0].PlannedAt)
+}
+
+// withoutPlannedAt clears the PlannedAt timestamps of projects so statuses can
+// be compared.
+func withoutPlannedAt(projects []models.ProjectStatus) []models.ProjectStatus {
+	cleared := make([]models.ProjectStatus, len(projects))
+	for i, p := range projects {
+		p.PlannedAt = time.Time{}
+		cleared[i] = p
+	}
+	return cleared
+}
+
+func TestLockQueue(t *testing.T) {
+	b := newTestDB2(t)
+	infra := models.NewProject("owner/repo", "infra", "")
+	queueEntry := func(p models.Project, pullNum int) models.LockQueueEntry {
+		return models.LockQueueEntry{Project: p, Workspace: "default", Pull: models.PullRequest{Num: pullNum}}
+	}
+
+	for i, pullNum := range []int{2, 3, 2} {
+		position, err := b.EnqueueLock(queueEntry(infra, pullNum))
+		Ok(t, err)
+		Equals(t, []int{1, 2, 1}[i], position)
+	}
+	_, err := b.EnqueueLock(queueEntry(models.NewProject("owner/repo", "app", ""), 3))
+	Ok(t, err)
+	_, err = b.EnqueueLock(queueEntry(models.NewProject("owner/repo2", "infra", ""), 3))
+	Ok(t, err)
+
+	queue, err := b.GetLockQueue(infra, "default")
+	Ok(t, err)
+	Equals(t, []models.LockQueueEntry{queueEntry(infra, 2), queueEntry(infra, 3)}, queue)
+
+	Ok(t, b.DequeueLock(infra, "default", 2))
+	queue, err = b.GetLockQueue(infra, "default")
+	Ok(t, err)
+	Equals(t, []models.LockQueueEntry{queueEntry(infra, 3)}, queue)
+
+	Ok(t, b.DequeuePull("owner/repo", 3))
+	queue, err = b.GetLockQueue(infra, "default")
+	Ok(t, err)
+	Equals(t, 0, len(queue))
+	queue, err = b.GetLockQueue(models.NewProject("owner/repo", "app", ""), "default")
+	Ok(t, err)
+	Equals(t, 0, len(queue))
+	queue, err = b.GetLockQueue(models.NewProject("owner/repo2", "infra", ""), "default")
+	Ok(t, err)
+	Equals(t, 1, len(queue))
+}
+
+func TestPolicyExceptions(t *testing.T) {
+	b := newTestDB2(t)
+	exception := func(repo string, pullNum int, reason string) models.PolicyException {
+		granted := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		return models.PolicyException{
+			Project:       models.NewProject(repo, "infra", ""),
+			Workspace:     "default",
+			PullNum:       pullNum,
+			PolicySetName: "policies",
+			Hashes:        []string{"abc"},
+			Approver:      "approver",
+			Reason:        reason,
+			Time:          granted,
+			Expires:       granted.Add(24 * time.Hour),
+		}
+	}
+
+	exceptions, err := b.GetPolicyExceptions("owner/repo", 1)
+	Ok(t, err)
+	Equals(t, 0, len(exceptions))
+
+	for _, e := range []models.PolicyException{
+		exception("owner/repo", 1, "first"),
+		exception("owner/repo", 10, "other pull"),
+		exception("owner/repo", 1, "second"),
+		exception("owner/repo2", 1, "other repo"),
+	} {
+		Ok(t, b.AddPolicyException(e))
+	}
+
+	exceptions, err = b.GetPolicyExceptions("owner/repo", 1)
+	Ok(t, err)
+	Equals(t, []models.PolicyException{exception("owner/repo", 1, "first"), exception("owner/repo", 1, "second")}, exceptions)
+
+	exceptions, err = b.GetPolicyExceptions("owner/repo", 0)
+	Ok(t, err)
+	Equals(t, []models.PolicyException{
+		exception("owner/repo", 1, "first"),
+		exception("owner/repo", 1, "second"),
+		exception("owner/repo", 10, "other pull"),
+	}, exceptions)
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+const driftBucketName = "drift"
+
+// DriftStorage is a drift.Storage backed by the BoltDB database used for
+// locking. Each repository gets a nested bucket under the drift bucket, keyed
+// by drift.StorageKey, so drift results survive server restarts.
+type DriftStorage struct {
+	db              *bolt.DB
+	driftBucketName []byte
+}
+
+// NewDriftStorage returns a drift.Storage that shares b's underlying database.
+func NewDriftStorage(b *BoltDB) (*DriftStorage, error) {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte(driftBucketName)); err != nil {
+			return fmt.Errorf("creating bucket %q: %w", driftBucketName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initializing drift storage: %w", err)
+	}
+	return &DriftStorage{
+		db:              b.db,
+		driftBucketName: []byte(driftBucketName),
+	}, nil
+}
+
+// Store saves a drift result for a project.
+func (d *DriftStorage) Store(repository string, projectDrift models.ProjectDrift) error {
+	serialized, err := json.Marshal(projectDrift)
+	if err != nil {
+		return fmt.Errorf("serializing drift: %w", err)
+	}
+	err = d.db.Update(func(tx *bolt.Tx) error {
+		repoBucket, err := tx.Bucket(d.driftBucketName).CreateBucketIfNotExists([]byte(repository))
+		if err != nil {
+			return fmt.Errorf("creating drift bucket for %q: %w", repository, err)
+		}
+		return repoBucket.Put([]byte(drift.StorageKey(projectDrift)), serialized)
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves drift results for a repository with optional filtering.
+func (d *DriftStorage) Get(repository string, opts drift.GetOptions) ([]models.ProjectDrift, error) {
+	now := time.Now()
+	result := make([]models.ProjectDrift, 0)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		repoBucket := tx.Bucket(d.driftBucketName).Bucket([]byte(repository))
+		if repoBucket == nil {
+			return nil
+		}
+		return repoBucket.ForEach(func(k, v []byte) error {
+			projectDrift, err := d.deserialize(k, v)
+			if err != nil {
+				return err
+			}
+			if drift.MatchesGetOptions(projectDrift, opts, now) {
+				result = append(result, projectDrift)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return result, nil
+}
+
+// Delete removes drift results for a repository.
+// If projectName is empty, all drift results for the repository are removed.
+func (d *DriftStorage) Delete(repository string, projectName string) error {
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(d.driftBucketName)
+		if projectName == "" {
+			if bucket.Bucket([]byte(repository)) == nil {
+				return nil
+			}
+			return bucket.DeleteBucket([]byte(repository))
+		}
+		return d.deleteWhere(bucket.Bucket([]byte(repository)), func(projectDrift models.ProjectDrift) bool {
+			return projectDrift.ProjectName == projectName
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteMatching removes drift results for a repository that match the given filters.
+func (d *DriftStorage) DeleteMatching(repository string, opts drift.GetOptions) error {
+	if opts == (drift.GetOptions{}) {
+		return drift.ErrDeleteFilterRequired
+	}
+	now := time.Now()
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		return d.deleteWhere(tx.Bucket(d.driftBucketName).Bucket([]byte(repository)), func(projectDrift models.ProjectDrift) bool {
+			return drift.MatchesDeleteOptions(projectDrift, opts, now)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// GetAll retrieves all stored drift results across all repositories.
+func (d *DriftStorage) GetAll() (map[string][]models.ProjectDrift, error) {
+	result := make(map[string][]models.ProjectDrift)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(d.driftBucketName).ForEachBucket(func(repository []byte) error {
+			repoBucket := tx.Bucket(d.driftBucketName).Bucket(repository)
+			drifts := make([]models.ProjectDrift, 0)
+			if err := repoBucket.ForEach(func(k, v []byte) error {
+				projectDrift, err := d.deserialize(k, v)
+				if err != nil {
+					return err
+				}
+				drifts = append(drifts, projectDrift)
+				return nil
+			}); err != nil {
+				return err
+			}
+			result[string(repository)] = drifts
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return result, nil
+}
+
+// deleteWhere removes every entry in repoBucket for which match returns true.
+// Keys are collected first because bolt cursors must not be mutated while
+// iterating with ForEach.
+func (d *DriftStorage) deleteWhere(repoBucket *bolt.Bucket, match func(models.ProjectDrift) bool) error {
+	if repoBucket == nil {
+		return nil
+	}
+	var keys [][]byte
+	if err := repoBucket.ForEach(func(k, v []byte) error {
+		projectDrift, err := d.deserialize(k, v)
+		if err != nil {
+			return err
+		}
+		if match(projectDrift) {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := repoBucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DriftStorage) deserialize(key []byte, serialized []byte) (models.ProjectDrift, error) {
+	var projectDrift models.ProjectDrift
+	if err := json.Unmarshal(serialized, &projectDrift); err != nil {
+		return projectDrift, fmt.Errorf("deserializing drift at key %q: %w", key, err)
+	}
+	return projectDrift, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/boltdb"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestDriftStorage_StoreAndGet(t *testing.T) {
+	storage := newTestDriftStorage(t)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "vpc",
+		Path:        "modules/vpc",
+		Workspace:   "default",
+		Ref:         "main",
+		Drift:       models.DriftSummary{HasDrift: true, ToAdd: 2},
+		LastChecked: time.Now(),
+	}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "vpc",
+		Path:        "modules/vpc",
+		Workspace:   "default",
+		Ref:         "main",
+		Drift:       models.DriftSummary{HasDrift: false},
+		LastChecked: time.Now(),
+	}))
+
+	results, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, false, results[0].Drift.HasDrift)
+
+	results, err = storage.Get("owner/other", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+}
+
+func TestDriftStorage_GetFilters(t *testing.T) {
+	storage := newTestDriftStorage(t)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "vpc",
+		Workspace:   "default",
+		Ref:         "main",
+		BaseBranch:  "main",
+		LastChecked: time.Now(),
+	}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "vpc",
+		Workspace:   "default",
+		Ref:         "release",
+		BaseBranch:  "release",
+		LastChecked: time.Now().Add(-2 * time.Hour),
+	}))
+
+	results, err := storage.Get("owner/repo", drift.GetOptions{Ref: "release"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "release", results[0].Ref)
+
+	results, err = storage.Get("owner/repo", drift.GetOptions{BaseBranch: "main"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "main", results[0].BaseBranch)
+
+	results, err = storage.Get("owner/repo", drift.GetOptions{MaxAge: time.Hour})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "main", results[0].Ref)
+}
+
+func TestDriftStorage_Delete(t *testing.T) {
+	storage := newTestDriftStorage(t)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "a", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "b", LastChecked: time.Now()}))
+
+	Ok(t, storage.Delete("owner/repo", "a"))
+	results, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "b", results[0].ProjectName)
+
+	Ok(t, storage.Delete("owner/repo", ""))
+	results, err = storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+
+	Ok(t, storage.Delete("owner/missing", ""))
+}
+
+func TestDriftStorage_DeleteMatching(t *testing.T) {
+	storage := newTestDriftStorage(t)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "", Path: "env", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "named", Path: "env", Ref: "main", LastChecked: time.Now()}))
+
+	ErrEquals(t, drift.ErrDeleteFilterRequired.Error(), storage.DeleteMatching("owner/repo", drift.GetOptions{}))
+
+	Ok(t, storage.DeleteMatching("owner/repo", drift.GetOptions{Path: "env", Ref: "main", Exact: true}))
+	results, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "named", results[0].ProjectName)
+
+	Ok(t, storage.DeleteMatching("owner/repo", drift.GetOptions{Ref: "main"}))
+	results, err = storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+}
+
+func TestDriftStorage_GetAllPersistsAcrossReopen(t *testing.T) {
+	dataDir := t.TempDir()
+	b, err := boltdb.New(dataDir)
+	Ok(t, err)
+	storage, err := boltdb.NewDriftStorage(b)
+	Ok(t, err)
+	Ok(t, storage.Store("owner/repo1", models.ProjectDrift{ProjectName: "a", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/repo2", models.ProjectDrift{ProjectName: "b", LastChecked: time.Now()}))
+	Ok(t, b.Close())
+
+	b, err = boltdb.New(dataDir)
+	Ok(t, err)
+	defer b.Close() // nolint: errcheck
+	storage, err = boltdb.NewDriftStorage(b)
+	Ok(t, err)
+
+	all, err := storage.GetAll()
+	Ok(t, err)
+	Equals(t, 2, len(all))
+	Equals(t, "a", all["owner/repo1"][0].ProjectName)
+	Equals(t, "b", all["owner/repo2"][0].ProjectName)
+}
+
+func newTestDriftStorage(t *testing.T) *boltdb.DriftStorage {
+	b := newTestDB2(t)
+	t.Cleanup(func() { b.Close() }) // nolint: errcheck
+	storage, err := boltdb.NewDriftStorage(b)
+	Ok(t, err)
+	return storage
+}
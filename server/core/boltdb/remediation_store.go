@@ -0,0 +1,155 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb
+
+import (
+	"fmt"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	remediationsBucketName     = "remediations"
+	remediationIndexBucketName = "remediationIndex"
+)
+
+// RemediationStore is a drift.RemediationStore backed by the BoltDB database
+// used for locking. Results are kept in a nested bucket per repository, and an
+// index bucket maps each result ID to its repository.
+type RemediationStore struct {
+	db                         *bolt.DB
+	remediationsBucketName     []byte
+	remediationIndexBucketName []byte
+}
+
+// NewRemediationStore returns a drift.RemediationStore that shares b's
+// underlying database.
+func NewRemediationStore(b *BoltDB) (*RemediationStore, error) {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{remediationsBucketName, remediationIndexBucketName} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return fmt.Errorf("creating bucket %q: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initializing remediation store: %w", err)
+	}
+	return &RemediationStore{
+		db:                         b.db,
+		remediationsBucketName:     []byte(remediationsBucketName),
+		remediationIndexBucketName: []byte(remediationIndexBucketName),
+	}, nil
+}
+
+// StoreRemediation creates or replaces a remediation result.
+func (s *RemediationStore) StoreRemediation(result *models.RemediationResult) error {
+	serialized, err := drift.MarshalRemediation(result)
+	if err != nil {
+		return err
+	}
+	repository := []byte(drift.RemediationRepositoryKey(result))
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		repoBucket, err := tx.Bucket(s.remediationsBucketName).CreateBucketIfNotExists(repository)
+		if err != nil {
+			return fmt.Errorf("creating remediation bucket for %q: %w", repository, err)
+		}
+		if err := repoBucket.Put([]byte(result.ID), serialized); err != nil {
+			return err
+		}
+		return tx.Bucket(s.remediationIndexBucketName).Put([]byte(result.ID), repository)
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// GetRemediation returns the result with the given ID.
+func (s *RemediationStore) GetRemediation(id string) (*models.RemediationResult, error) {
+	var serialized []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		repository := tx.Bucket(s.remediationIndexBucketName).Get([]byte(id))
+		if repository == nil {
+			return nil
+		}
+		repoBucket := tx.Bucket(s.remediationsBucketName).Bucket(repository)
+		if repoBucket == nil {
+			return nil
+		}
+		if v := repoBucket.Get([]byte(id)); v != nil {
+			serialized = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	if serialized == nil {
+		return nil, fmt.Errorf("%w: %s", drift.ErrRemediationNotFound, id)
+	}
+	return drift.UnmarshalRemediation(serialized)
+}
+
+// ListRemediations returns results for a repository, newest first. An empty
+// repository lists results across all repositories.
+func (s *RemediationStore) ListRemediations(repository string, opts drift.RemediationListOptions) (drift.RemediationPage, error) {
+	var results []*models.RemediationResult
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(s.remediationsBucketName)
+		if repository != "" {
+			return s.appendRepo(bucket.Bucket([]byte(repository)), &results)
+		}
+		return bucket.ForEachBucket(func(k []byte) error {
+			return s.appendRepo(bucket.Bucket(k), &results)
+		})
+	})
+	if err != nil {
+		return drift.RemediationPage{}, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return drift.PaginateRemediations(results, opts)
+}
+
+// DeleteRemediations removes the results with the given IDs.
+func (s *RemediationStore) DeleteRemediations(ids []string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		index := tx.Bucket(s.remediationIndexBucketName)
+		for _, id := range ids {
+			repository := index.Get([]byte(id))
+			if repository == nil {
+				continue
+			}
+			if repoBucket := tx.Bucket(s.remediationsBucketName).Bucket(repository); repoBucket != nil {
+				if err := repoBucket.Delete([]byte(id)); err != nil {
+					return err
+				}
+			}
+			if err := index.Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+func (s *RemediationStore) appendRepo(repoBucket *bolt.Bucket, results *[]*models.RemediationResult) error {
+	if repoBucket == nil {
+		return nil
+	}
+	return repoBucket.ForEach(func(k, v []byte) error {
+		result, err := drift.UnmarshalRemediation(v)
+		if err != nil {
+			return fmt.Errorf("remediation %q: %w", k, err)
+		}
+		*results = append(*results, result)
+		return nil
+	})
+}
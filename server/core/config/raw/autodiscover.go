@@ -58,7 +58,7 @@ func (a AutoDiscover) Validate() error {
 
 	res := validation.ValidateStruct(&a,
 		// If a.Mode is nil, this should still pass validation.
-		validation.Field(&a.Mode, validation.In(valid.AutoDiscoverAutoMode, valid.AutoDiscoverDisabledMode, valid.AutoDiscoverEnabledMode)),
+		validation.Field(&a.Mode, validation.In(valid.AutoDiscoverAutoMode, valid.AutoDiscoverDisabledMode, valid.AutoDiscoverEnabledMode, valid.AutoDiscoverTerragruntMode)),
 		validation.Field(&a.IgnorePaths, validation.By(ignoreValid)),
 	)
 	return res
@@ -54,6 +54,7 @@ func TestAutoDiscover_Validate(t *testing.T) {
 	autoDiscoverAuto := valid.AutoDiscoverAutoMode
 	autoDiscoverEnabled := valid.AutoDiscoverEnabledMode
 	autoDiscoverDisabled := valid.AutoDiscoverDisabledMode
+	autoDiscoverTerragrunt := valid.AutoDiscoverTerragruntMode
 	randomString := valid.AutoDiscoverMode("random_string")
 	cases := []struct {
 		description string
@@ -86,6 +87,13 @@ func TestAutoDiscover_Validate(t *testing.T) {
 			},
 			errContains: nil,
 		},
+		{
+			description: "mode set to terragrunt",
+			input: raw.AutoDiscover{
+				Mode: &autoDiscoverTerragrunt,
+			},
+			errContains: nil,
+		},
 		{
 			description: "mode set to random string",
 			input: raw.AutoDiscover{
@@ -0,0 +1,120 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/scheduled"
+)
+
+// DriftDetection is the raw schema for a repo's scheduled drift detection.
+type DriftDetection struct {
+	Schedule string               `yaml:"schedule" json:"schedule"`
+	Ref      string               `yaml:"ref" json:"ref"`
+	Projects []string             `yaml:"projects,omitempty" json:"projects,omitempty"`
+	Paths    []DriftDetectionPath `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Jitter   string               `yaml:"jitter,omitempty" json:"jitter,omitempty"`
+}
+
+type DriftDetectionPath struct {
+	Directory string `yaml:"directory" json:"directory"`
+	Workspace string `yaml:"workspace,omitempty" json:"workspace,omitempty"`
+}
+
+func (d DriftDetection) Validate() error {
+	scheduleValid := func(value any) error {
+		_, err := scheduled.ParseCronSchedule(value.(string))
+		return err
+	}
+
+	refValid := func(value any) error {
+		ref := value.(string)
+		if models.IsUnsafeAPIRef(ref) {
+			return errors.New("ref is invalid")
+		}
+		if models.RequiresBaseBranchForRef(ref) {
+			return errors.New("ref must be a branch")
+		}
+		return nil
+	}
+
+	projectsValid := func(value any) error {
+		for _, project := range value.([]string) {
+			if strings.TrimSpace(project) == "" {
+				return errors.New("project names cannot be empty")
+			}
+		}
+		return nil
+	}
+
+	pathsValid := func(value any) error {
+		paths := value.([]DriftDetectionPath)
+		if len(paths) > 0 && len(d.Projects) > 0 {
+			return errors.New("projects and paths cannot both be set")
+		}
+		for _, path := range paths {
+			if _, ok := models.NormalizeAPIPath(path.Directory); !ok {
+				return fmt.Errorf("directory %q must be a clean repo-relative path", path.Directory)
+			}
+			if path.Workspace != "" && !models.IsValidAPIWorkspace(path.Workspace) {
+				return fmt.Errorf("workspace %q is invalid", path.Workspace)
+			}
+		}
+		return nil
+	}
+
+	jitterValid := func(value any) error {
+		jitter := value.(string)
+		if jitter == "" {
+			return nil
+		}
+		parsed, err := time.ParseDuration(jitter)
+		if err != nil {
+			return err
+		}
+		if parsed < 0 {
+			return errors.New("must not be negative")
+		}
+		return nil
+	}
+
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Schedule, validation.Required, validation.By(scheduleValid)),
+		validation.Field(&d.Ref, validation.Required, validation.By(refValid)),
+		validation.Field(&d.Projects, validation.By(projectsValid)),
+		validation.Field(&d.Paths, validation.By(pathsValid)),
+		validation.Field(&d.Jitter, validation.By(jitterValid)),
+	)
+}
+
+func (d DriftDetection) ToValid() *valid.DriftDetection {
+	// Safe to ignore errors because we test them in Validate().
+	schedule, _ := scheduled.ParseCronSchedule(d.Schedule)
+	var jitter time.Duration
+	if d.Jitter != "" {
+		jitter, _ = time.ParseDuration(d.Jitter)
+	}
+
+	v := valid.DriftDetection{
+		Schedule: schedule,
+		Ref:      d.Ref,
+		Projects: d.Projects,
+		Jitter:   jitter,
+	}
+	for _, path := range d.Paths {
+		dir, _ := models.NormalizeAPIPath(path.Directory)
+		v.Paths = append(v.Paths, valid.DriftDetectionPath{
+			Directory: dir,
+			Workspace: path.Workspace,
+		})
+	}
+	return &v
+}
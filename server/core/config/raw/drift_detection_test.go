@@ -0,0 +1,132 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/raw"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestDriftDetection_UnmarshalYAML(t *testing.T) {
+	var d raw.DriftDetection
+	err := unmarshalString(`
+schedule: "0 */6 * * *"
+ref: main
+paths:
+- directory: env/prod
+  workspace: default
+jitter: 10m
+`, &d)
+	Ok(t, err)
+	Equals(t, raw.DriftDetection{
+		Schedule: "0 */6 * * *",
+		Ref:      "main",
+		Paths:    []raw.DriftDetectionPath{{Directory: "env/prod", Workspace: "default"}},
+		Jitter:   "10m",
+	}, d)
+}
+
+func TestDriftDetection_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		input       raw.DriftDetection
+		errContains *string
+	}{
+		{
+			description: "minimal",
+			input:       raw.DriftDetection{Schedule: "@daily", Ref: "main"},
+		},
+		{
+			description: "all fields set",
+			input: raw.DriftDetection{
+				Schedule: "0 */6 * * *",
+				Ref:      "main",
+				Projects: []string{"vpc"},
+				Jitter:   "5m",
+			},
+		},
+		{
+			description: "missing schedule",
+			input:       raw.DriftDetection{Ref: "main"},
+			errContains: String("schedule: cannot be blank"),
+		},
+		{
+			description: "invalid schedule",
+			input:       raw.DriftDetection{Schedule: "0 25 * * *", Ref: "main"},
+			errContains: String("value 25 out of range [0-23] in hour field"),
+		},
+		{
+			description: "missing ref",
+			input:       raw.DriftDetection{Schedule: "@daily"},
+			errContains: String("ref: cannot be blank"),
+		},
+		{
+			description: "commit ref",
+			input:       raw.DriftDetection{Schedule: "@daily", Ref: "0123456789abcdef"},
+			errContains: String("ref must be a branch"),
+		},
+		{
+			description: "projects and paths",
+			input: raw.DriftDetection{
+				Schedule: "@daily",
+				Ref:      "main",
+				Projects: []string{"vpc"},
+				Paths:    []raw.DriftDetectionPath{{Directory: "vpc"}},
+			},
+			errContains: String("projects and paths cannot both be set"),
+		},
+		{
+			description: "path escapes repo",
+			input: raw.DriftDetection{
+				Schedule: "@daily",
+				Ref:      "main",
+				Paths:    []raw.DriftDetectionPath{{Directory: "../other"}},
+			},
+			errContains: String("must be a clean repo-relative path"),
+		},
+		{
+			description: "negative jitter",
+			input:       raw.DriftDetection{Schedule: "@daily", Ref: "main", Jitter: "-1m"},
+			errContains: String("jitter: must not be negative"),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if c.errContains == nil {
+				Ok(t, c.input.Validate())
+			} else {
+				ErrContains(t, *c.errContains, c.input.Validate())
+			}
+		})
+	}
+}
+
+func TestDriftDetection_ToValid(t *testing.T) {
+	v := raw.DriftDetection{
+		Schedule: "0 */6 * * *",
+		Ref:      "main",
+		Paths:    []raw.DriftDetectionPath{{Directory: "./env/prod/", Workspace: "prod"}},
+		Jitter:   "90s",
+	}.ToValid()
+
+	Equals(t, "0 */6 * * *", v.Schedule.String())
+	Equals(t, "main", v.Ref)
+	Equals(t, []valid.DriftDetectionPath{{Directory: "env/prod", Workspace: "prod"}}, v.Paths)
+	Equals(t, 90*time.Second, v.Jitter)
+}
+
+func TestRepo_ValidateDriftDetectionRequiresExactID(t *testing.T) {
+	r := raw.Repo{
+		ID:             "/.*/",
+		DriftDetection: &raw.DriftDetection{Schedule: "@daily", Ref: "main"},
+	}
+	ErrContains(t, "drift_detection: requires an exact repo id, not a regex", r.Validate())
+
+	r.ID = "github.com/owner/repo"
+	Ok(t, r.Validate())
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -26,13 +27,34 @@ type GlobalCfg struct {
 
 // ExternalStores is the raw schema for external storage backends.
 type ExternalStores struct {
-	PlanStore PlanStoreConfig `yaml:"plan_store" json:"plan_store"`
+	PlanStore PlanStoreConfig   `yaml:"plan_store" json:"plan_store"`
+	JobLogs   JobLogStoreConfig `yaml:"job_logs" json:"job_logs"`
 }
 
-// PlanStoreConfig is the raw schema for plan storage configuration.
-type PlanStoreConfig struct {
+// JobLogStoreConfig is the raw schema for the job log archive configuration.
+type JobLogStoreConfig struct {
 	Type string        `yaml:"type" json:"type"`
+	Dir  string        `yaml:"dir" json:"dir"`
 	S3   S3StoreConfig `yaml:"s3" json:"s3"`
+	// Retention is how long archived job logs are kept, as a Go duration.
+	Retention string `yaml:"retention" json:"retention"`
+}
+
+// PlanStoreConfig is the raw schema for plan storage configuration.
+type PlanStoreConfig struct {
+	Type      string               `yaml:"type" json:"type"`
+	S3        S3StoreConfig        `yaml:"s3" json:"s3"`
+	GCS       GCSStoreConfig       `yaml:"gcs" json:"gcs"`
+	AzureBlob AzureBlobStoreConfig `yaml:"azureblob" json:"azureblob"`
+	// Encryption enables client-side encryption of stored plans.
+	Encryption *PlanStoreEncryption `yaml:"encryption,omitempty" json:"encryption,omitempty"`
+}
+
+// PlanStoreEncryption is the raw schema for plan store encryption. Exactly
+// one key source must be set.
+type PlanStoreEncryption struct {
+	KeyFile string `yaml:"key_file" json:"key_file"`
+	KeyEnv  string `yaml:"key_env" json:"key_env"`
 }
 
 // S3StoreConfig is the raw schema for S3 plan store configuration.
@@ -45,27 +67,95 @@ type S3StoreConfig struct {
 	Profile        string `yaml:"profile" json:"profile"`
 }
 
+// GCSStoreConfig is the raw schema for GCS plan store configuration.
+type GCSStoreConfig struct {
+	Bucket          string `yaml:"bucket" json:"bucket"`
+	Prefix          string `yaml:"prefix" json:"prefix"`
+	Endpoint        string `yaml:"endpoint" json:"endpoint"`
+	CredentialsFile string `yaml:"credentials_file" json:"credentials_file"`
+}
+
+// AzureBlobStoreConfig is the raw schema for Azure Blob plan store configuration.
+type AzureBlobStoreConfig struct {
+	AccountName string `yaml:"account_name" json:"account_name"`
+	Container   string `yaml:"container" json:"container"`
+	Prefix      string `yaml:"prefix" json:"prefix"`
+	Endpoint    string `yaml:"endpoint" json:"endpoint"`
+}
+
 func (e ExternalStores) Validate() error {
-	return e.PlanStore.Validate()
+	if err := e.PlanStore.Validate(); err != nil {
+		return err
+	}
+	return e.JobLogs.Validate()
 }
 
-func (p PlanStoreConfig) Validate() error {
-	if p.Type == "" {
+func (j JobLogStoreConfig) Validate() error {
+	switch j.Type {
+	case "":
 		return nil
+	case "local":
+	case "s3":
+		if j.S3.Bucket == "" {
+			return fmt.Errorf("external_stores.job_logs.s3.bucket is required when type is 's3'")
+		}
+		if j.S3.Region == "" {
+			return fmt.Errorf("external_stores.job_logs.s3.region is required when type is 's3'")
+		}
+	default:
+		return fmt.Errorf("unsupported job log store type %q: must be one of 'local' or 's3'", j.Type)
 	}
-	if p.Type != "s3" {
-		return fmt.Errorf("unsupported plan store type %q: only 's3' is supported", p.Type)
+	if j.Retention != "" {
+		retention, err := time.ParseDuration(j.Retention)
+		if err != nil {
+			return fmt.Errorf("external_stores.job_logs.retention: %w", err)
+		}
+		if retention <= 0 {
+			return fmt.Errorf("external_stores.job_logs.retention must be positive")
+		}
 	}
-	if p.S3.Bucket == "" {
-		return fmt.Errorf("external_stores.plan_store.s3.bucket is required when type is 's3'")
+	return nil
+}
+
+func (p PlanStoreConfig) Validate() error {
+	switch p.Type {
+	case "":
+		return nil
+	case "s3":
+		if p.S3.Bucket == "" {
+			return fmt.Errorf("external_stores.plan_store.s3.bucket is required when type is 's3'")
+		}
+		if p.S3.Region == "" {
+			return fmt.Errorf("external_stores.plan_store.s3.region is required when type is 's3'")
+		}
+	case "gcs":
+		if p.GCS.Bucket == "" {
+			return fmt.Errorf("external_stores.plan_store.gcs.bucket is required when type is 'gcs'")
+		}
+	case "azureblob":
+		if p.AzureBlob.AccountName == "" {
+			return fmt.Errorf("external_stores.plan_store.azureblob.account_name is required when type is 'azureblob'")
+		}
+		if p.AzureBlob.Container == "" {
+			return fmt.Errorf("external_stores.plan_store.azureblob.container is required when type is 'azureblob'")
+		}
+	default:
+		return fmt.Errorf("unsupported plan store type %q: must be one of 's3', 'gcs' or 'azureblob'", p.Type)
 	}
-	if p.S3.Region == "" {
-		return fmt.Errorf("external_stores.plan_store.s3.region is required when type is 's3'")
+	if p.Encryption != nil && (p.Encryption.KeyFile == "") == (p.Encryption.KeyEnv == "") {
+		return fmt.Errorf("external_stores.plan_store.encryption requires exactly one of key_file or key_env")
 	}
 	return nil
 }
 
 func (e ExternalStores) ToValid() valid.ExternalStores {
+	var encryption *valid.PlanStoreEncryption
+	if e.PlanStore.Encryption != nil {
+		encryption = &valid.PlanStoreEncryption{
+			KeyFile: e.PlanStore.Encryption.KeyFile,
+			KeyEnv:  e.PlanStore.Encryption.KeyEnv,
+		}
+	}
 	return valid.ExternalStores{
 		PlanStore: valid.PlanStoreConfig{
 			Type: e.PlanStore.Type,
@@ -77,31 +167,67 @@ func (e ExternalStores) ToValid() valid.ExternalStores {
 				ForcePathStyle: e.PlanStore.S3.ForcePathStyle,
 				Profile:        e.PlanStore.S3.Profile,
 			},
+			GCS: valid.GCSStoreConfig{
+				Bucket:          e.PlanStore.GCS.Bucket,
+				Prefix:          e.PlanStore.GCS.Prefix,
+				Endpoint:        e.PlanStore.GCS.Endpoint,
+				CredentialsFile: e.PlanStore.GCS.CredentialsFile,
+			},
+			AzureBlob: valid.AzureBlobStoreConfig{
+				AccountName: e.PlanStore.AzureBlob.AccountName,
+				Container:   e.PlanStore.AzureBlob.Container,
+				Prefix:      e.PlanStore.AzureBlob.Prefix,
+				Endpoint:    e.PlanStore.AzureBlob.Endpoint,
+			},
+			Encryption: encryption,
+		},
+		JobLogs: e.JobLogs.ToValid(),
+	}
+}
+
+func (j JobLogStoreConfig) ToValid() valid.JobLogStoreConfig {
+	// Validate has already checked that retention parses.
+	retention, _ := time.ParseDuration(j.Retention)
+	return valid.JobLogStoreConfig{
+		Type: j.Type,
+		Dir:  j.Dir,
+		S3: valid.S3StoreConfig{
+			Bucket:         j.S3.Bucket,
+			Region:         j.S3.Region,
+			Prefix:         j.S3.Prefix,
+			Endpoint:       j.S3.Endpoint,
+			ForcePathStyle: j.S3.ForcePathStyle,
+			Profile:        j.S3.Profile,
 		},
+		Retention: retention,
 	}
 }
 
 // Repo is the raw schema for repos in the server-side repo config.
 type Repo struct {
-	ID                        string         `yaml:"id" json:"id"`
-	Branch                    string         `yaml:"branch" json:"branch"`
-	RepoConfigFile            string         `yaml:"repo_config_file" json:"repo_config_file"`
-	PlanRequirements          []string       `yaml:"plan_requirements" json:"plan_requirements"`
-	ApplyRequirements         []string       `yaml:"apply_requirements" json:"apply_requirements"`
-	ImportRequirements        []string       `yaml:"import_requirements" json:"import_requirements"`
-	PreWorkflowHooks          []WorkflowHook `yaml:"pre_workflow_hooks" json:"pre_workflow_hooks"`
-	Workflow                  *string        `yaml:"workflow,omitempty" json:"workflow,omitempty"`
-	PostWorkflowHooks         []WorkflowHook `yaml:"post_workflow_hooks" json:"post_workflow_hooks"`
-	AllowedWorkflows          []string       `yaml:"allowed_workflows,omitempty" json:"allowed_workflows,omitempty"`
-	AllowedOverrides          []string       `yaml:"allowed_overrides" json:"allowed_overrides"`
-	AllowCustomWorkflows      *bool          `yaml:"allow_custom_workflows,omitempty" json:"allow_custom_workflows,omitempty"`
-	DeleteSourceBranchOnMerge *bool          `yaml:"delete_source_branch_on_merge,omitempty" json:"delete_source_branch_on_merge,omitempty"`
-	RepoLocking               *bool          `yaml:"repo_locking,omitempty" json:"repo_locking,omitempty"`
-	RepoLocks                 *RepoLocks     `yaml:"repo_locks,omitempty" json:"repo_locks,omitempty"`
-	PolicyCheck               *bool          `yaml:"policy_check,omitempty" json:"policy_check,omitempty"`
-	CustomPolicyCheck         *bool          `yaml:"custom_policy_check,omitempty" json:"custom_policy_check,omitempty"`
-	AutoDiscover              *AutoDiscover  `yaml:"autodiscover,omitempty" json:"autodiscover,omitempty"`
-	SilencePRComments         []string       `yaml:"silence_pr_comments,omitempty" json:"silence_pr_comments,omitempty"`
+	ID                        string          `yaml:"id" json:"id"`
+	Branch                    string          `yaml:"branch" json:"branch"`
+	RepoConfigFile            string          `yaml:"repo_config_file" json:"repo_config_file"`
+	PlanRequirements          []string        `yaml:"plan_requirements" json:"plan_requirements"`
+	ApplyRequirements         []string        `yaml:"apply_requirements" json:"apply_requirements"`
+	ImportRequirements        []string        `yaml:"import_requirements" json:"import_requirements"`
+	PreWorkflowHooks          []WorkflowHook  `yaml:"pre_workflow_hooks" json:"pre_workflow_hooks"`
+	Workflow                  *string         `yaml:"workflow,omitempty" json:"workflow,omitempty"`
+	PostWorkflowHooks         []WorkflowHook  `yaml:"post_workflow_hooks" json:"post_workflow_hooks"`
+	AllowedWorkflows          []string        `yaml:"allowed_workflows,omitempty" json:"allowed_workflows,omitempty"`
+	AllowedOverrides          []string        `yaml:"allowed_overrides" json:"allowed_overrides"`
+	AllowCustomWorkflows      *bool           `yaml:"allow_custom_workflows,omitempty" json:"allow_custom_workflows,omitempty"`
+	DeleteSourceBranchOnMerge *bool           `yaml:"delete_source_branch_on_merge,omitempty" json:"delete_source_branch_on_merge,omitempty"`
+	RepoLocking               *bool           `yaml:"repo_locking,omitempty" json:"repo_locking,omitempty"`
+	RepoLocks                 *RepoLocks      `yaml:"repo_locks,omitempty" json:"repo_locks,omitempty"`
+	LockTTL                   string          `yaml:"lock_ttl,omitempty" json:"lock_ttl,omitempty"`
+	PolicyCheck               *bool           `yaml:"policy_check,omitempty" json:"policy_check,omitempty"`
+	CustomPolicyCheck         *bool           `yaml:"custom_policy_check,omitempty" json:"custom_policy_check,omitempty"`
+	AutoDiscover              *AutoDiscover   `yaml:"autodiscover,omitempty" json:"autodiscover,omitempty"`
+	SilencePRComments         []string        `yaml:"silence_pr_comments,omitempty" json:"silence_pr_comments,omitempty"`
+	DriftDetection            *DriftDetection `yaml:"drift_detection,omitempty" json:"drift_detection,omitempty"`
+	CostBudget                *float64        `yaml:"cost_budget,omitempty" json:"cost_budget,omitempty"`
+	TFECredentials            []TFECredential `yaml:"tfe_credentials,omitempty" json:"tfe_credentials,omitempty"`
 }
 
 func (g GlobalCfg) Validate() error {
@@ -289,8 +415,8 @@ func (r Repo) Validate() error {
 	overridesValid := func(value any) error {
 		overrides := value.([]string)
 		for _, o := range overrides {
-			if o != valid.PlanRequirementsKey && o != valid.ApplyRequirementsKey && o != valid.ImportRequirementsKey && o != valid.WorkflowKey && o != valid.DeleteSourceBranchOnMergeKey && o != valid.RepoLockingKey && o != valid.RepoLocksKey && o != valid.PolicyCheckKey && o != valid.CustomPolicyCheckKey && o != valid.SilencePRCommentsKey {
-				return fmt.Errorf("%q is not a valid override, only %q, %q, %q, %q, %q, %q, %q, %q, %q, and %q are supported", o, valid.PlanRequirementsKey, valid.ApplyRequirementsKey, valid.ImportRequirementsKey, valid.WorkflowKey, valid.DeleteSourceBranchOnMergeKey, valid.RepoLockingKey, valid.RepoLocksKey, valid.PolicyCheckKey, valid.CustomPolicyCheckKey, valid.SilencePRCommentsKey)
+			if o != valid.PlanRequirementsKey && o != valid.ApplyRequirementsKey && o != valid.ImportRequirementsKey && o != valid.WorkflowKey && o != valid.DeleteSourceBranchOnMergeKey && o != valid.RepoLockingKey && o != valid.RepoLocksKey && o != valid.PolicyCheckKey && o != valid.CustomPolicyCheckKey && o != valid.SilencePRCommentsKey && o != valid.CostBudgetKey {
+				return fmt.Errorf("%q is not a valid override, only %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, and %q are supported", o, valid.PlanRequirementsKey, valid.ApplyRequirementsKey, valid.ImportRequirementsKey, valid.WorkflowKey, valid.DeleteSourceBranchOnMergeKey, valid.RepoLockingKey, valid.RepoLocksKey, valid.PolicyCheckKey, valid.CustomPolicyCheckKey, valid.SilencePRCommentsKey, valid.CostBudgetKey)
 			}
 		}
 		return nil
@@ -323,6 +449,32 @@ func (r Repo) Validate() error {
 		return nil
 	}
 
+	tfeCredentialsValid := func(value any) error {
+		hostnames := make(map[string]bool)
+		for _, c := range value.([]TFECredential) {
+			if err := c.Validate(); err != nil {
+				return err
+			}
+			hostname := strings.ToLower(c.Hostname)
+			if hostnames[hostname] {
+				return fmt.Errorf("hostname %q is set more than once", c.Hostname)
+			}
+			hostnames[hostname] = true
+		}
+		return nil
+	}
+
+	driftDetectionValid := func(value any) error {
+		driftDetection := value.(*DriftDetection)
+		if driftDetection == nil {
+			return nil
+		}
+		if r.HasRegexID() {
+			return errors.New("requires an exact repo id, not a regex")
+		}
+		return driftDetection.Validate()
+	}
+
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.ID, validation.Required, validation.By(idValid)),
 		validation.Field(&r.Branch, validation.By(branchValid)),
@@ -335,6 +487,10 @@ func (r Repo) Validate() error {
 		validation.Field(&r.DeleteSourceBranchOnMerge, validation.By(deleteSourceBranchOnMergeValid)),
 		validation.Field(&r.AutoDiscover, validation.By(autoDiscoverValid)),
 		validation.Field(&r.RepoLocks, validation.By(repoLocksValid)),
+		validation.Field(&r.LockTTL, validation.By(lockTTLValid)),
+		validation.Field(&r.DriftDetection, validation.By(driftDetectionValid)),
+		validation.Field(&r.CostBudget, validation.By(validCostBudget)),
+		validation.Field(&r.TFECredentials, validation.By(tfeCredentialsValid)),
 	)
 }
 
@@ -439,6 +595,22 @@ OuterGlobalImportReqs:
 		repoLocks = r.RepoLocks.ToValid()
 	}
 
+	var lockTTL time.Duration
+	if r.LockTTL != "" {
+		// Safe to ignore the error because we test it in Validate().
+		lockTTL, _ = time.ParseDuration(r.LockTTL)
+	}
+
+	var driftDetection *valid.DriftDetection
+	if r.DriftDetection != nil {
+		driftDetection = r.DriftDetection.ToValid()
+	}
+
+	var tfeCredentials []valid.TFECredential
+	for _, c := range r.TFECredentials {
+		tfeCredentials = append(tfeCredentials, c.ToValid())
+	}
+
 	return valid.Repo{
 		ID:                        id,
 		IDRegex:                   idRegex,
@@ -456,9 +628,13 @@ OuterGlobalImportReqs:
 		DeleteSourceBranchOnMerge: r.DeleteSourceBranchOnMerge,
 		RepoLocking:               r.RepoLocking,
 		RepoLocks:                 repoLocks,
+		LockTTL:                   lockTTL,
 		PolicyCheck:               r.PolicyCheck,
 		CustomPolicyCheck:         r.CustomPolicyCheck,
 		AutoDiscover:              autoDiscover,
 		SilencePRComments:         r.SilencePRComments,
+		DriftDetection:            driftDetection,
+		CostBudget:                r.CostBudget,
+		TFECredentials:            tfeCredentials,
 	}
 }
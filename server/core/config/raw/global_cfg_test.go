@@ -0,0 +1,188 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/raw"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestPlanStoreConfig_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		input       raw.PlanStoreConfig
+		expErr      string
+	}{
+		{
+			description: "empty",
+			input:       raw.PlanStoreConfig{},
+		},
+		{
+			description: "s3",
+			input:       raw.PlanStoreConfig{Type: "s3", S3: raw.S3StoreConfig{Bucket: "b", Region: "us-east-1"}},
+		},
+		{
+			description: "s3 without region",
+			input:       raw.PlanStoreConfig{Type: "s3", S3: raw.S3StoreConfig{Bucket: "b"}},
+			expErr:      "external_stores.plan_store.s3.region is required when type is 's3'",
+		},
+		{
+			description: "gcs",
+			input:       raw.PlanStoreConfig{Type: "gcs", GCS: raw.GCSStoreConfig{Bucket: "b"}},
+		},
+		{
+			description: "gcs without bucket",
+			input:       raw.PlanStoreConfig{Type: "gcs"},
+			expErr:      "external_stores.plan_store.gcs.bucket is required when type is 'gcs'",
+		},
+		{
+			description: "azureblob",
+			input:       raw.PlanStoreConfig{Type: "azureblob", AzureBlob: raw.AzureBlobStoreConfig{AccountName: "a", Container: "c"}},
+		},
+		{
+			description: "azureblob without account",
+			input:       raw.PlanStoreConfig{Type: "azureblob", AzureBlob: raw.AzureBlobStoreConfig{Container: "c"}},
+			expErr:      "external_stores.plan_store.azureblob.account_name is required when type is 'azureblob'",
+		},
+		{
+			description: "azureblob without container",
+			input:       raw.PlanStoreConfig{Type: "azureblob", AzureBlob: raw.AzureBlobStoreConfig{AccountName: "a"}},
+			expErr:      "external_stores.plan_store.azureblob.container is required when type is 'azureblob'",
+		},
+		{
+			description: "encryption with key file",
+			input:       raw.PlanStoreConfig{Type: "gcs", GCS: raw.GCSStoreConfig{Bucket: "b"}, Encryption: &raw.PlanStoreEncryption{KeyFile: "/etc/plan.key"}},
+		},
+		{
+			description: "encryption without key",
+			input:       raw.PlanStoreConfig{Type: "gcs", GCS: raw.GCSStoreConfig{Bucket: "b"}, Encryption: &raw.PlanStoreEncryption{}},
+			expErr:      "external_stores.plan_store.encryption requires exactly one of key_file or key_env",
+		},
+		{
+			description: "encryption with both keys",
+			input:       raw.PlanStoreConfig{Type: "gcs", GCS: raw.GCSStoreConfig{Bucket: "b"}, Encryption: &raw.PlanStoreEncryption{KeyFile: "/etc/plan.key", KeyEnv: "PLAN_KEY"}},
+			expErr:      "external_stores.plan_store.encryption requires exactly one of key_file or key_env",
+		},
+		{
+			description: "unknown type",
+			input:       raw.PlanStoreConfig{Type: "ftp"},
+			expErr:      `unsupported plan store type "ftp": must be one of 's3', 'gcs' or 'azureblob'`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.input.Validate()
+			if c.expErr == "" {
+				Ok(t, err)
+				return
+			}
+			ErrEquals(t, c.expErr, err)
+		})
+	}
+}
+
+func TestExternalStores_ToValid(t *testing.T) {
+	input := `
+plan_store:
+  type: azureblob
+  gcs:
+    bucket: plans
+    prefix: atlantis
+    endpoint: https://storage.example.com/storage/v1/
+    credentials_file: /etc/gcs.json
+  azureblob:
+    account_name: acme
+    container: plans
+    prefix: atlantis
+    endpoint: https://acme.blob.example.com/
+  encryption:
+    key_env: ATLANTIS_PLAN_KEY
+job_logs:
+  type: local
+  dir: /var/lib/atlantis/job-logs
+  retention: 720h
+`
+	var e raw.ExternalStores
+	Ok(t, yaml.Unmarshal([]byte(input), &e))
+	Ok(t, e.Validate())
+	Equals(t, valid.ExternalStores{
+		PlanStore: valid.PlanStoreConfig{
+			Type: "azureblob",
+			GCS: valid.GCSStoreConfig{
+				Bucket:          "plans",
+				Prefix:          "atlantis",
+				Endpoint:        "https://storage.example.com/storage/v1/",
+				CredentialsFile: "/etc/gcs.json",
+			},
+			AzureBlob: valid.AzureBlobStoreConfig{
+				AccountName: "acme",
+				Container:   "plans",
+				Prefix:      "atlantis",
+				Endpoint:    "https://acme.blob.example.com/",
+			},
+			Encryption: &valid.PlanStoreEncryption{KeyEnv: "ATLANTIS_PLAN_KEY"},
+		},
+		JobLogs: valid.JobLogStoreConfig{
+			Type:      "local",
+			Dir:       "/var/lib/atlantis/job-logs",
+			Retention: 720 * time.Hour,
+		},
+	}, e.ToValid())
+}
+
+func TestJobLogStoreConfig_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		input       raw.JobLogStoreConfig
+		expErr      string
+	}{
+		{
+			description: "empty",
+			input:       raw.JobLogStoreConfig{},
+		},
+		{
+			description: "local",
+			input:       raw.JobLogStoreConfig{Type: "local", Retention: "168h"},
+		},
+		{
+			description: "s3",
+			input:       raw.JobLogStoreConfig{Type: "s3", S3: raw.S3StoreConfig{Bucket: "b", Region: "us-east-1"}},
+		},
+		{
+			description: "s3 without bucket",
+			input:       raw.JobLogStoreConfig{Type: "s3", S3: raw.S3StoreConfig{Region: "us-east-1"}},
+			expErr:      "external_stores.job_logs.s3.bucket is required when type is 's3'",
+		},
+		{
+			description: "invalid retention",
+			input:       raw.JobLogStoreConfig{Type: "local", Retention: "30d"},
+			expErr:      `external_stores.job_logs.retention: time: unknown unit "d" in duration "30d"`,
+		},
+		{
+			description: "negative retention",
+			input:       raw.JobLogStoreConfig{Type: "local", Retention: "-1h"},
+			expErr:      "external_stores.job_logs.retention must be positive",
+		},
+		{
+			description: "unknown type",
+			input:       raw.JobLogStoreConfig{Type: "gcs"},
+			expErr:      `unsupported job log store type "gcs": must be one of 'local' or 's3'`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.input.Validate()
+			if c.expErr == "" {
+				Ok(t, err)
+				return
+			}
+			ErrEquals(t, c.expErr, err)
+		})
+	}
+}
@@ -97,6 +97,7 @@ type PolicySet struct {
 	StickyApprovals    *bool        `yaml:"sticky_policy_approvals,omitempty" json:"sticky_policy_approvals,omitempty"`
 	PolicyItemRegex    *string      `yaml:"policy_item_regex,omitempty" json:"policy_item_regex,omitempty"`
 	PreventSelfApprove bool         `yaml:"prevent_self_approve,omitempty" json:"prevent_self_approve,omitempty"`
+	Engine             string       `yaml:"engine,omitempty" json:"engine,omitempty"`
 }
 
 func (p PolicySet) Validate() error {
@@ -107,6 +108,7 @@ func (p PolicySet) Validate() error {
 		validation.Field(&p.Path, validation.Required.Error("is required")),
 		validation.Field(&p.Source, validation.In(valid.LocalPolicySet, valid.GithubPolicySet).Error("only 'local' and 'github' source types are supported")),
 		validation.Field(&p.PolicyItemRegex, validation.By(RegexValidator)),
+		validation.Field(&p.Engine, validation.In(valid.ConftestPolicyEngine, valid.OPAPolicyEngine).Error("only 'conftest' and 'opa' engines are supported")),
 	)
 }
 
@@ -119,6 +121,7 @@ func (p PolicySet) ToValid(stickyApprovals bool) valid.PolicySet {
 	policySet.ApproveCount = p.ApproveCount
 	policySet.StickyApprovals = stickyApprovals
 	policySet.PreventSelfApprove = p.PreventSelfApprove
+	policySet.Engine = p.Engine
 	policySet.Owners = p.Owners.ToValid()
 	if p.PolicyItemRegex != nil {
 		policySet.PolicyItemRegex = *p.PolicyItemRegex
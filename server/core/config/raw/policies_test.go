@@ -126,6 +126,20 @@ func TestPolicySets_Validate(t *testing.T) {
 			},
 			expErr: "policy_sets: (0: (source: only 'local' and 'github' source types are supported.).).",
 		},
+		{
+			description: "invalid engine",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:   "good-policy",
+						Source: valid.LocalPolicySet,
+						Path:   "rel/path/to/source",
+						Engine: "sentinel",
+					},
+				},
+			},
+			expErr: "policy_sets: (0: (engine: only 'conftest' and 'opa' engines are supported.).).",
+		},
 		{
 			description: "empty string version",
 			input: raw.PolicySets{
@@ -440,6 +454,35 @@ func TestPolicySets_ToValid(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "opa engine",
+			input: raw.PolicySets{
+				Version: String("v1.0.0"),
+				PolicySets: []raw.PolicySet{
+					{
+						Name:   "in-process",
+						Path:   "rel/path",
+						Source: valid.LocalPolicySet,
+						Engine: valid.OPAPolicyEngine,
+					},
+				},
+			},
+			exp: valid.PolicySets{
+				Version:         version,
+				ApproveCount:    1,
+				PolicyItemRegex: valid.DefaultPolicyItemRegex,
+				PolicySets: []valid.PolicySet{
+					{
+						Name:            "in-process",
+						Path:            "rel/path",
+						Source:          "local",
+						Engine:          "opa",
+						ApproveCount:    1,
+						PolicyItemRegex: valid.DefaultPolicyItemRegex,
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -23,6 +23,9 @@ const (
 	ApprovedRequirement   = "approved"
 	MergeableRequirement  = "mergeable"
 	UnDivergedRequirement = "undiverged"
+	// CostUnderBudgetRequirement blocks apply when the monthly cost delta from
+	// the cost_estimate step exceeds the project's cost_budget.
+	CostUnderBudgetRequirement = "cost_under_budget"
 )
 
 // terraformProjectIndicators are configuration files that suggest a directory
@@ -67,6 +70,7 @@ type Project struct {
 	PolicyCheck               *bool      `yaml:"policy_check,omitempty"`
 	CustomPolicyCheck         *bool      `yaml:"custom_policy_check,omitempty"`
 	SilencePRComments         []string   `yaml:"silence_pr_comments,omitempty"`
+	CostBudget                *float64   `yaml:"cost_budget,omitempty"`
 }
 
 // IsTerraformProjectDir returns true if the directory contains files that make it look like a Terraform project
@@ -170,6 +174,7 @@ func (p Project) Validate() error {
 		validation.Field(&p.DependsOn, validation.By(DependsOn)),
 		validation.Field(&p.Name, validation.By(validName)),
 		validation.Field(&p.Branch, validation.By(branchValid)),
+		validation.Field(&p.CostBudget, validation.By(validCostBudget)),
 	)
 }
 
@@ -244,6 +249,8 @@ func (p Project) ToValid() valid.Project {
 		v.SilencePRComments = p.SilencePRComments
 	}
 
+	v.CostBudget = p.CostBudget
+
 	return v
 }
 
@@ -269,8 +276,8 @@ func validPlanReq(value any) error {
 func validApplyReq(value any) error {
 	reqs := value.([]string)
 	for _, r := range reqs {
-		if r != ApprovedRequirement && r != MergeableRequirement && r != UnDivergedRequirement {
-			return fmt.Errorf("%q is not a valid apply_requirement, only %q, %q and %q are supported", r, ApprovedRequirement, MergeableRequirement, UnDivergedRequirement)
+		if r != ApprovedRequirement && r != MergeableRequirement && r != UnDivergedRequirement && r != CostUnderBudgetRequirement {
+			return fmt.Errorf("%q is not a valid apply_requirement, only %q, %q, %q and %q are supported", r, ApprovedRequirement, MergeableRequirement, UnDivergedRequirement, CostUnderBudgetRequirement)
 		}
 	}
 	return nil
@@ -286,6 +293,14 @@ func validImportReq(value any) error {
 	return nil
 }
 
+func validCostBudget(value any) error {
+	budget := value.(*float64)
+	if budget != nil && *budget < 0 {
+		return fmt.Errorf("'%g' is not a valid cost_budget, it must not be negative", *budget)
+	}
+	return nil
+}
+
 func validDistribution(value any) error {
 	distribution := value.(*string)
 	if distribution != nil && *distribution != "terraform" && *distribution != "opentofu" {
@@ -174,7 +174,7 @@ func TestProject_Validate(t *testing.T) {
 				Dir:               String("."),
 				ApplyRequirements: []string{"unsupported"},
 			},
-			expErr: "apply_requirements: \"unsupported\" is not a valid apply_requirement, only \"approved\", \"mergeable\" and \"undiverged\" are supported.",
+			expErr: "apply_requirements: \"unsupported\" is not a valid apply_requirement, only \"approved\", \"mergeable\", \"undiverged\" and \"cost_under_budget\" are supported.",
 		},
 		{
 			description: "apply reqs with approved requirement",
@@ -460,6 +460,23 @@ func TestProject_Validate(t *testing.T) {
 			},
 			expErr: "",
 		},
+		{
+			description: "apply reqs with cost_under_budget requirement",
+			input: raw.Project{
+				Dir:               String("."),
+				ApplyRequirements: []string{"cost_under_budget"},
+				CostBudget:        Float(250),
+			},
+			expErr: "",
+		},
+		{
+			description: "negative cost_budget",
+			input: raw.Project{
+				Dir:        String("."),
+				CostBudget: Float(-1),
+			},
+			expErr: "cost_budget: '-1' is not a valid cost_budget, it must not be negative.",
+		},
 	}
 	validation.ErrorTag = "yaml"
 	for _, c := range cases {
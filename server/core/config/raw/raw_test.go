@@ -15,6 +15,10 @@ import (
 // to store v and returns a pointer to it.
 func Bool(v bool) *bool { return &v }
 
+// Float is a helper routine that allocates a new float64 value
+// to store v and returns a pointer to it.
+func Float(v float64) *float64 { return &v }
+
 // Int is a helper routine that allocates a new int value
 // to store v and returns a pointer to it.
 func Int(v int) *int { return &v }
@@ -4,12 +4,16 @@
 package raw
 
 import (
+	"errors"
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 )
 
 type RepoLocks struct {
 	Mode *valid.RepoLocksMode `yaml:"mode,omitempty"`
+	TTL  string               `yaml:"ttl,omitempty"`
 }
 
 func (a RepoLocks) ToValid() *valid.RepoLocks {
@@ -20,6 +24,10 @@ func (a RepoLocks) ToValid() *valid.RepoLocks {
 	} else {
 		v.Mode = valid.DefaultRepoLocksMode
 	}
+	if a.TTL != "" {
+		// Safe to ignore the error because we test it in Validate().
+		v.TTL, _ = time.ParseDuration(a.TTL)
+	}
 
 	return &v
 }
@@ -28,6 +36,24 @@ func (a RepoLocks) Validate() error {
 	res := validation.ValidateStruct(&a,
 		// If a.Mode is nil, this should still pass validation.
 		validation.Field(&a.Mode, validation.In(valid.RepoLocksDisabledMode, valid.RepoLocksOnPlanMode, valid.RepoLocksOnApplyMode)),
+		validation.Field(&a.TTL, validation.By(lockTTLValid)),
 	)
 	return res
 }
+
+// lockTTLValid validates a lock_ttl or repo_locks ttl duration. Empty means
+// locks don't expire.
+func lockTTLValid(value any) error {
+	ttl := value.(string)
+	if ttl == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(ttl)
+	if err != nil {
+		return err
+	}
+	if parsed <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
@@ -18,23 +18,24 @@ import (
 )
 
 const (
-	ExtraArgsKey        = "extra_args"
-	NameArgKey          = "name"
-	CommandArgKey       = "command"
-	ValueArgKey         = "value"
-	OutputArgKey        = "output"
-	RunStepName         = "run"
-	PlanStepName        = "plan"
-	ShowStepName        = "show"
-	PolicyCheckStepName = "policy_check"
-	ApplyStepName       = "apply"
-	InitStepName        = "init"
-	EnvStepName         = "env"
-	MultiEnvStepName    = "multienv"
-	ImportStepName      = "import"
-	StateRmStepName     = "state_rm"
-	ShellArgKey         = "shell"
-	ShellArgsArgKey     = "shellArgs"
+	ExtraArgsKey         = "extra_args"
+	NameArgKey           = "name"
+	CommandArgKey        = "command"
+	ValueArgKey          = "value"
+	OutputArgKey         = "output"
+	RunStepName          = "run"
+	PlanStepName         = "plan"
+	ShowStepName         = "show"
+	PolicyCheckStepName  = "policy_check"
+	CostEstimateStepName = "cost_estimate"
+	ApplyStepName        = "apply"
+	InitStepName         = "init"
+	EnvStepName          = "env"
+	MultiEnvStepName     = "multienv"
+	ImportStepName       = "import"
+	StateRmStepName      = "state_rm"
+	ShellArgKey          = "shell"
+	ShellArgsArgKey      = "shellArgs"
 )
 
 /*
@@ -43,6 +44,7 @@ Step represents a single action/command to perform. In YAML, it can be set as
   - init
   - plan
   - policy_check
+  - cost_estimate
 
 2. A map for an env step with name and command or value, or a run step with a command and output config
   - env:
@@ -120,6 +122,7 @@ func (s Step) validStepName(stepName string) bool {
 		stepName == MultiEnvStepName ||
 		stepName == ShowStepName ||
 		stepName == PolicyCheckStepName ||
+		stepName == CostEstimateStepName ||
 		stepName == ImportStepName ||
 		stepName == StateRmStepName
 }
@@ -542,6 +542,15 @@ func TestStep_ToValid(t *testing.T) {
 				StepName: "policy_check",
 			},
 		},
+		{
+			description: "cost_estimate step",
+			input: raw.Step{
+				Key: String("cost_estimate"),
+			},
+			exp: valid.Step{
+				StepName: "cost_estimate",
+			},
+		},
 		{
 			description: "apply step",
 			input: raw.Step{
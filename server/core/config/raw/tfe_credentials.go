@@ -0,0 +1,70 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw
+
+import (
+	"errors"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+)
+
+// tfeHostnameRegex matches the hostnames Terraform accepts in TF_TOKEN_*
+// environment variables. Ports and schemes aren't allowed.
+var tfeHostnameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+// envVarNameRegex matches valid environment variable names.
+var envVarNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// TFECredential is the raw schema for a Terraform Cloud or Terraform
+// Enterprise token in a server-side repo config.
+type TFECredential struct {
+	Hostname  string `yaml:"hostname" json:"hostname"`
+	TokenFile string `yaml:"token_file,omitempty" json:"token_file,omitempty"`
+	TokenEnv  string `yaml:"token_env,omitempty" json:"token_env,omitempty"`
+}
+
+func (t TFECredential) Validate() error {
+	hostnameValid := func(value any) error {
+		hostname := value.(string)
+		if hostname != "" && !tfeHostnameRegex.MatchString(hostname) {
+			return errors.New("must be a hostname without a scheme, port or path")
+		}
+		return nil
+	}
+
+	tokenFileValid := func(value any) error {
+		tokenFile := value.(string)
+		if tokenFile != "" && t.TokenEnv != "" {
+			return errors.New("token_file and token_env cannot both be set")
+		}
+		if tokenFile == "" && t.TokenEnv == "" {
+			return errors.New("one of token_file or token_env must be set")
+		}
+		return nil
+	}
+
+	tokenEnvValid := func(value any) error {
+		tokenEnv := value.(string)
+		if tokenEnv != "" && !envVarNameRegex.MatchString(tokenEnv) {
+			return errors.New("must be a valid environment variable name")
+		}
+		return nil
+	}
+
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Hostname, validation.Required, validation.By(hostnameValid)),
+		validation.Field(&t.TokenFile, validation.By(tokenFileValid)),
+		validation.Field(&t.TokenEnv, validation.By(tokenEnvValid)),
+	)
+}
+
+func (t TFECredential) ToValid() valid.TFECredential {
+	return valid.TFECredential{
+		Hostname:  t.Hostname,
+		TokenFile: t.TokenFile,
+		TokenEnv:  t.TokenEnv,
+	}
+}
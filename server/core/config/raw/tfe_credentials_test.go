@@ -0,0 +1,87 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/config/raw"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestTFECredential_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		input       raw.TFECredential
+		errContains *string
+	}{
+		{
+			description: "token file",
+			input:       raw.TFECredential{Hostname: "app.terraform.io", TokenFile: "/etc/atlantis/tfc-token"},
+		},
+		{
+			description: "token env",
+			input:       raw.TFECredential{Hostname: "tfe.my-company.com", TokenEnv: "TFE_TOKEN"},
+		},
+		{
+			description: "missing hostname",
+			input:       raw.TFECredential{TokenEnv: "TFE_TOKEN"},
+			errContains: String("hostname: cannot be blank"),
+		},
+		{
+			description: "hostname with scheme",
+			input:       raw.TFECredential{Hostname: "https://tfe.example.com", TokenEnv: "TFE_TOKEN"},
+			errContains: String("must be a hostname without a scheme, port or path"),
+		},
+		{
+			description: "hostname with port",
+			input:       raw.TFECredential{Hostname: "tfe.example.com:8443", TokenEnv: "TFE_TOKEN"},
+			errContains: String("must be a hostname without a scheme, port or path"),
+		},
+		{
+			description: "no token",
+			input:       raw.TFECredential{Hostname: "app.terraform.io"},
+			errContains: String("one of token_file or token_env must be set"),
+		},
+		{
+			description: "both tokens",
+			input:       raw.TFECredential{Hostname: "app.terraform.io", TokenFile: "/etc/atlantis/tfc-token", TokenEnv: "TFE_TOKEN"},
+			errContains: String("token_file and token_env cannot both be set"),
+		},
+		{
+			description: "invalid env var",
+			input:       raw.TFECredential{Hostname: "app.terraform.io", TokenEnv: "TFE-TOKEN"},
+			errContains: String("must be a valid environment variable name"),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.input.Validate()
+			if c.errContains == nil {
+				Ok(t, err)
+				return
+			}
+			ErrContains(t, *c.errContains, err)
+		})
+	}
+}
+
+func TestRepo_TFECredentials(t *testing.T) {
+	repo := raw.Repo{
+		ID: "/.*/",
+		TFECredentials: []raw.TFECredential{
+			{Hostname: "app.terraform.io", TokenEnv: "TFC_TOKEN"},
+			{Hostname: "tfe.example.com", TokenFile: "/etc/atlantis/tfe-token"},
+		},
+	}
+	Ok(t, repo.Validate())
+	Equals(t, []valid.TFECredential{
+		{Hostname: "app.terraform.io", TokenEnv: "TFC_TOKEN"},
+		{Hostname: "tfe.example.com", TokenFile: "/etc/atlantis/tfe-token"},
+	}, repo.ToValid(nil, nil, nil, nil).TFECredentials)
+
+	repo.TFECredentials = append(repo.TFECredentials, raw.TFECredential{Hostname: "App.Terraform.io", TokenEnv: "OTHER_TOKEN"})
+	ErrContains(t, "hostname \"App.Terraform.io\" is set more than once", repo.Validate())
+}
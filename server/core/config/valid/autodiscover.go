@@ -12,6 +12,9 @@ const (
 	AutoDiscoverEnabledMode  AutoDiscoverMode = "enabled"
 	AutoDiscoverDisabledMode AutoDiscoverMode = "disabled"
 	AutoDiscoverAutoMode     AutoDiscoverMode = "auto"
+	// AutoDiscoverTerragruntMode discovers projects from terragrunt.hcl files
+	// instead of Terraform files and runs them through terragrunt.
+	AutoDiscoverTerragruntMode AutoDiscoverMode = "terragrunt"
 )
 
 type AutoDiscover struct {
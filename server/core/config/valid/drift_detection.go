@@ -0,0 +1,29 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package valid
+
+import (
+	"time"
+
+	"github.com/runatlantis/atlantis/server/scheduled"
+)
+
+// DriftDetection is the parsed drift_detection schedule of a server-side repo
+// config. Atlantis runs drift detection for the repo whenever Schedule fires.
+type DriftDetection struct {
+	Schedule *scheduled.CronSchedule
+	Ref      string
+	// Projects and Paths limit detection to the given projects. When both are
+	// empty all projects in the repo are checked.
+	Projects []string
+	Paths    []DriftDetectionPath
+	// Jitter is the upper bound of a random delay added to each run so that
+	// repos sharing a schedule don't all clone and plan at the same moment.
+	Jitter time.Duration
+}
+
+type DriftDetectionPath struct {
+	Directory string
+	Workspace string
+}
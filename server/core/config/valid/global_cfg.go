@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	version "github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/logging"
@@ -31,6 +32,7 @@ const PolicyCheckKey = "policy_check"
 const CustomPolicyCheckKey = "custom_policy_check"
 const AutoDiscoverKey = "autodiscover"
 const SilencePRCommentsKey = "silence_pr_comments"
+const CostBudgetKey = "cost_budget"
 
 var AllowedSilencePRComments = []string{"plan", "apply"}
 
@@ -57,12 +59,35 @@ type GlobalCfg struct {
 // ExternalStores holds configuration for external storage backends.
 type ExternalStores struct {
 	PlanStore PlanStoreConfig
+	JobLogs   JobLogStoreConfig
+}
+
+// JobLogStoreConfig holds the type and backend-specific config for archiving
+// job output. An empty Type means job output isn't archived.
+type JobLogStoreConfig struct {
+	Type string
+	// Dir is the directory logs are archived to when Type is "local". It
+	// defaults to a directory under the Atlantis data dir.
+	Dir string
+	S3  S3StoreConfig
+	// Retention is how long archived logs are kept. Zero keeps them forever.
+	Retention time.Duration
 }
 
 // PlanStoreConfig holds the type and backend-specific config for plan storage.
 type PlanStoreConfig struct {
-	Type string
-	S3   S3StoreConfig
+	Type      string
+	S3        S3StoreConfig
+	GCS       GCSStoreConfig
+	AzureBlob AzureBlobStoreConfig
+	// Encryption is nil when stored plans aren't encrypted client-side.
+	Encryption *PlanStoreEncryption
+}
+
+// PlanStoreEncryption holds the key source for client-side plan encryption.
+type PlanStoreEncryption struct {
+	KeyFile string
+	KeyEnv  string
 }
 
 // S3StoreConfig holds S3-specific configuration for the plan store.
@@ -75,6 +100,22 @@ type S3StoreConfig struct {
 	Profile        string
 }
 
+// GCSStoreConfig holds GCS-specific configuration for the plan store.
+type GCSStoreConfig struct {
+	Bucket          string
+	Prefix          string
+	Endpoint        string
+	CredentialsFile string
+}
+
+// AzureBlobStoreConfig holds Azure Blob-specific configuration for the plan store.
+type AzureBlobStoreConfig struct {
+	AccountName string
+	Container   string
+	Prefix      string
+	Endpoint    string
+}
+
 type Metrics struct {
 	Statsd     *Statsd
 	Prometheus *Prometheus
@@ -111,10 +152,20 @@ type Repo struct {
 	DeleteSourceBranchOnMerge *bool
 	RepoLocking               *bool
 	RepoLocks                 *RepoLocks
-	PolicyCheck               *bool
-	CustomPolicyCheck         *bool
-	AutoDiscover              *AutoDiscover
-	SilencePRComments         []string
+	// LockTTL is how long a project lock can go without a plan before it
+	// expires. Zero means locks don't expire.
+	LockTTL           time.Duration
+	PolicyCheck       *bool
+	CustomPolicyCheck *bool
+	AutoDiscover      *AutoDiscover
+	SilencePRComments []string
+	DriftDetection    *DriftDetection
+	// CostBudget is the default monthly cost budget for projects in this repo.
+	// Nil means no budget is set.
+	CostBudget *float64
+	// TFECredentials are the Terraform Cloud and Terraform Enterprise tokens
+	// for projects in this repo.
+	TFECredentials []TFECredential
 }
 
 type MergedProjectCfg struct {
@@ -141,6 +192,9 @@ type MergedProjectCfg struct {
 	PolicyCheck               bool
 	CustomPolicyCheck         bool
 	SilencePRComments         []string
+	CostBudget                *float64
+	Terragrunt                bool
+	TFECredentials            []TFECredential
 }
 
 // WorkflowHook is a map of custom run commands to run before or after workflows.
@@ -313,6 +367,7 @@ func (r Repo) IDString() string {
 func (g GlobalCfg) MergeProjectCfg(log logging.SimpleLogging, repoID string, proj Project, rCfg RepoCfg) MergedProjectCfg {
 	log.Debug("MergeProjectCfg started")
 	planReqs, applyReqs, importReqs, workflow, allowedOverrides, allowCustomWorkflows, deleteSourceBranchOnMerge, repoLocks, policyCheck, customPolicyCheck, _, silencePRComments := g.getMatchingCfg(log, repoID)
+	costBudget := g.CostBudget(repoID)
 	// If repos are allowed to override certain keys then override them.
 	for _, key := range allowedOverrides {
 		switch key {
@@ -413,6 +468,11 @@ func (g GlobalCfg) MergeProjectCfg(log logging.SimpleLogging, repoID string, pro
 				log.Debug("overriding server-defined %s with repo settings: [%s]", SilencePRCommentsKey, strings.Join(rCfg.SilencePRComments, ","))
 				silencePRComments = rCfg.SilencePRComments
 			}
+		case CostBudgetKey:
+			if proj.CostBudget != nil {
+				log.Debug("overriding server-defined %s with repo settings: [%g]", CostBudgetKey, *proj.CostBudget)
+				costBudget = proj.CostBudget
+			}
 		}
 		log.Debug("MergeProjectCfg completed")
 	}
@@ -450,6 +510,9 @@ func (g GlobalCfg) MergeProjectCfg(log logging.SimpleLogging, repoID string, pro
 		PolicyCheck:               policyCheck,
 		CustomPolicyCheck:         customPolicyCheck,
 		SilencePRComments:         silencePRComments,
+		CostBudget:                costBudget,
+		Terragrunt:                proj.Terragrunt,
+		TFECredentials:            g.TFECredentials(repoID),
 	}
 }
 
@@ -476,6 +539,8 @@ func (g GlobalCfg) DefaultProjCfg(log logging.SimpleLogging, repoID string, repo
 		PolicyCheck:               policyCheck,
 		CustomPolicyCheck:         customPolicyCheck,
 		SilencePRComments:         silencePRComments,
+		CostBudget:                g.CostBudget(repoID),
+		TFECredentials:            g.TFECredentials(repoID),
 	}
 }
 
@@ -492,6 +557,59 @@ func (g GlobalCfg) RepoAutoDiscoverCfg(repoID string) *AutoDiscover {
 	return autoDiscover
 }
 
+// LockTTL returns how long a project lock in the repo with id repoID can go
+// without a plan before it's released. A repo_locks ttl overrides lock_ttl and,
+// as with other keys, the last matching repo config wins. Zero means locks
+// don't expire.
+func (g GlobalCfg) LockTTL(repoID string) time.Duration {
+	var ttl time.Duration
+	for _, repo := range g.Repos {
+		if !repo.IDMatches(repoID) {
+			continue
+		}
+		if repo.LockTTL > 0 {
+			ttl = repo.LockTTL
+		}
+		if repo.RepoLocks != nil && repo.RepoLocks.TTL > 0 {
+			ttl = repo.RepoLocks.TTL
+		}
+	}
+	return ttl
+}
+
+// CostBudget returns the server-side monthly cost budget for projects in the
+// repo with id repoID, or nil if none is set. The last matching repo config
+// wins.
+func (g GlobalCfg) CostBudget(repoID string) *float64 {
+	var budget *float64
+	for _, repo := range g.Repos {
+		if repo.IDMatches(repoID) && repo.CostBudget != nil {
+			budget = repo.CostBudget
+		}
+	}
+	return budget
+}
+
+// TFECredentials returns the Terraform Cloud and Terraform Enterprise tokens
+// for projects in the repo with id repoID. Every matching repo config
+// contributes its hosts and, as with other keys, the last matching repo config
+// wins for a host that's set more than once.
+func (g GlobalCfg) TFECredentials(repoID string) []TFECredential {
+	var creds []TFECredential
+	for _, repo := range g.Repos {
+		if !repo.IDMatches(repoID) {
+			continue
+		}
+		for _, c := range repo.TFECredentials {
+			creds = slices.DeleteFunc(creds, func(existing TFECredential) bool {
+				return strings.EqualFold(existing.Hostname, c.Hostname)
+			})
+			creds = append(creds, c)
+		}
+	}
+	return creds
+}
+
 // ValidateRepoCfg validates that rCfg for repo with id repoID is valid based
 // on our global config.
 func (g GlobalCfg) ValidateRepoCfg(rCfg RepoCfg, repoID string) error {
@@ -513,7 +631,15 @@ func (g GlobalCfg) ValidateRepoCfg(rCfg RepoCfg, repoID string) error {
 			}
 		}
 	}
+	// Locks are expired by a background job that only sees the server-side
+	// config, so their TTL can't be set in the repo.
+	if rCfg.RepoLocks != nil && rCfg.RepoLocks.TTL > 0 {
+		return fmt.Errorf("repo config not allowed to set '%s' ttl: lock TTLs can only be set in server-side config", RepoLocksKey)
+	}
 	for _, p := range rCfg.Projects {
+		if p.RepoLocks != nil && p.RepoLocks.TTL > 0 {
+			return fmt.Errorf("repo config not allowed to set '%s' ttl: lock TTLs can only be set in server-side config", RepoLocksKey)
+		}
 		if p.WorkflowName != nil && !slices.Contains(allowedOverrides, WorkflowKey) {
 			return fmt.Errorf("repo config not allowed to set '%s' key: server-side config needs '%s: [%s]'", WorkflowKey, AllowedOverridesKey, WorkflowKey)
 		}
@@ -538,6 +664,9 @@ func (g GlobalCfg) ValidateRepoCfg(rCfg RepoCfg, repoID string) error {
 		if p.CustomPolicyCheck != nil && !slices.Contains(allowedOverrides, CustomPolicyCheckKey) {
 			return fmt.Errorf("repo config not allowed to set '%s' key: server-side config needs '%s: [%s]'", CustomPolicyCheckKey, AllowedOverridesKey, CustomPolicyCheckKey)
 		}
+		if p.CostBudget != nil && !slices.Contains(allowedOverrides, CostBudgetKey) {
+			return fmt.Errorf("repo config not allowed to set '%s' key: server-side config needs '%s: [%s]'", CostBudgetKey, AllowedOverridesKey, CostBudgetKey)
+		}
 		if p.SilencePRComments != nil {
 			if !slices.Contains(allowedOverrides, SilencePRCommentsKey) {
 				return fmt.Errorf(
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/mohae/deepcopy"
@@ -159,12 +160,45 @@ func TestNewGlobalCfg(t *testing.T) {
 }
 
 func TestGlobalCfg_ValidateRepoCfg(t *testing.T) {
+	budget := 100.0
 	cases := map[string]struct {
 		gCfg   valid.GlobalCfg
 		rCfg   valid.RepoCfg
 		repoID string
 		expErr string
 	}{
+		"repo sets repo_locks ttl": {
+			gCfg: valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{
+				AllowAllRepoSettings: true,
+			}),
+			rCfg: valid.RepoCfg{
+				Projects: []valid.Project{
+					{
+						Dir:       ".",
+						Workspace: "default",
+						RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnPlanMode, TTL: time.Hour},
+					},
+				},
+			},
+			repoID: "github.com/owner/repo",
+			expErr: "repo config not allowed to set 'repo_locks' ttl: lock TTLs can only be set in server-side config",
+		},
+		"repo sets cost_budget without override": {
+			gCfg: valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{
+				AllowAllRepoSettings: true,
+			}),
+			rCfg: valid.RepoCfg{
+				Projects: []valid.Project{
+					{
+						Dir:        ".",
+						Workspace:  "default",
+						CostBudget: &budget,
+					},
+				},
+			},
+			repoID: "github.com/owner/repo",
+			expErr: "repo config not allowed to set 'cost_budget' key: server-side config needs 'allowed_overrides: [cost_budget]'",
+		},
 		"repo uses workflow that is defined server side but not allowed (with custom workflows)": {
 			gCfg: valid.GlobalCfg{
 				Repos: []valid.Repo{
@@ -1241,6 +1275,119 @@ func TestGlobalCfg_RepoAutoDiscoverCfg(t *testing.T) {
 	}
 }
 
+func TestGlobalCfg_LockTTL(t *testing.T) {
+	cases := map[string]struct {
+		repos []valid.Repo
+		exp   time.Duration
+	}{
+		"no ttl": {
+			repos: []valid.Repo{{IDRegex: regexp.MustCompile(".*")}},
+		},
+		"inherits lock_ttl from earlier matching repo": {
+			repos: []valid.Repo{
+				{IDRegex: regexp.MustCompile(".*"), LockTTL: 72 * time.Hour},
+				{ID: "github.com/owner/repo"},
+			},
+			exp: 72 * time.Hour,
+		},
+		"later matching lock_ttl wins": {
+			repos: []valid.Repo{
+				{IDRegex: regexp.MustCompile(".*"), LockTTL: 72 * time.Hour},
+				{ID: "github.com/owner/repo", LockTTL: 24 * time.Hour},
+			},
+			exp: 24 * time.Hour,
+		},
+		"repo_locks ttl overrides lock_ttl": {
+			repos: []valid.Repo{
+				{ID: "github.com/owner/repo", LockTTL: 72 * time.Hour, RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnPlanMode, TTL: time.Hour}},
+			},
+			exp: time.Hour,
+		},
+		"ignores other repos": {
+			repos: []valid.Repo{{ID: "github.com/owner/other", LockTTL: time.Hour}},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			Equals(t, c.exp, valid.GlobalCfg{Repos: c.repos}.LockTTL("github.com/owner/repo"))
+		})
+	}
+}
+
+func TestGlobalCfg_CostBudget(t *testing.T) {
+	serverBudget, repoBudget := 500.0, 1000.0
+	proj := valid.Project{Dir: ".", Workspace: "default", CostBudget: &repoBudget}
+	cases := map[string]struct {
+		repos []valid.Repo
+		exp   *float64
+	}{
+		"no budget": {
+			repos: []valid.Repo{{IDRegex: regexp.MustCompile(".*")}},
+		},
+		"server-side budget": {
+			repos: []valid.Repo{{IDRegex: regexp.MustCompile(".*"), CostBudget: &serverBudget}},
+			exp:   &serverBudget,
+		},
+		"repo budget ignored without override": {
+			repos: []valid.Repo{{ID: "github.com/owner/repo", CostBudget: &serverBudget}},
+			exp:   &serverBudget,
+		},
+		"repo budget overrides server-side budget": {
+			repos: []valid.Repo{
+				{IDRegex: regexp.MustCompile(".*"), CostBudget: &serverBudget},
+				{ID: "github.com/owner/repo", AllowedOverrides: []string{valid.CostBudgetKey}},
+			},
+			exp: &repoBudget,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			global := valid.GlobalCfg{Repos: c.repos}
+			merged := global.MergeProjectCfg(logging.NewNoopLogger(t), "github.com/owner/repo", proj, valid.RepoCfg{})
+			Equals(t, c.exp, merged.CostBudget)
+		})
+	}
+}
+
+func TestGlobalCfg_TFECredentials(t *testing.T) {
+	tfc := valid.TFECredential{Hostname: "app.terraform.io", TokenEnv: "TFC_TOKEN"}
+	tfe := valid.TFECredential{Hostname: "tfe.example.com", TokenFile: "/etc/atlantis/tfe-token"}
+	teamTFC := valid.TFECredential{Hostname: "APP.terraform.io", TokenEnv: "TEAM_TFC_TOKEN"}
+	cases := map[string]struct {
+		repos []valid.Repo
+		exp   []valid.TFECredential
+	}{
+		"no credentials": {
+			repos: []valid.Repo{{IDRegex: regexp.MustCompile(".*")}},
+		},
+		"combines hosts from matching repos": {
+			repos: []valid.Repo{
+				{IDRegex: regexp.MustCompile(".*"), TFECredentials: []valid.TFECredential{tfc}},
+				{ID: "github.com/owner/repo", TFECredentials: []valid.TFECredential{tfe}},
+			},
+			exp: []valid.TFECredential{tfc, tfe},
+		},
+		"later matching repo wins for a host": {
+			repos: []valid.Repo{
+				{IDRegex: regexp.MustCompile(".*"), TFECredentials: []valid.TFECredential{tfc, tfe}},
+				{ID: "github.com/owner/repo", TFECredentials: []valid.TFECredential{teamTFC}},
+			},
+			exp: []valid.TFECredential{tfe, teamTFC},
+		},
+		"ignores other repos": {
+			repos: []valid.Repo{{ID: "github.com/owner/other", TFECredentials: []valid.TFECredential{tfc}}},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			global := valid.GlobalCfg{Repos: c.repos}
+			Equals(t, c.exp, global.TFECredentials("github.com/owner/repo"))
+			merged := global.DefaultProjCfg(logging.NewNoopLogger(t), "github.com/owner/repo", ".", "default")
+			Equals(t, c.exp, merged.TFECredentials)
+		})
+	}
+}
+
 func TestGlobalCfg_PolicyCheckOverride(t *testing.T) {
 	var emptyPolicySets valid.PolicySets
 
@@ -15,6 +15,14 @@ const (
 	GithubPolicySet string = "github"
 )
 
+const (
+	// ConftestPolicyEngine evaluates a policy set by running the conftest
+	// binary. It's the default.
+	ConftestPolicyEngine string = "conftest"
+	// OPAPolicyEngine evaluates a policy set in-process with the OPA library.
+	OPAPolicyEngine string = "opa"
+)
+
 // DefaultPolicyItemRegex matches the entire non-empty policy output as a
 // single item ((?s) makes . match newlines). Any change invalidates the
 // approval. Override with `.+` for per-line matching.
@@ -46,12 +54,29 @@ type PolicySet struct {
 	PolicyItemRegex    string
 	Owners             PolicyOwners
 	PreventSelfApprove bool
+	// Engine is the policy engine that evaluates this policy set. Empty means
+	// ConftestPolicyEngine.
+	Engine string
 }
 
 func (p *PolicySets) HasPolicies() bool {
 	return len(p.PolicySets) > 0
 }
 
+// EvaluatedInProcess returns true if every policy set uses the OPA engine, so
+// a conftest binary isn't needed to check them.
+func (p *PolicySets) EvaluatedInProcess() bool {
+	if !p.HasPolicies() {
+		return false
+	}
+	for _, policySet := range p.PolicySets {
+		if policySet.Engine != OPAPolicyEngine {
+			return false
+		}
+	}
+	return true
+}
+
 // Check if any level of policy owners includes teams
 func (p *PolicySets) HasTeamOwners() bool {
 	hasTeamOwners := len(p.Owners.Teams) > 0
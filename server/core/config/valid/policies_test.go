@@ -186,3 +186,25 @@ func TestPoliciesConfig_AllTeams(t *testing.T) {
 		})
 	}
 }
+
+func TestPoliciesConfig_EvaluatedInProcess(t *testing.T) {
+	cases := []struct {
+		description string
+		engines     []string
+		exp         bool
+	}{
+		{description: "no policy sets"},
+		{description: "conftest by default", engines: []string{""}},
+		{description: "mixed engines", engines: []string{valid.OPAPolicyEngine, valid.ConftestPolicyEngine}},
+		{description: "all opa", engines: []string{valid.OPAPolicyEngine, valid.OPAPolicyEngine}, exp: true},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			var policySets valid.PolicySets
+			for _, engine := range c.engines {
+				policySets.PolicySets = append(policySets.PolicySets, valid.PolicySet{Engine: engine})
+			}
+			Equals(t, c.exp, policySets.EvaluatedInProcess())
+		})
+	}
+}
@@ -139,6 +139,8 @@ func isRegexAllowed(name string, allowedRegexpPrefixes []string) bool {
 // AutoDiscoverEnabled returns a final true/false decision for whether
 // AutoDiscover is enabled for a repo. The caller must resolve precedence
 // before passing autoDiscoverMode. This method does not read r.AutoDiscover.
+// It returns false for AutoDiscoverTerragruntMode, which discovers projects
+// from terragrunt.hcl files rather than Terraform files.
 func (r RepoCfg) AutoDiscoverEnabled(autoDiscoverMode AutoDiscoverMode) bool {
 	if autoDiscoverMode == AutoDiscoverAutoMode {
 		// AutoDiscover is enabled by default when no projects are defined
@@ -198,6 +200,12 @@ type Project struct {
 	PolicyCheck               *bool
 	CustomPolicyCheck         *bool
 	SilencePRComments         []string
+	// CostBudget is the most the project's monthly cost may grow by for the
+	// cost_under_budget apply requirement to pass.
+	CostBudget *float64
+	// Terragrunt is true if the project was discovered from a terragrunt.hcl
+	// file and its commands should run through terragrunt.
+	Terragrunt bool
 }
 
 // GetName returns the name of the project or an empty string if there is no
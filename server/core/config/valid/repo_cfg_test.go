@@ -260,6 +260,11 @@ func TestConfig_AutoDiscoverEnabled(t *testing.T) {
 			projects:            []valid.Project{{}},
 			expEnabled:          false,
 		},
+		{
+			description:         "terragrunt mode with no projects",
+			defaultAutoDiscover: valid.AutoDiscoverTerragruntMode,
+			expEnabled:          false,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.description, func(t *testing.T) {
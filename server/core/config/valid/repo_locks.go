@@ -3,6 +3,8 @@
 
 package valid
 
+import "time"
+
 // RepoLocksMode enum
 type RepoLocksMode string
 
@@ -19,4 +21,6 @@ const (
 
 type RepoLocks struct {
 	Mode RepoLocksMode
+	// TTL overrides the repo's lock_ttl. Zero means it isn't set.
+	TTL time.Duration
 }
@@ -0,0 +1,52 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package valid
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TFECredential is a Terraform Cloud or Terraform Enterprise token for a
+// single host, from a server-side repo config's tfe_credentials. Exactly one of
+// TokenFile and TokenEnv is set.
+type TFECredential struct {
+	Hostname string
+	// TokenFile is the path of a file containing the token.
+	TokenFile string
+	// TokenEnv is the name of an environment variable of the Atlantis process
+	// containing the token.
+	TokenEnv string
+}
+
+// Token reads the credential's token. It's read on every call so that tokens
+// can be rotated without restarting Atlantis.
+func (t TFECredential) Token() (string, error) {
+	if t.TokenFile != "" {
+		contents, err := os.ReadFile(t.TokenFile) // nolint: gosec
+		if err != nil {
+			return "", fmt.Errorf("reading token for %s: %w", t.Hostname, err)
+		}
+		token := strings.TrimSpace(string(contents))
+		if token == "" {
+			return "", fmt.Errorf("token file %s for %s is empty", t.TokenFile, t.Hostname)
+		}
+		return token, nil
+	}
+	token := strings.TrimSpace(os.Getenv(t.TokenEnv))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s with the token for %s is not set", t.TokenEnv, t.Hostname)
+	}
+	return token, nil
+}
+
+// EnvVar returns the name of the environment variable Terraform reads the
+// credential's token from. Periods in the hostname are encoded as underscores
+// and hyphens as double underscores, e.g. TF_TOKEN_tfe_my__company_com for
+// tfe.my-company.com.
+func (t TFECredential) EnvVar() string {
+	name := strings.ReplaceAll(t.Hostname, "-", "__")
+	return "TF_TOKEN_" + strings.ReplaceAll(name, ".", "_")
+}
@@ -27,6 +27,26 @@ type Database interface {
 	DeletePullStatus(pull models.PullRequest) error
 	UpdatePullWithResults(pull models.PullRequest, newResults []command.ProjectResult) (models.PullStatus, error)
 
+	// EnqueueLock adds entry to the end of the queue for its project and
+	// workspace, unless its pull request is already queued. It returns the
+	// 1-based position of the pull request in the queue.
+	EnqueueLock(entry models.LockQueueEntry) (int, error)
+	// GetLockQueue returns the pull requests queued for the lock on project
+	// and workspace, in order.
+	GetLockQueue(project models.Project, workspace string) ([]models.LockQueueEntry, error)
+	// DequeueLock removes pullNum from the queue for project and workspace.
+	DequeueLock(project models.Project, workspace string, pullNum int) error
+	// DequeuePull removes pullNum from every queue in the repo.
+	DequeuePull(repoFullName string, pullNum int) error
+
+	// AddPolicyException records a policy exception.
+	AddPolicyException(exception models.PolicyException) error
+	// GetPolicyExceptions returns the policy exceptions granted on pullNum in
+	// the repo, including expired ones, in the order they were granted. If
+	// pullNum is 0, it returns the exceptions for every pull request in the
+	// repo.
+	GetPolicyExceptions(repoFullName string, pullNum int) ([]models.PolicyException, error)
+
 	LockCommand(cmdName command.Name, lockTime time.Time) (*command.Lock, error)
 	UnlockCommand(cmdName command.Name) error
 	CheckCommandLock(cmdName command.Name) (*command.Lock, error)
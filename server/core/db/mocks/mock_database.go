@@ -42,6 +42,20 @@ func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
 	return m.recorder
 }
 
+// AddPolicyException mocks base method.
+func (m *MockDatabase) AddPolicyException(exception models.PolicyException) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPolicyException", exception)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPolicyException indicates an expected call of AddPolicyException.
+func (mr *MockDatabaseMockRecorder) AddPolicyException(exception any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPolicyException", reflect.TypeOf((*MockDatabase)(nil).AddPolicyException), exception)
+}
+
 // CheckCommandLock mocks base method.
 func (m *MockDatabase) CheckCommandLock(cmdName command.Name) (*command.Lock, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +99,49 @@ func (mr *MockDatabaseMockRecorder) DeletePullStatus(pull any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePullStatus", reflect.TypeOf((*MockDatabase)(nil).DeletePullStatus), pull)
 }
 
+// DequeueLock mocks base method.
+func (m *MockDatabase) DequeueLock(project models.Project, workspace string, pullNum int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DequeueLock", project, workspace, pullNum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DequeueLock indicates an expected call of DequeueLock.
+func (mr *MockDatabaseMockRecorder) DequeueLock(project, workspace, pullNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DequeueLock", reflect.TypeOf((*MockDatabase)(nil).DequeueLock), project, workspace, pullNum)
+}
+
+// DequeuePull mocks base method.
+func (m *MockDatabase) DequeuePull(repoFullName string, pullNum int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DequeuePull", repoFullName, pullNum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DequeuePull indicates an expected call of DequeuePull.
+func (mr *MockDatabaseMockRecorder) DequeuePull(repoFullName, pullNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DequeuePull", reflect.TypeOf((*MockDatabase)(nil).DequeuePull), repoFullName, pullNum)
+}
+
+// EnqueueLock mocks base method.
+func (m *MockDatabase) EnqueueLock(entry models.LockQueueEntry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueLock", entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueLock indicates an expected call of EnqueueLock.
+func (mr *MockDatabaseMockRecorder) EnqueueLock(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueLock", reflect.TypeOf((*MockDatabase)(nil).EnqueueLock), entry)
+}
+
 // GetLock mocks base method.
 func (m *MockDatabase) GetLock(project models.Project, workspace string) (*models.ProjectLock, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +157,36 @@ func (mr *MockDatabaseMockRecorder) GetLock(project, workspace any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLock", reflect.TypeOf((*MockDatabase)(nil).GetLock), project, workspace)
 }
 
+// GetLockQueue mocks base method.
+func (m *MockDatabase) GetLockQueue(project models.Project, workspace string) ([]models.LockQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockQueue", project, workspace)
+	ret0, _ := ret[0].([]models.LockQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockQueue indicates an expected call of GetLockQueue.
+func (mr *MockDatabaseMockRecorder) GetLockQueue(project, workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockQueue", reflect.TypeOf((*MockDatabase)(nil).GetLockQueue), project, workspace)
+}
+
+// GetPolicyExceptions mocks base method.
+func (m *MockDatabase) GetPolicyExceptions(repoFullName string, pullNum int) ([]models.PolicyException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyExceptions", repoFullName, pullNum)
+	ret0, _ := ret[0].([]models.PolicyException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyExceptions indicates an expected call of GetPolicyExceptions.
+func (mr *MockDatabaseMockRecorder) GetPolicyExceptions(repoFullName, pullNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyExceptions", reflect.TypeOf((*MockDatabase)(nil).GetPolicyExceptions), repoFullName, pullNum)
+}
+
 // GetPullStatus mocks base method.
 func (m *MockDatabase) GetPullStatus(pull models.PullRequest) (*models.PullStatus, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,124 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	tally "github.com/uber-go/tally/v4"
+)
+
+// MetricsPublishPeriod is how often MetricsPublisher refreshes the per-project
+// drift gauges from storage.
+const MetricsPublishPeriod = 30 * time.Second
+
+// Metric names. With the default stats namespace they are reported as e.g.
+// atlantis_drift_project_has_drift in Prometheus and
+// atlantis.drift.project.has_drift in StatsD.
+const (
+	DetectionRunsMetric         = "runs"
+	DetectionErrorsMetric       = "errors"
+	RemediationOutcomesMetric   = "outcomes"
+	HasDriftMetric              = "has_drift"
+	ToAddMetric                 = "to_add"
+	ToChangeMetric              = "to_change"
+	ToDestroyMetric             = "to_destroy"
+	SecondsSinceLastCheckMetric = "seconds_since_last_check"
+)
+
+// Metrics records drift detection and remediation metrics on a stats scope,
+// so they are reported to whichever sink the scope is configured with.
+//
+// Every metric is always tagged with the same set of keys, since the
+// Prometheus reporter rejects a metric whose tags change between updates.
+type Metrics struct {
+	detection   tally.Scope
+	remediation tally.Scope
+	project     tally.Scope
+}
+
+// NewMetrics returns Metrics reporting under the "drift" sub-scope of scope.
+func NewMetrics(scope tally.Scope) *Metrics {
+	scope = scope.SubScope("drift")
+	return &Metrics{
+		detection:   scope.SubScope("detection"),
+		remediation: scope.SubScope("remediation"),
+		project:     scope.SubScope("project"),
+	}
+}
+
+// DetectionRun counts a drift detection run for repository. failed is true if
+// the run failed or any of its projects could not be checked.
+func (m *Metrics) DetectionRun(repository string, failed bool) {
+	scope := m.detection.Tagged(map[string]string{"repository": repository})
+	scope.Counter(DetectionRunsMetric).Inc(1)
+	if failed {
+		scope.Counter(DetectionErrorsMetric).Inc(1)
+	}
+}
+
+// RemediationFinished counts a remediation that reached a terminal status,
+// tagged with its action and status.
+func (m *Metrics) RemediationFinished(result *models.RemediationResult) {
+	m.remediation.Tagged(map[string]string{
+		"action": string(result.Action),
+		"status": string(result.Status),
+	}).Counter(RemediationOutcomesMetric).Inc(1)
+}
+
+// ProjectDrift updates the gauges of a stored drift result. now is used to
+// compute the time since the project was last checked.
+func (m *Metrics) ProjectDrift(repository string, drift models.ProjectDrift, now time.Time) {
+	scope := m.project.Tagged(map[string]string{
+		"repository": repository,
+		"project":    drift.ProjectName,
+		"path":       drift.Path,
+		"workspace":  drift.Workspace,
+		"ref":        drift.Ref,
+	})
+	hasDrift := 0.0
+	if drift.Drift.HasDrift {
+		hasDrift = 1
+	}
+	scope.Gauge(HasDriftMetric).Update(hasDrift)
+	scope.Gauge(ToAddMetric).Update(float64(drift.Drift.ToAdd))
+	scope.Gauge(ToChangeMetric).Update(float64(drift.Drift.ToChange))
+	scope.Gauge(ToDestroyMetric).Update(float64(drift.Drift.ToDestroy))
+	scope.Gauge(SecondsSinceLastCheckMetric).Update(now.Sub(drift.LastChecked).Seconds())
+}
+
+// MetricsPublisher is a scheduled.Job that publishes the gauges of every
+// stored drift result, keeping the time since each project was last checked
+// current between detection runs.
+type MetricsPublisher struct {
+	storage Storage
+	metrics *Metrics
+	log     logging.SimpleLogging
+}
+
+// NewMetricsPublisher returns a job publishing the drift results in storage.
+func NewMetricsPublisher(storage Storage, metrics *Metrics, log logging.SimpleLogging) *MetricsPublisher {
+	return &MetricsPublisher{
+		storage: storage,
+		metrics: metrics,
+		log:     log,
+	}
+}
+
+// Run publishes the gauges of all stored drift results.
+func (p *MetricsPublisher) Run() {
+	all, err := p.storage.GetAll()
+	if err != nil {
+		p.log.Warn("unable to read drift results for metrics: %s", err)
+		return
+	}
+	now := time.Now()
+	for repository, drifts := range all {
+		for _, d := range drifts {
+			p.metrics.ProjectDrift(repository, d, now)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	tally "github.com/uber-go/tally/v4"
+)
+
+func TestMetrics_DetectionRun(t *testing.T) {
+	scope := tally.NewTestScope("atlantis", nil)
+	metrics := drift.NewMetrics(scope)
+
+	metrics.DetectionRun("github.com/owner/repo", false)
+	metrics.DetectionRun("github.com/owner/repo", true)
+
+	counters := scope.Snapshot().Counters()
+	Equals(t, int64(2), counters["atlantis.drift.detection.runs+repository=github.com/owner/repo"].Value())
+	Equals(t, int64(1), counters["atlantis.drift.detection.errors+repository=github.com/owner/repo"].Value())
+}
+
+func TestMetricsPublisher_Run(t *testing.T) {
+	storage := drift.NewInMemoryStorage()
+	Ok(t, storage.Store("github.com/owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "main",
+		Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1, ToChange: 2, ToDestroy: 3},
+		LastChecked: time.Now().Add(-time.Hour),
+	}))
+	Ok(t, storage.Store("github.com/owner/repo", models.ProjectDrift{
+		Path:        "db",
+		Workspace:   "default",
+		Ref:         "main",
+		LastChecked: time.Now(),
+	}))
+	scope := tally.NewTestScope("atlantis", nil)
+
+	drift.NewMetricsPublisher(storage, drift.NewMetrics(scope), logging.NewNoopLogger(t)).Run()
+
+	gauges := scope.Snapshot().Gauges()
+	app := "+path=app,project=app,ref=main,repository=github.com/owner/repo,workspace=default"
+	Equals(t, 1.0, gauges["atlantis.drift.project.has_drift"+app].Value())
+	Equals(t, 1.0, gauges["atlantis.drift.project.to_add"+app].Value())
+	Equals(t, 2.0, gauges["atlantis.drift.project.to_change"+app].Value())
+	Equals(t, 3.0, gauges["atlantis.drift.project.to_destroy"+app].Value())
+	sinceLastCheck := gauges["atlantis.drift.project.seconds_since_last_check"+app].Value()
+	Assert(t, sinceLastCheck >= 3600 && sinceLastCheck < 3660, "expected about an hour since last check, got %f", sinceLastCheck)
+
+	db := "+path=db,project=,ref=main,repository=github.com/owner/repo,workspace=default"
+	Equals(t, 0.0, gauges["atlantis.drift.project.has_drift"+db].Value())
+}
+
+func TestRemediationService_CountsOutcomes(t *testing.T) {
+	scope := tally.NewTestScope("atlantis", nil)
+	service := drift.NewRemediationService(drift.NewInMemoryStorage(), drift.NewInMemoryRemediationStore(), 1, drift.RemediationRetention{}, drift.NewMetrics(scope))
+
+	accepted, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Action:     models.RemediationAutoApply,
+		Projects:   []string{"app"},
+	}, &recordingRemediationExecutor{})
+	Ok(t, err)
+	result, err := service.Wait(context.Background(), accepted.ID)
+	Ok(t, err)
+	Equals(t, models.RemediationStatusFailed, result.Status)
+
+	counters := scope.Snapshot().Counters()
+	Equals(t, int64(1), counters["atlantis.drift.remediation.outcomes+action=apply,status=failed"].Value())
+}
@@ -26,6 +26,25 @@ func NewMockRemediationService(options ...pegomock.Option) *MockRemediationServi
 func (mock *MockRemediationService) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
 func (mock *MockRemediationService) FailHandler() pegomock.FailHandler      { return mock.fail }
 
+func (mock *MockRemediationService) Cancel(id string) (*models.RemediationResult, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockRemediationService().")
+	}
+	_params := []pegomock.Param{id}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("Cancel", _params, []reflect.Type{reflect.TypeOf((**models.RemediationResult)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 *models.RemediationResult
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(*models.RemediationResult)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
 func (mock *MockRemediationService) GetResult(id string) (*models.RemediationResult, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockRemediationService().")
@@ -45,17 +64,17 @@ func (mock *MockRemediationService) GetResult(id string) (*models.RemediationRes
 	return _ret0, _ret1
 }
 
-func (mock *MockRemediationService) ListResults(repository string, limit int) ([]*models.RemediationResult, error) {
+func (mock *MockRemediationService) ListResults(repository string, opts drift.RemediationListOptions) (drift.RemediationPage, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockRemediationService().")
 	}
-	_params := []pegomock.Param{repository, limit}
-	_result := pegomock.GetGenericMockFrom(mock).Invoke("ListResults", _params, []reflect.Type{reflect.TypeOf((*[]*models.RemediationResult)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
-	var _ret0 []*models.RemediationResult
+	_params := []pegomock.Param{repository, opts}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ListResults", _params, []reflect.Type{reflect.TypeOf((*drift.RemediationPage)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 drift.RemediationPage
 	var _ret1 error
 	if len(_result) != 0 {
 		if _result[0] != nil {
-			_ret0 = _result[0].([]*models.RemediationResult)
+			_ret0 = _result[0].(drift.RemediationPage)
 		}
 		if _result[1] != nil {
 			_ret1 = _result[1].(error)
@@ -120,6 +139,35 @@ type VerifierMockRemediationService struct {
 	timeout                time.Duration
 }
 
+func (verifier *VerifierMockRemediationService) Cancel(id string) *MockRemediationService_Cancel_OngoingVerification {
+	_params := []pegomock.Param{id}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Cancel", _params, verifier.timeout)
+	return &MockRemediationService_Cancel_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockRemediationService_Cancel_OngoingVerification struct {
+	mock              *MockRemediationService
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockRemediationService_Cancel_OngoingVerification) GetCapturedArguments() string {
+	id := c.GetAllCapturedArguments()
+	return id[len(id)-1]
+}
+
+func (c *MockRemediationService_Cancel_OngoingVerification) GetAllCapturedArguments() (_param0 []string) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockRemediationService) GetResult(id string) *MockRemediationService_GetResult_OngoingVerification {
 	_params := []pegomock.Param{id}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetResult", _params, verifier.timeout)
@@ -149,8 +197,8 @@ func (c *MockRemediationService_GetResult_OngoingVerification) GetAllCapturedArg
 	return
 }
 
-func (verifier *VerifierMockRemediationService) ListResults(repository string, limit int) *MockRemediationService_ListResults_OngoingVerification {
-	_params := []pegomock.Param{repository, limit}
+func (verifier *VerifierMockRemediationService) ListResults(repository string, opts drift.RemediationListOptions) *MockRemediationService_ListResults_OngoingVerification {
+	_params := []pegomock.Param{repository, opts}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ListResults", _params, verifier.timeout)
 	return &MockRemediationService_ListResults_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
 }
@@ -160,12 +208,12 @@ type MockRemediationService_ListResults_OngoingVerification struct {
 	methodInvocations []pegomock.MethodInvocation
 }
 
-func (c *MockRemediationService_ListResults_OngoingVerification) GetCapturedArguments() (string, int) {
-	repository, limit := c.GetAllCapturedArguments()
-	return repository[len(repository)-1], limit[len(limit)-1]
+func (c *MockRemediationService_ListResults_OngoingVerification) GetCapturedArguments() (string, drift.RemediationListOptions) {
+	repository, opts := c.GetAllCapturedArguments()
+	return repository[len(repository)-1], opts[len(opts)-1]
 }
 
-func (c *MockRemediationService_ListResults_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []int) {
+func (c *MockRemediationService_ListResults_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []drift.RemediationListOptions) {
 	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
 	if len(_params) > 0 {
 		if len(_params) > 0 {
@@ -175,9 +223,9 @@ func (c *MockRemediationService_ListResults_OngoingVerification) GetAllCapturedA
 			}
 		}
 		if len(_params) > 1 {
-			_param1 = make([]int, len(c.methodInvocations))
+			_param1 = make([]drift.RemediationListOptions, len(c.methodInvocations))
 			for u, param := range _params[1] {
-				_param1[u] = param.(int)
+				_param1[u] = param.(drift.RemediationListOptions)
 			}
 		}
 	}
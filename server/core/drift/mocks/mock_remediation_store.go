@@ -0,0 +1,254 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/core/drift (interfaces: RemediationStore)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	drift "github.com/runatlantis/atlantis/server/core/drift"
+	models "github.com/runatlantis/atlantis/server/events/models"
+	"reflect"
+	"time"
+)
+
+type MockRemediationStore struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockRemediationStore(options ...pegomock.Option) *MockRemediationStore {
+	mock := &MockRemediationStore{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockRemediationStore) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockRemediationStore) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockRemediationStore) DeleteRemediations(ids []string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockRemediationStore().")
+	}
+	_params := []pegomock.Param{ids}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("DeleteRemediations", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockRemediationStore) GetRemediation(id string) (*models.RemediationResult, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockRemediationStore().")
+	}
+	_params := []pegomock.Param{id}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("GetRemediation", _params, []reflect.Type{reflect.TypeOf((**models.RemediationResult)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 *models.RemediationResult
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(*models.RemediationResult)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
+func (mock *MockRemediationStore) ListRemediations(repository string, opts drift.RemediationListOptions) (drift.RemediationPage, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockRemediationStore().")
+	}
+	_params := []pegomock.Param{repository, opts}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ListRemediations", _params, []reflect.Type{reflect.TypeOf((*drift.RemediationPage)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 drift.RemediationPage
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(drift.RemediationPage)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
+func (mock *MockRemediationStore) StoreRemediation(result *models.RemediationResult) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockRemediationStore().")
+	}
+	_params := []pegomock.Param{result}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("StoreRemediation", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockRemediationStore) VerifyWasCalledOnce() *VerifierMockRemediationStore {
+	return &VerifierMockRemediationStore{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockRemediationStore) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockRemediationStore {
+	return &VerifierMockRemediationStore{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockRemediationStore) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockRemediationStore {
+	return &VerifierMockRemediationStore{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockRemediationStore) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockRemediationStore {
+	return &VerifierMockRemediationStore{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockRemediationStore struct {
+	mock                   *MockRemediationStore
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockRemediationStore) DeleteRemediations(ids []string) *MockRemediationStore_DeleteRemediations_OngoingVerification {
+	_params := []pegomock.Param{ids}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "DeleteRemediations", _params, verifier.timeout)
+	return &MockRemediationStore_DeleteRemediations_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockRemediationStore_DeleteRemediations_OngoingVerification struct {
+	mock              *MockRemediationStore
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockRemediationStore_DeleteRemediations_OngoingVerification) GetCapturedArguments() []string {
+	ids := c.GetAllCapturedArguments()
+	return ids[len(ids)-1]
+}
+
+func (c *MockRemediationStore_DeleteRemediations_OngoingVerification) GetAllCapturedArguments() (_param0 [][]string) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([][]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.([]string)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockRemediationStore) GetRemediation(id string) *MockRemediationStore_GetRemediation_OngoingVerification {
+	_params := []pegomock.Param{id}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetRemediation", _params, verifier.timeout)
+	return &MockRemediationStore_GetRemediation_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockRemediationStore_GetRemediation_OngoingVerification struct {
+	mock              *MockRemediationStore
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockRemediationStore_GetRemediation_OngoingVerification) GetCapturedArguments() string {
+	id := c.GetAllCapturedArguments()
+	return id[len(id)-1]
+}
+
+func (c *MockRemediationStore_GetRemediation_OngoingVerification) GetAllCapturedArguments() (_param0 []string) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockRemediationStore) ListRemediations(repository string, opts drift.RemediationListOptions) *MockRemediationStore_ListRemediations_OngoingVerification {
+	_params := []pegomock.Param{repository, opts}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ListRemediations", _params, verifier.timeout)
+	return &MockRemediationStore_ListRemediations_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockRemediationStore_ListRemediations_OngoingVerification struct {
+	mock              *MockRemediationStore
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockRemediationStore_ListRemediations_OngoingVerification) GetCapturedArguments() (string, drift.RemediationListOptions) {
+	repository, opts := c.GetAllCapturedArguments()
+	return repository[len(repository)-1], opts[len(opts)-1]
+}
+
+func (c *MockRemediationStore_ListRemediations_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []drift.RemediationListOptions) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]drift.RemediationListOptions, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(drift.RemediationListOptions)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockRemediationStore) StoreRemediation(result *models.RemediationResult) *MockRemediationStore_StoreRemediation_OngoingVerification {
+	_params := []pegomock.Param{result}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "StoreRemediation", _params, verifier.timeout)
+	return &MockRemediationStore_StoreRemediation_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockRemediationStore_StoreRemediation_OngoingVerification struct {
+	mock              *MockRemediationStore
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockRemediationStore_StoreRemediation_OngoingVerification) GetCapturedArguments() *models.RemediationResult {
+	result := c.GetAllCapturedArguments()
+	return result[len(result)-1]
+}
+
+func (c *MockRemediationStore_StoreRemediation_OngoingVerification) GetAllCapturedArguments() (_param0 []*models.RemediationResult) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]*models.RemediationResult, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(*models.RemediationResult)
+			}
+		}
+	}
+	return
+}
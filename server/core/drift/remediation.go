@@ -6,6 +6,8 @@ package drift
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_remediation_service.go RemediationService
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"sync"
@@ -13,21 +15,29 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	tally "github.com/uber-go/tally/v4"
 )
 
 const defaultWorkspace = "default"
 
 // RemediationService handles drift remediation operations.
 type RemediationService interface {
-	// Remediate executes drift remediation for the given projects.
-	// It returns a result ID that can be used to track the remediation status.
+	// Remediate accepts drift remediation for the given projects and queues it
+	// for asynchronous execution. The returned result has
+	// RemediationStatusRunning and its ID can be used to track progress.
 	Remediate(req models.RemediationRequest, executor RemediationExecutor) (*models.RemediationResult, error)
 
 	// GetResult retrieves a remediation result by ID.
 	GetResult(id string) (*models.RemediationResult, error)
 
-	// ListResults returns all remediation results for a repository.
-	ListResults(repository string, limit int) ([]*models.RemediationResult, error)
+	// ListResults returns a page of remediation results for a repository,
+	// newest first.
+	ListResults(repository string, opts RemediationListOptions) (RemediationPage, error)
+
+	// Cancel stops a queued or running remediation. Projects that have not
+	// started yet are skipped; a project that is already executing finishes.
+	Cancel(id string) (*models.RemediationResult, error)
 }
 
 // RemediationExecutor executes the actual plan/apply operations.
@@ -40,25 +50,140 @@ type RemediationExecutor interface {
 	ExecuteApplyProjects(repository, ref, vcsType string, projects []models.ProjectDrift) ([]models.ProjectRemediationResult, error)
 }
 
-// InMemoryRemediationService implements RemediationService with in-memory storage.
-type InMemoryRemediationService struct {
-	mu           sync.RWMutex
-	results      map[string]*models.RemediationResult
-	repoResults  map[string][]string // repository -> result IDs
+const (
+	// DefaultRemediationWorkers is the number of remediations executed
+	// concurrently when no worker count is configured.
+	DefaultRemediationWorkers = 2
+	// remediationQueueSize bounds the number of remediations waiting for a
+	// worker. Requests beyond this are rejected with ErrRemediationQueueFull.
+	remediationQueueSize = 100
+	// RemediationHeartbeatInterval is how often the owner of a pending or
+	// running remediation refreshes its heartbeat in the store.
+	RemediationHeartbeatInterval = 30 * time.Second
+	// remediationStaleAfter is how long a pending or running remediation can
+	// go without a heartbeat before it's considered interrupted. It allows
+	// for a few missed heartbeats, e.g. while the store is unavailable.
+	remediationStaleAfter = 3 * RemediationHeartbeatInterval
+)
+
+var (
+	// ErrRemediationQueueFull is returned by Remediate when no more
+	// remediations can be queued.
+	ErrRemediationQueueFull = errors.New("drift remediation queue is full")
+	// ErrRemediationNotFound is returned when a remediation ID is unknown.
+	ErrRemediationNotFound = errors.New("remediation result not found")
+	// ErrRemediationNotCancellable is returned by Cancel when the remediation
+	// has already finished.
+	ErrRemediationNotCancellable = errors.New("remediation has already completed")
+)
+
+const errRemediationInterrupted = "remediation was interrupted because the Atlantis server running it stopped"
+
+// remediationJob tracks a queued or running remediation.
+type remediationJob struct {
+	req      models.RemediationRequest
+	executor RemediationExecutor
+	result   *models.RemediationResult
+	ctx      context.Context
+	cancel   context.CancelFunc
+	// started is set once a worker picks up the job. Guarded by the service mutex.
+	started bool
+	done    chan struct{}
+}
+
+// DefaultRemediationService implements RemediationService. Remediations are
+// executed by a bounded pool of background workers and their results are kept
+// in a RemediationStore.
+type DefaultRemediationService struct {
+	mu sync.RWMutex
+	// active holds the latest result of every remediation that has not been
+	// persisted in a terminal state yet, so progress stays visible even if
+	// the store is temporarily unavailable.
+	active map[string]*models.RemediationResult
+	jobs   map[string]*remediationJob
+	// persistMu serializes writes to the store so a heartbeat never
+	// overwrites newer progress.
+	persistMu sync.Mutex
+	// owner identifies this process on the results it executes.
+	owner        string
 	driftStorage Storage
+	store        RemediationStore
+	retention    RemediationRetention
+	metrics      *Metrics
+
+	workers   int
+	queue     chan *remediationJob
+	startOnce sync.Once
 }
 
-// NewInMemoryRemediationService creates a new in-memory remediation service.
-func NewInMemoryRemediationService(driftStorage Storage) *InMemoryRemediationService {
-	return &InMemoryRemediationService{
-		results:      make(map[string]*models.RemediationResult),
-		repoResults:  make(map[string][]string),
+// NewInMemoryRemediationService creates a remediation service that keeps
+// results in memory and uses DefaultRemediationWorkers workers.
+func NewInMemoryRemediationService(driftStorage Storage) *DefaultRemediationService {
+	return NewRemediationService(driftStorage, NewInMemoryRemediationStore(), DefaultRemediationWorkers, RemediationRetention{}, nil)
+}
+
+// NewRemediationService creates a remediation service that keeps results in
+// store, applies retention to them and executes at most workers remediations
+// concurrently. Finished remediations are counted in metrics, which may be nil.
+func NewRemediationService(driftStorage Storage, store RemediationStore, workers int, retention RemediationRetention, metrics *Metrics) *DefaultRemediationService {
+	if workers <= 0 {
+		workers = DefaultRemediationWorkers
+	}
+	if metrics == nil {
+		metrics = NewMetrics(tally.NoopScope)
+	}
+	return &DefaultRemediationService{
+		active:       make(map[string]*models.RemediationResult),
+		jobs:         make(map[string]*remediationJob),
+		owner:        uuid.New().String(),
 		driftStorage: driftStorage,
+		store:        store,
+		retention:    retention,
+		metrics:      metrics,
+		workers:      workers,
+		queue:        make(chan *remediationJob, remediationQueueSize),
+	}
+}
+
+// RecoverInterrupted marks pending or running remediations as failed if the
+// process executing them stopped, since no worker will finish them. A
+// remediation is interrupted when its owner hasn't refreshed its heartbeat
+// within remediationStaleAfter, so remediations executed by other Atlantis
+// servers sharing the store are left alone.
+func (s *DefaultRemediationService) RecoverInterrupted() error {
+	now := time.Now()
+	for _, status := range []models.RemediationStatus{models.RemediationStatusPending, models.RemediationStatusRunning} {
+		page, err := s.store.ListRemediations("", RemediationListOptions{Status: status})
+		if err != nil {
+			return fmt.Errorf("listing interrupted remediations: %w", err)
+		}
+		for _, result := range page.Results {
+			s.mu.RLock()
+			_, ours := s.jobs[result.ID]
+			s.mu.RUnlock()
+			if ours || result.Owner == s.owner || now.Sub(result.HeartbeatAt) < remediationStaleAfter {
+				continue
+			}
+			for i := range result.Projects {
+				if !result.Projects[i].Status.IsTerminal() {
+					result.Projects[i].Status = models.RemediationStatusFailed
+					result.Projects[i].Error = errRemediationInterrupted
+				}
+			}
+			result.Complete()
+			result.Status = models.RemediationStatusFailed
+			result.Error = errRemediationInterrupted
+			if err := s.store.StoreRemediation(result); err != nil {
+				return fmt.Errorf("marking remediation %s interrupted: %w", result.ID, err)
+			}
+		}
 	}
+	return nil
 }
 
-// Remediate executes drift remediation for the given projects.
-func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, executor RemediationExecutor) (*models.RemediationResult, error) {
+// Remediate validates the request and queues it for execution. It returns
+// immediately with a result in RemediationStatusRunning.
+func (s *DefaultRemediationService) Remediate(req models.RemediationRequest, executor RemediationExecutor) (*models.RemediationResult, error) {
 	// Validate action, default to plan-only
 	if req.Action == "" {
 		req.Action = models.RemediationPlanOnly
@@ -75,8 +200,170 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 	result.StorageRepository = remediationStorageRepository(req)
 	result.Status = models.RemediationStatusRunning
 
-	// Store initial result
-	s.storeResult(result)
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &remediationJob{
+		req:      req,
+		executor: executor,
+		result:   result,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	result.RequestedBy = req.RequestedBy
+
+	s.startOnce.Do(s.startWorkers)
+
+	if err := s.storeResult(result); err != nil {
+		cancel()
+		return nil, err
+	}
+	s.mu.Lock()
+	s.jobs[id] = job
+	s.mu.Unlock()
+	// Snapshot before enqueueing; once queued the worker owns result.
+	accepted := cloneRemediationResult(result)
+
+	select {
+	case s.queue <- job:
+	default:
+		cancel()
+		s.mu.Lock()
+		delete(s.jobs, id)
+		s.mu.Unlock()
+		result.Status = models.RemediationStatusFailed
+		result.Error = ErrRemediationQueueFull.Error()
+		completedAt := time.Now()
+		result.CompletedAt = &completedAt
+		s.storeResult(result) // nolint: errcheck
+		s.metrics.RemediationFinished(result)
+		close(job.done)
+		return nil, ErrRemediationQueueFull
+	}
+
+	return accepted, nil
+}
+
+// Cancel stops a queued or running remediation.
+func (s *DefaultRemediationService) Cancel(id string) (*models.RemediationResult, error) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		if _, err := s.GetResult(id); err != nil {
+			return nil, err
+		}
+		return nil, ErrRemediationNotCancellable
+	}
+	job.cancel()
+	if !job.started {
+		// The job is still queued, so no worker owns the result yet. Mark it
+		// cancelled now; the worker will skip it when it is dequeued.
+		delete(s.jobs, id)
+		result := cloneRemediationResult(s.active[id])
+		markRemediationCancelled(result)
+		s.mu.Unlock()
+		err := s.storeResult(result)
+		s.metrics.RemediationFinished(result)
+		close(job.done)
+		if err != nil {
+			return nil, err
+		}
+		return cloneRemediationResult(result), nil
+	}
+	s.mu.Unlock()
+	return s.GetResult(id)
+}
+
+// Wait blocks until the remediation with the given ID reaches a terminal
+// state or ctx is done, then returns its latest result.
+func (s *DefaultRemediationService) Wait(ctx context.Context, id string) (*models.RemediationResult, error) {
+	s.mu.RLock()
+	job, ok := s.jobs[id]
+	s.mu.RUnlock()
+	if ok {
+		select {
+		case <-job.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return s.GetResult(id)
+}
+
+func (s *DefaultRemediationService) startWorkers() {
+	for range s.workers {
+		go s.worker()
+	}
+	go s.heartbeat()
+}
+
+// heartbeat refreshes the heartbeat of every pending or running remediation
+// this process owns.
+func (s *DefaultRemediationService) heartbeat() {
+	ticker := time.NewTicker(RemediationHeartbeatInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.persistMu.Lock()
+		s.mu.RLock()
+		active := make([]*models.RemediationResult, 0, len(s.active))
+		for _, result := range s.active {
+			active = append(active, result)
+		}
+		s.mu.RUnlock()
+		for _, result := range active {
+			s.persist(result) // nolint: errcheck
+		}
+		s.persistMu.Unlock()
+	}
+}
+
+func (s *DefaultRemediationService) worker() {
+	for job := range s.queue {
+		s.mu.Lock()
+		if job.ctx.Err() != nil {
+			// Cancelled while queued; Cancel already recorded the result.
+			s.mu.Unlock()
+			continue
+		}
+		job.started = true
+		s.mu.Unlock()
+
+		s.execute(job)
+		s.metrics.RemediationFinished(job.result)
+
+		s.applyRetention(RemediationRepositoryKey(job.result))
+
+		s.mu.Lock()
+		delete(s.jobs, job.result.ID)
+		s.mu.Unlock()
+		job.cancel()
+		close(job.done)
+	}
+}
+
+// applyRetention removes finished results for repository that fall outside
+// the configured retention. Failures are ignored and retried after the next
+// remediation.
+func (s *DefaultRemediationService) applyRetention(repository string) {
+	if s.retention == (RemediationRetention{}) {
+		return
+	}
+	page, err := s.store.ListRemediations(repository, RemediationListOptions{})
+	if err != nil {
+		return
+	}
+	if ids := expiredRemediations(page.Results, s.retention, time.Now()); len(ids) > 0 {
+		s.store.DeleteRemediations(ids) // nolint: errcheck
+	}
+}
+
+// execute runs a remediation job, storing progress after each project so
+// GetResult reflects per-project status while the job is running.
+func (s *DefaultRemediationService) execute(job *remediationJob) {
+	req := job.req
+	result := job.result
+	executor := job.executor
 
 	// Get projects to remediate
 	projects, err := s.getProjectsToRemediate(req)
@@ -85,8 +372,8 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 		result.Status = models.RemediationStatusFailed
 		completedAt := time.Now()
 		result.CompletedAt = &completedAt
-		s.storeResult(result)
-		return result, nil
+		s.recordProgress(result)
+		return
 	}
 	projects, err = deduplicateRemediationTargets(req, projects)
 	if err != nil {
@@ -94,8 +381,8 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 		result.Status = models.RemediationStatusFailed
 		completedAt := time.Now()
 		result.CompletedAt = &completedAt
-		s.storeResult(result)
-		return result, nil
+		s.recordProgress(result)
+		return
 	}
 
 	if len(projects) == 0 {
@@ -107,8 +394,8 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 		} else {
 			result.Complete()
 		}
-		s.storeResult(result)
-		return result, nil
+		s.recordProgress(result)
+		return
 	}
 
 	if req.Action == models.RemediationAutoApply {
@@ -117,28 +404,84 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 				result.AddProjectResult(projectResult)
 			}
 			result.Complete()
-			s.storeResult(result)
-			return result, nil
-		}
-		for _, projectResult := range s.remediateProjectsWithApply(req, projects, executor) {
-			result.AddProjectResult(projectResult)
+			s.recordProgress(result)
+			return
 		}
+	}
+
+	// Record every target up front so callers polling the result can see
+	// which projects are still pending.
+	result.Projects = pendingProjectRemediationResults(projects, models.RemediationStatusPending)
+	result.TotalProjects = len(projects)
+	s.recordProgress(result)
+
+	if job.ctx.Err() != nil {
+		markRemediationCancelled(result)
+		s.recordProgress(result)
+		return
+	}
+
+	if req.Action == models.RemediationAutoApply {
+		// Apply runs all projects as one operation so dependency ordering is
+		// preserved; it cannot be interrupted once started.
+		result.Projects = pendingProjectRemediationResults(projects, models.RemediationStatusRunning)
+		s.recordProgress(result)
+		result.Projects = s.remediateProjectsWithApply(req, projects, executor)
 	} else {
 		// Execute remediation for each project
-		for _, proj := range projects {
-			projectResult := s.remediateProject(req, proj, executor)
-			result.AddProjectResult(projectResult)
+		for i, proj := range projects {
+			if job.ctx.Err() != nil {
+				markRemediationCancelled(result)
+				s.recordProgress(result)
+				return
+			}
+			result.Projects[i].Status = models.RemediationStatusRunning
+			s.recordProgress(result)
+			result.Projects[i] = s.remediateProject(req, proj, executor)
+			s.recordProgress(result)
 		}
 	}
 
 	// Mark as complete
 	result.Complete()
-	s.storeResult(result)
+	s.recordProgress(result)
+}
+
+func pendingProjectRemediationResults(projects []models.ProjectDrift, status models.RemediationStatus) []models.ProjectRemediationResult {
+	results := make([]models.ProjectRemediationResult, 0, len(projects))
+	for _, proj := range projects {
+		results = append(results, models.ProjectRemediationResult{
+			ProjectName: proj.ProjectName,
+			Path:        proj.Path,
+			Workspace:   proj.Workspace,
+			Status:      status,
+			DriftBefore: cloneDriftSummaryPtr(driftBeforeRemediation(proj)),
+		})
+	}
+	return results
+}
+
+func driftBeforeRemediation(proj models.ProjectDrift) *models.DriftSummary {
+	if proj.Drift.HasDrift {
+		return &proj.Drift
+	}
+	return nil
+}
 
-	return result, nil
+// markRemediationCancelled marks every project that has not finished as
+// cancelled and completes the result with RemediationStatusCancelled.
+func markRemediationCancelled(result *models.RemediationResult) {
+	for i := range result.Projects {
+		if !result.Projects[i].Status.IsTerminal() {
+			result.Projects[i].Status = models.RemediationStatusCancelled
+			result.Projects[i].Error = "remediation cancelled"
+		}
+	}
+	result.Complete()
+	result.Status = models.RemediationStatusCancelled
 }
 
-func (s *InMemoryRemediationService) remediateProjectsWithApply(req models.RemediationRequest, projects []models.ProjectDrift, executor RemediationExecutor) []models.ProjectRemediationResult {
+func (s *DefaultRemediationService) remediateProjectsWithApply(req models.RemediationRequest, projects []models.ProjectDrift, executor RemediationExecutor) []models.ProjectRemediationResult {
 	results, err := executor.ExecuteApplyProjects(req.Repository, remediationExecutionRef(req), req.Type, projects)
 	if err != nil && len(results) == 0 {
 		return failedProjectRemediationResults(projects, err.Error())
@@ -162,7 +505,7 @@ func failedProjectRemediationResults(projects []models.ProjectDrift, errorMessag
 	return results
 }
 
-func (s *InMemoryRemediationService) completeApplyProjectResults(req models.RemediationRequest, projects []models.ProjectDrift, results []models.ProjectRemediationResult, applyErr error) []models.ProjectRemediationResult {
+func (s *DefaultRemediationService) completeApplyProjectResults(req models.RemediationRequest, projects []models.ProjectDrift, results []models.ProjectRemediationResult, applyErr error) []models.ProjectRemediationResult {
 	projectsByKey := make(map[string]models.ProjectDrift, len(projects))
 	for _, proj := range projects {
 		projectsByKey[remediationProjectKey(proj.ProjectName, proj.Path, proj.Workspace)] = proj
@@ -226,7 +569,7 @@ func (s *InMemoryRemediationService) completeApplyProjectResults(req models.Reme
 }
 
 // getProjectsToRemediate determines which projects to remediate based on the request.
-func (s *InMemoryRemediationService) getProjectsToRemediate(req models.RemediationRequest) ([]models.ProjectDrift, error) {
+func (s *DefaultRemediationService) getProjectsToRemediate(req models.RemediationRequest) ([]models.ProjectDrift, error) {
 	var err error
 	req, err = normalizeRemediationRequest(req)
 	if err != nil {
@@ -507,7 +850,7 @@ func hasRemediationPathTarget(projects []models.ProjectDrift, path string, works
 }
 
 // matchesFilters checks if a project matches the request filters.
-func (s *InMemoryRemediationService) matchesFilters(proj models.ProjectDrift, req models.RemediationRequest) bool {
+func (s *DefaultRemediationService) matchesFilters(proj models.ProjectDrift, req models.RemediationRequest) bool {
 	// Check project name filter
 	if len(req.Projects) > 0 {
 		if !slices.Contains(req.Projects, proj.ProjectName) {
@@ -545,7 +888,7 @@ func (s *InMemoryRemediationService) matchesFilters(proj models.ProjectDrift, re
 }
 
 // remediateProject executes remediation for a single project.
-func (s *InMemoryRemediationService) remediateProject(req models.RemediationRequest, proj models.ProjectDrift, executor RemediationExecutor) models.ProjectRemediationResult {
+func (s *DefaultRemediationService) remediateProject(req models.RemediationRequest, proj models.ProjectDrift, executor RemediationExecutor) models.ProjectRemediationResult {
 	result := models.ProjectRemediationResult{
 		ProjectName: proj.ProjectName,
 		Path:        proj.Path,
@@ -583,23 +926,43 @@ func (s *InMemoryRemediationService) remediateProject(req models.RemediationRequ
 }
 
 // storeResult stores a remediation result.
-func (s *InMemoryRemediationService) storeResult(result *models.RemediationResult) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.results[result.ID] = cloneRemediationResult(result)
+// storeResult records the latest state of a remediation. While a remediation
+// is in progress its latest state is also kept in memory, and a finished
+// remediation that could not be persisted stays there so it isn't lost.
+func (s *DefaultRemediationService) storeResult(result *models.RemediationResult) error {
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+	return s.persist(result)
+}
 
-	repositoryKey := remediationResultRepositoryKey(result)
+// persist stores a snapshot of result owned by this process with a fresh
+// heartbeat. The caller must hold persistMu.
+func (s *DefaultRemediationService) persist(result *models.RemediationResult) error {
+	snapshot := cloneRemediationResult(result)
+	snapshot.Owner = s.owner
+	snapshot.HeartbeatAt = time.Now()
+	s.mu.Lock()
+	s.active[result.ID] = snapshot
+	s.mu.Unlock()
 
-	// Track by repository
-	if _, ok := s.repoResults[repositoryKey]; !ok {
-		s.repoResults[repositoryKey] = []string{}
+	if err := s.store.StoreRemediation(snapshot); err != nil {
+		return fmt.Errorf("storing remediation result: %w", err)
 	}
-
-	// Check if ID already exists in repo results
-	if !slices.Contains(s.repoResults[repositoryKey], result.ID) {
-		s.repoResults[repositoryKey] = append(s.repoResults[repositoryKey], result.ID)
+	if snapshot.Status.IsTerminal() {
+		s.mu.Lock()
+		if s.active[result.ID] == snapshot {
+			delete(s.active, result.ID)
+		}
+		s.mu.Unlock()
 	}
+	return nil
+}
+
+// recordProgress stores result while a worker executes it. Store errors are
+// ignored because the latest state stays available in memory and is stored
+// again with the next update.
+func (s *DefaultRemediationService) recordProgress(result *models.RemediationResult) {
+	s.storeResult(result) // nolint: errcheck
 }
 
 func remediationProjectKey(projectName, path, workspace string) string {
@@ -659,43 +1022,33 @@ func cloneDriftSummaryPtr(summary *models.DriftSummary) *models.DriftSummary {
 	return &clone
 }
 
-func remediationResultRepositoryKey(result *models.RemediationResult) string {
-	if result.StorageRepository != "" {
-		return result.StorageRepository
-	}
-	return result.Repository
-}
-
 // GetResult retrieves a remediation result by ID.
-func (s *InMemoryRemediationService) GetResult(id string) (*models.RemediationResult, error) {
+func (s *DefaultRemediationService) GetResult(id string) (*models.RemediationResult, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	result, ok := s.results[id]
-	if !ok {
-		return nil, fmt.Errorf("remediation result not found: %s", id)
+	result, ok := s.active[id]
+	s.mu.RUnlock()
+	if ok {
+		return cloneRemediationResult(result), nil
 	}
-	return cloneRemediationResult(result), nil
+	return s.store.GetRemediation(id)
 }
 
-// ListResults returns all remediation results for a repository.
-func (s *InMemoryRemediationService) ListResults(repository string, limit int) ([]*models.RemediationResult, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	ids, ok := s.repoResults[repository]
-	if !ok {
-		return []*models.RemediationResult{}, nil
+// ListResults returns a page of remediation results for a repository, newest
+// first.
+func (s *DefaultRemediationService) ListResults(repository string, opts RemediationListOptions) (RemediationPage, error) {
+	page, err := s.store.ListRemediations(repository, opts)
+	if err != nil {
+		return RemediationPage{}, err
 	}
-
-	results := make([]*models.RemediationResult, 0, len(ids))
-	for i := len(ids) - 1; i >= 0 && (limit <= 0 || len(results) < limit); i-- {
-		if result, ok := s.results[ids[i]]; ok {
-			results = append(results, cloneRemediationResult(result))
+	// Prefer in-memory progress over what was last persisted.
+	s.mu.RLock()
+	for i, result := range page.Results {
+		if active, ok := s.active[result.ID]; ok {
+			page.Results[i] = cloneRemediationResult(active)
 		}
 	}
-
-	return results, nil
+	s.mu.RUnlock()
+	return page, nil
 }
 
 // RemediationHistory represents the history of remediations for tracking.
@@ -717,3 +1070,23 @@ type RemediationHistory struct {
 	// SuccessCount is the number of successful remediations.
 	SuccessCount int `json:"success_count"`
 }
+
+// RemediationRecoverer is a job that periodically marks remediations
+// interrupted by a stopped Atlantis server as failed.
+type RemediationRecoverer struct {
+	service *DefaultRemediationService
+	log     logging.SimpleLogging
+}
+
+// NewRemediationRecoverer returns a job recovering interrupted remediations
+// of service.
+func NewRemediationRecoverer(service *DefaultRemediationService, log logging.SimpleLogging) *RemediationRecoverer {
+	return &RemediationRecoverer{service: service, log: log}
+}
+
+// Run marks interrupted remediations as failed.
+func (r *RemediationRecoverer) Run() {
+	if err := r.service.RecoverInterrupted(); err != nil {
+		r.log.Warn("unable to mark interrupted drift remediations as failed: %s", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+//go:generate go tool pegomock generate --package mocks -o mocks/mock_remediation_store.go RemediationStore
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// RemediationStore persists remediation results.
+type RemediationStore interface {
+	// StoreRemediation creates or replaces a remediation result.
+	StoreRemediation(result *models.RemediationResult) error
+
+	// GetRemediation returns the result with the given ID. It returns an error
+	// wrapping ErrRemediationNotFound if there is none.
+	GetRemediation(id string) (*models.RemediationResult, error)
+
+	// ListRemediations returns results for a storage repository, newest first.
+	// An empty repository lists results across all repositories.
+	ListRemediations(repository string, opts RemediationListOptions) (RemediationPage, error)
+
+	// DeleteRemediations removes the results with the given IDs.
+	DeleteRemediations(ids []string) error
+}
+
+// RemediationListOptions filters and paginates remediation history.
+type RemediationListOptions struct {
+	// Limit is the maximum number of results to return. Zero means no limit.
+	Limit int
+	// Cursor is the NextCursor of a previous page.
+	Cursor string
+	// Status only returns results with this status.
+	Status models.RemediationStatus
+	// StartedAfter and StartedBefore restrict results to those started
+	// within the range. Zero values are unbounded.
+	StartedAfter  time.Time
+	StartedBefore time.Time
+}
+
+// RemediationPage is one page of remediation history.
+type RemediationPage struct {
+	Results []*models.RemediationResult
+	// NextCursor fetches the next page. It is empty on the last page.
+	NextCursor string
+}
+
+// RemediationRetention limits how much finished remediation history is kept.
+// Results that are still running are never removed.
+type RemediationRetention struct {
+	// MaxAge removes results started longer ago than this. Zero keeps results
+	// regardless of age.
+	MaxAge time.Duration
+	// MaxPerRepository keeps at most this many results per repository. Zero
+	// means no limit.
+	MaxPerRepository int
+}
+
+// ErrInvalidRemediationCursor is returned when a list cursor can't be decoded.
+var ErrInvalidRemediationCursor = errors.New("invalid remediation cursor")
+
+// remediationRecord is the serialized form of a result. StorageRepository,
+// Owner and HeartbeatAt are not part of the result's JSON so they are stored
+// alongside it.
+type remediationRecord struct {
+	StorageRepository string                    `json:"storage_repository"`
+	Owner             string                    `json:"owner,omitempty"`
+	HeartbeatAt       time.Time                 `json:"heartbeat_at"`
+	Result            *models.RemediationResult `json:"result"`
+}
+
+// MarshalRemediation serializes a result for a RemediationStore.
+func MarshalRemediation(result *models.RemediationResult) ([]byte, error) {
+	serialized, err := json.Marshal(remediationRecord{
+		StorageRepository: result.StorageRepository,
+		Owner:             result.Owner,
+		HeartbeatAt:       result.HeartbeatAt,
+		Result:            result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("serializing remediation %s: %w", result.ID, err)
+	}
+	return serialized, nil
+}
+
+// UnmarshalRemediation deserializes a result written by MarshalRemediation.
+func UnmarshalRemediation(serialized []byte) (*models.RemediationResult, error) {
+	var record remediationRecord
+	if err := json.Unmarshal(serialized, &record); err != nil {
+		return nil, fmt.Errorf("deserializing remediation: %w", err)
+	}
+	if record.Result == nil {
+		return nil, errors.New("deserializing remediation: missing result")
+	}
+	record.Result.StorageRepository = record.StorageRepository
+	record.Result.Owner = record.Owner
+	record.Result.HeartbeatAt = record.HeartbeatAt
+	return record.Result, nil
+}
+
+// RemediationRepositoryKey returns the repository a result is indexed under.
+func RemediationRepositoryKey(result *models.RemediationResult) string {
+	if result.StorageRepository != "" {
+		return result.StorageRepository
+	}
+	return result.Repository
+}
+
+// PaginateRemediations filters results by opts, sorts them newest first and
+// returns the page following opts.Cursor. Stores use it so every backend
+// orders and pages history the same way.
+func PaginateRemediations(results []*models.RemediationResult, opts RemediationListOptions) (RemediationPage, error) {
+	var after *remediationCursor
+	if opts.Cursor != "" {
+		c, err := decodeRemediationCursor(opts.Cursor)
+		if err != nil {
+			return RemediationPage{}, err
+		}
+		after = &c
+	}
+
+	filtered := make([]*models.RemediationResult, 0, len(results))
+	for _, result := range results {
+		if opts.Status != "" && result.Status != opts.Status {
+			continue
+		}
+		if !opts.StartedAfter.IsZero() && result.StartedAt.Before(opts.StartedAfter) {
+			continue
+		}
+		if !opts.StartedBefore.IsZero() && !result.StartedAt.Before(opts.StartedBefore) {
+			continue
+		}
+		if after != nil && !after.precedes(result) {
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+	slices.SortFunc(filtered, compareRemediationsNewestFirst)
+
+	page := RemediationPage{Results: filtered}
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		page.Results = filtered[:opts.Limit]
+		page.NextCursor = newRemediationCursor(page.Results[opts.Limit-1]).encode()
+	}
+	return page, nil
+}
+
+func compareRemediationsNewestFirst(a, b *models.RemediationResult) int {
+	if c := b.StartedAt.Compare(a.StartedAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.ID, a.ID)
+}
+
+// remediationCursor is the position of the last result on a page.
+type remediationCursor struct {
+	startedAt time.Time
+	id        string
+}
+
+func newRemediationCursor(result *models.RemediationResult) remediationCursor {
+	return remediationCursor{startedAt: result.StartedAt, id: result.ID}
+}
+
+// precedes reports whether result sorts after the cursor, i.e. belongs on a
+// later page.
+func (c remediationCursor) precedes(result *models.RemediationResult) bool {
+	return compareRemediationsNewestFirst(&models.RemediationResult{StartedAt: c.startedAt, ID: c.id}, result) < 0
+}
+
+func (c remediationCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.startedAt.UnixNano(), 10) + ":" + c.id))
+}
+
+func decodeRemediationCursor(cursor string) (remediationCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return remediationCursor{}, ErrInvalidRemediationCursor
+	}
+	nanos, id, ok := strings.Cut(string(decoded), ":")
+	if !ok || id == "" {
+		return remediationCursor{}, ErrInvalidRemediationCursor
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return remediationCursor{}, ErrInvalidRemediationCursor
+	}
+	return remediationCursor{startedAt: time.Unix(0, unixNano), id: id}, nil
+}
+
+// expiredRemediations returns the IDs of finished results in results that fall
+// outside retention. results must be sorted newest first.
+func expiredRemediations(results []*models.RemediationResult, retention RemediationRetention, now time.Time) []string {
+	var ids []string
+	kept := 0
+	for _, result := range results {
+		if !result.Status.IsTerminal() {
+			continue
+		}
+		expired := retention.MaxAge > 0 && result.StartedAt.Before(now.Add(-retention.MaxAge))
+		if retention.MaxPerRepository > 0 && kept >= retention.MaxPerRepository {
+			expired = true
+		}
+		if expired {
+			ids = append(ids, result.ID)
+			continue
+		}
+		kept++
+	}
+	return ids
+}
+
+// InMemoryRemediationStore is a RemediationStore that keeps results in
+// process memory. Results are lost on restart.
+type InMemoryRemediationStore struct {
+	mu      sync.RWMutex
+	results map[string]*models.RemediationResult
+}
+
+// NewInMemoryRemediationStore creates an empty in-memory remediation store.
+func NewInMemoryRemediationStore() *InMemoryRemediationStore {
+	return &InMemoryRemediationStore{
+		results: make(map[string]*models.RemediationResult),
+	}
+}
+
+// StoreRemediation creates or replaces a remediation result.
+func (s *InMemoryRemediationStore) StoreRemediation(result *models.RemediationResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[result.ID] = cloneRemediationResult(result)
+	return nil
+}
+
+// GetRemediation returns the result with the given ID.
+func (s *InMemoryRemediationStore) GetRemediation(id string) (*models.RemediationResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result, ok := s.results[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRemediationNotFound, id)
+	}
+	return cloneRemediationResult(result), nil
+}
+
+// ListRemediations returns results for a storage repository, newest first.
+func (s *InMemoryRemediationStore) ListRemediations(repository string, opts RemediationListOptions) (RemediationPage, error) {
+	s.mu.RLock()
+	results := make([]*models.RemediationResult, 0, len(s.results))
+	for _, result := range s.results {
+		if repository == "" || RemediationRepositoryKey(result) == repository {
+			results = append(results, cloneRemediationResult(result))
+		}
+	}
+	s.mu.RUnlock()
+	return PaginateRemediations(results, opts)
+}
+
+// DeleteRemediations removes the results with the given IDs.
+func (s *InMemoryRemediationStore) DeleteRemediations(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.results, id)
+	}
+	return nil
+}
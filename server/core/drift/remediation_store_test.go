@@ -0,0 +1,188 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func remediationAt(id string, status models.RemediationStatus, startedAt time.Time) *models.RemediationResult {
+	return &models.RemediationResult{
+		ID:         id,
+		Repository: "owner/repo",
+		Status:     status,
+		StartedAt:  startedAt,
+	}
+}
+
+func remediationIDs(results []*models.RemediationResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	return ids
+}
+
+func TestPaginateRemediations(t *testing.T) {
+	base := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	results := []*models.RemediationResult{
+		remediationAt("a", models.RemediationStatusSuccess, base),
+		remediationAt("b", models.RemediationStatusFailed, base.Add(time.Hour)),
+		remediationAt("c", models.RemediationStatusSuccess, base.Add(2*time.Hour)),
+		// Same start time as c so the ID breaks the tie.
+		remediationAt("d", models.RemediationStatusSuccess, base.Add(2*time.Hour)),
+		remediationAt("e", models.RemediationStatusSuccess, base.Add(3*time.Hour)),
+	}
+
+	page, err := drift.PaginateRemediations(results, drift.RemediationListOptions{Limit: 2})
+	Ok(t, err)
+	Equals(t, []string{"e", "d"}, remediationIDs(page.Results))
+	Assert(t, page.NextCursor != "", "expected a next cursor")
+
+	page, err = drift.PaginateRemediations(results, drift.RemediationListOptions{Limit: 2, Cursor: page.NextCursor})
+	Ok(t, err)
+	Equals(t, []string{"c", "b"}, remediationIDs(page.Results))
+
+	page, err = drift.PaginateRemediations(results, drift.RemediationListOptions{Limit: 2, Cursor: page.NextCursor})
+	Ok(t, err)
+	Equals(t, []string{"a"}, remediationIDs(page.Results))
+	Equals(t, "", page.NextCursor)
+}
+
+func TestPaginateRemediations_Filters(t *testing.T) {
+	base := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	results := []*models.RemediationResult{
+		remediationAt("a", models.RemediationStatusSuccess, base),
+		remediationAt("b", models.RemediationStatusFailed, base.Add(time.Hour)),
+		remediationAt("c", models.RemediationStatusSuccess, base.Add(2*time.Hour)),
+	}
+
+	page, err := drift.PaginateRemediations(results, drift.RemediationListOptions{Status: models.RemediationStatusSuccess})
+	Ok(t, err)
+	Equals(t, []string{"c", "a"}, remediationIDs(page.Results))
+
+	page, err = drift.PaginateRemediations(results, drift.RemediationListOptions{
+		StartedAfter:  base.Add(time.Hour),
+		StartedBefore: base.Add(2 * time.Hour),
+	})
+	Ok(t, err)
+	Equals(t, []string{"b"}, remediationIDs(page.Results))
+}
+
+func TestPaginateRemediations_InvalidCursor(t *testing.T) {
+	for _, cursor := range []string{"!!!", "bm90LWEtY3Vyc29y", "MTIzOg"} {
+		t.Run(cursor, func(t *testing.T) {
+			_, err := drift.PaginateRemediations(nil, drift.RemediationListOptions{Cursor: cursor})
+			Assert(t, errors.Is(err, drift.ErrInvalidRemediationCursor), "expected invalid cursor error, got %v", err)
+		})
+	}
+}
+
+func TestMarshalRemediation_PreservesStorageRepository(t *testing.T) {
+	result := remediationAt("a", models.RemediationStatusSuccess, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	result.StorageRepository = "github.com/owner/repo"
+	result.Owner = "server"
+	result.HeartbeatAt = time.Date(2025, time.January, 1, 0, 1, 0, 0, time.UTC)
+	result.RequestedBy = &models.APICaller{Name: "jdoe", AuthMethod: "api_secret"}
+
+	serialized, err := drift.MarshalRemediation(result)
+	Ok(t, err)
+	got, err := drift.UnmarshalRemediation(serialized)
+	Ok(t, err)
+	Equals(t, result, got)
+}
+
+func TestRemediationService_RetentionRemovesOldestFinishedResults(t *testing.T) {
+	store := drift.NewInMemoryRemediationStore()
+	now := time.Now()
+	for _, result := range []*models.RemediationResult{
+		remediationAt("expired", models.RemediationStatusSuccess, now.Add(-48*time.Hour)),
+		remediationAt("old", models.RemediationStatusSuccess, now.Add(-3*time.Hour)),
+		remediationAt("recent", models.RemediationStatusFailed, now.Add(-2*time.Hour)),
+		remediationAt("other-repo", models.RemediationStatusSuccess, now.Add(-48*time.Hour)),
+	} {
+		if result.ID == "other-repo" {
+			result.Repository = "owner/other"
+		}
+		Ok(t, store.StoreRemediation(result))
+	}
+	service := drift.NewRemediationService(nil, store, 1, drift.RemediationRetention{
+		MaxAge:           24 * time.Hour,
+		MaxPerRepository: 2,
+	}, nil)
+
+	result, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Projects:   []string{"app"},
+	}, &recordingRemediationExecutor{})
+	Ok(t, err)
+	waitForRemediation(t, service, result.ID)
+
+	page, err := store.ListRemediations("owner/repo", drift.RemediationListOptions{})
+	Ok(t, err)
+	Equals(t, []string{result.ID, "recent"}, remediationIDs(page.Results))
+
+	// Retention only applies to the repository that was remediated.
+	page, err = store.ListRemediations("owner/other", drift.RemediationListOptions{})
+	Ok(t, err)
+	Equals(t, []string{"other-repo"}, remediationIDs(page.Results))
+}
+
+func TestRemediationService_RecoverInterrupted(t *testing.T) {
+	store := drift.NewInMemoryRemediationStore()
+	startedAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	running := remediationAt("running", models.RemediationStatusRunning, startedAt)
+	running.TotalProjects = 2
+	running.Projects = []models.ProjectRemediationResult{
+		{ProjectName: "done", Status: models.RemediationStatusSuccess},
+		{ProjectName: "pending", Status: models.RemediationStatusPending},
+	}
+	Ok(t, store.StoreRemediation(running))
+	Ok(t, store.StoreRemediation(remediationAt("finished", models.RemediationStatusSuccess, startedAt)))
+	// Another Atlantis server sharing the store is still running this one.
+	elsewhere := remediationAt("elsewhere", models.RemediationStatusRunning, startedAt)
+	elsewhere.Owner = "other-server"
+	elsewhere.HeartbeatAt = time.Now()
+	Ok(t, store.StoreRemediation(elsewhere))
+
+	service := drift.NewRemediationService(nil, store, 1, drift.RemediationRetention{}, nil)
+	Ok(t, service.RecoverInterrupted())
+
+	got, err := service.GetResult("running")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusFailed, got.Status)
+	Equals(t, "remediation was interrupted because the Atlantis server running it stopped", got.Error)
+	Assert(t, got.CompletedAt != nil, "expected completed_at to be set")
+	Equals(t, models.RemediationStatusSuccess, got.Projects[0].Status)
+	Equals(t, models.RemediationStatusFailed, got.Projects[1].Status)
+
+	got, err = service.GetResult("finished")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusSuccess, got.Status)
+
+	got, err = service.GetResult("elsewhere")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusRunning, got.Status)
+
+	// Once the other server stops refreshing its heartbeat, it's recovered.
+	elsewhere.HeartbeatAt = time.Now().Add(-time.Hour)
+	Ok(t, store.StoreRemediation(elsewhere))
+	Ok(t, service.RecoverInterrupted())
+	got, err = service.GetResult("elsewhere")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusFailed, got.Status)
+}
+
+func TestInMemoryRemediationStore_GetMissing(t *testing.T) {
+	_, err := drift.NewInMemoryRemediationStore().GetRemediation("missing")
+	Assert(t, errors.Is(err, drift.ErrRemediationNotFound), "expected not found error, got %v", err)
+}
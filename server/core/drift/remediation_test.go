@@ -4,6 +4,7 @@
 package drift_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -135,6 +136,15 @@ func (r *recordingRemediationExecutor) ExecuteApplyProjects(_, ref, _ string, pr
 	return results, r.applyProjectErr
 }
 
+func waitForRemediation(t *testing.T, service *drift.DefaultRemediationService, id string) *models.RemediationResult {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := service.Wait(ctx, id)
+	Ok(t, err)
+	return result
+}
+
 func TestInMemoryRemediationService_ExplicitProjectsHonorWorkspaceFilters(t *testing.T) {
 	service := drift.NewInMemoryRemediationService(nil)
 	executor := &recordingRemediationExecutor{}
@@ -147,6 +157,7 @@ func TestInMemoryRemediationService_ExplicitProjectsHonorWorkspaceFilters(t *tes
 		Workspaces: []string{"staging", "production"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 2, len(executor.planCalls))
@@ -165,6 +176,7 @@ func TestInMemoryRemediationService_GetProjectsErrorPreservesFailureStatus(t *te
 		DriftOnly:  true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Equals(t, "failed to get drift data: storage unavailable", result.Error)
@@ -206,6 +218,7 @@ func TestInMemoryRemediationService_AutoApplyRefreshesCachedDrift(t *testing.T)
 		DriftOnly:  true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -261,6 +274,7 @@ func TestInMemoryRemediationService_AutoApplyUsesSingleProjectBatch(t *testing.T
 		DriftOnly:  true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.applyProjectCalls))
@@ -301,6 +315,7 @@ func TestInMemoryRemediationService_AutoApplyExecutorErrorWithoutResultsFailsTar
 	}, executor)
 
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Equals(t, 2, len(result.Projects))
 	for _, project := range result.Projects {
@@ -322,6 +337,7 @@ func TestInMemoryRemediationService_AutoApplyRequiresCachedDriftBeforeExecutor(t
 		Action:     models.RemediationAutoApply,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Equals(t, 0, len(executor.planCalls))
@@ -348,6 +364,7 @@ func TestInMemoryRemediationService_AutoApplyWithoutCachedTargetsFails(t *testin
 		Action:     models.RemediationAutoApply,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Assert(t, strings.Contains(result.Error, "cached drift with has_drift=true is required"), "expected cached drift error, got %q", result.Error)
@@ -379,6 +396,7 @@ func TestInMemoryRemediationService_AutoApplyRequiresCachedPositiveDrift(t *test
 		Action:     models.RemediationAutoApply,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Equals(t, 0, len(executor.planCalls))
@@ -413,6 +431,7 @@ func TestInMemoryRemediationService_AutoApplyDeduplicatesProjectSelectors(t *tes
 		Projects:   []string{"app", "app"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.applyProjectCalls))
@@ -447,6 +466,7 @@ func TestInMemoryRemediationService_AutoApplyDeduplicatesPathSelectors(t *testin
 		},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.applyProjectCalls))
@@ -482,6 +502,7 @@ func TestInMemoryRemediationService_AutoApplyDeduplicatesMixedSelectors(t *testi
 		},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.applyProjectCalls))
@@ -525,6 +546,7 @@ func TestInMemoryRemediationService_AutoApplyRejectsConflictingDuplicateTargets(
 		DriftOnly:  true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Assert(t, strings.Contains(result.Error, "conflicting cached drift records"), "expected conflict error, got %q", result.Error)
@@ -544,6 +566,7 @@ func TestInMemoryRemediationService_PlanOnlyExplicitWithoutCachedDriftStillRuns(
 		Action:     models.RemediationPlanOnly,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -581,6 +604,7 @@ func TestInMemoryRemediationService_ExplicitProjectsPreserveCachedTargetMetadata
 		Projects:   []string{"app"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -630,6 +654,7 @@ func TestInMemoryRemediationService_ExplicitProjectNamesWithDotsMatchExactly(t *
 		Projects:   []string{"app.prod"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -669,6 +694,7 @@ func TestInMemoryRemediationService_PathSelectorsTargetCachedDrift(t *testing.T)
 		}},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -700,6 +726,7 @@ func TestInMemoryRemediationService_PathSelectorsNormalizeBeforeCacheLookup(t *t
 		}},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -720,6 +747,7 @@ func TestInMemoryRemediationService_PathSelectorsNormalizeFallbackTargets(t *tes
 		Workspaces: []string{"prod"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -740,6 +768,7 @@ func TestInMemoryRemediationService_PathSelectorsHonorTopLevelWorkspaceFallbacks
 		Workspaces: []string{"prod", "stage"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 2, len(executor.planCalls))
@@ -762,6 +791,7 @@ func TestInMemoryRemediationService_PathSelectorsPreserveProjectFallbacks(t *tes
 		Workspaces: []string{"prod"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -794,6 +824,7 @@ func TestInMemoryRemediationService_PathSelectorsHonorCachedWorkspaceFilters(t *
 		Workspaces: []string{"prod", "stage"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 2, len(executor.planCalls))
@@ -824,6 +855,7 @@ func TestInMemoryRemediationService_PathSelectorWithoutWorkspaceTargetsDefaultOn
 		}},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -848,6 +880,7 @@ func TestInMemoryRemediationService_PathSelectorRejectsConflictingWorkspaceFilte
 	}, executor)
 
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 	Equals(t, models.RemediationStatusFailed, result.Status)
 	Assert(t, strings.Contains(result.Error, "path workspace"), "expected path workspace error, got %q", result.Error)
 	Equals(t, 0, len(executor.planCalls))
@@ -887,6 +920,7 @@ func TestInMemoryRemediationService_PathOnlyApplyMatchesResolvedProjectResult(t
 		Workspaces: []string{"prod"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(result.Projects))
@@ -930,6 +964,7 @@ func TestInMemoryRemediationService_PathSelectorsUseMatchingBaseBranch(t *testin
 		}},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -958,6 +993,7 @@ func TestInMemoryRemediationService_NormalizesBranchRefsForCacheLookup(t *testin
 		DriftOnly:  true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -988,6 +1024,7 @@ func TestInMemoryRemediationService_PreservesExecutionRefForAutoApply(t *testing
 		DriftOnly:    true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.applyProjectRefs))
@@ -1034,6 +1071,7 @@ func TestInMemoryRemediationService_UsesHostQualifiedStorageRepository(t *testin
 		DriftOnly:         true,
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	Equals(t, models.RemediationStatusSuccess, result.Status)
 	Equals(t, 1, len(executor.planCalls))
@@ -1053,6 +1091,7 @@ func TestInMemoryRemediationService_ListResultsUsesHostQualifiedStorageRepositor
 		Projects:          []string{"app"},
 	}, executor)
 	Ok(t, err)
+	githubResult = waitForRemediation(t, service, githubResult.ID)
 
 	gitlabResult, err := service.Remediate(models.RemediationRequest{
 		Repository:        "acme/infra",
@@ -1062,20 +1101,21 @@ func TestInMemoryRemediationService_ListResultsUsesHostQualifiedStorageRepositor
 		Projects:          []string{"app"},
 	}, executor)
 	Ok(t, err)
+	gitlabResult = waitForRemediation(t, service, gitlabResult.ID)
 
-	githubResults, err := service.ListResults("github.com/acme/infra", 10)
+	githubResults, err := service.ListResults("github.com/acme/infra", drift.RemediationListOptions{Limit: 10})
 	Ok(t, err)
-	Equals(t, 1, len(githubResults))
-	Equals(t, githubResult.ID, githubResults[0].ID)
+	Equals(t, 1, len(githubResults.Results))
+	Equals(t, githubResult.ID, githubResults.Results[0].ID)
 
-	gitlabResults, err := service.ListResults("gitlab.com/acme/infra", 10)
+	gitlabResults, err := service.ListResults("gitlab.com/acme/infra", drift.RemediationListOptions{Limit: 10})
 	Ok(t, err)
-	Equals(t, 1, len(gitlabResults))
-	Equals(t, gitlabResult.ID, gitlabResults[0].ID)
+	Equals(t, 1, len(gitlabResults.Results))
+	Equals(t, gitlabResult.ID, gitlabResults.Results[0].ID)
 
-	rawResults, err := service.ListResults("acme/infra", 10)
+	rawResults, err := service.ListResults("acme/infra", drift.RemediationListOptions{Limit: 10})
 	Ok(t, err)
-	Equals(t, 0, len(rawResults))
+	Equals(t, 0, len(rawResults.Results))
 }
 
 func TestInMemoryRemediationService_ReturnsResultSnapshots(t *testing.T) {
@@ -1089,6 +1129,7 @@ func TestInMemoryRemediationService_ReturnsResultSnapshots(t *testing.T) {
 		Projects:   []string{"app"},
 	}, executor)
 	Ok(t, err)
+	result = waitForRemediation(t, service, result.ID)
 
 	got, err := service.GetResult(result.ID)
 	Ok(t, err)
@@ -1102,12 +1143,134 @@ func TestInMemoryRemediationService_ReturnsResultSnapshots(t *testing.T) {
 	Equals(t, models.RemediationStatusSuccess, gotAgain.Projects[0].Status)
 	Equals(t, false, gotAgain.Projects[0].DriftAfter.HasDrift)
 
-	listed, err := service.ListResults("owner/repo", 10)
+	listed, err := service.ListResults("owner/repo", drift.RemediationListOptions{Limit: 10})
+	Ok(t, err)
+	Equals(t, 1, len(listed.Results))
+	listed.Results[0].Status = models.RemediationStatusFailed
+
+	listedAgain, err := service.ListResults("owner/repo", drift.RemediationListOptions{Limit: 10})
+	Ok(t, err)
+	Equals(t, models.RemediationStatusSuccess, listedAgain.Results[0].Status)
+}
+
+// blockingRemediationExecutor blocks each plan until released so tests can
+// observe in-flight remediation state.
+type blockingRemediationExecutor struct {
+	started chan string
+	release chan struct{}
+}
+
+func newBlockingRemediationExecutor() *blockingRemediationExecutor {
+	return &blockingRemediationExecutor{
+		started: make(chan string, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (b *blockingRemediationExecutor) ExecutePlan(_, _, _ string, projectName, _, _ string) (string, *models.DriftSummary, error) {
+	b.started <- projectName
+	<-b.release
+	return "plan", &models.DriftSummary{}, nil
+}
+
+func (b *blockingRemediationExecutor) ExecuteApplyProjects(string, string, string, []models.ProjectDrift) ([]models.ProjectRemediationResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestInMemoryRemediationService_RemediateReturnsRunningAndReportsProgress(t *testing.T) {
+	service := drift.NewInMemoryRemediationService(nil)
+	executor := newBlockingRemediationExecutor()
+
+	result, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Projects:   []string{"app", "network"},
+	}, executor)
 	Ok(t, err)
-	Equals(t, 1, len(listed))
-	listed[0].Status = models.RemediationStatusFailed
+	Equals(t, models.RemediationStatusRunning, result.Status)
+	Assert(t, result.ID != "", "expected result ID")
 
-	listedAgain, err := service.ListResults("owner/repo", 10)
+	Equals(t, "app", <-executor.started)
+	inFlight, err := service.GetResult(result.ID)
 	Ok(t, err)
-	Equals(t, models.RemediationStatusSuccess, listedAgain[0].Status)
+	Equals(t, models.RemediationStatusRunning, inFlight.Status)
+	Equals(t, 2, inFlight.TotalProjects)
+	Equals(t, models.RemediationStatusRunning, inFlight.Projects[0].Status)
+	Equals(t, models.RemediationStatusPending, inFlight.Projects[1].Status)
+
+	close(executor.release)
+	result = waitForRemediation(t, service, result.ID)
+	Equals(t, models.RemediationStatusSuccess, result.Status)
+	Equals(t, 2, result.SuccessCount)
+}
+
+func TestInMemoryRemediationService_CancelSkipsRemainingProjects(t *testing.T) {
+	service := drift.NewInMemoryRemediationService(nil)
+	executor := newBlockingRemediationExecutor()
+
+	result, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Projects:   []string{"app", "network"},
+	}, executor)
+	Ok(t, err)
+	Equals(t, "app", <-executor.started)
+
+	_, err = service.Cancel(result.ID)
+	Ok(t, err)
+	close(executor.release)
+
+	result = waitForRemediation(t, service, result.ID)
+	Equals(t, models.RemediationStatusCancelled, result.Status)
+	Equals(t, models.RemediationStatusSuccess, result.Projects[0].Status)
+	Equals(t, models.RemediationStatusCancelled, result.Projects[1].Status)
+	Equals(t, 0, len(executor.started))
+
+	_, err = service.Cancel(result.ID)
+	Assert(t, errors.Is(err, drift.ErrRemediationNotCancellable), "expected not cancellable error, got %v", err)
+}
+
+func TestInMemoryRemediationService_CancelQueuedRemediation(t *testing.T) {
+	service := drift.NewRemediationService(nil, drift.NewInMemoryRemediationStore(), 1, drift.RemediationRetention{}, nil)
+	executor := newBlockingRemediationExecutor()
+	defer close(executor.release)
+
+	first, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Projects:   []string{"app"},
+	}, executor)
+	Ok(t, err)
+	Equals(t, "app", <-executor.started)
+
+	queued, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Projects:   []string{"queued"},
+	}, executor)
+	Ok(t, err)
+
+	cancelled, err := service.Cancel(queued.ID)
+	Ok(t, err)
+	Equals(t, models.RemediationStatusCancelled, cancelled.Status)
+	Assert(t, cancelled.CompletedAt != nil, "expected completed timestamp")
+
+	stored, err := service.GetResult(queued.ID)
+	Ok(t, err)
+	Equals(t, models.RemediationStatusCancelled, stored.Status)
+
+	inFlight, err := service.GetResult(first.ID)
+	Ok(t, err)
+	Equals(t, models.RemediationStatusRunning, inFlight.Status)
+}
+
+func TestInMemoryRemediationService_CancelUnknownRemediation(t *testing.T) {
+	service := drift.NewInMemoryRemediationService(nil)
+
+	_, err := service.Cancel("missing")
+	Assert(t, errors.Is(err, drift.ErrRemediationNotFound), "expected not found error, got %v", err)
 }
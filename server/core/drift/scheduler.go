@@ -0,0 +1,121 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/scheduled"
+)
+
+// ScheduleCheckPeriod is how often scheduled detection jobs check whether
+// their cron schedule is due. Cron schedules have minute granularity so this
+// only needs to be shorter than a minute.
+const ScheduleCheckPeriod = 30 * time.Second
+
+// Detector runs drift detection, storing results in the drift storage and
+// sending drift webhooks the same way POST /api/drift/detect does.
+type Detector interface {
+	RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error)
+}
+
+// ScheduledDetectionJob is a scheduled.Job that runs drift detection for one
+// repository whenever its cron schedule fires. A run that is still in
+// progress when the schedule fires again causes that firing to be skipped.
+type ScheduledDetectionJob struct {
+	detector Detector
+	request  models.DriftDetectionRequest
+	schedule *scheduled.CronSchedule
+	jitter   time.Duration
+	log      logging.SimpleLogging
+
+	mu      sync.Mutex
+	next    time.Time
+	running atomic.Bool
+	wg      sync.WaitGroup
+	// now and randDuration are swapped out in tests.
+	now          func() time.Time
+	randDuration func(time.Duration) time.Duration
+}
+
+// NewScheduledDetectionJob returns a job that runs request on schedule with a
+// random delay of up to jitter added to each run.
+func NewScheduledDetectionJob(detector Detector, request models.DriftDetectionRequest, schedule *scheduled.CronSchedule, jitter time.Duration, log logging.SimpleLogging) *ScheduledDetectionJob {
+	return &ScheduledDetectionJob{
+		detector:     detector,
+		request:      request,
+		schedule:     schedule,
+		jitter:       jitter,
+		log:          log,
+		now:          time.Now,
+		randDuration: randDuration,
+	}
+}
+
+// Run starts a detection in the background if the schedule is due. It is
+// called every ScheduleCheckPeriod by the scheduled.ExecutorService.
+func (j *ScheduledDetectionJob) Run() {
+	now := j.now()
+
+	j.mu.Lock()
+	if j.next.IsZero() {
+		j.next = j.nextRun(now)
+	}
+	due := !j.next.IsZero() && !now.Before(j.next)
+	if due {
+		j.next = j.nextRun(now)
+	}
+	j.mu.Unlock()
+	if !due {
+		return
+	}
+
+	if !j.running.CompareAndSwap(false, true) {
+		j.log.Warn("skipping scheduled drift detection for %s at ref %s: previous run is still in progress", j.request.Repository, j.request.Ref)
+		return
+	}
+	j.wg.Go(func() {
+		defer j.running.Store(false)
+		defer func() {
+			if r := recover(); r != nil {
+				j.log.Err("recovered from panic in scheduled drift detection for %s: %v", j.request.Repository, r)
+			}
+		}()
+		j.detect()
+	})
+}
+
+// Wait blocks until any in-progress detection has finished.
+func (j *ScheduledDetectionJob) Wait() {
+	j.wg.Wait()
+}
+
+func (j *ScheduledDetectionJob) detect() {
+	j.log.Info("running scheduled drift detection for %s at ref %s", j.request.Repository, j.request.Ref)
+	result, err := j.detector.RunDriftDetection(j.request)
+	if err != nil {
+		j.log.Err("scheduled drift detection for %s failed: %s", j.request.Repository, err)
+		return
+	}
+	j.log.Info("scheduled drift detection for %s finished: %d of %d projects have drift", j.request.Repository, result.ProjectsWithDrift, result.TotalProjects)
+}
+
+// nextRun returns the next time the job should run after now, or the zero
+// time if the schedule never fires again.
+func (j *ScheduledDetectionJob) nextRun(now time.Time) time.Time {
+	next := j.schedule.Next(now)
+	if next.IsZero() || j.jitter <= 0 {
+		return next
+	}
+	return next.Add(j.randDuration(j.jitter))
+}
+
+func randDuration(maxDuration time.Duration) time.Duration {
+	return time.Duration(rand.Int64N(int64(maxDuration)))
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/scheduled"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+type fakeDetector struct {
+	mu       sync.Mutex
+	requests []models.DriftDetectionRequest
+	release  chan struct{}
+	err      error
+}
+
+func (f *fakeDetector) RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, request)
+	f.mu.Unlock()
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return models.NewDriftDetectionResult(request.Repository), nil
+}
+
+func (f *fakeDetector) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func newTestScheduledDetectionJob(t *testing.T, detector Detector, expr string, jitter time.Duration, now *time.Time) *ScheduledDetectionJob {
+	schedule, err := scheduled.ParseCronSchedule(expr)
+	Ok(t, err)
+	request := models.DriftDetectionRequest{Repository: "owner/repo", Ref: "main", Type: "Github"}
+	job := NewScheduledDetectionJob(detector, request, schedule, jitter, logging.NewNoopLogger(t))
+	job.now = func() time.Time { return *now }
+	return job
+}
+
+func TestScheduledDetectionJob_RunsWhenDue(t *testing.T) {
+	detector := &fakeDetector{}
+	now := time.Date(2025, time.January, 15, 10, 0, 10, 0, time.UTC)
+	job := newTestScheduledDetectionJob(t, detector, "0 * * * *", 0, &now)
+
+	job.Run()
+	job.Wait()
+	Equals(t, 0, detector.calls())
+
+	now = now.Add(30 * time.Minute)
+	job.Run()
+	job.Wait()
+	Equals(t, 0, detector.calls())
+
+	now = time.Date(2025, time.January, 15, 11, 0, 20, 0, time.UTC)
+	job.Run()
+	job.Wait()
+	Equals(t, 1, detector.calls())
+	Equals(t, "owner/repo", detector.requests[0].Repository)
+
+	// The same firing doesn't run twice.
+	now = now.Add(ScheduleCheckPeriod)
+	job.Run()
+	job.Wait()
+	Equals(t, 1, detector.calls())
+}
+
+func TestScheduledDetectionJob_AppliesJitter(t *testing.T) {
+	detector := &fakeDetector{}
+	now := time.Date(2025, time.January, 15, 10, 0, 10, 0, time.UTC)
+	job := newTestScheduledDetectionJob(t, detector, "0 * * * *", 10*time.Minute, &now)
+	job.randDuration = func(maxDuration time.Duration) time.Duration {
+		Equals(t, 10*time.Minute, maxDuration)
+		return 5 * time.Minute
+	}
+
+	job.Run()
+	now = time.Date(2025, time.January, 15, 11, 4, 0, 0, time.UTC)
+	job.Run()
+	job.Wait()
+	Equals(t, 0, detector.calls())
+
+	now = time.Date(2025, time.January, 15, 11, 5, 0, 0, time.UTC)
+	job.Run()
+	job.Wait()
+	Equals(t, 1, detector.calls())
+}
+
+func TestScheduledDetectionJob_SkipsOverlappingRuns(t *testing.T) {
+	detector := &fakeDetector{release: make(chan struct{})}
+	now := time.Date(2025, time.January, 15, 10, 0, 10, 0, time.UTC)
+	job := newTestScheduledDetectionJob(t, detector, "* * * * *", 0, &now)
+
+	job.Run()
+	now = now.Add(time.Minute)
+	job.Run()
+	now = now.Add(time.Minute)
+	job.Run()
+
+	close(detector.release)
+	job.Wait()
+	Equals(t, 1, detector.calls())
+
+	now = now.Add(time.Minute)
+	job.Run()
+	job.Wait()
+	Equals(t, 2, detector.calls())
+}
+
+func TestScheduledDetectionJob_ErrorDoesNotStopSchedule(t *testing.T) {
+	detector := &fakeDetector{err: errors.New("clone failed")}
+	now := time.Date(2025, time.January, 15, 10, 0, 10, 0, time.UTC)
+	job := newTestScheduledDetectionJob(t, detector, "* * * * *", 0, &now)
+
+	job.Run()
+	for range 2 {
+		now = now.Add(time.Minute)
+		job.Run()
+		job.Wait()
+	}
+	Equals(t, 2, detector.calls())
+}
@@ -4,7 +4,8 @@
 package drift
 
 import (
-	"fmt"
+	"encoding/json"
+	"errors"
 	"sync"
 	"time"
 
@@ -60,6 +61,9 @@ type GetOptions struct {
 	Exact bool
 }
 
+// ErrDeleteFilterRequired is returned by DeleteMatching when no filter is set.
+var ErrDeleteFilterRequired = errors.New("at least one drift delete filter is required")
+
 type driftCacheKey struct {
 	ProjectName string
 	Path        string
@@ -81,6 +85,17 @@ func driftKey(drift models.ProjectDrift) driftCacheKey {
 	}
 }
 
+// StorageKey returns a delimiter-safe string identifying the drift record
+// within its repository. Two records with the same StorageKey overwrite each
+// other, matching the in-memory storage semantics.
+func StorageKey(drift models.ProjectDrift) string {
+	// Encoding the fields as a JSON array keeps the key unambiguous even when
+	// project names, paths, workspaces or refs contain separator characters.
+	key := driftKey(drift)
+	serialized, _ := json.Marshal([]string{key.ProjectName, key.Path, key.Workspace, key.Ref, key.BaseBranch})
+	return string(serialized)
+}
+
 // InMemoryStorage is an in-memory implementation of drift Storage.
 // Drift results are lost on server restart.
 type InMemoryStorage struct {
@@ -124,7 +139,7 @@ func (s *InMemoryStorage) Get(repository string, opts GetOptions) ([]models.Proj
 	result := make([]models.ProjectDrift, 0)
 
 	for _, drift := range repoData {
-		if !matchesGetOptions(drift, opts, now) {
+		if !MatchesGetOptions(drift, opts, now) {
 			continue
 		}
 
@@ -134,7 +149,10 @@ func (s *InMemoryStorage) Get(repository string, opts GetOptions) ([]models.Proj
 	return result, nil
 }
 
-func matchesGetOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
+// MatchesGetOptions reports whether drift satisfies the filters in opts.
+// Empty filter fields act as wildcards. Persistent Storage implementations
+// use it so filtering behaves identically across backends.
+func MatchesGetOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
 	if opts.ProjectName != "" && drift.ProjectName != opts.ProjectName {
 		return false
 	}
@@ -188,7 +206,7 @@ func (s *InMemoryStorage) DeleteMatching(repository string, opts GetOptions) err
 	defer s.mu.Unlock()
 
 	if opts == (GetOptions{}) {
-		return fmt.Errorf("at least one drift delete filter is required")
+		return ErrDeleteFilterRequired
 	}
 
 	repoData, ok := s.data[repository]
@@ -197,7 +215,7 @@ func (s *InMemoryStorage) DeleteMatching(repository string, opts GetOptions) err
 	}
 
 	for key, drift := range repoData {
-		if matchesDeleteOptions(drift, opts, time.Now()) {
+		if MatchesDeleteOptions(drift, opts, time.Now()) {
 			delete(repoData, key)
 		}
 	}
@@ -205,9 +223,12 @@ func (s *InMemoryStorage) DeleteMatching(repository string, opts GetOptions) err
 	return nil
 }
 
-func matchesDeleteOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
+// MatchesDeleteOptions reports whether drift should be removed by
+// DeleteMatching. When opts.Exact is set, empty identity fields must match
+// exactly instead of acting as wildcards.
+func MatchesDeleteOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
 	if !opts.Exact {
-		return matchesGetOptions(drift, opts, now)
+		return MatchesGetOptions(drift, opts, now)
 	}
 	if drift.ProjectName != opts.ProjectName {
 		return false
@@ -0,0 +1,79 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package locking
+
+import (
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+//go:generate go tool mockgen -package mocks -destination mocks/mock_lock_queue.go . LockQueue
+
+// LockQueue lets pull requests that find a project locked by another pull
+// request wait for the lock instead of failing.
+type LockQueue interface {
+	// Enqueue adds entry's pull request to the queue for its project and
+	// workspace and returns its 1-based position. Queueing a pull request
+	// that's already queued keeps its position.
+	Enqueue(entry models.LockQueueEntry) (int, error)
+	// Queue returns the pull requests waiting for the lock at key, in order.
+	Queue(key string) ([]models.LockQueueEntry, error)
+	// LockNext gives the lock on project and workspace to the first pull
+	// request queued for it and removes it from the queue. It returns nil if
+	// nothing is queued or the lock is held.
+	LockNext(project models.Project, workspace string) (*models.LockQueueEntry, error)
+	// RemovePull removes pullNum from every queue in the repo, e.g. because
+	// it was closed.
+	RemovePull(repoFullName string, pullNum int) error
+}
+
+// Enqueue implements LockQueue.Enqueue.
+func (c *Client) Enqueue(entry models.LockQueueEntry) (int, error) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().Local()
+	}
+	return c.database.EnqueueLock(entry)
+}
+
+// Queue implements LockQueue.Queue.
+func (c *Client) Queue(key string) ([]models.LockQueueEntry, error) {
+	project, workspace, err := c.lockKeyToProjectWorkspace(key)
+	if err != nil {
+		return nil, err
+	}
+	return c.database.GetLockQueue(project, workspace)
+}
+
+// LockNext implements LockQueue.LockNext.
+func (c *Client) LockNext(project models.Project, workspace string) (*models.LockQueueEntry, error) {
+	queue, err := c.database.GetLockQueue(project, workspace)
+	if err != nil || len(queue) == 0 {
+		return nil, err
+	}
+	next := queue[0]
+	resp, err := c.TryLock(next.Project, next.Workspace, next.Pull, next.User)
+	if err != nil {
+		return nil, err
+	}
+	// If the lock was taken before the queue was checked, the next pull
+	// request gets its turn when that lock is released.
+	if !resp.LockAcquired && resp.CurrLock.Pull.Num != next.Pull.Num {
+		return nil, nil
+	}
+	if err := c.database.DequeueLock(project, workspace, next.Pull.Num); err != nil {
+		return nil, err
+	}
+	if !resp.LockAcquired {
+		// The pull request already holds the lock, so it doesn't need to be
+		// planned again.
+		return nil, nil
+	}
+	return &next, nil
+}
+
+// RemovePull implements LockQueue.RemovePull.
+func (c *Client) RemovePull(repoFullName string, pullNum int) error {
+	return c.database.DequeuePull(repoFullName, pullNum)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package locking_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/db/mocks"
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func queuedPull(num int) models.LockQueueEntry {
+	return models.LockQueueEntry{Project: project, Workspace: workspace, Pull: models.PullRequest{Num: num}}
+}
+
+func TestEnqueue_SetsTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	database.EXPECT().EnqueueLock(gomock.Cond(func(e models.LockQueueEntry) bool { return !e.Time.IsZero() })).Return(2, nil)
+	l := locking.NewClient(database)
+
+	position, err := l.Enqueue(queuedPull(2))
+	Ok(t, err)
+	Equals(t, 2, position)
+}
+
+func TestQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	database.EXPECT().GetLockQueue(project, workspace).Return([]models.LockQueueEntry{queuedPull(2)}, nil)
+	l := locking.NewClient(database)
+
+	queue, err := l.Queue("owner/repo/path/workspace/projectName")
+	Ok(t, err)
+	Equals(t, []models.LockQueueEntry{queuedPull(2)}, queue)
+
+	_, err = l.Queue("invalidkey")
+	ErrContains(t, "invalid key format", err)
+}
+
+func TestLockNext(t *testing.T) {
+	cases := []struct {
+		description string
+		queue       []models.LockQueueEntry
+		acquired    bool
+		currLock    models.ProjectLock
+		expDequeue  bool
+		expNext     *models.LockQueueEntry
+	}{
+		{
+			description: "empty queue",
+		},
+		{
+			description: "lock acquired",
+			queue:       []models.LockQueueEntry{queuedPull(2), queuedPull(3)},
+			acquired:    true,
+			expDequeue:  true,
+			expNext:     &models.LockQueueEntry{Project: project, Workspace: workspace, Pull: models.PullRequest{Num: 2}},
+		},
+		{
+			description: "lock taken by another pull request",
+			queue:       []models.LockQueueEntry{queuedPull(2)},
+			currLock:    models.ProjectLock{Pull: models.PullRequest{Num: 4}},
+		},
+		{
+			description: "lock already held by the queued pull request",
+			queue:       []models.LockQueueEntry{queuedPull(2)},
+			currLock:    models.ProjectLock{Pull: models.PullRequest{Num: 2}},
+			expDequeue:  true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			database := mocks.NewMockDatabase(ctrl)
+			database.EXPECT().GetLockQueue(project, workspace).Return(c.queue, nil)
+			if len(c.queue) > 0 {
+				database.EXPECT().TryLock(gomock.Any()).Return(c.acquired, c.currLock, nil)
+			}
+			if c.expDequeue {
+				database.EXPECT().DequeueLock(project, workspace, 2).Return(nil)
+			}
+			l := locking.NewClient(database)
+
+			next, err := l.LockNext(project, workspace)
+			Ok(t, err)
+			Equals(t, c.expNext, next)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/core/locking (interfaces: LockQueue)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_lock_queue.go . LockQueue
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/runatlantis/atlantis/server/events/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLockQueue is a mock of LockQueue interface.
+type MockLockQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockLockQueueMockRecorder is the mock recorder for MockLockQueue.
+type MockLockQueueMockRecorder struct {
+	mock *MockLockQueue
+}
+
+// NewMockLockQueue creates a new mock instance.
+func NewMockLockQueue(ctrl *gomock.Controller) *MockLockQueue {
+	mock := &MockLockQueue{ctrl: ctrl}
+	mock.recorder = &MockLockQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockQueue) EXPECT() *MockLockQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockLockQueue) Enqueue(entry models.LockQueueEntry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockLockQueueMockRecorder) Enqueue(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockLockQueue)(nil).Enqueue), entry)
+}
+
+// LockNext mocks base method.
+func (m *MockLockQueue) LockNext(project models.Project, workspace string) (*models.LockQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockNext", project, workspace)
+	ret0, _ := ret[0].(*models.LockQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockNext indicates an expected call of LockNext.
+func (mr *MockLockQueueMockRecorder) LockNext(project, workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockNext", reflect.TypeOf((*MockLockQueue)(nil).LockNext), project, workspace)
+}
+
+// Queue mocks base method.
+func (m *MockLockQueue) Queue(key string) ([]models.LockQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Queue", key)
+	ret0, _ := ret[0].([]models.LockQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Queue indicates an expected call of Queue.
+func (mr *MockLockQueueMockRecorder) Queue(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Queue", reflect.TypeOf((*MockLockQueue)(nil).Queue), key)
+}
+
+// RemovePull mocks base method.
+func (m *MockLockQueue) RemovePull(repoFullName string, pullNum int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePull", repoFullName, pullNum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePull indicates an expected call of RemovePull.
+func (mr *MockLockQueueMockRecorder) RemovePull(repoFullName, pullNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePull", reflect.TypeOf((*MockLockQueue)(nil).RemovePull), repoFullName, pullNum)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// AzureStorageConnectionStringEnvVar is the environment variable holding a
+// storage account connection string. When set it takes precedence over
+// Azure AD credentials, which is how Azurite and shared-key accounts are used.
+const AzureStorageConnectionStringEnvVar = "AZURE_STORAGE_CONNECTION_STRING" // nolint: gosec
+
+// AzureBlobPlanStoreConfig holds configuration for connecting to Azure Blob
+// Storage.
+type AzureBlobPlanStoreConfig struct {
+	AccountName string
+	Container   string
+	Prefix      string
+	// Endpoint overrides the blob service URL, which defaults to
+	// https://<account>.blob.core.windows.net/.
+	Endpoint string
+}
+
+// AzureBlobPlanStore implements PlanStore by persisting plan files to an
+// Azure Blob Storage container using the same key layout as S3PlanStore.
+type AzureBlobPlanStore struct {
+	objectPlanStore
+}
+
+// NewAzureBlobPlanStore creates an AzureBlobPlanStore and checks that the
+// container is reachable. It authenticates with the connection string in
+// AZURE_STORAGE_CONNECTION_STRING if set, otherwise with the Azure SDK
+// default credential chain.
+func NewAzureBlobPlanStore(cfg AzureBlobPlanStoreConfig, logger logging.SimpleLogging) (*AzureBlobPlanStore, error) {
+	var client *azblob.Client
+	if connStr := os.Getenv(AzureStorageConnectionStringEnvVar); connStr != "" {
+		var err error
+		client, err = azblob.NewClientFromConnectionString(connStr, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating Azure Blob client from %s: %w", AzureStorageConnectionStringEnvVar, err)
+		}
+	} else {
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("loading Azure credentials: %w", err)
+		}
+		serviceURL := cfg.Endpoint
+		if serviceURL == "" {
+			serviceURL = fmt.Sprintf("https://%s.blob.core.windows.net/", cfg.AccountName)
+		}
+		client, err = azblob.NewClient(serviceURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating Azure Blob client: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.ServiceClient().NewContainerClient(cfg.Container).GetProperties(ctx, nil); err != nil {
+		return nil, fmt.Errorf("validating Azure Blob plan store container %q: %w", cfg.Container, err)
+	}
+
+	location := fmt.Sprintf("azblob://%s/%s", cfg.AccountName, cfg.Container)
+	return &AzureBlobPlanStore{
+		objectPlanStore: newObjectPlanStore(&azureBlobObjectClient{client: client, container: cfg.Container}, "Azure Blob", location, cfg.Prefix, logger),
+	}, nil
+}
+
+// NewAzureBlobPlanStoreWithClient creates an AzureBlobPlanStore with an
+// injected ObjectClient (for testing).
+func NewAzureBlobPlanStoreWithClient(client ObjectClient, accountName, container, prefix string, logger logging.SimpleLogging) *AzureBlobPlanStore {
+	location := fmt.Sprintf("azblob://%s/%s", accountName, container)
+	return &AzureBlobPlanStore{
+		objectPlanStore: newObjectPlanStore(client, "Azure Blob", location, prefix, logger),
+	}
+}
+
+// azureBlobObjectClient adapts an Azure Blob container to ObjectClient.
+type azureBlobObjectClient struct {
+	client    *azblob.Client
+	container string
+}
+
+func (c *azureBlobObjectClient) Upload(ctx context.Context, key string, body io.Reader, metadata map[string]string) error {
+	blobMetadata := make(map[string]*string, len(metadata))
+	for k, v := range metadata {
+		blobMetadata[azureMetadataKey(k)] = &v
+	}
+	_, err := c.client.UploadStream(ctx, c.container, key, body, &azblob.UploadStreamOptions{
+		Metadata: blobMetadata,
+	})
+	return err
+}
+
+func (c *azureBlobObjectClient) Download(ctx context.Context, key string) (io.ReadCloser, map[string]string, error) {
+	resp, err := c.client.DownloadStream(ctx, c.container, key, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := make(map[string]string, len(resp.Metadata))
+	for k, v := range resp.Metadata {
+		if v != nil {
+			metadata[strings.ReplaceAll(k, "_", "-")] = *v
+		}
+	}
+	return resp.Body, metadata, nil
+}
+
+func (c *azureBlobObjectClient) Delete(ctx context.Context, key string) error {
+	_, err := c.client.DeleteBlob(ctx, c.container, key, nil)
+	return err
+}
+
+func (c *azureBlobObjectClient) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	pager := c.client.NewListBlobsFlatPager(c.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name != nil {
+				keys = append(keys, *item.Name)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// azureMetadataKey converts a metadata key to a valid Azure metadata name.
+// Azure requires names to be C# identifiers, so "head-commit" is stored as
+// "head_commit" and converted back on download.
+func azureMetadataKey(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
+
+// Ensure AzureBlobPlanStore satisfies PlanStore at compile time.
+var _ PlanStore = (*AzureBlobPlanStore)(nil)
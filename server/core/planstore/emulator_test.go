@@ -0,0 +1,66 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests run the plan store against local emulators and are skipped
+// unless one is configured:
+//
+//	docker run -d -p 4443:4443 fsouza/fake-gcs-server -scheme http
+//	STORAGE_EMULATOR_HOST=localhost:4443 go test ./server/core/planstore/
+//
+//	docker run -d -p 10000:10000 mcr.microsoft.com/azure-storage/azurite azurite-blob --blobHost 0.0.0.0
+//	ATLANTIS_TEST_AZURITE_CONNECTION_STRING='DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;' go test ./server/core/planstore/
+
+func TestGCSPlanStore_Emulator(t *testing.T) {
+	if os.Getenv("STORAGE_EMULATOR_HOST") == "" {
+		t.Skip("STORAGE_EMULATOR_HOST is not set")
+	}
+	bucket := fmt.Sprintf("atlantis-test-%d", time.Now().UnixNano())
+	client, err := storage.NewClient(context.Background())
+	require.NoError(t, err)
+	defer client.Close() // nolint: errcheck
+	require.NoError(t, client.Bucket(bucket).Create(context.Background(), "atlantis-test", nil))
+
+	store, err := planstore.NewGCSPlanStore(planstore.GCSPlanStoreConfig{
+		Bucket: bucket,
+		Prefix: "plans",
+	}, logging.NewNoopLogger(t))
+	require.NoError(t, err)
+	testObjectPlanStore(t, store)
+}
+
+func TestAzureBlobPlanStore_Emulator(t *testing.T) {
+	connStr := os.Getenv("ATLANTIS_TEST_AZURITE_CONNECTION_STRING")
+	if connStr == "" {
+		t.Skip("ATLANTIS_TEST_AZURITE_CONNECTION_STRING is not set")
+	}
+	t.Setenv(planstore.AzureStorageConnectionStringEnvVar, connStr)
+	container := fmt.Sprintf("atlantis-test-%d", time.Now().UnixNano())
+	client, err := azblob.NewClientFromConnectionString(connStr, nil)
+	require.NoError(t, err)
+	_, err = client.CreateContainer(context.Background(), container, nil)
+	require.NoError(t, err)
+
+	store, err := planstore.NewAzureBlobPlanStore(planstore.AzureBlobPlanStoreConfig{
+		AccountName: "devstoreaccount1",
+		Container:   container,
+		Prefix:      "plans",
+	}, logging.NewNoopLogger(t))
+	require.NoError(t, err)
+	testObjectPlanStore(t, store)
+}
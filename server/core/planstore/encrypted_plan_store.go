@@ -0,0 +1,161 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+)
+
+// EncryptedPlanStore wraps another PlanStore and encrypts plan files before
+// they leave the host. Each plan is encrypted with AES-256-GCM under its own
+// data key, which is itself wrapped by a KeyManager (envelope encryption).
+// Local plan files stay in plaintext since terraform reads them directly.
+type EncryptedPlanStore struct {
+	inner PlanStore
+	keys  KeyManager
+}
+
+// NewEncryptedPlanStore returns a PlanStore that encrypts plans stored in inner.
+func NewEncryptedPlanStore(inner PlanStore, keys KeyManager) *EncryptedPlanStore {
+	return &EncryptedPlanStore{inner: inner, keys: keys}
+}
+
+// Save encrypts the plan at planPath and saves the ciphertext to the inner
+// store under the same key.
+func (s *EncryptedPlanStore) Save(ctx command.ProjectContext, planPath string) error {
+	plaintext, err := os.ReadFile(planPath) // nolint: gosec
+	if err != nil {
+		return fmt.Errorf("reading plan file for encryption: %w", err)
+	}
+	ciphertext, err := encryptPlan(context.Background(), s.keys, plaintext, planAAD(ctx, planPath))
+	if err != nil {
+		return fmt.Errorf("encrypting plan: %w", err)
+	}
+
+	// The inner store derives the object key from the file name, so stage the
+	// ciphertext under the same name in a private directory.
+	return s.withStagingPath(planPath, func(stagingPath string) error {
+		if err := os.WriteFile(stagingPath, ciphertext, 0o600); err != nil {
+			return fmt.Errorf("staging encrypted plan: %w", err)
+		}
+		return s.inner.Save(ctx, stagingPath)
+	})
+}
+
+// Load fetches the encrypted plan from the inner store and writes the
+// decrypted plan to planPath. Plans that fail authentication are rejected.
+func (s *EncryptedPlanStore) Load(ctx command.ProjectContext, planPath string) error {
+	return s.withStagingPath(planPath, func(stagingPath string) error {
+		if err := s.inner.Load(ctx, stagingPath); err != nil {
+			return err
+		}
+		ciphertext, err := os.ReadFile(stagingPath) // nolint: gosec
+		if err != nil {
+			return fmt.Errorf("reading encrypted plan: %w", err)
+		}
+		plaintext, err := decryptPlan(context.Background(), s.keys, ciphertext, planAAD(ctx, planPath))
+		if err != nil {
+			return fmt.Errorf("decrypting plan for %s: %w", planPath, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(planPath), 0o700); err != nil {
+			return fmt.Errorf("creating parent directories for plan file: %w", err)
+		}
+		return os.WriteFile(planPath, plaintext, 0o600)
+	})
+}
+
+// Remove deletes the plan from the inner store and locally.
+func (s *EncryptedPlanStore) Remove(ctx command.ProjectContext, planPath string) error {
+	return s.inner.Remove(ctx, planPath)
+}
+
+// ListWorkspaces returns the workspaces with stored plans.
+func (s *EncryptedPlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string, error) {
+	return s.inner.ListWorkspaces(owner, repo, pullNum)
+}
+
+// RestorePlans restores the pull request's plans into a private directory and
+// decrypts each of them into pullDir.
+func (s *EncryptedPlanStore) RestorePlans(pullDir, owner, repo string, pullNum int) error {
+	if pullDir == "" {
+		return s.inner.RestorePlans(pullDir, owner, repo, pullNum)
+	}
+	stagingDir, err := os.MkdirTemp("", "atlantis-plans-")
+	if err != nil {
+		return fmt.Errorf("creating plan staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir) // nolint: errcheck
+
+	if err := s.inner.RestorePlans(stagingDir, owner, repo, pullNum); err != nil {
+		return err
+	}
+	pullKey := joinObjectKey("", owner, repo, strconv.Itoa(pullNum))
+	return filepath.WalkDir(stagingDir, func(stagedPath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(stagingDir, stagedPath)
+		if err != nil {
+			return err
+		}
+		ciphertext, err := os.ReadFile(stagedPath) // nolint: gosec
+		if err != nil {
+			return fmt.Errorf("reading restored plan: %w", err)
+		}
+		aad := []byte(path.Clean(pullKey + "/" + filepath.ToSlash(rel)))
+		plaintext, err := decryptPlan(context.Background(), s.keys, ciphertext, aad)
+		if err != nil {
+			return fmt.Errorf("decrypting restored plan %s: %w", rel, err)
+		}
+		localPath, err := securejoin.SecureJoin(pullDir, rel)
+		if err != nil {
+			return fmt.Errorf("resolving safe path for restored plan %s: %w", rel, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o700); err != nil {
+			return fmt.Errorf("creating directory for restored plan: %w", err)
+		}
+		return os.WriteFile(localPath, plaintext, 0o600)
+	})
+}
+
+// DeleteForPull removes all stored plans for a pull request.
+func (s *EncryptedPlanStore) DeleteForPull(owner, repo string, pullNum int) error {
+	return s.inner.DeleteForPull(owner, repo, pullNum)
+}
+
+// DeletePlanForProject removes a single project's stored plan.
+func (s *EncryptedPlanStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, repoRelDir, projectName string) error {
+	return s.inner.DeletePlanForProject(owner, repo, pullNum, workspace, repoRelDir, projectName)
+}
+
+// withStagingPath calls fn with a path in a new private directory that has
+// the same file name as planPath, and removes the directory afterwards.
+func (s *EncryptedPlanStore) withStagingPath(planPath string, fn func(stagingPath string) error) error {
+	stagingDir, err := os.MkdirTemp("", "atlantis-plan-")
+	if err != nil {
+		return fmt.Errorf("creating plan staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir) // nolint: errcheck
+	return fn(filepath.Join(stagingDir, filepath.Base(planPath)))
+}
+
+// planAAD is the additional authenticated data for a plan: its object key
+// without the store prefix, so a plan only decrypts at the location it was
+// saved to.
+func planAAD(ctx command.ProjectContext, planPath string) []byte {
+	return []byte(path.Clean(planObjectKey("", ctx, planPath)))
+}
+
+// Ensure EncryptedPlanStore satisfies PlanStore at compile time.
+var _ PlanStore = (*EncryptedPlanStore)(nil)
@@ -0,0 +1,181 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEncryptionKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func newTestEncryptedStore(t *testing.T, client planstore.ObjectClient, key []byte) *planstore.EncryptedPlanStore {
+	t.Helper()
+	keys, err := planstore.NewLocalKeyManager(key)
+	require.NoError(t, err)
+	inner := planstore.NewGCSPlanStoreWithClient(client, "bucket", "plans", logging.NewNoopLogger(t))
+	return planstore.NewEncryptedPlanStore(inner, keys)
+}
+
+func writeTestPlan(t *testing.T, ctx command.ProjectContext, content string) string {
+	t.Helper()
+	planPath := filepath.Join(t.TempDir(), ctx.Workspace, ctx.RepoRelDir, "default.tfplan")
+	require.NoError(t, os.MkdirAll(filepath.Dir(planPath), 0o700))
+	require.NoError(t, os.WriteFile(planPath, []byte(content), 0o600))
+	return planPath
+}
+
+func encryptedTestContext() command.ProjectContext {
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+	return ctx
+}
+
+func TestEncryptedPlanStore_RoundTrip(t *testing.T) {
+	client := newFakeObjectClient()
+	store := newTestEncryptedStore(t, client, testEncryptionKey(1))
+	ctx := encryptedTestContext()
+	planPath := writeTestPlan(t, ctx, "secret plan")
+
+	require.NoError(t, store.Save(ctx, planPath))
+
+	// The local plan is untouched and the stored object is ciphertext.
+	content, err := os.ReadFile(planPath)
+	require.NoError(t, err)
+	assert.Equal(t, "secret plan", string(content))
+	require.Len(t, client.keys(), 1)
+	key := client.keys()[0]
+	assert.Equal(t, "plans/acme/infra/42/default/modules/vpc/default.tfplan", key)
+	assert.NotContains(t, string(client.objects[key].body), "secret plan")
+	assert.Equal(t, "abc123", client.objects[key].metadata["head-commit"])
+
+	loadPath := filepath.Join(t.TempDir(), "default.tfplan")
+	require.NoError(t, store.Load(ctx, loadPath))
+	content, err = os.ReadFile(loadPath)
+	require.NoError(t, err)
+	assert.Equal(t, "secret plan", string(content))
+}
+
+func TestEncryptedPlanStore_RejectsTamperedPlan(t *testing.T) {
+	client := newFakeObjectClient()
+	store := newTestEncryptedStore(t, client, testEncryptionKey(1))
+	ctx := encryptedTestContext()
+	require.NoError(t, store.Save(ctx, writeTestPlan(t, ctx, "secret plan")))
+
+	key := client.keys()[0]
+	obj := client.objects[key]
+	obj.body[len(obj.body)-1] ^= 0xff
+	client.objects[key] = obj
+
+	loadPath := filepath.Join(t.TempDir(), "default.tfplan")
+	err := store.Load(ctx, loadPath)
+	require.ErrorIs(t, err, planstore.ErrPlanDecryption)
+	_, statErr := os.Stat(loadPath)
+	assert.True(t, os.IsNotExist(statErr), "tampered plan must not be written")
+}
+
+func TestEncryptedPlanStore_RejectsWrongKey(t *testing.T) {
+	client := newFakeObjectClient()
+	ctx := encryptedTestContext()
+	require.NoError(t, newTestEncryptedStore(t, client, testEncryptionKey(1)).Save(ctx, writeTestPlan(t, ctx, "secret plan")))
+
+	err := newTestEncryptedStore(t, client, testEncryptionKey(2)).Load(ctx, filepath.Join(t.TempDir(), "default.tfplan"))
+	require.ErrorIs(t, err, planstore.ErrPlanDecryption)
+}
+
+func TestEncryptedPlanStore_RejectsPlanMovedToAnotherProject(t *testing.T) {
+	client := newFakeObjectClient()
+	store := newTestEncryptedStore(t, client, testEncryptionKey(1))
+	ctx := encryptedTestContext()
+	require.NoError(t, store.Save(ctx, writeTestPlan(t, ctx, "secret plan")))
+
+	// Copy the ciphertext to where another directory's plan would live.
+	obj := client.objects[client.keys()[0]]
+	client.objects["plans/acme/infra/42/default/modules/db/default.tfplan"] = obj
+	otherCtx := ctx
+	otherCtx.RepoRelDir = "modules/db"
+
+	err := store.Load(otherCtx, filepath.Join(t.TempDir(), "default.tfplan"))
+	require.ErrorIs(t, err, planstore.ErrPlanDecryption)
+}
+
+func TestEncryptedPlanStore_RejectsUnencryptedPlan(t *testing.T) {
+	client := newFakeObjectClient()
+	ctx := encryptedTestContext()
+	inner := planstore.NewGCSPlanStoreWithClient(client, "bucket", "plans", logging.NewNoopLogger(t))
+	require.NoError(t, inner.Save(ctx, writeTestPlan(t, ctx, "plain plan")))
+
+	err := newTestEncryptedStore(t, client, testEncryptionKey(1)).Load(ctx, filepath.Join(t.TempDir(), "default.tfplan"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plan is not encrypted")
+}
+
+func TestEncryptedPlanStore_RestorePlans(t *testing.T) {
+	client := newFakeObjectClient()
+	store := newTestEncryptedStore(t, client, testEncryptionKey(1))
+	ctx := encryptedTestContext()
+	ctx.RepoRelDir = "."
+	require.NoError(t, store.Save(ctx, writeTestPlan(t, ctx, "root plan")))
+
+	require.NoError(t, store.RestorePlans("", "", "", 0))
+	pullDir := t.TempDir()
+	require.NoError(t, store.RestorePlans(pullDir, "acme", "infra", 42))
+	content, err := os.ReadFile(filepath.Join(pullDir, "default", "default.tfplan"))
+	require.NoError(t, err)
+	assert.Equal(t, "root plan", string(content))
+
+	key := client.keys()[0]
+	obj := client.objects[key]
+	obj.body[len(obj.body)-1] ^= 0xff
+	client.objects[key] = obj
+	err = store.RestorePlans(t.TempDir(), "acme", "infra", 42)
+	require.ErrorIs(t, err, planstore.ErrPlanDecryption)
+}
+
+func TestEncryptedPlanStore_RestoreNotSupported(t *testing.T) {
+	keys, err := planstore.NewLocalKeyManager(testEncryptionKey(1))
+	require.NoError(t, err)
+	store := planstore.NewEncryptedPlanStore(&planstore.LocalPlanStore{}, keys)
+	require.ErrorIs(t, store.RestorePlans("", "", "", 0), planstore.ErrRestoreNotSupported)
+}
+
+func TestLoadKey(t *testing.T) {
+	key := testEncryptionKey(7)
+	dir := t.TempDir()
+
+	rawPath := filepath.Join(dir, "raw")
+	require.NoError(t, os.WriteFile(rawPath, key, 0o600))
+	got, err := planstore.LoadKeyFromFile(rawPath)
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	b64Path := filepath.Join(dir, "b64")
+	require.NoError(t, os.WriteFile(b64Path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+	got, err = planstore.LoadKeyFromFile(b64Path)
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	t.Setenv("ATLANTIS_TEST_PLAN_KEY", base64.StdEncoding.EncodeToString(key))
+	got, err = planstore.LoadKeyFromEnv("ATLANTIS_TEST_PLAN_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	t.Setenv("ATLANTIS_TEST_PLAN_KEY", base64.StdEncoding.EncodeToString(key[:16]))
+	_, err = planstore.LoadKeyFromEnv("ATLANTIS_TEST_PLAN_KEY")
+	require.EqualError(t, err, "plan encryption key must be 32 bytes, got 16")
+
+	_, err = planstore.LoadKeyFromEnv("ATLANTIS_TEST_PLAN_KEY_UNSET")
+	require.EqualError(t, err, "plan encryption key environment variable ATLANTIS_TEST_PLAN_KEY_UNSET is not set")
+}
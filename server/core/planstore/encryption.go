@@ -0,0 +1,192 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeyManager wraps and unwraps the per-plan data keys used by
+// EncryptedPlanStore. It matches the encrypt/decrypt shape of cloud KMS
+// services so a KMS key can be used in place of LocalKeyManager.
+type KeyManager interface {
+	// WrapKey encrypts a data key.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key returned by WrapKey.
+	UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+// encryptionKeySize is the size of AES-256 keys, used both for data keys and
+// for LocalKeyManager's key-encryption key.
+const encryptionKeySize = 32
+
+// ErrPlanDecryption is returned when an encrypted plan fails authentication,
+// i.e. it was modified, truncated, or encrypted with a different key.
+var ErrPlanDecryption = errors.New("plan failed decryption: authentication tag mismatch")
+
+// LocalKeyManager wraps data keys with AES-256-GCM using a key held in memory.
+type LocalKeyManager struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKeyManager returns a KeyManager using key, which must be 32 bytes.
+func NewLocalKeyManager(key []byte) (*LocalKeyManager, error) {
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("plan encryption key must be %d bytes, got %d", encryptionKeySize, len(key))
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKeyManager{aead: aead}, nil
+}
+
+// LoadKeyFromFile reads a 32-byte key from path. The file may contain the raw
+// key or its base64 encoding.
+func LoadKeyFromFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("reading plan encryption key file: %w", err)
+	}
+	return decodeKey(content)
+}
+
+// LoadKeyFromEnv reads a base64-encoded 32-byte key from the environment
+// variable name.
+func LoadKeyFromEnv(name string) ([]byte, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil, fmt.Errorf("plan encryption key environment variable %s is not set", name)
+	}
+	return decodeKey([]byte(value))
+}
+
+func decodeKey(content []byte) ([]byte, error) {
+	if len(content) == encryptionKeySize {
+		return content, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("plan encryption key must be %d raw bytes or base64 encoded: %w", encryptionKeySize, err)
+	}
+	if len(decoded) != encryptionKeySize {
+		return nil, fmt.Errorf("plan encryption key must be %d bytes, got %d", encryptionKeySize, len(decoded))
+	}
+	return decoded, nil
+}
+
+// WrapKey encrypts dataKey with the local key.
+func (m *LocalKeyManager) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return seal(m.aead, dataKey, nil)
+}
+
+// UnwrapKey decrypts a key returned by WrapKey.
+func (m *LocalKeyManager) UnwrapKey(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	return open(m.aead, wrappedKey, nil)
+}
+
+// encryptedPlanMagic prefixes every encrypted plan so unencrypted plans are
+// detected instead of failing with a confusing authentication error.
+var encryptedPlanMagic = []byte("ATLANTIS-PLAN-AESGCM-1\n")
+
+// encryptPlan encrypts plaintext with a new data key. aad binds the ciphertext
+// to the plan's location so it can't be swapped for another project's plan.
+//
+// Format: magic | uint32 wrapped key length | wrapped key | nonce | ciphertext.
+func encryptPlan(ctx context.Context, keys KeyManager, plaintext, aad []byte) ([]byte, error) {
+	dataKey := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("generating data key: %w", err)
+	}
+	wrappedKey, err := keys.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping data key: %w", err)
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := seal(aead, plaintext, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(encryptedPlanMagic) + 4 + len(wrappedKey) + len(sealed))
+	buf.Write(encryptedPlanMagic)
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(wrappedKey)))) // nolint: gosec
+	buf.Write(wrappedKey)
+	buf.Write(sealed)
+	return buf.Bytes(), nil
+}
+
+// decryptPlan reverses encryptPlan. It returns ErrPlanDecryption if the plan
+// or its data key fails authentication.
+func decryptPlan(ctx context.Context, keys KeyManager, ciphertext, aad []byte) ([]byte, error) {
+	rest, ok := bytes.CutPrefix(ciphertext, encryptedPlanMagic)
+	if !ok {
+		return nil, errors.New("plan is not encrypted — run plan again")
+	}
+	if len(rest) < 4 {
+		return nil, ErrPlanDecryption
+	}
+	keyLen := binary.BigEndian.Uint32(rest)
+	rest = rest[4:]
+	if uint64(keyLen) > uint64(len(rest)) {
+		return nil, ErrPlanDecryption
+	}
+	dataKey, err := keys.UnwrapKey(ctx, rest[:keyLen])
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, rest[keyLen:], aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating AES cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM cipher: %w", err)
+	}
+	return aead, nil
+}
+
+// seal encrypts plaintext with a random nonce and prepends the nonce.
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts the output of seal.
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrPlanDecryption
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrPlanDecryption
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// GCSPlanStoreConfig holds configuration for connecting to Google Cloud Storage.
+type GCSPlanStoreConfig struct {
+	Bucket string
+	Prefix string
+	// Endpoint overrides the JSON API endpoint, e.g. for Private Service
+	// Connect. Emulators such as fake-gcs-server are configured with the
+	// STORAGE_EMULATOR_HOST environment variable instead.
+	Endpoint string
+	// CredentialsFile is a service account key file. If empty, Application
+	// Default Credentials are used.
+	CredentialsFile string
+}
+
+// GCSPlanStore implements PlanStore by persisting plan files to a GCS bucket
+// using the same key layout as S3PlanStore.
+type GCSPlanStore struct {
+	objectPlanStore
+}
+
+// NewGCSPlanStore creates a GCSPlanStore and checks that the bucket is
+// reachable.
+func NewGCSPlanStore(cfg GCSPlanStoreConfig, logger logging.SimpleLogging) (*GCSPlanStore, error) {
+	var opts []option.ClientOption
+	if cfg.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, option.WithAuthCredentialsFile(option.ServiceAccount, cfg.CredentialsFile))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCS client: %w", err)
+	}
+	bucket := client.Bucket(cfg.Bucket)
+	if _, err := bucket.Attrs(ctx); err != nil {
+		client.Close() // nolint: errcheck
+		return nil, fmt.Errorf("validating GCS plan store bucket %q: %w", cfg.Bucket, err)
+	}
+
+	return NewGCSPlanStoreWithClient(&gcsObjectClient{bucket: bucket}, cfg.Bucket, cfg.Prefix, logger), nil
+}
+
+// NewGCSPlanStoreWithClient creates a GCSPlanStore with an injected
+// ObjectClient (for testing).
+func NewGCSPlanStoreWithClient(client ObjectClient, bucket, prefix string, logger logging.SimpleLogging) *GCSPlanStore {
+	return &GCSPlanStore{
+		objectPlanStore: newObjectPlanStore(client, "GCS", "gs://"+bucket, prefix, logger),
+	}
+}
+
+// gcsObjectClient adapts a GCS bucket handle to ObjectClient.
+type gcsObjectClient struct {
+	bucket *storage.BucketHandle
+}
+
+func (c *gcsObjectClient) Upload(ctx context.Context, key string, body io.Reader, metadata map[string]string) error {
+	// Cancelling the writer's context is the only way to abort an upload;
+	// closing it would commit whatever was written so far.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := c.bucket.Object(key).NewWriter(ctx)
+	w.Metadata = metadata
+	if _, err := io.Copy(w, body); err != nil {
+		cancel()
+		w.Close() // nolint: errcheck
+		return err
+	}
+	return w.Close()
+}
+
+func (c *gcsObjectClient) Download(ctx context.Context, key string) (io.ReadCloser, map[string]string, error) {
+	obj := c.bucket.Object(key)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Pin the generation so the content matches the metadata just read.
+	r, err := obj.Generation(attrs.Generation).NewReader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, attrs.Metadata, nil
+}
+
+func (c *gcsObjectClient) Delete(ctx context.Context, key string) error {
+	return c.bucket.Object(key).Delete(ctx)
+}
+
+func (c *gcsObjectClient) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	it := c.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, attrs.Name)
+	}
+}
+
+// Ensure GCSPlanStore satisfies PlanStore at compile time.
+var _ PlanStore = (*GCSPlanStore)(nil)
@@ -0,0 +1,67 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+)
+
+// Object stores (S3, GCS, Azure Blob) share one key layout so plans can be
+// moved between backends and tooling can find them in the same place:
+//
+//	<prefix>/<owner>/<repo>/<pullNum>/<workspace>/<repoRelDir>/<planfilename>
+
+// planObjectKey builds the object key for the plan at planPath.
+func planObjectKey(prefix string, ctx command.ProjectContext, planPath string) string {
+	return joinObjectKey(prefix,
+		ctx.BaseRepo.Owner,
+		ctx.BaseRepo.Name,
+		strconv.Itoa(ctx.Pull.Num),
+		ctx.Workspace,
+		ctx.RepoRelDir,
+		filepath.Base(planPath),
+	)
+}
+
+// projectPlanObjectKey builds the object key for a project's plan from its
+// lock fields. The filename matches runtime.GetPlanFilename.
+func projectPlanObjectKey(prefix, owner, repo string, pullNum int, workspace, repoRelDir, projectName string) string {
+	var planFilename string
+	if projectName == "" {
+		planFilename = workspace + ".tfplan"
+	} else {
+		planFilename = strings.ReplaceAll(projectName, "/", "::") + "-" + workspace + ".tfplan"
+	}
+	return joinObjectKey(prefix, owner, repo, strconv.Itoa(pullNum), workspace, repoRelDir, planFilename)
+}
+
+// pullObjectPrefix returns the prefix, including the trailing slash, under
+// which all plans for a pull request are stored.
+func pullObjectPrefix(prefix, owner, repo string, pullNum int) string {
+	return joinObjectKey(prefix, owner, repo, strconv.Itoa(pullNum)) + "/"
+}
+
+// planObjectWorkspace returns the workspace of the plan stored at key, which
+// must be under listPrefix. It returns false for objects that aren't plans.
+func planObjectWorkspace(listPrefix, key string) (string, bool) {
+	if !strings.HasSuffix(key, ".tfplan") {
+		return "", false
+	}
+	workspace, _, ok := strings.Cut(strings.TrimPrefix(key, listPrefix), "/")
+	if !ok || workspace == "" {
+		return "", false
+	}
+	return workspace, true
+}
+
+func joinObjectKey(prefix string, parts ...string) string {
+	if prefix != "" {
+		parts = append([]string{prefix}, parts...)
+	}
+	return strings.Join(parts, "/")
+}
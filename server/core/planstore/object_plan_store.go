@@ -0,0 +1,269 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/utils"
+)
+
+// ObjectClient is the subset of a blob storage API used by the GCS and Azure
+// Blob plan stores, extracted for testability. Keys are full object names
+// within the configured bucket or container.
+type ObjectClient interface {
+	// Upload creates or replaces the object at key.
+	Upload(ctx context.Context, key string, body io.Reader, metadata map[string]string) error
+	// Download returns the object's content and user-defined metadata.
+	Download(ctx context.Context, key string) (io.ReadCloser, map[string]string, error)
+	// Delete removes the object at key.
+	Delete(ctx context.Context, key string) error
+	// List returns the keys of all objects whose name starts with prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// objectOpTimeout is the per-operation timeout for ObjectClient calls.
+const objectOpTimeout = 30 * time.Second
+
+func objectCtx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), objectOpTimeout)
+}
+
+// objectPlanStore implements PlanStore on top of an ObjectClient using the
+// same key layout and head-commit metadata as S3PlanStore.
+type objectPlanStore struct {
+	client ObjectClient
+	// backend names the storage service in errors, e.g. "GCS".
+	backend string
+	// location is the URL-style bucket location used in logs, e.g. "gs://bucket".
+	location string
+	prefix   string
+	logger   logging.SimpleLogging
+}
+
+func newObjectPlanStore(client ObjectClient, backend, location, prefix string, logger logging.SimpleLogging) objectPlanStore {
+	return objectPlanStore{
+		client:   client,
+		backend:  backend,
+		location: location,
+		prefix:   strings.TrimSuffix(prefix, "/"),
+		logger:   logger,
+	}
+}
+
+// Save uploads the plan file at planPath.
+func (s *objectPlanStore) Save(ctx command.ProjectContext, planPath string) error {
+	key := planObjectKey(s.prefix, ctx, planPath)
+
+	f, err := os.Open(planPath)
+	if err != nil {
+		return fmt.Errorf("opening plan file for %s upload: %w", s.backend, err)
+	}
+	defer f.Close()
+
+	metadata := map[string]string{}
+	if ctx.Pull.HeadCommit != "" {
+		metadata["head-commit"] = ctx.Pull.HeadCommit
+	}
+	if ctx.User.Username != "" {
+		metadata["planned-by"] = ctx.User.Username
+	}
+
+	opCtx, opCancel := objectCtx()
+	defer opCancel()
+	if err := s.client.Upload(opCtx, key, f, metadata); err != nil {
+		return fmt.Errorf("uploading plan to %s (key=%s): %w", s.backend, key, err)
+	}
+
+	s.logger.Info("uploaded plan to %s/%s", s.location, key)
+	return nil
+}
+
+// Load downloads the plan file and writes it to planPath. Plans created at a
+// different commit than the pull request's current head are rejected.
+func (s *objectPlanStore) Load(ctx command.ProjectContext, planPath string) error {
+	key := planObjectKey(s.prefix, ctx, planPath)
+
+	opCtx, opCancel := objectCtx()
+	defer opCancel()
+	body, metadata, err := s.client.Download(opCtx, key)
+	if err != nil {
+		return fmt.Errorf("downloading plan from %s (key=%s): %w", s.backend, key, err)
+	}
+	defer body.Close()
+
+	var planCommit string
+	for k, v := range metadata {
+		if strings.EqualFold(k, "head-commit") {
+			planCommit = v
+			break
+		}
+	}
+	if planCommit == "" {
+		return fmt.Errorf("plan in %s has no head-commit metadata (key=%s) — run plan again", s.backend, key)
+	}
+	if ctx.Pull.HeadCommit != "" && planCommit != ctx.Pull.HeadCommit {
+		return fmt.Errorf("plan was created at commit %.8s but PR is now at %.8s — run plan again", planCommit, ctx.Pull.HeadCommit)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(planPath), 0o700); err != nil {
+		return fmt.Errorf("creating parent directories for plan file: %w", err)
+	}
+	if err := writePlanFile(planPath, body); err != nil {
+		return fmt.Errorf("writing plan file from %s: %w", s.backend, err)
+	}
+
+	s.logger.Debug("downloaded plan from %s/%s", s.location, key)
+	return nil
+}
+
+// Remove deletes the plan file from the bucket and locally.
+func (s *objectPlanStore) Remove(ctx command.ProjectContext, planPath string) error {
+	s.deleteObject(planObjectKey(s.prefix, ctx, planPath))
+	return utils.RemoveIgnoreNonExistent(planPath)
+}
+
+// ListWorkspaces returns the unique workspace names that have at least one
+// plan stored for the pull request.
+func (s *objectPlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string, error) {
+	listPrefix := pullObjectPrefix(s.prefix, owner, repo, pullNum)
+	keys, err := s.list(listPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspaces from %s (prefix=%s): %w", s.backend, listPrefix, err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, key := range keys {
+		if workspace, ok := planObjectWorkspace(listPrefix, key); ok {
+			seen[workspace] = struct{}{}
+		}
+	}
+	workspaces := make([]string, 0, len(seen))
+	for w := range seen {
+		workspaces = append(workspaces, w)
+	}
+	sort.Strings(workspaces)
+	return workspaces, nil
+}
+
+// RestorePlans downloads every plan stored for the pull request into pullDir
+// so PendingPlanFinder can discover them.
+func (s *objectPlanStore) RestorePlans(pullDir, owner, repo string, pullNum int) error {
+	if pullDir == "" {
+		return nil // capability probe: external store supports restore
+	}
+	listPrefix := pullObjectPrefix(s.prefix, owner, repo, pullNum)
+	keys, err := s.list(listPrefix)
+	if err != nil {
+		return fmt.Errorf("listing plans from %s (prefix=%s): %w", s.backend, listPrefix, err)
+	}
+
+	var restored int
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ".tfplan") {
+			continue
+		}
+		// SecureJoin guarantees the result stays within pullDir,
+		// preventing path traversal from untrusted object names.
+		localPath, err := securejoin.SecureJoin(pullDir, strings.TrimPrefix(key, listPrefix))
+		if err != nil {
+			return fmt.Errorf("resolving safe path for %s key %s: %w", s.backend, key, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o700); err != nil {
+			return fmt.Errorf("creating directory for restored plan: %w", err)
+		}
+		if err := s.downloadObjectTo(key, localPath); err != nil {
+			return err
+		}
+		restored++
+		s.logger.Info("restored plan from %s/%s to %s", s.location, key, localPath)
+	}
+
+	s.logger.Info("restored %d plan(s) from %s for %s/%s#%d", restored, s.backend, owner, repo, pullNum)
+	return nil
+}
+
+// DeleteForPull removes all objects stored under the pull request prefix.
+func (s *objectPlanStore) DeleteForPull(owner, repo string, pullNum int) error {
+	listPrefix := pullObjectPrefix(s.prefix, owner, repo, pullNum)
+	keys, err := s.list(listPrefix)
+	if err != nil {
+		return fmt.Errorf("listing plans for deletion (prefix=%s): %w", listPrefix, err)
+	}
+
+	var deleted int
+	for _, key := range keys {
+		if s.deleteObject(key) {
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		s.logger.Info("deleted %d plan(s) from %s for %s/%s#%d", deleted, s.backend, owner, repo, pullNum)
+	}
+	return nil
+}
+
+// DeletePlanForProject removes a single project's plan.
+func (s *objectPlanStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, repoRelDir, projectName string) error {
+	s.deleteObject(projectPlanObjectKey(s.prefix, owner, repo, pullNum, workspace, repoRelDir, projectName))
+	return nil
+}
+
+func (s *objectPlanStore) list(prefix string) ([]string, error) {
+	opCtx, opCancel := objectCtx()
+	defer opCancel()
+	return s.client.List(opCtx, prefix)
+}
+
+// deleteObject deletes key, logging rather than returning failures since a
+// leftover plan is rejected by Load once the pull request moves on.
+func (s *objectPlanStore) deleteObject(key string) bool {
+	opCtx, opCancel := objectCtx()
+	defer opCancel()
+	if err := s.client.Delete(opCtx, key); err != nil {
+		s.logger.Warn("failed to delete plan from %s (key=%s): %v", s.backend, key, err)
+		return false
+	}
+	s.logger.Debug("deleted plan from %s/%s", s.location, key)
+	return true
+}
+
+// downloadObjectTo fetches the object at key and writes it to localPath.
+func (s *objectPlanStore) downloadObjectTo(key, localPath string) error {
+	opCtx, opCancel := objectCtx()
+	defer opCancel()
+	body, _, err := s.client.Download(opCtx, key)
+	if err != nil {
+		return fmt.Errorf("downloading plan from %s (key=%s): %w", s.backend, key, err)
+	}
+	defer body.Close()
+
+	if err := writePlanFile(localPath, body); err != nil {
+		return fmt.Errorf("writing restored plan file %s: %w", localPath, err)
+	}
+	return nil
+}
+
+func writePlanFile(path string, body io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeObject struct {
+	body     []byte
+	metadata map[string]string
+}
+
+// fakeObjectClient is an in-memory planstore.ObjectClient.
+type fakeObjectClient struct {
+	mu        sync.Mutex
+	objects   map[string]fakeObject
+	deleteErr error
+}
+
+func newFakeObjectClient() *fakeObjectClient {
+	return &fakeObjectClient{objects: map[string]fakeObject{}}
+}
+
+func (f *fakeObjectClient) Upload(_ context.Context, key string, body io.Reader, metadata map[string]string) error {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[key] = fakeObject{body: b, metadata: metadata}
+	return nil
+}
+
+func (f *fakeObjectClient) Download(_ context.Context, key string) (io.ReadCloser, map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	obj, ok := f.objects[key]
+	if !ok {
+		return nil, nil, errors.New("object not found: " + key)
+	}
+	return io.NopCloser(bytes.NewReader(obj.body)), obj.metadata, nil
+}
+
+func (f *fakeObjectClient) Delete(_ context.Context, key string) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.objects, key)
+	return nil
+}
+
+func (f *fakeObjectClient) List(_ context.Context, prefix string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (f *fakeObjectClient) keys() []string {
+	keys, _ := f.List(context.Background(), "")
+	return keys
+}
+
+func TestObjectPlanStores_KeyLayoutMatchesS3(t *testing.T) {
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+	planPath := filepath.Join(t.TempDir(), "myproject-default.tfplan")
+	require.NoError(t, os.WriteFile(planPath, []byte("plan"), 0o600))
+	expKey := planstore.NewS3PlanStoreWithClient(&mockS3Client{}, "bucket", "atlantis/plans", logging.NewNoopLogger(t)).TestS3Key(ctx, planPath)
+
+	stores := map[string]func(planstore.ObjectClient) planstore.PlanStore{
+		"gcs": func(c planstore.ObjectClient) planstore.PlanStore {
+			return planstore.NewGCSPlanStoreWithClient(c, "bucket", "atlantis/plans/", logging.NewNoopLogger(t))
+		},
+		"azureblob": func(c planstore.ObjectClient) planstore.PlanStore {
+			return planstore.NewAzureBlobPlanStoreWithClient(c, "account", "container", "atlantis/plans/", logging.NewNoopLogger(t))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeObjectClient()
+			require.NoError(t, newStore(client).Save(ctx, planPath))
+			assert.Equal(t, []string{expKey}, client.keys())
+			assert.Equal(t, "abc123", client.objects[expKey].metadata["head-commit"])
+		})
+	}
+}
+
+func TestGCSPlanStore(t *testing.T) {
+	client := newFakeObjectClient()
+	testObjectPlanStore(t, planstore.NewGCSPlanStoreWithClient(client, "bucket", "plans", logging.NewNoopLogger(t)))
+}
+
+func TestAzureBlobPlanStore(t *testing.T) {
+	client := newFakeObjectClient()
+	testObjectPlanStore(t, planstore.NewAzureBlobPlanStoreWithClient(client, "account", "container", "plans", logging.NewNoopLogger(t)))
+}
+
+func TestObjectPlanStore_DeleteErrorsAreNotFatal(t *testing.T) {
+	client := newFakeObjectClient()
+	client.deleteErr = errors.New("forbidden")
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+	planPath := filepath.Join(t.TempDir(), "default.tfplan")
+	require.NoError(t, os.WriteFile(planPath, []byte("plan"), 0o600))
+
+	require.NoError(t, store.Remove(testProjectContext(), planPath))
+	_, err := os.Stat(planPath)
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, store.DeleteForPull("acme", "infra", 42))
+	require.NoError(t, store.DeletePlanForProject("acme", "infra", 42, "default", ".", ""))
+}
+
+// testObjectPlanStore exercises the full plan lifecycle against store, which
+// must be empty.
+func testObjectPlanStore(t *testing.T, store planstore.PlanStore) {
+	t.Helper()
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+	ctx.User.Username = "jdoe"
+	dir := t.TempDir()
+
+	planPath := filepath.Join(dir, "default", "modules", "vpc", "vpc-default.tfplan")
+	require.NoError(t, os.MkdirAll(filepath.Dir(planPath), 0o700))
+	require.NoError(t, os.WriteFile(planPath, []byte("vpc plan"), 0o600))
+	require.NoError(t, store.Save(ctx, planPath))
+
+	stagingCtx := ctx
+	stagingCtx.Workspace = "staging"
+	stagingPath := filepath.Join(dir, "staging", "modules", "vpc", "vpc-staging.tfplan")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stagingPath), 0o700))
+	require.NoError(t, os.WriteFile(stagingPath, []byte("staging plan"), 0o600))
+	require.NoError(t, store.Save(stagingCtx, stagingPath))
+
+	// Load into a fresh location, as after a re-clone.
+	loadPath := filepath.Join(t.TempDir(), "vpc-default.tfplan")
+	require.NoError(t, store.Load(ctx, loadPath))
+	content, err := os.ReadFile(loadPath)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc plan", string(content))
+
+	staleCtx := ctx
+	staleCtx.Pull.HeadCommit = "def456"
+	err = store.Load(staleCtx, loadPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "run plan again")
+
+	workspaces, err := store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "staging"}, workspaces)
+
+	require.NoError(t, store.RestorePlans("", "acme", "infra", 42))
+	pullDir := t.TempDir()
+	require.NoError(t, store.RestorePlans(pullDir, "acme", "infra", 42))
+	content, err = os.ReadFile(filepath.Join(pullDir, "staging", "modules", "vpc", "vpc-staging.tfplan"))
+	require.NoError(t, err)
+	assert.Equal(t, "staging plan", string(content))
+
+	require.NoError(t, store.DeletePlanForProject("acme", "infra", 42, "staging", "modules/vpc", "vpc"))
+	workspaces, err = store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, workspaces)
+
+	require.NoError(t, store.DeleteForPull("acme", "infra", 42))
+	workspaces, err = store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Empty(t, workspaces)
+}
@@ -10,7 +10,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -53,6 +52,16 @@ type S3PlanStore struct {
 
 // NewS3PlanStore creates an S3PlanStore using the AWS SDK default credential chain.
 func NewS3PlanStore(cfg S3PlanStoreConfig, logger logging.SimpleLogging) (*S3PlanStore, error) {
+	client, err := NewS3Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewS3PlanStoreWithClient(client, cfg.Bucket, cfg.Prefix, logger), nil
+}
+
+// NewS3Client creates an S3 client for cfg using the AWS SDK default credential
+// chain and checks that cfg.Bucket is reachable.
+func NewS3Client(cfg S3PlanStoreConfig) (S3Client, error) {
 	var opts []func(*awsconfig.LoadOptions) error
 	opts = append(opts, awsconfig.WithRegion(cfg.Region))
 
@@ -87,10 +96,9 @@ func NewS3PlanStore(cfg S3PlanStoreConfig, logger logging.SimpleLogging) (*S3Pla
 	if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
 		Bucket: aws.String(cfg.Bucket),
 	}); err != nil {
-		return nil, fmt.Errorf("validating S3 plan store bucket %q: %w", cfg.Bucket, err)
+		return nil, fmt.Errorf("validating S3 bucket %q: %w", cfg.Bucket, err)
 	}
-
-	return NewS3PlanStoreWithClient(client, cfg.Bucket, cfg.Prefix, logger), nil
+	return client, nil
 }
 
 // s3OpTimeout is the per-operation timeout for S3 API calls.
@@ -231,12 +239,7 @@ func (s *S3PlanStore) Remove(ctx command.ProjectContext, planPath string) error
 // least one .tfplan stored. Callers use this to clone each workspace before
 // invoking RestorePlans, so plan files don't get wiped by a subsequent Clone.
 func (s *S3PlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string, error) {
-	prefixParts := []string{}
-	if s.prefix != "" {
-		prefixParts = append(prefixParts, s.prefix)
-	}
-	prefixParts = append(prefixParts, owner, repo, strconv.Itoa(pullNum))
-	listPrefix := strings.Join(prefixParts, "/") + "/"
+	listPrefix := pullObjectPrefix(s.prefix, owner, repo, pullNum)
 
 	seen := map[string]struct{}{}
 	var continuationToken *string
@@ -252,13 +255,8 @@ func (s *S3PlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string,
 			return nil, fmt.Errorf("listing workspaces from S3 (prefix=%s): %w", listPrefix, err)
 		}
 		for _, obj := range resp.Contents {
-			key := aws.ToString(obj.Key)
-			if !strings.HasSuffix(key, ".tfplan") {
-				continue
-			}
-			rel := strings.TrimPrefix(key, listPrefix)
-			workspace, _, ok := strings.Cut(rel, "/")
-			if !ok || workspace == "" {
+			workspace, ok := planObjectWorkspace(listPrefix, aws.ToString(obj.Key))
+			if !ok {
 				continue
 			}
 			seen[workspace] = struct{}{}
@@ -282,12 +280,7 @@ func (s *S3PlanStore) RestorePlans(pullDir, owner, repo string, pullNum int) err
 		return nil // capability probe: external store supports restore
 	}
 	// Build the S3 prefix for all plans under this pull request.
-	prefixParts := []string{}
-	if s.prefix != "" {
-		prefixParts = append(prefixParts, s.prefix)
-	}
-	prefixParts = append(prefixParts, owner, repo, strconv.Itoa(pullNum))
-	listPrefix := strings.Join(prefixParts, "/") + "/"
+	listPrefix := pullObjectPrefix(s.prefix, owner, repo, pullNum)
 
 	var restored int
 	var continuationToken *string
@@ -370,12 +363,7 @@ func (s *S3PlanStore) downloadObjectTo(key, localPath string) error {
 
 // DeleteForPull removes all plan objects stored under the pull request prefix in S3.
 func (s *S3PlanStore) DeleteForPull(owner, repo string, pullNum int) error {
-	prefixParts := []string{}
-	if s.prefix != "" {
-		prefixParts = append(prefixParts, s.prefix)
-	}
-	prefixParts = append(prefixParts, owner, repo, strconv.Itoa(pullNum))
-	listPrefix := strings.Join(prefixParts, "/") + "/"
+	listPrefix := pullObjectPrefix(s.prefix, owner, repo, pullNum)
 
 	var deleted int
 	var continuationToken *string
@@ -421,19 +409,7 @@ func (s *S3PlanStore) DeleteForPull(owner, repo string, pullNum int) error {
 // s3Key builds a deterministic S3 object key from the ProjectContext and plan filename.
 // Format: <prefix>/<owner>/<repo>/<pullNum>/<workspace>/<repoRelDir>/<planfilename>
 func (s *S3PlanStore) s3Key(ctx command.ProjectContext, planPath string) string {
-	parts := []string{}
-	if s.prefix != "" {
-		parts = append(parts, s.prefix)
-	}
-	parts = append(parts,
-		ctx.BaseRepo.Owner,
-		ctx.BaseRepo.Name,
-		strconv.Itoa(ctx.Pull.Num),
-		ctx.Workspace,
-		ctx.RepoRelDir,
-		filepath.Base(planPath),
-	)
-	return strings.Join(parts, "/")
+	return planObjectKey(s.prefix, ctx, planPath)
 }
 
 // TestS3Key is exported for testing only.
@@ -442,18 +418,7 @@ func (s *S3PlanStore) TestS3Key(ctx command.ProjectContext, planPath string) str
 }
 
 func (s *S3PlanStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, repoRelDir, projectName string) error {
-	var planFilename string
-	if projectName == "" {
-		planFilename = workspace + ".tfplan"
-	} else {
-		planFilename = strings.ReplaceAll(projectName, "/", "::") + "-" + workspace + ".tfplan"
-	}
-	parts := []string{}
-	if s.prefix != "" {
-		parts = append(parts, s.prefix)
-	}
-	parts = append(parts, owner, repo, strconv.Itoa(pullNum), workspace, repoRelDir, planFilename)
-	key := strings.Join(parts, "/")
+	key := projectPlanObjectKey(s.prefix, owner, repo, pullNum, workspace, repoRelDir, projectName)
 
 	opCtx, opCancel := s3Ctx()
 	defer opCancel()
@@ -0,0 +1,156 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+const driftKeyPrefix = "drift/"
+
+// DriftStorage is a drift.Storage backed by the Redis database used for
+// locking. Each repository is stored as a single hash keyed by
+// drift.StorageKey, so every operation touches one key and works in cluster
+// mode, and all replicas share the same drift results.
+type DriftStorage struct {
+	client redis.Cmdable
+}
+
+// NewDriftStorage returns a drift.Storage that shares r's client.
+func NewDriftStorage(r *RedisDB) *DriftStorage {
+	return &DriftStorage{
+		client: r.client,
+	}
+}
+
+// Store saves a drift result for a project.
+func (d *DriftStorage) Store(repository string, projectDrift models.ProjectDrift) error {
+	serialized, err := json.Marshal(projectDrift)
+	if err != nil {
+		return fmt.Errorf("serializing drift: %w", err)
+	}
+	if err := d.client.HSet(ctx, d.repoKey(repository), drift.StorageKey(projectDrift), serialized).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves drift results for a repository with optional filtering.
+func (d *DriftStorage) Get(repository string, opts drift.GetOptions) ([]models.ProjectDrift, error) {
+	drifts, err := d.getRepo(d.repoKey(repository))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result := make([]models.ProjectDrift, 0, len(drifts))
+	for _, projectDrift := range drifts {
+		if drift.MatchesGetOptions(projectDrift, opts, now) {
+			result = append(result, projectDrift)
+		}
+	}
+	return result, nil
+}
+
+// Delete removes drift results for a repository.
+// If projectName is empty, all drift results for the repository are removed.
+func (d *DriftStorage) Delete(repository string, projectName string) error {
+	if projectName == "" {
+		if err := d.client.Del(ctx, d.repoKey(repository)).Err(); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		return nil
+	}
+	return d.deleteWhere(repository, func(projectDrift models.ProjectDrift) bool {
+		return projectDrift.ProjectName == projectName
+	})
+}
+
+// DeleteMatching removes drift results for a repository that match the given filters.
+func (d *DriftStorage) DeleteMatching(repository string, opts drift.GetOptions) error {
+	if opts == (drift.GetOptions{}) {
+		return drift.ErrDeleteFilterRequired
+	}
+	now := time.Now()
+	return d.deleteWhere(repository, func(projectDrift models.ProjectDrift) bool {
+		return drift.MatchesDeleteOptions(projectDrift, opts, now)
+	})
+}
+
+// GetAll retrieves all stored drift results across all repositories.
+// Uses Scan instead of Keys for compatibility with Redis Cluster.
+func (d *DriftStorage) GetAll() (map[string][]models.ProjectDrift, error) {
+	result := make(map[string][]models.ProjectDrift)
+	iter := d.client.Scan(ctx, 0, driftKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		drifts, err := d.getRepo(iter.Val())
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimPrefix(iter.Val(), driftKeyPrefix)] = drifts
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return result, nil
+}
+
+func (d *DriftStorage) deleteWhere(repository string, match func(models.ProjectDrift) bool) error {
+	key := d.repoKey(repository)
+	vals, err := d.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	var fields []string
+	for field, val := range vals {
+		projectDrift, err := d.deserialize(field, val)
+		if err != nil {
+			return err
+		}
+		if match(projectDrift) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	if err := d.client.HDel(ctx, key, fields...).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+func (d *DriftStorage) getRepo(key string) ([]models.ProjectDrift, error) {
+	vals, err := d.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	drifts := make([]models.ProjectDrift, 0, len(vals))
+	for field, val := range vals {
+		projectDrift, err := d.deserialize(field, val)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, projectDrift)
+	}
+	return drifts, nil
+}
+
+func (d *DriftStorage) deserialize(field string, val string) (models.ProjectDrift, error) {
+	var projectDrift models.ProjectDrift
+	if err := json.Unmarshal([]byte(val), &projectDrift); err != nil {
+		return projectDrift, fmt.Errorf("deserializing drift at field %q: %w", field, err)
+	}
+	return projectDrift, nil
+}
+
+func (d *DriftStorage) repoKey(repository string) string {
+	return driftKeyPrefix + repository
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -343,6 +344,116 @@ func (r *RedisDB) UnlockByPull(repoFullName string, pullNum int) ([]models.Proje
 	return locks, nil
 }
 
+// EnqueueLock adds entry to the end of the queue for its project and
+// workspace, unless its pull request is already queued. It returns the
+// 1-based position of the pull request in the queue.
+func (r *RedisDB) EnqueueLock(entry models.LockQueueEntry) (int, error) {
+	key := r.lockQueueKey(entry.Project, entry.Workspace)
+	queue, err := r.getLockQueue(key)
+	if err != nil {
+		return 0, err
+	}
+	for i, queued := range queue {
+		if queued.Pull.Num == entry.Pull.Num {
+			return i + 1, nil
+		}
+	}
+	queue = append(queue, entry)
+	if err := r.writeLockQueue(key, queue); err != nil {
+		return 0, err
+	}
+	return len(queue), nil
+}
+
+// GetLockQueue returns the pull requests queued for the lock on project and
+// workspace, in order.
+func (r *RedisDB) GetLockQueue(project models.Project, workspace string) ([]models.LockQueueEntry, error) {
+	return r.getLockQueue(r.lockQueueKey(project, workspace))
+}
+
+// DequeueLock removes pullNum from the queue for project and workspace.
+func (r *RedisDB) DequeueLock(project models.Project, workspace string, pullNum int) error {
+	key := r.lockQueueKey(project, workspace)
+	queue, err := r.getLockQueue(key)
+	if err != nil {
+		return err
+	}
+	return r.writeLockQueue(key, slices.DeleteFunc(queue, func(e models.LockQueueEntry) bool {
+		return e.Pull.Num == pullNum
+	}))
+}
+
+// DequeuePull removes pullNum from every queue in the repo.
+func (r *RedisDB) DequeuePull(repoFullName string, pullNum int) error {
+	iter := r.client.Scan(ctx, 0, fmt.Sprintf("lockqueue/%s/*", repoFullName), 0).Iterator()
+	for iter.Next(ctx) {
+		queue, err := r.getLockQueue(iter.Val())
+		if err != nil {
+			return err
+		}
+		remaining := slices.DeleteFunc(slices.Clone(queue), func(e models.LockQueueEntry) bool {
+			return e.Pull.Num == pullNum
+		})
+		if len(remaining) == len(queue) {
+			continue
+		}
+		if err := r.writeLockQueue(iter.Val(), remaining); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// AddPolicyException records a policy exception.
+func (r *RedisDB) AddPolicyException(exception models.PolicyException) error {
+	serialized, err := json.Marshal(exception)
+	if err != nil {
+		return fmt.Errorf("serializing policy exception: %w", err)
+	}
+	key := r.policyExceptionsKey(exception.Project.RepoFullName, exception.PullNum)
+	if err := r.client.RPush(ctx, key, serialized).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// GetPolicyExceptions returns the policy exceptions granted on pullNum in the
+// repo, including expired ones, in the order they were granted. If pullNum is
+// 0, it returns the exceptions for every pull request in the repo.
+func (r *RedisDB) GetPolicyExceptions(repoFullName string, pullNum int) ([]models.PolicyException, error) {
+	keys := []string{r.policyExceptionsKey(repoFullName, pullNum)}
+	if pullNum == 0 {
+		keys = nil
+		iter := r.client.Scan(ctx, 0, fmt.Sprintf("policyexceptions/%s/*", repoFullName), 0).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return nil, fmt.Errorf("db transaction failed: %w", err)
+		}
+		slices.Sort(keys)
+	}
+
+	var exceptions []models.PolicyException
+	for _, key := range keys {
+		vals, err := r.client.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("db transaction failed: %w", err)
+		}
+		for _, val := range vals {
+			var exception models.PolicyException
+			if err := json.Unmarshal([]byte(val), &exception); err != nil {
+				return nil, fmt.Errorf("deserializing policy exception at key %q: %w", key, err)
+			}
+			exceptions = append(exceptions, exception)
+		}
+	}
+	return exceptions, nil
+}
+
 func (r *RedisDB) LockCommand(cmdName command.Name, lockTime time.Time) (*command.Lock, error) {
 
 	lock := command.Lock{
@@ -523,6 +634,11 @@ func (r *RedisDB) UpdatePullWithResults(pull models.PullRequest, newResults []co
 					res.ProjectName == proj.ProjectName {
 
 					proj.Status = res.PlanStatus()
+					if res.Command == command.Plan {
+						proj.PlanDigest, proj.PlanCommit = res.PlanIntegrity()
+						proj.CostEstimate = res.PlanCostEstimate()
+						proj.PlannedAt = time.Now()
+					}
 
 					// Updating only policy sets which are included in results; keeping the rest.
 					if len(proj.PolicyStatus) > 0 {
@@ -603,6 +719,48 @@ func (r *RedisDB) deletePull(key string) error {
 	return nil
 }
 
+func (r *RedisDB) getLockQueue(key string) ([]models.LockQueueEntry, error) {
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	var queue []models.LockQueueEntry
+	if err := json.Unmarshal([]byte(val), &queue); err != nil {
+		return nil, fmt.Errorf("deserializing lock queue at key %q: %w", key, err)
+	}
+	return queue, nil
+}
+
+// writeLockQueue stores queue at key, deleting the key once the queue is
+// empty.
+func (r *RedisDB) writeLockQueue(key string, queue []models.LockQueueEntry) error {
+	if len(queue) == 0 {
+		if err := r.client.Del(ctx, key).Err(); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		return nil
+	}
+	serialized, err := json.Marshal(queue)
+	if err != nil {
+		return fmt.Errorf("serializing lock queue: %w", err)
+	}
+	if err := r.client.Set(ctx, key, serialized, 0).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisDB) policyExceptionsKey(repoFullName string, pullNum int) string {
+	return fmt.Sprintf("policyexceptions/%s/%d", repoFullName, pullNum)
+}
+
+// lockQueueKey doesn't start with "pr" so queues aren't listed as locks.
+func (r *RedisDB) lockQueueKey(p models.Project, workspace string) string {
+	return fmt.Sprintf("lockqueue/%s", models.GenerateLockKey(p, workspace))
+}
+
 func (r *RedisDB) lockKey(p models.Project, workspace string) string {
 	return fmt.Sprintf("pr/%s", models.GenerateLockKey(p, workspace))
 }
@@ -625,12 +783,21 @@ func (r *RedisDB) pullKey(pull models.PullRequest) (string, error) {
 }
 
 func (r *RedisDB) projectResultToProject(p command.ProjectResult) models.ProjectStatus {
+	planDigest, planCommit := p.PlanIntegrity()
+	var plannedAt time.Time
+	if p.Command == command.Plan {
+		plannedAt = time.Now()
+	}
 	return models.ProjectStatus{
 		Workspace:    p.Workspace,
 		RepoRelDir:   p.RepoRelDir,
 		ProjectName:  p.ProjectName,
 		PolicyStatus: p.PolicyStatus(),
 		Status:       p.PlanStatus(),
+		PlanDigest:   planDigest,
+		PlanCommit:   planCommit,
+		PlannedAt:    plannedAt,
+		CostEstimate: p.PlanCostEstimate(),
 	}
 }
 
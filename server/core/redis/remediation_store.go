@@ -0,0 +1,127 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+const (
+	remediationsKeyPrefix     = "remediations/"
+	remediationIndexKeyPrefix = "remediation/"
+)
+
+// RemediationStore is a drift.RemediationStore backed by the Redis database
+// used for locking. Results for a repository are stored in one hash keyed by
+// result ID, and a per-result key maps the ID back to its repository. Every
+// command touches a single key so it works in cluster mode.
+type RemediationStore struct {
+	client redis.Cmdable
+}
+
+// NewRemediationStore returns a drift.RemediationStore that shares r's client.
+func NewRemediationStore(r *RedisDB) *RemediationStore {
+	return &RemediationStore{
+		client: r.client,
+	}
+}
+
+// StoreRemediation creates or replaces a remediation result.
+func (s *RemediationStore) StoreRemediation(result *models.RemediationResult) error {
+	serialized, err := drift.MarshalRemediation(result)
+	if err != nil {
+		return err
+	}
+	repository := drift.RemediationRepositoryKey(result)
+	if err := s.client.HSet(ctx, remediationsKeyPrefix+repository, result.ID, serialized).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	if err := s.client.Set(ctx, remediationIndexKeyPrefix+result.ID, repository, 0).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// GetRemediation returns the result with the given ID.
+func (s *RemediationStore) GetRemediation(id string) (*models.RemediationResult, error) {
+	repository, err := s.client.Get(ctx, remediationIndexKeyPrefix+id).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("%w: %s", drift.ErrRemediationNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	serialized, err := s.client.HGet(ctx, remediationsKeyPrefix+repository, id).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("%w: %s", drift.ErrRemediationNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return drift.UnmarshalRemediation([]byte(serialized))
+}
+
+// ListRemediations returns results for a repository, newest first. An empty
+// repository lists results across all repositories.
+// Uses Scan instead of Keys for compatibility with Redis Cluster.
+func (s *RemediationStore) ListRemediations(repository string, opts drift.RemediationListOptions) (drift.RemediationPage, error) {
+	var results []*models.RemediationResult
+	if repository != "" {
+		if err := s.appendRepo(remediationsKeyPrefix+repository, &results); err != nil {
+			return drift.RemediationPage{}, err
+		}
+		return drift.PaginateRemediations(results, opts)
+	}
+
+	iter := s.client.Scan(ctx, 0, remediationsKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		if err := s.appendRepo(iter.Val(), &results); err != nil {
+			return drift.RemediationPage{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return drift.RemediationPage{}, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return drift.PaginateRemediations(results, opts)
+}
+
+// DeleteRemediations removes the results with the given IDs.
+func (s *RemediationStore) DeleteRemediations(ids []string) error {
+	for _, id := range ids {
+		repository, err := s.client.Get(ctx, remediationIndexKeyPrefix+id).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		if err := s.client.HDel(ctx, remediationsKeyPrefix+repository, id).Err(); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		if err := s.client.Del(ctx, remediationIndexKeyPrefix+id).Err(); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *RemediationStore) appendRepo(key string, results *[]*models.RemediationResult) error {
+	vals, err := s.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	for id, val := range vals {
+		result, err := drift.UnmarshalRemediation([]byte(val))
+		if err != nil {
+			return fmt.Errorf("remediation %q: %w", id, err)
+		}
+		*results = append(*results, result)
+	}
+	return nil
+}
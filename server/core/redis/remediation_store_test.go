@@ -0,0 +1,82 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestRemediationStore_StoreGetAndList(t *testing.T) {
+	store := redis.NewRemediationStore(newTestRedis(miniredis.RunT(t)))
+	base := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	first := &models.RemediationResult{
+		ID:                "first",
+		Repository:        "owner/repo",
+		StorageRepository: "github.com/owner/repo",
+		Status:            models.RemediationStatusRunning,
+		StartedAt:         base,
+		RequestedBy:       &models.APICaller{Name: "jdoe", AuthMethod: "api_secret"},
+	}
+	Ok(t, store.StoreRemediation(first))
+	first.Status = models.RemediationStatusSuccess
+	Ok(t, store.StoreRemediation(first))
+	Ok(t, store.StoreRemediation(&models.RemediationResult{
+		ID:                "second",
+		Repository:        "owner/repo",
+		StorageRepository: "github.com/owner/repo",
+		Status:            models.RemediationStatusFailed,
+		StartedAt:         base.Add(time.Hour),
+	}))
+	Ok(t, store.StoreRemediation(&models.RemediationResult{
+		ID:                "other",
+		Repository:        "owner/other",
+		StorageRepository: "github.com/owner/other",
+		Status:            models.RemediationStatusSuccess,
+		StartedAt:         base.Add(2 * time.Hour),
+	}))
+
+	got, err := store.GetRemediation("first")
+	Ok(t, err)
+	Equals(t, first, got)
+
+	page, err := store.ListRemediations("github.com/owner/repo", drift.RemediationListOptions{Limit: 1})
+	Ok(t, err)
+	Equals(t, 1, len(page.Results))
+	Equals(t, "second", page.Results[0].ID)
+	page, err = store.ListRemediations("github.com/owner/repo", drift.RemediationListOptions{Limit: 1, Cursor: page.NextCursor})
+	Ok(t, err)
+	Equals(t, 1, len(page.Results))
+	Equals(t, "first", page.Results[0].ID)
+	Equals(t, "", page.NextCursor)
+
+	page, err = store.ListRemediations("", drift.RemediationListOptions{Status: models.RemediationStatusSuccess})
+	Ok(t, err)
+	Equals(t, 2, len(page.Results))
+	Equals(t, "other", page.Results[0].ID)
+	Equals(t, "first", page.Results[1].ID)
+}
+
+func TestRemediationStore_Delete(t *testing.T) {
+	store := redis.NewRemediationStore(newTestRedis(miniredis.RunT(t)))
+
+	Ok(t, store.StoreRemediation(&models.RemediationResult{ID: "a", Repository: "owner/repo"}))
+	Ok(t, store.StoreRemediation(&models.RemediationResult{ID: "b", Repository: "owner/repo"}))
+	Ok(t, store.DeleteRemediations([]string{"a", "missing"}))
+
+	_, err := store.GetRemediation("a")
+	Assert(t, errors.Is(err, drift.ErrRemediationNotFound), "expected not found error, got %v", err)
+	page, err := store.ListRemediations("owner/repo", drift.RemediationListOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(page.Results))
+	Equals(t, "b", page.Results[0].ID)
+}
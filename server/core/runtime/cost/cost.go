@@ -0,0 +1,179 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cost estimates how much a Terraform plan changes the monthly cost of
+// a project using a static pricing table. It never makes network calls.
+package cost
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	"go.yaml.in/yaml/v4"
+)
+
+// PricingTable holds the monthly price of each Terraform resource type.
+type PricingTable struct {
+	// Currency is the currency all prices are in. It's only used for display.
+	Currency string `yaml:"currency"`
+	// Resources maps resource types, ex. aws_instance, to their price.
+	Resources map[string]ResourcePrice `yaml:"resources"`
+}
+
+// ResourcePrice is the monthly price of one instance of a resource type.
+type ResourcePrice struct {
+	// Monthly is the flat monthly price of the resource.
+	Monthly float64 `yaml:"monthly"`
+	// Attribute optionally names a resource attribute, ex. instance_type,
+	// whose value selects a price from Prices that's added to Monthly.
+	Attribute string `yaml:"attribute"`
+	// Prices maps values of Attribute to their monthly price.
+	Prices map[string]float64 `yaml:"prices"`
+}
+
+// LoadPricingTable reads and validates the pricing table at path.
+func LoadPricingTable(path string) (*PricingTable, error) {
+	data, err := os.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("reading pricing table: %w", err)
+	}
+	var table PricingTable
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&table); err != nil {
+		return nil, fmt.Errorf("parsing pricing table %s: %w", path, err)
+	}
+	if err := table.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid pricing table %s: %w", path, err)
+	}
+	return &table, nil
+}
+
+// Validate returns an error if any price is negative or a resource has
+// attribute prices without naming the attribute.
+func (t PricingTable) Validate() error {
+	if len(t.Resources) == 0 {
+		return errors.New("no resources are priced")
+	}
+	for resourceType, price := range t.Resources {
+		if price.Monthly < 0 {
+			return fmt.Errorf("%s: monthly price must not be negative", resourceType)
+		}
+		if price.Attribute == "" && len(price.Prices) > 0 {
+			return fmt.Errorf("%s: prices are set but attribute is not", resourceType)
+		}
+		for value, p := range price.Prices {
+			if p < 0 {
+				return fmt.Errorf("%s: price for %s %q must not be negative", resourceType, price.Attribute, value)
+			}
+		}
+	}
+	return nil
+}
+
+// price returns the monthly price of a resource of type resourceType with the
+// given attribute values. It returns false if the type isn't in the table or
+// the value of its pricing attribute has no price, ex. because it's unknown
+// until apply.
+func (t PricingTable) price(resourceType string, values map[string]any) (float64, bool) {
+	resourcePrice, ok := t.Resources[resourceType]
+	if !ok {
+		return 0, false
+	}
+	if resourcePrice.Attribute == "" {
+		return resourcePrice.Monthly, true
+	}
+	value, ok := values[resourcePrice.Attribute].(string)
+	if !ok {
+		return 0, false
+	}
+	attributePrice, ok := resourcePrice.Prices[value]
+	if !ok {
+		return 0, false
+	}
+	return resourcePrice.Monthly + attributePrice, true
+}
+
+// plan is the subset of the `terraform show -json` output needed to estimate
+// costs.
+type plan struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Mode    string `json:"mode"`
+		Type    string `json:"type"`
+		Change  struct {
+			Actions []string       `json:"actions"`
+			Before  map[string]any `json:"before"`
+			After   map[string]any `json:"after"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// Estimate returns the change in monthly cost of the plan in showJSON, the
+// output of `terraform show -json`.
+func (t PricingTable) Estimate(showJSON []byte) (models.CostEstimate, error) {
+	estimate := models.CostEstimate{Currency: t.Currency}
+	var p plan
+	if err := json.Unmarshal(showJSON, &p); err != nil {
+		return estimate, fmt.Errorf("parsing plan JSON: %w", err)
+	}
+
+	for _, rc := range p.ResourceChanges {
+		if rc.Mode == "data" {
+			continue
+		}
+		action := changeAction(rc.Change.Actions)
+		if action == "" {
+			continue
+		}
+
+		var before, after float64
+		priced := true
+		if action != "create" {
+			var ok bool
+			before, ok = t.price(rc.Type, rc.Change.Before)
+			priced = priced && ok
+		}
+		if action != "delete" {
+			var ok bool
+			after, ok = t.price(rc.Type, rc.Change.After)
+			priced = priced && ok
+		}
+		if !priced {
+			estimate.Unpriced = append(estimate.Unpriced, rc.Address)
+			continue
+		}
+
+		delta := after - before
+		estimate.MonthlyDelta += delta
+		estimate.Resources = append(estimate.Resources, models.ResourceCostEstimate{
+			Address:      rc.Address,
+			Action:       action,
+			MonthlyDelta: delta,
+		})
+	}
+	return estimate, nil
+}
+
+// changeAction maps the actions Terraform plans for a resource to one of
+// create, update, replace or delete. It returns an empty string for changes
+// that can't affect cost, like no-op and read.
+func changeAction(actions []string) string {
+	switch {
+	case slices.Contains(actions, "create") && slices.Contains(actions, "delete"):
+		return "replace"
+	case slices.Contains(actions, "create"):
+		return "create"
+	case slices.Contains(actions, "delete"):
+		return "delete"
+	case slices.Contains(actions, "update"):
+		return "update"
+	default:
+		return ""
+	}
+}
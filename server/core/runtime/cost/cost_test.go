@@ -0,0 +1,129 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cost_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/runtime/cost"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+var pricingTable = cost.PricingTable{
+	Currency: "USD",
+	Resources: map[string]cost.ResourcePrice{
+		"aws_nat_gateway": {Monthly: 32.85},
+		"aws_instance": {
+			Attribute: "instance_type",
+			Prices:    map[string]float64{"t3.micro": 7.59, "m5.large": 70.08},
+		},
+		"aws_db_instance": {
+			Monthly:   10,
+			Attribute: "instance_class",
+			Prices:    map[string]float64{"db.t3.micro": 12.41},
+		},
+	},
+}
+
+func TestLoadPricingTable(t *testing.T) {
+	cases := []struct {
+		description string
+		contents    string
+		expErr      string
+	}{
+		{
+			description: "valid",
+			contents: `currency: USD
+resources:
+  aws_nat_gateway:
+    monthly: 32.85
+  aws_instance:
+    attribute: instance_type
+    prices:
+      t3.micro: 7.59
+`,
+		},
+		{
+			description: "unknown key",
+			contents:    "currency: USD\nresource: {}\n",
+			expErr:      "field resource not found",
+		},
+		{
+			description: "no resources",
+			contents:    "currency: USD\n",
+			expErr:      "no resources are priced",
+		},
+		{
+			description: "negative price",
+			contents:    "resources:\n  aws_instance:\n    attribute: instance_type\n    prices:\n      t3.micro: -1\n",
+			expErr:      "aws_instance: price for instance_type \"t3.micro\" must not be negative",
+		},
+		{
+			description: "prices without attribute",
+			contents:    "resources:\n  aws_instance:\n    prices:\n      t3.micro: 7.59\n",
+			expErr:      "aws_instance: prices are set but attribute is not",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pricing.yaml")
+			Ok(t, os.WriteFile(path, []byte(c.contents), 0600))
+
+			table, err := cost.LoadPricingTable(path)
+			if c.expErr != "" {
+				ErrContains(t, c.expErr, err)
+				return
+			}
+			Ok(t, err)
+			Equals(t, "USD", table.Currency)
+			Equals(t, 7.59, table.Resources["aws_instance"].Prices["t3.micro"])
+		})
+	}
+
+	_, err := cost.LoadPricingTable(filepath.Join(t.TempDir(), "missing.yaml"))
+	ErrContains(t, "reading pricing table", err)
+}
+
+func TestEstimate(t *testing.T) {
+	showJSON := `{
+  "resource_changes": [
+    {"address": "aws_nat_gateway.new", "mode": "managed", "type": "aws_nat_gateway",
+     "change": {"actions": ["create"], "before": null, "after": {}}},
+    {"address": "aws_instance.resized", "mode": "managed", "type": "aws_instance",
+     "change": {"actions": ["update"], "before": {"instance_type": "t3.micro"}, "after": {"instance_type": "m5.large"}}},
+    {"address": "aws_db_instance.replaced", "mode": "managed", "type": "aws_db_instance",
+     "change": {"actions": ["delete", "create"], "before": {"instance_class": "db.t3.micro"}, "after": {"instance_class": "db.t3.micro"}}},
+    {"address": "aws_instance.removed", "mode": "managed", "type": "aws_instance",
+     "change": {"actions": ["delete"], "before": {"instance_type": "t3.micro"}, "after": null}},
+    {"address": "aws_instance.unchanged", "mode": "managed", "type": "aws_instance",
+     "change": {"actions": ["no-op"], "before": {"instance_type": "m5.large"}, "after": {"instance_type": "m5.large"}}},
+    {"address": "data.aws_nat_gateway.read", "mode": "data", "type": "aws_nat_gateway",
+     "change": {"actions": ["read"], "before": null, "after": {}}},
+    {"address": "aws_iam_role.new", "mode": "managed", "type": "aws_iam_role",
+     "change": {"actions": ["create"], "before": null, "after": {}}},
+    {"address": "aws_instance.unknown", "mode": "managed", "type": "aws_instance",
+     "change": {"actions": ["create"], "before": null, "after": {}}}
+  ]
+}`
+
+	estimate, err := pricingTable.Estimate([]byte(showJSON))
+	Ok(t, err)
+	Equals(t, "USD", estimate.Currency)
+	Equals(t, []models.ResourceCostEstimate{
+		{Address: "aws_nat_gateway.new", Action: "create", MonthlyDelta: 32.85},
+		{Address: "aws_instance.resized", Action: "update", MonthlyDelta: 70.08 - 7.59},
+		{Address: "aws_db_instance.replaced", Action: "replace", MonthlyDelta: 0},
+		{Address: "aws_instance.removed", Action: "delete", MonthlyDelta: -7.59},
+	}, estimate.Resources)
+	Equals(t, []string{"aws_iam_role.new", "aws_instance.unknown"}, estimate.Unpriced)
+	Assert(t, estimate.MonthlyDelta > 87.74 && estimate.MonthlyDelta < 87.76, "expected a monthly delta of 87.75, got %f", estimate.MonthlyDelta)
+}
+
+func TestEstimate_InvalidJSON(t *testing.T) {
+	_, err := pricingTable.Estimate([]byte("not json"))
+	ErrContains(t, "parsing plan JSON", err)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/runatlantis/atlantis/server/core/runtime/cost"
+	"github.com/runatlantis/atlantis/server/events/command"
+)
+
+// NewCostEstimateStepRunner returns a runner for the cost_estimate step that
+// prices plans with pricingTable. pricingTable may be nil if no pricing table
+// is configured, in which case the step fails.
+func NewCostEstimateStepRunner(pricingTable *cost.PricingTable) Runner {
+	return NewPlanTypeStepRunnerDelegate(&costEstimateStepRunner{pricingTable: pricingTable}, NullRunner{})
+}
+
+// costEstimateStepRunner estimates the change in monthly cost of the plan from
+// the output of the show step and writes it to a json file.
+type costEstimateStepRunner struct {
+	pricingTable *cost.PricingTable
+}
+
+func (c *costEstimateStepRunner) Run(ctx command.ProjectContext, _ []string, path string, _ map[string]string) (string, error) {
+	if c.pricingTable == nil {
+		return "", errors.New("no pricing table is configured, set --cost-pricing-file to use the cost_estimate step")
+	}
+
+	showJSON, err := os.ReadFile(filepath.Join(path, ctx.GetShowResultFileName()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.New("no plan JSON found, the cost_estimate step must run after the show step")
+		}
+		return "", fmt.Errorf("reading plan JSON: %w", err)
+	}
+
+	estimate, err := c.pricingTable.Estimate(showJSON)
+	if err != nil {
+		return "", fmt.Errorf("estimating cost: %w", err)
+	}
+	out, err := json.Marshal(estimate)
+	if err != nil {
+		return "", fmt.Errorf("encoding cost estimate: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, ctx.GetCostEstimateFileName()), out, 0600); err != nil {
+		return "", fmt.Errorf("writing cost estimate: %w", err)
+	}
+	ctx.Log.Info("estimated monthly cost delta of %.2f %s", estimate.MonthlyDelta, estimate.Currency)
+
+	// The estimate is rendered in the plan comment separately from the step
+	// output.
+	return "", nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/runtime/cost"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestCostEstimateStepRunner(t *testing.T) {
+	ctx := command.ProjectContext{
+		Workspace:   "default",
+		ProjectName: "test",
+		Log:         logging.NewNoopLogger(t),
+	}
+	pricingTable := &cost.PricingTable{
+		Currency:  "USD",
+		Resources: map[string]cost.ResourcePrice{"aws_nat_gateway": {Monthly: 32.85}},
+	}
+	showJSON := `{"resource_changes": [{"address": "aws_nat_gateway.main", "mode": "managed", "type": "aws_nat_gateway",
+		"change": {"actions": ["create"], "before": null, "after": {}}}]}`
+
+	t.Run("success", func(t *testing.T) {
+		path := t.TempDir()
+		Ok(t, os.WriteFile(filepath.Join(path, "test-default.json"), []byte(showJSON), 0600))
+		subject := costEstimateStepRunner{pricingTable: pricingTable}
+
+		out, err := subject.Run(ctx, nil, path, map[string]string{})
+		Ok(t, err)
+		Equals(t, "", out)
+
+		data, err := os.ReadFile(filepath.Join(path, "test-default-cost.json"))
+		Ok(t, err)
+		var estimate models.CostEstimate
+		Ok(t, json.Unmarshal(data, &estimate))
+		Equals(t, models.CostEstimate{
+			Currency:     "USD",
+			MonthlyDelta: 32.85,
+			Resources:    []models.ResourceCostEstimate{{Address: "aws_nat_gateway.main", Action: "create", MonthlyDelta: 32.85}},
+		}, estimate)
+	})
+
+	t.Run("show step hasn't run", func(t *testing.T) {
+		subject := costEstimateStepRunner{pricingTable: pricingTable}
+
+		_, err := subject.Run(ctx, nil, t.TempDir(), map[string]string{})
+		ErrEquals(t, "no plan JSON found, the cost_estimate step must run after the show step", err)
+	})
+
+	t.Run("no pricing table", func(t *testing.T) {
+		subject := costEstimateStepRunner{}
+
+		_, err := subject.Run(ctx, nil, t.TempDir(), map[string]string{})
+		ErrEquals(t, "no pricing table is configured, set --cost-pricing-file to use the cost_estimate step", err)
+	})
+}
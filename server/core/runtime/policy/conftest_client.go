@@ -203,33 +203,22 @@ func (c *ConfTestExecutorWorkflow) Run(ctx command.ProjectContext, executablePat
 			continue
 		}
 
-		args := ConftestTestCommandArgs{
-			PolicyArgs: []Arg{NewPolicyArg(path)},
-			ExtraArgs:  extraArgs,
-			InputFile:  inputFile,
-			Command:    executablePath,
+		var output string
+		var passed bool
+		var runErr error
+		if policySet.Engine == valid.OPAPolicyEngine {
+			output, passed, runErr = c.runOPA(path, inputFile, extraArgs)
+		} else {
+			output, passed, runErr = c.runConftest(executablePath, path, inputFile, envs, workdir, extraArgs)
 		}
-
-		serializedArgs, _ := args.build()
-		cmdOutput, cmdErr := c.Exec.CombinedOutput(serializedArgs, envs, workdir)
-
-		if cmdErr != nil {
-			// Since we're running conftest for each policyset, individual command errors should be concatenated.
-			if isValidConftestOutput(cmdOutput) {
-				combinedErr = errors.Join(combinedErr, fmt.Errorf("policy_set: %s: conftest: some policies failed", policySet.Name))
-			} else {
-				combinedErr = errors.Join(combinedErr, fmt.Errorf("policy_set: %s: conftest: %s", policySet.Name, cmdOutput))
-			}
-		}
-
-		passed := true
-		if cmdErr != nil || hasFailures(cmdOutput) {
-			passed = false
+		if runErr != nil {
+			// Since we're checking each policy set separately, individual errors should be concatenated.
+			combinedErr = errors.Join(combinedErr, fmt.Errorf("policy_set: %s: %w", policySet.Name, runErr))
 		}
 
 		// Sanitize before hashing so that hashes are stable across runs
 		// (temp file paths vary) and match what ends up in PolicyOutput.
-		sanitizedOutput := c.sanitizeOutput(inputFile, cmdOutput)
+		sanitizedOutput := c.sanitizeOutput(inputFile, output)
 
 		result, regexErr := models.NewPolicySetResult(
 			policySet.Name,
@@ -280,6 +269,44 @@ func (c *ConfTestExecutorWorkflow) Run(ctx command.ProjectContext, executablePat
 
 }
 
+// runConftest runs conftest on the policies at policyPath and returns its
+// output and whether all policies passed.
+func (c *ConfTestExecutorWorkflow) runConftest(executablePath string, policyPath string, inputFile string, envs map[string]string, workdir string, extraArgs []string) (string, bool, error) {
+	args := ConftestTestCommandArgs{
+		PolicyArgs: []Arg{NewPolicyArg(policyPath)},
+		ExtraArgs:  extraArgs,
+		InputFile:  inputFile,
+		Command:    executablePath,
+	}
+
+	serializedArgs, _ := args.build()
+	cmdOutput, cmdErr := c.Exec.CombinedOutput(serializedArgs, envs, workdir)
+
+	var err error
+	if cmdErr != nil {
+		if isValidConftestOutput(cmdOutput) {
+			err = errors.New("conftest: some policies failed")
+		} else {
+			err = fmt.Errorf("conftest: %s", cmdOutput)
+		}
+	}
+	passed := cmdErr == nil && !hasFailures(cmdOutput)
+	return cmdOutput, passed, err
+}
+
+// runOPA evaluates the policies at policyPath in-process and returns output
+// in the same format as conftest and whether all policies passed.
+func (c *ConfTestExecutorWorkflow) runOPA(policyPath string, inputFile string, extraArgs []string) (string, bool, error) {
+	output, passed, err := evaluateRego(context.Background(), policyPath, inputFile, extraArgs)
+	if err != nil {
+		return err.Error(), false, fmt.Errorf("opa: %w", err)
+	}
+	if !passed {
+		return output, false, errors.New("opa: some policies failed")
+	}
+	return output, true, nil
+}
+
 func (c *ConfTestExecutorWorkflow) sanitizeOutput(inputFile string, output string) string {
 	return strings.ReplaceAll(output, inputFile, "<redacted plan file>")
 }
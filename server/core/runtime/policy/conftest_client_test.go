@@ -4,9 +4,12 @@
 package policy
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-version"
@@ -356,3 +359,95 @@ func TestRun(t *testing.T) {
 
 	})
 }
+
+func TestRun_OPAEngine(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockResolver := conftest_mocks.NewMockSourceResolver()
+	mockExec := models_mocks.NewMockExec()
+	subject := &ConfTestExecutorWorkflow{
+		SourceResolver: mockResolver,
+		Exec:           mockExec,
+	}
+
+	policyDir, inputFile := writeRegoPolicies(t, map[string]string{"deletes.rego": denyDeletesPolicy})
+	workdir := filepath.Dir(inputFile)
+	Ok(t, os.Rename(inputFile, filepath.Join(workdir, "testproj-default.json")))
+
+	policySet := valid.PolicySet{
+		Source:          valid.LocalPolicySet,
+		Path:            policyDir,
+		Name:            "opa",
+		Engine:          valid.OPAPolicyEngine,
+		PolicyItemRegex: valid.DefaultPolicyItemRegex,
+	}
+	ctx := command.ProjectContext{
+		PolicySets: valid.PolicySets{
+			PolicySets:      []valid.PolicySet{policySet},
+			PolicyItemRegex: valid.DefaultPolicyItemRegex,
+		},
+		ProjectName: "testproj",
+		Workspace:   "default",
+		Log:         logging.NewNoopLogger(t),
+	}
+	When(mockResolver.Resolve(policySet)).ThenReturn(policyDir, nil)
+
+	result, err := subject.Run(ctx, "", nil, workdir, nil)
+	ErrContains(t, "policy_set: opa: opa: some policies failed", err)
+
+	expOutput := "FAIL - <redacted plan file> - main - aws_s3_bucket.logs is deleted\n\n1 test, 0 passed, 0 warnings, 1 failure, 0 exceptions\n"
+	var results []models.PolicySetResult
+	Ok(t, json.Unmarshal([]byte(result), &results))
+	Equals(t, []models.PolicySetResult{{
+		PolicySetName:   "opa",
+		PolicyOutput:    expOutput,
+		Passed:          false,
+		Hashes:          []string{models.HashPolicyItem(expOutput)},
+		PolicyItemRegex: valid.DefaultPolicyItemRegex,
+	}}, results)
+	mockExec.VerifyWasCalled(Never()).CombinedOutput(Any[[]string](), Any[map[string]string](), Any[string]())
+}
+
+// Test that the OPA engine gives the same policy item hashes as conftest, so
+// approvals carry over when a policy set switches engines.
+func TestRun_OPAEngineMatchesConftestHashes(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockResolver := conftest_mocks.NewMockSourceResolver()
+	mockExec := models_mocks.NewMockExec()
+	subject := &ConfTestExecutorWorkflow{
+		SourceResolver: mockResolver,
+		Exec:           mockExec,
+	}
+
+	policyDir, inputFile := writeRegoPolicies(t, map[string]string{"rules.rego": conftestOrderPolicy})
+	workdir := filepath.Dir(inputFile)
+	inputFile = filepath.Join(workdir, "testproj-default.json")
+	Ok(t, os.Rename(filepath.Join(workdir, "plan.json"), inputFile))
+	conftestOutput := strings.ReplaceAll(conftestOrderOutput, "INPUT", inputFile)
+	When(mockExec.CombinedOutput(Any[[]string](), Any[map[string]string](), Any[string]())).ThenReturn(conftestOutput, errors.New("exit status 1"))
+
+	for _, regex := range []string{valid.DefaultPolicyItemRegex, `(?m)^(FAIL|WARN) - .*$`} {
+		t.Run(regex, func(t *testing.T) {
+			conftestSet := valid.PolicySet{Source: valid.LocalPolicySet, Path: policyDir, Name: "conftest", PolicyItemRegex: regex}
+			opaSet := valid.PolicySet{Source: valid.LocalPolicySet, Path: policyDir, Name: "opa", Engine: valid.OPAPolicyEngine, PolicyItemRegex: regex}
+			ctx := command.ProjectContext{
+				PolicySets: valid.PolicySets{
+					PolicySets:      []valid.PolicySet{conftestSet, opaSet},
+					PolicyItemRegex: regex,
+				},
+				ProjectName: "testproj",
+				Workspace:   "default",
+				Log:         logging.NewNoopLogger(t),
+			}
+			When(mockResolver.Resolve(conftestSet)).ThenReturn(policyDir, nil)
+			When(mockResolver.Resolve(opaSet)).ThenReturn(policyDir, nil)
+
+			result, err := subject.Run(ctx, "/usr/bin/conftest", nil, workdir, nil)
+			Assert(t, err != nil, "expected policies to fail")
+			var results []models.PolicySetResult
+			Ok(t, json.Unmarshal([]byte(result), &results))
+			Equals(t, 2, len(results))
+			Equals(t, results[0].PolicyOutput, results[1].PolicyOutput)
+			Equals(t, results[0].Hashes, results[1].Hashes)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/loader"
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+const defaultRegoNamespace = "main"
+
+var (
+	// Rules are matched by name the same way conftest does, so policies
+	// written for conftest work unchanged.
+	denyRuleRegex = regexp.MustCompile(`^(deny|violation)(_[a-zA-Z0-9_]+)*$`)
+	warnRuleRegex = regexp.MustCompile(`^warn(_[a-zA-Z0-9_]+)*$`)
+)
+
+// evaluateRego evaluates the Rego policies under policyPath against the plan
+// JSON in inputFile, as `conftest test` would. Only the --namespace and
+// --all-namespaces conftest flags in extraArgs are used. The output is the same
+// as conftest's, so policy item hashes, and so approvals, match those of the
+// conftest engine.
+func evaluateRego(ctx context.Context, policyPath string, inputFile string, extraArgs []string) (output string, passed bool, err error) {
+	result, err := loader.NewFileLoader().Filtered([]string{policyPath}, isNotPolicyFile)
+	if err != nil {
+		return "", false, fmt.Errorf("loading policies: %w", err)
+	}
+	modules := result.ParsedModules()
+	if len(modules) == 0 {
+		return "", false, fmt.Errorf("no policies found in %s", policyPath)
+	}
+	compiler := ast.NewCompiler()
+	if compiler.Compile(modules); compiler.Failed() {
+		return "", false, fmt.Errorf("compiling policies: %w", compiler.Errors)
+	}
+
+	data, err := os.ReadFile(inputFile) // nolint: gosec
+	if err != nil {
+		return "", false, fmt.Errorf("reading plan JSON: %w", err)
+	}
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return "", false, fmt.Errorf("parsing plan JSON: %w", err)
+	}
+
+	var lines []string
+	var successes, warnings, failures int
+	for _, namespace := range regoNamespaces(modules, extraArgs) {
+		rs, err := rego.New(
+			rego.Query("data."+namespace),
+			rego.Compiler(compiler),
+			rego.Input(input),
+		).Eval(ctx)
+		if err != nil {
+			return "", false, fmt.Errorf("evaluating namespace %s: %w", namespace, err)
+		}
+		if len(rs) == 0 || len(rs[0].Expressions) == 0 {
+			continue
+		}
+		rules, ok := rs[0].Expressions[0].Value.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		// conftest prints each namespace's warnings before its failures.
+		var warnLines, failLines []string
+		for _, name := range namespaceRules(modules, namespace) {
+			value, ok := rules[name]
+			if !ok {
+				// The rule is undefined for this input.
+				continue
+			}
+			messages := ruleMessages(value)
+			if len(messages) == 0 {
+				successes++
+				continue
+			}
+			for _, msg := range messages {
+				if denyRuleRegex.MatchString(name) {
+					failLines = append(failLines, fmt.Sprintf("FAIL - %s - %s - %s", inputFile, namespace, msg))
+				} else {
+					warnLines = append(warnLines, fmt.Sprintf("WARN - %s - %s - %s", inputFile, namespace, msg))
+				}
+			}
+		}
+		lines = append(lines, warnLines...)
+		lines = append(lines, failLines...)
+		warnings += len(warnLines)
+		failures += len(failLines)
+	}
+
+	tests := successes + warnings + failures
+	lines = append(lines, "", fmt.Sprintf("%s, %d passed, %s, %s, 0 exceptions",
+		plural(tests, "test"), successes, plural(warnings, "warning"), plural(failures, "failure")))
+	return strings.Join(lines, "\n") + "\n", failures == 0, nil
+}
+
+// namespaceRules returns the deny and warn rules in namespace in the order
+// conftest evaluates them: each file's rules in the order they're written,
+// with each rule name once. conftest doesn't order the files, so they're
+// sorted by path.
+func namespaceRules(modules map[string]*ast.Module, namespace string) []string {
+	var names []string
+	for _, path := range slices.Sorted(maps.Keys(modules)) {
+		module := modules[path]
+		if strings.TrimPrefix(module.Package.Path.String(), "data.") != namespace {
+			continue
+		}
+		for _, rule := range module.Rules {
+			name := rule.Head.Ref().String()
+			if !denyRuleRegex.MatchString(name) && !warnRuleRegex.MatchString(name) {
+				continue
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// isNotPolicyFile filters out everything but Rego policies. Rego unit tests
+// are skipped as conftest does.
+func isNotPolicyFile(_ string, info fs.FileInfo, _ int) bool {
+	if info.IsDir() {
+		return false
+	}
+	return !strings.HasSuffix(info.Name(), ".rego") || strings.HasSuffix(info.Name(), "_test.rego")
+}
+
+// regoNamespaces returns the packages to evaluate based on conftest's
+// --namespace and --all-namespaces flags in extraArgs.
+func regoNamespaces(modules map[string]*ast.Module, extraArgs []string) []string {
+	var namespaces []string
+	for i := 0; i < len(extraArgs); i++ {
+		arg := extraArgs[i]
+		switch {
+		case arg == "--all-namespaces":
+			var all []string
+			for _, module := range modules {
+				all = append(all, strings.TrimPrefix(module.Package.Path.String(), "data."))
+			}
+			slices.Sort(all)
+			return slices.Compact(all)
+		case (arg == "--namespace" || arg == "-n") && i+1 < len(extraArgs):
+			i++
+			namespaces = append(namespaces, extraArgs[i])
+		case strings.HasPrefix(arg, "--namespace="):
+			namespaces = append(namespaces, strings.TrimPrefix(arg, "--namespace="))
+		}
+	}
+	if len(namespaces) == 0 {
+		return []string{defaultRegoNamespace}
+	}
+	return namespaces
+}
+
+// ruleMessages returns the messages of a deny or warn rule. Rules can produce
+// strings, or objects with a msg key as conftest allows.
+func ruleMessages(value any) []string {
+	var results []any
+	switch v := value.(type) {
+	case []any:
+		results = v
+	case string, map[string]any:
+		results = []any{v}
+	case bool:
+		if v {
+			return []string{""}
+		}
+		return nil
+	}
+
+	var messages []string
+	for _, result := range results {
+		switch r := result.(type) {
+		case string:
+			messages = append(messages, r)
+		case map[string]any:
+			if msg, ok := r["msg"].(string); ok {
+				messages = append(messages, msg)
+				continue
+			}
+			encoded, _ := json.Marshal(r)
+			messages = append(messages, string(encoded))
+		default:
+			encoded, _ := json.Marshal(r)
+			messages = append(messages, string(encoded))
+		}
+	}
+	return messages
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
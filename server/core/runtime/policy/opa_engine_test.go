@@ -0,0 +1,175 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+const testPlanJSON = `{"resource_changes":[{"address":"aws_s3_bucket.logs","type":"aws_s3_bucket","change":{"actions":["delete"]}}]}`
+
+func writeRegoPolicies(t *testing.T, files map[string]string) (string, string) {
+	t.Helper()
+	policyDir := t.TempDir()
+	for name, content := range files {
+		Ok(t, os.WriteFile(filepath.Join(policyDir, name), []byte(content), 0600))
+	}
+	inputFile := filepath.Join(t.TempDir(), "plan.json")
+	Ok(t, os.WriteFile(inputFile, []byte(testPlanJSON), 0600))
+	return policyDir, inputFile
+}
+
+const denyDeletesPolicy = `package main
+
+deny contains msg if {
+	some rc in input.resource_changes
+	"delete" in rc.change.actions
+	msg := sprintf("%s is deleted", [rc.address])
+}
+`
+
+// conftestOrderPolicy has rules that conftest reports in the order they're
+// written, not by name, and with warnings first.
+const conftestOrderPolicy = `package main
+
+deny_tags contains msg if {
+	some rc in input.resource_changes
+	msg := sprintf("%s has no tags", [rc.address])
+}
+
+warn_deletes contains "deleting resources" if {
+	some rc in input.resource_changes
+	"delete" in rc.change.actions
+}
+
+deny_deletes contains msg if {
+	some rc in input.resource_changes
+	"delete" in rc.change.actions
+	msg := sprintf("%s is deleted", [rc.address])
+}
+`
+
+// conftestOrderOutput is what `conftest test --no-color` prints for
+// conftestOrderPolicy, with the input file's path replaced by INPUT.
+const conftestOrderOutput = `WARN - INPUT - main - deleting resources
+FAIL - INPUT - main - aws_s3_bucket.logs has no tags
+FAIL - INPUT - main - aws_s3_bucket.logs is deleted
+
+3 tests, 0 passed, 1 warning, 2 failures, 0 exceptions
+`
+
+func TestEvaluateRego(t *testing.T) {
+	cases := []struct {
+		description string
+		files       map[string]string
+		extraArgs   []string
+		expOutput   string
+		expPassed   bool
+	}{
+		{
+			description: "failure",
+			files:       map[string]string{"deletes.rego": denyDeletesPolicy},
+			expOutput:   "FAIL - INPUT - main - aws_s3_bucket.logs is deleted\n\n1 test, 0 passed, 0 warnings, 1 failure, 0 exceptions\n",
+		},
+		{
+			description: "success",
+			files: map[string]string{"creates.rego": `package main
+
+deny contains msg if {
+	some rc in input.resource_changes
+	"create" in rc.change.actions
+	msg := "created"
+}
+`},
+			expOutput: "\n1 test, 1 passed, 0 warnings, 0 failures, 0 exceptions\n",
+			expPassed: true,
+		},
+		{
+			description: "warnings and msg objects",
+			files: map[string]string{"warn.rego": `package main
+
+warn_deletes contains {"msg": "deleting resources"} if {
+	some rc in input.resource_changes
+	"delete" in rc.change.actions
+}
+`},
+			expOutput: "WARN - INPUT - main - deleting resources\n\n1 test, 0 passed, 1 warning, 0 failures, 0 exceptions\n",
+			expPassed: true,
+		},
+		{
+			description: "rules in conftest's order",
+			files:       map[string]string{"rules.rego": conftestOrderPolicy},
+			expOutput:   conftestOrderOutput,
+		},
+		{
+			description: "rego tests are skipped",
+			files: map[string]string{
+				"deletes.rego":      denyDeletesPolicy,
+				"deletes_test.rego": "package main\n\ntest_fails if { false }\n",
+			},
+			expOutput: "FAIL - INPUT - main - aws_s3_bucket.logs is deleted\n\n1 test, 0 passed, 0 warnings, 1 failure, 0 exceptions\n",
+		},
+		{
+			description: "other namespaces are ignored by default",
+			files: map[string]string{
+				"deletes.rego": "package other\n" + denyDeletesPolicy[len("package main\n"):],
+			},
+			expOutput: "\n0 tests, 0 passed, 0 warnings, 0 failures, 0 exceptions\n",
+			expPassed: true,
+		},
+		{
+			description: "namespace flag",
+			files: map[string]string{
+				"deletes.rego": "package other\n" + denyDeletesPolicy[len("package main\n"):],
+			},
+			extraArgs: []string{"--namespace", "other"},
+			expOutput: "FAIL - INPUT - other - aws_s3_bucket.logs is deleted\n\n1 test, 0 passed, 0 warnings, 1 failure, 0 exceptions\n",
+		},
+		{
+			description: "all namespaces",
+			files: map[string]string{
+				"main.rego":  denyDeletesPolicy,
+				"other.rego": "package other\n" + denyDeletesPolicy[len("package main\n"):],
+			},
+			extraArgs: []string{"--all-namespaces"},
+			expOutput: "FAIL - INPUT - main - aws_s3_bucket.logs is deleted\nFAIL - INPUT - other - aws_s3_bucket.logs is deleted\n\n2 tests, 0 passed, 0 warnings, 2 failures, 0 exceptions\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			policyDir, inputFile := writeRegoPolicies(t, c.files)
+
+			output, passed, err := evaluateRego(context.Background(), policyDir, inputFile, c.extraArgs)
+			Ok(t, err)
+			Equals(t, c.expPassed, passed)
+			Equals(t, c.expOutput, strings.ReplaceAll(output, inputFile, "INPUT"))
+		})
+	}
+}
+
+func TestEvaluateRego_Errors(t *testing.T) {
+	t.Run("no policies", func(t *testing.T) {
+		policyDir, inputFile := writeRegoPolicies(t, map[string]string{"README.md": "# policies"})
+		_, _, err := evaluateRego(context.Background(), policyDir, inputFile, nil)
+		ErrContains(t, "no policies found", err)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		policyDir, inputFile := writeRegoPolicies(t, map[string]string{"bad.rego": "package main\n\ndeny contains msg if {\n"})
+		_, _, err := evaluateRego(context.Background(), policyDir, inputFile, nil)
+		ErrContains(t, "loading policies", err)
+	})
+
+	t.Run("compile error", func(t *testing.T) {
+		policyDir, inputFile := writeRegoPolicies(t, map[string]string{"bad.rego": "package main\n\ndeny contains msg if {\n\tmsg := undefined_function(input)\n}\n"})
+		_, _, err := evaluateRego(context.Background(), policyDir, inputFile, nil)
+		ErrContains(t, "compiling policies", err)
+	})
+}
@@ -30,10 +30,15 @@ func NewPolicyCheckStepRunner(defaultTfDistribution terraform.Distribution, defa
 
 // Run ensures a given version for the executable, builds the args from the project context and then runs executable returning the result
 func (p *policyCheckStepRunner) Run(ctx command.ProjectContext, extraArgs []string, path string, envs map[string]string) (string, error) {
-	executable, err := p.versionEnsurer.EnsureExecutorVersion(ctx.Log, ctx.PolicySets.Version)
-
-	if err != nil {
-		return "", fmt.Errorf("ensuring policy executor version: %w", err)
+	// Policy sets evaluated in-process don't need the conftest binary, so
+	// don't download it unless a policy set runs it.
+	var executable string
+	if !ctx.PolicySets.EvaluatedInProcess() {
+		var err error
+		executable, err = p.versionEnsurer.EnsureExecutorVersion(ctx.Log, ctx.PolicySets.Version)
+		if err != nil {
+			return "", fmt.Errorf("ensuring policy executor version: %w", err)
+		}
 	}
 
 	return p.executor.Run(ctx, executable, envs, path, extraArgs)
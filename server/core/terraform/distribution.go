@@ -142,3 +142,47 @@ func (*DistributionTerraform) ResolveConstraint(ctx context.Context, constraintS
 	// Get the Version object from the versionDownloader.
 	return versionDownloader.(*releases.ExactVersion).Version, nil
 }
+
+// WrappedDistribution is a Distribution whose commands run through a wrapper
+// binary that in turn runs the distribution's binary.
+type WrappedDistribution interface {
+	Distribution
+	// WrapperBinName is the name of the wrapper binary, looked up in $PATH.
+	WrapperBinName() string
+	// WrapperEnv returns the environment variables that point the wrapper at
+	// the distribution's binary at binPath.
+	WrapperEnv(binPath string) []string
+}
+
+// DistributionTerragrunt runs the commands of another distribution through
+// terragrunt. Versions are still resolved and downloaded for the wrapped
+// distribution, so BinName, Downloader and ResolveConstraint are its own.
+type DistributionTerragrunt struct {
+	Distribution
+}
+
+func NewDistributionTerragrunt(d Distribution) Distribution {
+	return &DistributionTerragrunt{
+		Distribution: d,
+	}
+}
+
+func (*DistributionTerragrunt) WrapperBinName() string {
+	return "terragrunt"
+}
+
+func (*DistributionTerragrunt) WrapperEnv(binPath string) []string {
+	return []string{
+		fmt.Sprintf("TG_TF_PATH=%s", binPath),
+		// Terragrunt versions before 0.73 use the TERRAGRUNT_ prefix.
+		fmt.Sprintf("TERRAGRUNT_TFPATH=%s", binPath),
+		"TG_NON_INTERACTIVE=true",
+		"TERRAGRUNT_NON_INTERACTIVE=true",
+		// Pass the distribution's stdout through without terragrunt's prefixes
+		// so output like `show -json` can be parsed.
+		"TG_TF_FORWARD_STDOUT=true",
+		"TERRAGRUNT_FORWARD_TF_STDOUT=true",
+		"TG_LOG_FORMAT=bare",
+		"TERRAGRUNT_LOG_FORMAT=bare",
+	}
+}
@@ -34,3 +34,14 @@ func TestResolveTerraformVersions(t *testing.T) {
 	Ok(t, err)
 	Equals(t, version.String(), "1.9.3")
 }
+
+func TestTerragruntWrapsDistribution(t *testing.T) {
+	d := terraform.NewDistributionTerragrunt(terraform.NewDistributionOpenTofu())
+	Equals(t, d.BinName(), "tofu")
+
+	wrapped, ok := d.(terraform.WrappedDistribution)
+	Assert(t, ok, "expected terragrunt to be a wrapped distribution")
+	Equals(t, wrapped.WrapperBinName(), "terragrunt")
+	Contains(t, "TG_TF_PATH=/bin/tofu1.8.0", wrapped.WrapperEnv("/bin/tofu1.8.0"))
+	Contains(t, "TG_TF_FORWARD_STDOUT=true", wrapped.WrapperEnv("/bin/tofu1.8.0"))
+}
@@ -0,0 +1,611 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package providercache manages the Terraform provider plugin cache and serves
+// a provider network mirror.
+//
+// Terraform doesn't lock its plugin cache, so projects that run terraform init
+// at the same time with a shared TF_PLUGIN_CACHE_DIR can corrupt it. Instead,
+// each project gets its own cache dir that's populated before init from a
+// shared store of extracted provider packages keyed by their h1: hashes from
+// .terraform.lock.hcl. New packages terraform downloads are added to the store
+// after init. Files are hard linked rather than copied, so a package only takes
+// up disk space once.
+package providercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+const (
+	storeDirName    = "store"
+	projectsDirName = "projects"
+	tmpDirName      = "tmp"
+	// pathFileSuffix is the suffix of the file next to each project cache dir
+	// that records which working dir it's for. It's kept outside the cache dir
+	// so terraform doesn't see it.
+	pathFileSuffix = ".path"
+)
+
+// addressPartRegex matches each part of a provider source address. It keeps
+// addresses from a lock file from escaping the cache dir.
+var addressPartRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Cache is a content-addressed store of provider packages that populates a
+// plugin cache dir for each project.
+type Cache struct {
+	dir string
+	// platform is the os_arch of the packages terraform installs, ex.
+	// linux_amd64.
+	platform string
+
+	// maxSize is the most bytes the store may use before the least recently
+	// used packages are evicted. Zero means no limit.
+	maxSize atomic.Int64
+
+	// storeLock is held for reading while packages are linked out of or added
+	// to the store and for writing while they're evicted.
+	storeLock sync.RWMutex
+	// projectLocks serializes commands that use the same project cache dir.
+	// Use projectLocksLock to control access.
+	projectLocks     map[string]*sync.Mutex
+	projectLocksLock sync.Mutex
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	added     atomic.Int64
+	evictions atomic.Int64
+}
+
+// Stats describes the cache's contents and how often it's been used since
+// Atlantis started.
+type Stats struct {
+	Dir string
+	// MaxSizeBytes is zero if the cache isn't limited.
+	MaxSizeBytes int64
+	SizeBytes    int64
+	Entries      int
+	// Hits is how many providers were in a project's cache dir, or were linked
+	// into it from the store, before terraform init.
+	Hits int64
+	// Misses is how many providers terraform init had to download.
+	Misses int64
+	// HitRate is Hits / (Hits + Misses), or zero before the first init.
+	HitRate   float64
+	Added     int64
+	Evictions int64
+}
+
+// New returns a cache in dir, creating it if it doesn't exist.
+func New(dir string) (*Cache, error) {
+	for _, sub := range []string{storeDirName, projectsDirName} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("creating provider cache dir: %w", err)
+		}
+	}
+	// Anything left in tmp is from a copy that was interrupted by a restart.
+	if err := os.RemoveAll(filepath.Join(dir, tmpDirName)); err != nil {
+		return nil, fmt.Errorf("cleaning provider cache dir: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, tmpDirName), 0700); err != nil {
+		return nil, fmt.Errorf("creating provider cache dir: %w", err)
+	}
+	return &Cache{
+		dir:          dir,
+		platform:     runtime.GOOS + "_" + runtime.GOARCH,
+		projectLocks: make(map[string]*sync.Mutex),
+	}, nil
+}
+
+// SetMaxSize sets the most bytes the store may use. Zero means no limit.
+func (c *Cache) SetMaxSize(bytes int64) {
+	c.maxSize.Store(bytes)
+}
+
+// ProjectDir returns the plugin cache dir for the project in the working dir
+// path, creating it if it doesn't exist.
+func (c *Cache) ProjectDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	dir := filepath.Join(c.dir, projectsDirName, hex.EncodeToString(sum[:8]))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("creating provider cache dir: %w", err)
+	}
+	// Record which working dir this is for so Evict can remove the cache dirs
+	// of working dirs that were deleted.
+	if err := os.WriteFile(dir+pathFileSuffix, []byte(abs), 0600); err != nil {
+		return "", fmt.Errorf("creating provider cache dir: %w", err)
+	}
+	return dir, nil
+}
+
+// Session is a terraform init using a project's cache dir. The dir is locked
+// until the session is closed.
+type Session struct {
+	// Dir is the project's cache dir, to use as TF_PLUGIN_CACHE_DIR.
+	Dir string
+
+	cache  *Cache
+	log    logging.SimpleLogging
+	path   string
+	unlock func()
+}
+
+// Prepare links the providers selected by the lock file in the working dir
+// path into the project's cache dir so terraform init doesn't need to
+// download them. Close the session once init finishes.
+func (c *Cache) Prepare(log logging.SimpleLogging, path string) (*Session, error) {
+	dir, err := c.ProjectDir(path)
+	if err != nil {
+		return nil, err
+	}
+	lock := c.projectLock(dir)
+	lock.Lock()
+	s := &Session{Dir: dir, cache: c, log: log, path: path, unlock: lock.Unlock}
+
+	providers, err := ParseLockFile(filepath.Join(path, LockFileName))
+	if err != nil {
+		// Init will still work, it'll just download the providers.
+		log.Warn("not populating provider cache: %s", err)
+		return s, nil
+	}
+	for _, p := range providers {
+		c.link(log, dir, p)
+	}
+	return s, nil
+}
+
+// Close adds the providers terraform init downloaded to the store, evicts
+// packages if the store is over its size limit and unlocks the project's
+// cache dir.
+func (s *Session) Close() {
+	defer s.unlock()
+	s.cache.collect(s.log, s.Dir, s.path)
+	if err := s.cache.Evict(s.log); err != nil {
+		s.log.Warn("evicting providers from cache: %s", err)
+	}
+}
+
+// link links p's package from the store into the project cache dir if it
+// isn't there already.
+func (c *Cache) link(log logging.SimpleLogging, projectDir string, p LockedProvider) {
+	target, ok := c.packageDir(projectDir, p)
+	if !ok {
+		return
+	}
+	if _, err := os.Stat(target); err == nil {
+		c.hits.Add(1)
+		return
+	}
+
+	c.storeLock.RLock()
+	defer c.storeLock.RUnlock()
+	for _, h := range p.h1Hashes() {
+		entry := c.entryDir(h)
+		if _, err := os.Stat(entry); err != nil {
+			continue
+		}
+		if _, err := c.linkIn(entry, target); err != nil {
+			log.Warn("linking %s %s from provider cache: %s", p.Address, p.Version, err)
+			break
+		}
+		touch(entry)
+		log.Debug("linked %s %s from provider cache", p.Address, p.Version)
+		c.hits.Add(1)
+		return
+	}
+	c.misses.Add(1)
+}
+
+// collect adds the packages in the project cache dir that match the working
+// dir's lock file to the store and removes packages the lock file no longer
+// selects.
+func (c *Cache) collect(log logging.SimpleLogging, projectDir string, path string) {
+	providers, err := ParseLockFile(filepath.Join(path, LockFileName))
+	if err != nil {
+		log.Warn("not adding providers to cache: %s", err)
+		return
+	}
+
+	c.storeLock.RLock()
+	defer c.storeLock.RUnlock()
+	var keep []string
+	for _, p := range providers {
+		pkg, ok := c.packageDir(projectDir, p)
+		if !ok {
+			continue
+		}
+		keep = append(keep, filepath.Dir(pkg))
+		info, err := os.Lstat(pkg)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		hash, err := dirhash.HashDir(pkg, "", dirhash.Hash1)
+		if err != nil {
+			log.Warn("hashing %s %s: %s", p.Address, p.Version, err)
+			continue
+		}
+		// Only trust packages terraform verified against the lock file.
+		if !slices.Contains(p.h1Hashes(), hash) {
+			log.Debug("not caching %s %s because its hash %s isn't in %s", p.Address, p.Version, hash, LockFileName)
+			continue
+		}
+		entry := c.entryDir(hash)
+		if _, err := os.Stat(entry); err == nil {
+			touch(entry)
+			continue
+		}
+		added, err := c.linkIn(pkg, entry)
+		if err != nil {
+			log.Warn("adding %s %s to provider cache: %s", p.Address, p.Version, err)
+			continue
+		}
+		if !added {
+			continue
+		}
+		log.Debug("added %s %s to provider cache", p.Address, p.Version)
+		c.added.Add(1)
+	}
+	if err := removeUnselected(projectDir, keep); err != nil {
+		log.Warn("removing old providers from cache: %s", err)
+	}
+}
+
+// Evict removes the cache dirs of working dirs that no longer exist and, if
+// the store is over its size limit, its least recently used packages.
+func (c *Cache) Evict(log logging.SimpleLogging) error {
+	if err := c.pruneProjects(log); err != nil {
+		return err
+	}
+	maxSize := c.maxSize.Load()
+	if maxSize <= 0 {
+		return nil
+	}
+
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	entries, err := c.storeEntries()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	// Oldest first.
+	slices.SortFunc(entries, func(a, b storeEntry) int {
+		return a.lastUsed.Compare(b.lastUsed)
+	})
+	for _, e := range entries {
+		if total <= maxSize {
+			break
+		}
+		if err := os.RemoveAll(e.path); err != nil {
+			return fmt.Errorf("evicting %s: %w", e.path, err)
+		}
+		total -= e.size
+		c.evictions.Add(1)
+		log.Debug("evicted %s from provider cache", filepath.Base(e.path))
+	}
+	return nil
+}
+
+// MigrateLegacy moves the providers that terraform installed directly into
+// the cache dir, when all projects shared it as TF_PLUGIN_CACHE_DIR, into the
+// store so that they count towards its size limit, and removes the old layout.
+// Packages for other platforms can't be used, so they're removed too.
+func (c *Cache) MigrateLegacy(log logging.SimpleLogging) error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("reading provider cache: %w", err)
+	}
+
+	c.storeLock.RLock()
+	defer c.storeLock.RUnlock()
+	for _, d := range dirEntries {
+		switch d.Name() {
+		case storeDirName, projectsDirName, tmpDirName:
+			continue
+		}
+		hostDir := filepath.Join(c.dir, d.Name())
+		// Packages are at host/namespace/type/version/platform.
+		pkgs, err := filepath.Glob(filepath.Join(hostDir, "*", "*", "*", c.platform))
+		if err != nil {
+			return err
+		}
+		for _, pkg := range pkgs {
+			c.migrate(log, pkg)
+		}
+		if err := os.RemoveAll(hostDir); err != nil {
+			return fmt.Errorf("removing old provider cache dir %s: %w", hostDir, err)
+		}
+		log.Info("moved providers from old provider cache dir %s", hostDir)
+	}
+	return nil
+}
+
+// migrate adds the package in the old cache layout at pkg to the store. It's
+// keyed by its own hash, so only projects whose lock file accepts that hash
+// use it.
+func (c *Cache) migrate(log logging.SimpleLogging, pkg string) {
+	info, err := os.Lstat(pkg)
+	if err != nil || !info.IsDir() {
+		return
+	}
+	hash, err := dirhash.HashDir(pkg, "", dirhash.Hash1)
+	if err != nil {
+		log.Warn("hashing %s: %s", pkg, err)
+		return
+	}
+	entry := c.entryDir(hash)
+	if _, err := os.Stat(entry); err == nil {
+		return
+	}
+	added, err := c.linkIn(pkg, entry)
+	if err != nil {
+		log.Warn("adding %s to provider cache: %s", pkg, err)
+		return
+	}
+	if added {
+		// Keep its age so that it's evicted before packages in use.
+		_ = os.Chtimes(entry, info.ModTime(), info.ModTime())
+	}
+}
+
+// Stats returns the cache's size and hit rate.
+func (c *Cache) Stats() (Stats, error) {
+	c.storeLock.RLock()
+	entries, err := c.storeEntries()
+	c.storeLock.RUnlock()
+	if err != nil {
+		return Stats{}, err
+	}
+	stats := Stats{
+		Dir:          c.dir,
+		MaxSizeBytes: c.maxSize.Load(),
+		Entries:      len(entries),
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		Added:        c.added.Load(),
+		Evictions:    c.evictions.Load(),
+	}
+	for _, e := range entries {
+		stats.SizeBytes += e.size
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	return stats, nil
+}
+
+// pruneProjects removes the cache dirs of working dirs that no longer exist,
+// ex. because their pull request was closed. Their files are hard links, so
+// this frees the disk space of packages that were evicted from the store.
+func (c *Cache) pruneProjects(log logging.SimpleLogging) error {
+	projectsDir := filepath.Join(c.dir, projectsDirName)
+	pathFiles, err := filepath.Glob(filepath.Join(projectsDir, "*"+pathFileSuffix))
+	if err != nil {
+		return err
+	}
+	for _, pathFile := range pathFiles {
+		workingDir, err := os.ReadFile(pathFile) // nolint: gosec
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(string(workingDir)); !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		dir := strings.TrimSuffix(pathFile, pathFileSuffix)
+		lock := c.projectLock(dir)
+		if !lock.TryLock() {
+			// It's in use, so the working dir was just recreated.
+			continue
+		}
+		err = os.RemoveAll(dir)
+		if err == nil {
+			err = os.Remove(pathFile)
+		}
+		lock.Unlock()
+		if err != nil {
+			return fmt.Errorf("removing provider cache dir for %s: %w", workingDir, err)
+		}
+		log.Debug("removed provider cache dir for deleted working dir %s", workingDir)
+	}
+	return nil
+}
+
+type storeEntry struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// storeEntries lists the packages in the store. Callers must hold storeLock.
+func (c *Cache) storeEntries() ([]storeEntry, error) {
+	storeDir := filepath.Join(c.dir, storeDirName)
+	dirEntries, err := os.ReadDir(storeDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading provider cache: %w", err)
+	}
+	entries := make([]storeEntry, 0, len(dirEntries))
+	for _, d := range dirEntries {
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+		e := storeEntry{path: filepath.Join(storeDir, d.Name()), lastUsed: info.ModTime()}
+		err = filepath.WalkDir(e.path, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			e.size += info.Size()
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading provider cache: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// packageDir returns where terraform expects p's package in a plugin cache
+// dir, or false if p's address isn't safe to use as a path.
+func (c *Cache) packageDir(cacheDir string, p LockedProvider) (string, bool) {
+	parts := strings.Split(p.Address, "/")
+	if len(parts) != 3 || !addressPartRegex.MatchString(p.Version) {
+		return "", false
+	}
+	for _, part := range parts {
+		if !addressPartRegex.MatchString(part) || part == ".." {
+			return "", false
+		}
+	}
+	return filepath.Join(cacheDir, parts[0], parts[1], parts[2], p.Version, c.platform), true
+}
+
+// entryDir returns the store dir for the package with h1 hash h. The hash is
+// base64, so it's made safe for filenames.
+func (c *Cache) entryDir(h string) string {
+	name := strings.NewReplacer("/", "_", "+", "-", ":", "-").Replace(h)
+	return filepath.Join(c.dir, storeDirName, name)
+}
+
+func (c *Cache) projectLock(dir string) *sync.Mutex {
+	c.projectLocksLock.Lock()
+	defer c.projectLocksLock.Unlock()
+	lock, ok := c.projectLocks[dir]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.projectLocks[dir] = lock
+	}
+	return lock
+}
+
+// linkIn hard links the package in src to dst. It's linked into a temporary
+// dir first and then renamed so that other projects never see a partial
+// package. If dst already exists, it's left as is and linkIn returns false.
+func (c *Cache) linkIn(src string, dst string) (bool, error) {
+	tmp, err := os.MkdirTemp(filepath.Join(c.dir, tmpDirName), "pkg")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(tmp) // nolint: errcheck
+	staged := filepath.Join(tmp, "pkg")
+	if err := linkTree(src, staged); err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return false, err
+	}
+	if err := os.Rename(staged, dst); err != nil {
+		if _, statErr := os.Stat(dst); statErr == nil {
+			// Another project added it first.
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// removeUnselected removes the provider versions in the project cache dir
+// that aren't in keep.
+func removeUnselected(projectDir string, keep []string) error {
+	// Versions are at host/namespace/type/version.
+	versionDirs, err := filepath.Glob(filepath.Join(projectDir, "*", "*", "*", "*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range versionDirs {
+		if slices.Contains(keep, dir) {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkTree recreates the dir src at dst, hard linking its files. Files are
+// copied if they can't be linked, ex. because dst is on another device.
+func linkTree(src string, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0755) // nolint: gosec
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			if err := os.Link(path, target); err == nil {
+				return nil
+			}
+			return copyFile(path, target)
+		default:
+			return nil
+		}
+	})
+}
+
+func copyFile(src string, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	defer in.Close() // nolint: errcheck
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close() // nolint: errcheck
+		return err
+	}
+	return out.Close()
+}
+
+// touch marks a store entry as used for least recently used eviction.
+func touch(path string) {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
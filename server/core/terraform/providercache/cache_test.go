@@ -0,0 +1,239 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package providercache_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+const nullAddress = "registry.terraform.io/hashicorp/null"
+
+// writePackage writes a fake provider package to dir and returns its h1 hash.
+func writePackage(t *testing.T, dir string, contents string) string {
+	Ok(t, os.MkdirAll(dir, 0700))
+	Ok(t, os.WriteFile(filepath.Join(dir, "terraform-provider-null_v3.2.0_x5"), []byte(contents), 0700)) // nolint: gosec
+	hash, err := dirhash.HashDir(dir, "", dirhash.Hash1)
+	Ok(t, err)
+	return hash
+}
+
+// writeLockFile writes a lock file selecting null 3.2.0 to a new working dir.
+func writeLockFile(t *testing.T, hashes ...string) string {
+	dir := t.TempDir()
+	lockFile := fmt.Sprintf("provider %q {\n  version     = \"3.2.0\"\n  constraints = \"~> 3.0\"\n  hashes = [\n", nullAddress)
+	for _, h := range hashes {
+		lockFile += fmt.Sprintf("    %q,\n", h)
+	}
+	lockFile += "  ]\n}\n"
+	Ok(t, os.WriteFile(filepath.Join(dir, providercache.LockFileName), []byte(lockFile), 0600))
+	return dir
+}
+
+// installedPackage returns where terraform init installs null 3.2.0 in the
+// plugin cache dir cacheDir.
+func installedPackage(cacheDir string) string {
+	return filepath.Join(cacheDir, nullAddress, "3.2.0", runtime.GOOS+"_"+runtime.GOARCH)
+}
+
+func TestParseLockFile(t *testing.T) {
+	dir := writeLockFile(t, "h1:abc=", "zh:def")
+	providers, err := providercache.ParseLockFile(filepath.Join(dir, providercache.LockFileName))
+	Ok(t, err)
+	Equals(t, []providercache.LockedProvider{{Address: nullAddress, Version: "3.2.0", Hashes: []string{"h1:abc=", "zh:def"}}}, providers)
+
+	providers, err = providercache.ParseLockFile(filepath.Join(t.TempDir(), providercache.LockFileName))
+	Ok(t, err)
+	Equals(t, 0, len(providers))
+
+	Ok(t, os.WriteFile(filepath.Join(dir, providercache.LockFileName), []byte("provider {"), 0600))
+	_, err = providercache.ParseLockFile(filepath.Join(dir, providercache.LockFileName))
+	ErrContains(t, "parsing", err)
+}
+
+func TestCache(t *testing.T) {
+	log := logging.NewNoopLogger(t)
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+	download := t.TempDir()
+	hash := writePackage(t, download, "null provider")
+
+	// The first project has to download the provider.
+	first := writeLockFile(t, hash, "zh:0123")
+	session, err := cache.Prepare(log, first)
+	Ok(t, err)
+	_, err = os.Stat(installedPackage(session.Dir))
+	Assert(t, os.IsNotExist(err), "expected provider not to be cached yet")
+	writePackage(t, installedPackage(session.Dir), "null provider")
+	session.Close()
+
+	// The second gets it from the store.
+	second := writeLockFile(t, hash, "zh:0123")
+	session, err = cache.Prepare(log, second)
+	Ok(t, err)
+	contents, err := os.ReadFile(filepath.Join(installedPackage(session.Dir), "terraform-provider-null_v3.2.0_x5"))
+	Ok(t, err)
+	Equals(t, "null provider", string(contents))
+	session.Close()
+
+	stats, err := cache.Stats()
+	Ok(t, err)
+	Equals(t, int64(1), stats.Hits)
+	Equals(t, int64(1), stats.Misses)
+	Equals(t, 0.5, stats.HitRate)
+	Equals(t, int64(1), stats.Added)
+	Equals(t, 1, stats.Entries)
+	Equals(t, int64(len("null provider")), stats.SizeBytes)
+
+	// Running init again in the same working dir reuses its cache dir.
+	session, err = cache.Prepare(log, second)
+	Ok(t, err)
+	session.Close()
+	stats, err = cache.Stats()
+	Ok(t, err)
+	Equals(t, int64(2), stats.Hits)
+}
+
+func TestCache_ConcurrentInits(t *testing.T) {
+	log := logging.NewNoopLogger(t)
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+	hash := writePackage(t, t.TempDir(), "null provider")
+
+	var dirs []string
+	for range 10 {
+		dirs = append(dirs, writeLockFile(t, hash))
+	}
+	var wg sync.WaitGroup
+	for _, dir := range dirs {
+		wg.Go(func() {
+			session, err := cache.Prepare(log, dir)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer session.Close()
+			if _, err := os.Stat(installedPackage(session.Dir)); os.IsNotExist(err) {
+				writePackage(t, installedPackage(session.Dir), "null provider")
+			}
+		})
+	}
+	wg.Wait()
+
+	stats, err := cache.Stats()
+	Ok(t, err)
+	Equals(t, 1, stats.Entries)
+	Equals(t, int64(1), stats.Added)
+	Equals(t, int64(10), stats.Hits+stats.Misses)
+}
+
+func TestCache_IgnoresPackagesNotInLockFile(t *testing.T) {
+	log := logging.NewNoopLogger(t)
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+	hash := writePackage(t, t.TempDir(), "null provider")
+
+	dir := writeLockFile(t, hash)
+	session, err := cache.Prepare(log, dir)
+	Ok(t, err)
+	writePackage(t, installedPackage(session.Dir), "tampered provider")
+	session.Close()
+
+	stats, err := cache.Stats()
+	Ok(t, err)
+	Equals(t, 0, stats.Entries)
+}
+
+func TestCache_RemovesUnselectedVersions(t *testing.T) {
+	log := logging.NewNoopLogger(t)
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+
+	dir := writeLockFile(t, writePackage(t, t.TempDir(), "null provider"))
+	session, err := cache.Prepare(log, dir)
+	Ok(t, err)
+	oldVersion := filepath.Join(session.Dir, nullAddress, "3.1.0")
+	writePackage(t, filepath.Join(oldVersion, runtime.GOOS+"_"+runtime.GOARCH), "old null provider")
+	session.Close()
+
+	_, err = os.Stat(oldVersion)
+	Assert(t, os.IsNotExist(err), "expected %s to be removed", oldVersion)
+}
+
+func TestCache_Evict(t *testing.T) {
+	log := logging.NewNoopLogger(t)
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+
+	dir := writeLockFile(t, writePackage(t, t.TempDir(), "null provider"))
+	session, err := cache.Prepare(log, dir)
+	Ok(t, err)
+	writePackage(t, installedPackage(session.Dir), "null provider")
+	projectDir := session.Dir
+	session.Close()
+
+	// Within the limit.
+	cache.SetMaxSize(1024)
+	Ok(t, cache.Evict(log))
+	stats, err := cache.Stats()
+	Ok(t, err)
+	Equals(t, 1, stats.Entries)
+
+	cache.SetMaxSize(1)
+	Ok(t, cache.Evict(log))
+	stats, err = cache.Stats()
+	Ok(t, err)
+	Equals(t, 0, stats.Entries)
+	Equals(t, int64(1), stats.Evictions)
+
+	// The project's cache dir is removed once its working dir is deleted.
+	_, err = os.Stat(projectDir)
+	Ok(t, err)
+	Ok(t, os.RemoveAll(dir))
+	Ok(t, cache.Evict(log))
+	_, err = os.Stat(projectDir)
+	Assert(t, os.IsNotExist(err), "expected %s to be removed", projectDir)
+}
+
+func TestCache_MigrateLegacy(t *testing.T) {
+	log := logging.NewNoopLogger(t)
+	dir := t.TempDir()
+	// Providers terraform installed when the cache dir was shared.
+	hash := writePackage(t, installedPackage(dir), "null provider")
+	writePackage(t, filepath.Join(dir, nullAddress, "3.2.0", "plan9_386"), "other platform")
+	cache, err := providercache.New(dir)
+	Ok(t, err)
+
+	Ok(t, cache.MigrateLegacy(log))
+	_, err = os.Stat(filepath.Join(dir, "registry.terraform.io"))
+	Assert(t, os.IsNotExist(err), "expected the old cache layout to be removed")
+	stats, err := cache.Stats()
+	Ok(t, err)
+	Equals(t, 1, stats.Entries)
+	Equals(t, int64(len("null provider")), stats.SizeBytes)
+
+	// Projects whose lock file accepts the migrated package get it from the
+	// store.
+	session, err := cache.Prepare(log, writeLockFile(t, hash))
+	Ok(t, err)
+	contents, err := os.ReadFile(filepath.Join(installedPackage(session.Dir), "terraform-provider-null_v3.2.0_x5"))
+	Ok(t, err)
+	Equals(t, "null provider", string(contents))
+	session.Close()
+
+	// Migrating again is a no-op.
+	Ok(t, cache.MigrateLegacy(log))
+	stats, err = cache.Stats()
+	Ok(t, err)
+	Equals(t, 1, stats.Entries)
+}
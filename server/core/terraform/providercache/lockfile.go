@@ -0,0 +1,82 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package providercache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// LockFileName is the name of the dependency lock file terraform init writes.
+const LockFileName = ".terraform.lock.hcl"
+
+// LockedProvider is a provider selection from a dependency lock file.
+type LockedProvider struct {
+	// Address is the provider's source address, ex.
+	// registry.terraform.io/hashicorp/aws.
+	Address string
+	Version string
+	// Hashes are the package checksums terraform accepts for this version,
+	// ex. h1:... or zh:....
+	Hashes []string
+}
+
+// h1Hashes returns the provider's h1: hashes. These are hashes of the
+// extracted package, so they identify cache entries.
+func (p LockedProvider) h1Hashes() []string {
+	var hashes []string
+	for _, h := range p.Hashes {
+		if strings.HasPrefix(h, "h1:") {
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
+}
+
+// lockFile is the HCL schema of a dependency lock file. Only the attributes
+// Atlantis uses are decoded.
+type lockFile struct {
+	Providers []struct {
+		Address string   `hcl:"address,label"`
+		Version string   `hcl:"version"`
+		Hashes  []string `hcl:"hashes,optional"`
+		Remain  hcl.Body `hcl:",remain"`
+	} `hcl:"provider,block"`
+	Remain hcl.Body `hcl:",remain"`
+}
+
+// ParseLockFile returns the providers selected in the lock file at path. It
+// returns no providers and no error if the file doesn't exist.
+func ParseLockFile(path string) ([]LockedProvider, error) {
+	src, err := os.ReadFile(path) // nolint: gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	file, diags := hclparse.NewParser().ParseHCL(src, path)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", path, diags.Error())
+	}
+	var lf lockFile
+	if diags := gohcl.DecodeBody(file.Body, nil, &lf); diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", path, diags.Error())
+	}
+	providers := make([]LockedProvider, 0, len(lf.Providers))
+	for _, p := range lf.Providers {
+		providers = append(providers, LockedProvider{
+			Address: p.Address,
+			Version: p.Version,
+			Hashes:  p.Hashes,
+		})
+	}
+	return providers, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package providercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Mirror serves the provider network mirror protocol from a local dir, so
+// that servers without internet access can install providers from Atlantis.
+// See https://developer.hashicorp.com/terraform/internals/provider-network-mirror-protocol.
+//
+// The dir uses the packed layout written by terraform providers mirror:
+// HOSTNAME/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_OS_ARCH.zip. The
+// index.json and VERSION.json files that command also writes are served as
+// is. If they're missing, they're generated from the zip files, so zip files
+// can be copied into the dir by hand.
+type Mirror struct {
+	dir string
+
+	// zipHashes caches the zh: hashes of zip files by path.
+	zipHashes sync.Map
+
+	requests atomic.Int64
+	notFound atomic.Int64
+}
+
+// MirrorStats describes how often the mirror has been used since Atlantis
+// started.
+type MirrorStats struct {
+	Dir      string
+	Requests int64
+	NotFound int64
+}
+
+type zipHash struct {
+	size    int64
+	modTime time.Time
+	hash    string
+}
+
+type mirrorIndex struct {
+	Versions map[string]struct{} `json:"versions"`
+}
+
+type mirrorArchive struct {
+	URL    string   `json:"url"`
+	Hashes []string `json:"hashes,omitempty"`
+}
+
+type mirrorVersion struct {
+	Archives map[string]mirrorArchive `json:"archives"`
+}
+
+// NewMirror returns a mirror of the providers in dir.
+func NewMirror(dir string) *Mirror {
+	return &Mirror{dir: dir}
+}
+
+// Stats returns how many requests the mirror has served.
+func (m *Mirror) Stats() MirrorStats {
+	return MirrorStats{
+		Dir:      m.dir,
+		Requests: m.requests.Load(),
+		NotFound: m.notFound.Load(),
+	}
+}
+
+// ServeHTTP serves paths of the form HOSTNAME/NAMESPACE/TYPE/FILE. Strip the
+// mirror's base path before calling it.
+func (m *Mirror) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	m.requests.Add(1)
+
+	parts := strings.Split(strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/"), "/")
+	if len(parts) != 4 {
+		m.respondNotFound(w)
+		return
+	}
+	for _, part := range parts {
+		if !addressPartRegex.MatchString(part) || part == ".." {
+			m.respondNotFound(w)
+			return
+		}
+	}
+	providerDir := filepath.Join(m.dir, parts[0], parts[1], parts[2])
+	file := parts[3]
+
+	if info, err := os.Stat(filepath.Join(providerDir, file)); err == nil && info.Mode().IsRegular() {
+		http.ServeFile(w, r, filepath.Join(providerDir, file))
+		return
+	}
+
+	var body any
+	switch {
+	case file == "index.json":
+		index, ok := m.index(providerDir, parts[2])
+		if !ok {
+			m.respondNotFound(w)
+			return
+		}
+		body = index
+	case strings.HasSuffix(file, ".json"):
+		version, ok := m.version(providerDir, parts[2], strings.TrimSuffix(file, ".json"))
+		if !ok {
+			m.respondNotFound(w)
+			return
+		}
+		body = version
+	default:
+		m.respondNotFound(w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body) // nolint: errcheck
+}
+
+func (m *Mirror) respondNotFound(w http.ResponseWriter) {
+	m.notFound.Add(1)
+	http.Error(w, "not found", http.StatusNotFound)
+}
+
+// index lists the versions that have a zip file in providerDir.
+func (m *Mirror) index(providerDir string, providerType string) (mirrorIndex, bool) {
+	index := mirrorIndex{Versions: map[string]struct{}{}}
+	for _, zip := range m.zips(providerDir, providerType) {
+		index.Versions[zip.version] = struct{}{}
+	}
+	return index, len(index.Versions) > 0
+}
+
+// version lists the platforms that have a zip file for version in
+// providerDir.
+func (m *Mirror) version(providerDir string, providerType string, version string) (mirrorVersion, bool) {
+	v := mirrorVersion{Archives: map[string]mirrorArchive{}}
+	for _, zip := range m.zips(providerDir, providerType) {
+		if zip.version != version {
+			continue
+		}
+		archive := mirrorArchive{URL: zip.name}
+		if hash, err := m.zipHash(filepath.Join(providerDir, zip.name)); err == nil {
+			archive.Hashes = []string{hash}
+		}
+		v.Archives[zip.platform] = archive
+	}
+	return v, len(v.Archives) > 0
+}
+
+type mirrorZip struct {
+	name     string
+	version  string
+	platform string
+}
+
+// zips returns the zip files in providerDir named
+// terraform-provider-TYPE_VERSION_OS_ARCH.zip.
+func (m *Mirror) zips(providerDir string, providerType string) []mirrorZip {
+	entries, err := os.ReadDir(providerDir)
+	if err != nil {
+		return nil
+	}
+	prefix := "terraform-provider-" + providerType + "_"
+	var zips []mirrorZip
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".zip") {
+			continue
+		}
+		// VERSION_OS_ARCH. Versions don't contain underscores.
+		fields := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".zip"), "_")
+		if len(fields) != 3 {
+			continue
+		}
+		zips = append(zips, mirrorZip{name: name, version: fields[0], platform: fields[1] + "_" + fields[2]})
+	}
+	return zips
+}
+
+// zipHash returns the zh: hash of the zip file at path, which is the SHA-256
+// of the file.
+func (m *Mirror) zipHash(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if cached, ok := m.zipHashes.Load(path); ok {
+		c := cached.(zipHash)
+		if c.size == info.Size() && c.modTime.Equal(info.ModTime()) {
+			return c.hash, nil
+		}
+	}
+	f, err := os.Open(path) // nolint: gosec
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() // nolint: errcheck
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	hash := "zh:" + hex.EncodeToString(h.Sum(nil))
+	m.zipHashes.Store(path, zipHash{size: info.Size(), modTime: info.ModTime(), hash: hash})
+	return hash, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package providercache_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func setupMirror(t *testing.T) (*providercache.Mirror, string) {
+	dir := t.TempDir()
+	providerDir := filepath.Join(dir, "registry.terraform.io", "hashicorp", "null")
+	Ok(t, os.MkdirAll(providerDir, 0700))
+	for _, name := range []string{
+		"terraform-provider-null_3.2.0_linux_amd64.zip",
+		"terraform-provider-null_3.2.0_darwin_arm64.zip",
+		"terraform-provider-null_3.1.0_linux_amd64.zip",
+		"README.md",
+	} {
+		Ok(t, os.WriteFile(filepath.Join(providerDir, name), []byte(name), 0600))
+	}
+	return providercache.NewMirror(dir), providerDir
+}
+
+func mirrorGet(m *providercache.Mirror, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w
+}
+
+func TestMirror_GeneratesIndex(t *testing.T) {
+	m, _ := setupMirror(t)
+
+	w := mirrorGet(m, "/registry.terraform.io/hashicorp/null/index.json")
+	Equals(t, http.StatusOK, w.Code)
+	Equals(t, "application/json", w.Header().Get("Content-Type"))
+	var index struct {
+		Versions map[string]struct{} `json:"versions"`
+	}
+	Ok(t, json.Unmarshal(w.Body.Bytes(), &index))
+	Equals(t, map[string]struct{}{"3.1.0": {}, "3.2.0": {}}, index.Versions)
+
+	w = mirrorGet(m, "/registry.terraform.io/hashicorp/null/3.2.0.json")
+	Equals(t, http.StatusOK, w.Code)
+	var version struct {
+		Archives map[string]struct {
+			URL    string   `json:"url"`
+			Hashes []string `json:"hashes"`
+		} `json:"archives"`
+	}
+	Ok(t, json.Unmarshal(w.Body.Bytes(), &version))
+	Equals(t, 2, len(version.Archives))
+	linux := version.Archives["linux_amd64"]
+	Equals(t, "terraform-provider-null_3.2.0_linux_amd64.zip", linux.URL)
+	sum := sha256.Sum256([]byte("terraform-provider-null_3.2.0_linux_amd64.zip"))
+	Equals(t, []string{"zh:" + hex.EncodeToString(sum[:])}, linux.Hashes)
+
+	w = mirrorGet(m, "/registry.terraform.io/hashicorp/null/terraform-provider-null_3.2.0_linux_amd64.zip")
+	Equals(t, http.StatusOK, w.Code)
+	Equals(t, "terraform-provider-null_3.2.0_linux_amd64.zip", w.Body.String())
+}
+
+func TestMirror_ServesStaticIndex(t *testing.T) {
+	m, providerDir := setupMirror(t)
+	Ok(t, os.WriteFile(filepath.Join(providerDir, "index.json"), []byte(`{"versions":{"3.2.0":{}}}`), 0600))
+
+	w := mirrorGet(m, "/registry.terraform.io/hashicorp/null/index.json")
+	Equals(t, http.StatusOK, w.Code)
+	Equals(t, `{"versions":{"3.2.0":{}}}`, w.Body.String())
+}
+
+func TestMirror_NotFound(t *testing.T) {
+	m, _ := setupMirror(t)
+	for _, path := range []string{
+		"/registry.terraform.io/hashicorp/aws/index.json",
+		"/registry.terraform.io/hashicorp/null/9.9.9.json",
+		"/registry.terraform.io/hashicorp/null/missing.zip",
+		"/registry.terraform.io/hashicorp/null",
+		"/registry.terraform.io/../../etc/passwd",
+	} {
+		t.Run(path, func(t *testing.T) {
+			Equals(t, http.StatusNotFound, mirrorGet(m, path).Code)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("POST", "/registry.terraform.io/hashicorp/null/index.json", nil))
+	Equals(t, http.StatusMethodNotAllowed, w.Code)
+
+	stats := m.Stats()
+	Equals(t, int64(5), stats.Requests)
+	Equals(t, int64(5), stats.NotFound)
+}
@@ -6,6 +6,7 @@
 package tfclient
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"github.com/runatlantis/atlantis/server/core/runtime/models"
 	"github.com/runatlantis/atlantis/server/core/terraform"
 	"github.com/runatlantis/atlantis/server/core/terraform/ansi"
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/jobs"
 	"github.com/runatlantis/atlantis/server/logging"
@@ -82,6 +84,10 @@ type DefaultClient struct {
 
 	// usePluginCache determines whether or not to set the TF_PLUGIN_CACHE_DIR env var
 	usePluginCache bool
+	// providerCache gives each project its own TF_PLUGIN_CACHE_DIR populated
+	// from a shared store. If it's nil, terraformPluginCacheDir is shared by
+	// every project.
+	providerCache *providercache.Cache
 
 	projectCmdOutputHandler jobs.ProjectCommandOutputHandler
 }
@@ -161,6 +167,14 @@ func NewClientWithDefaultVersion(
 		}
 	}
 
+	var providerCache *providercache.Cache
+	if usePluginCache {
+		providerCache, err = providercache.New(cacheDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// If tfeToken is set, we try to create a ~/.terraformrc file.
 	if tfeToken != "" {
 		home, err := homedir.Dir()
@@ -183,6 +197,7 @@ func NewClientWithDefaultVersion(
 		versionLocks:            versionLocks,
 		downloadLock:            &downloadLock,
 		usePluginCache:          usePluginCache,
+		providerCache:           providerCache,
 		projectCmdOutputHandler: projectCmdOutputHandler,
 	}, nil
 
@@ -272,6 +287,12 @@ func (c *DefaultClient) TerraformBinDir() string {
 	return c.binDir
 }
 
+// ProviderCache returns the provider plugin cache, or nil if the plugin cache
+// is disabled.
+func (c *DefaultClient) ProviderCache() *providercache.Cache {
+	return c.providerCache
+}
+
 // ExtractExactRegex attempts to extract an exact version number from the provided string as a fallback.
 // The function expects the version string to be in one of the following formats: "= x.y.z", "=x.y.z", or "x.y.z" where x, y, and z are integers.
 // If the version string matches one of these formats, the function returns a slice containing the exact version number.
@@ -390,7 +411,8 @@ func (c *DefaultClient) RunCommandWithVersion(ctx command.ProjectContext, path s
 		output = ansi.Strip(output)
 		return fmt.Sprintf("%s\n", output), err
 	}
-	tfCmd, cmd, err := c.prepExecCmd(ctx.Log, d, v, workspace, path, args)
+	dist := c.commandDistribution(ctx, d)
+	tfCmd, cmd, err := c.prepExecCmd(ctx.Log, dist, v, workspace, path, args)
 	if err != nil {
 		return "", err
 	}
@@ -400,7 +422,12 @@ func (c *DefaultClient) RunCommandWithVersion(ctx command.ProjectContext, path s
 	}
 	cmd.Env = envVars
 	start := time.Now()
-	out, err := cmd.CombinedOutput()
+	var out []byte
+	if _, ok := dist.(terraform.WrappedDistribution); ok {
+		out, err = wrapperOutput(ctx.Log, tfCmd, cmd)
+	} else {
+		out, err = cmd.CombinedOutput()
+	}
 	dur := time.Since(start)
 	log := ctx.Log.With("duration", dur)
 	if err != nil {
@@ -413,6 +440,22 @@ func (c *DefaultClient) RunCommandWithVersion(ctx command.ProjectContext, path s
 	return ansi.Strip(string(out)), nil
 }
 
+// wrapperOutput runs cmd, which runs through a wrapper like terragrunt, and
+// returns its stdout. Wrappers log to stderr, which would corrupt output that
+// callers parse, like `show -json`, so stderr is only returned if cmd fails.
+func wrapperOutput(log logging.SimpleLogging, tfCmd string, cmd *exec.Cmd) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return append(stdout.Bytes(), stderr.Bytes()...), err
+	}
+	if stderr.Len() > 0 {
+		log.Debug("'%s' wrote to stderr: %s", tfCmd, ansi.Strip(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
 // prepExecCmd builds a ready to execute command based on the version of terraform
 // v, and args. It returns a printable representation of the command that will
 // be run and the actual command.
@@ -459,11 +502,25 @@ func (c *DefaultClient) prepCmd(log logging.SimpleLogging, d terraform.Distribut
 		fmt.Sprintf("DIR=%s", path),
 	}
 	if c.usePluginCache {
-		envVars = append(envVars, fmt.Sprintf("TF_PLUGIN_CACHE_DIR=%s", c.terraformPluginCacheDir))
+		cacheDir := c.terraformPluginCacheDir
+		if c.providerCache != nil {
+			var err error
+			cacheDir, err = c.providerCache.ProjectDir(path)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		envVars = append(envVars, fmt.Sprintf("TF_PLUGIN_CACHE_DIR=%s", cacheDir))
 	}
 	// Append current Atlantis process's environment variables, ex.
 	// AWS_ACCESS_KEY.
 	envVars = append(envVars, os.Environ()...)
+	if wrapped, ok := d.(terraform.WrappedDistribution); ok {
+		// Set after the process's environment so it can't point the wrapper
+		// at a different binary.
+		envVars = append(envVars, wrapped.WrapperEnv(binPath)...)
+		binPath = wrapped.WrapperBinName()
+	}
 	tfCmd := fmt.Sprintf("%s %s", binPath, strings.Join(args, " "))
 	return tfCmd, envVars, nil
 }
@@ -475,6 +532,15 @@ func (c *DefaultClient) effectiveDistribution(d terraform.Distribution) terrafor
 	return c.distribution
 }
 
+// commandDistribution returns the distribution to run ctx's commands with.
+// Terragrunt projects run the distribution through terragrunt.
+func (c *DefaultClient) commandDistribution(ctx command.ProjectContext, d terraform.Distribution) terraform.Distribution {
+	if ctx.Terragrunt {
+		return terraform.NewDistributionTerragrunt(c.effectiveDistribution(d))
+	}
+	return d
+}
+
 // RunCommandAsync runs terraform with args. It immediately returns an
 // input and output channel. Callers can use the output channel to
 // get the realtime output from the command.
@@ -482,8 +548,22 @@ func (c *DefaultClient) effectiveDistribution(d terraform.Distribution) terrafor
 // If any error is passed on the out channel, there will be no
 // further output (so callers are free to exit).
 func (c *DefaultClient) RunCommandAsync(ctx command.ProjectContext, path string, args []string, customEnvVars map[string]string, d terraform.Distribution, v *version.Version, workspace string) (chan<- string, <-chan models.Line) {
-	cmd, envVars, err := c.prepCmd(ctx.Log, d, v, workspace, path, args)
+	// Populate the project's plugin cache dir before init and collect what it
+	// downloaded afterwards.
+	var cacheSession *providercache.Session
+	if c.providerCache != nil && c.usePluginCache && args[0] == "init" {
+		var err error
+		cacheSession, err = c.providerCache.Prepare(ctx.Log, path)
+		if err != nil {
+			ctx.Log.Warn("preparing provider cache: %s", err)
+		}
+	}
+
+	cmd, envVars, err := c.prepCmd(ctx.Log, c.commandDistribution(ctx, d), v, workspace, path, args)
 	if err != nil {
+		if cacheSession != nil {
+			cacheSession.Close()
+		}
 		// The signature of `RunCommandAsync` doesn't provide for returning an immediate error, only one
 		// once reading the output. Since we won't be spawning a process, simulate that by sending the
 		// errorcustomEnvVars to the output channel.
@@ -503,9 +583,32 @@ func (c *DefaultClient) RunCommandAsync(ctx command.ProjectContext, path string,
 
 	runner := models.NewShellCommandRunner(nil, cmd, envVars, path, !ctx.SuppressJobOutput, c.projectCmdOutputHandler)
 	inCh, outCh := runner.RunCommandAsync(ctx)
+	if cacheSession != nil {
+		outCh = closeAfter(outCh, cacheSession)
+	}
 	return inCh, outCh
 }
 
+// closeAfter forwards the output of a command and closes session once the
+// command exits, before closing the returned channel.
+func closeAfter(outCh <-chan models.Line, session *providercache.Session) <-chan models.Line {
+	forwarded := make(chan models.Line)
+	go func() {
+		defer close(forwarded)
+		defer session.Close()
+		stopped := false
+		for line := range outCh {
+			// Callers stop reading after an error, so drain the rest without
+			// forwarding it.
+			if !stopped {
+				forwarded <- line
+			}
+			stopped = stopped || line.Err != nil
+		}
+	}()
+	return forwarded
+}
+
 // MustConstraint will parse one or more constraints from the given
 // constraint string. The string must be a comma-separated list of
 // constraints. It panics if there is an error.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -15,12 +16,14 @@ import (
 	runtimemodels "github.com/runatlantis/atlantis/server/core/runtime/models"
 	"github.com/runatlantis/atlantis/server/core/terraform"
 	terraform_mocks "github.com/runatlantis/atlantis/server/core/terraform/mocks"
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	jobmocks "github.com/runatlantis/atlantis/server/jobs/mocks"
 	"github.com/runatlantis/atlantis/server/logging"
 	logmocks "github.com/runatlantis/atlantis/server/logging/mocks"
 	. "github.com/runatlantis/atlantis/testing"
+	"golang.org/x/mod/sumdb/dirhash"
 )
 
 // Test that we write the file as expected
@@ -133,6 +136,67 @@ func TestDefaultClient_RunCommandWithVersion_EnvVars(t *testing.T) {
 	Equals(t, exp, out)
 }
 
+// Test that terragrunt projects run through terragrunt, pointed at the
+// distribution's binary.
+func TestDefaultClient_RunCommandWithVersion_Terragrunt(t *testing.T) {
+	v, err := version.NewVersion("0.11.11")
+	Ok(t, err)
+	tmp := t.TempDir()
+	binDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(binDir, "terragrunt"), []byte("#!/bin/sh\necho \"$TG_TF_PATH $*\"\n"), 0700) // nolint: gosec
+	Ok(t, err)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ctx := command.ProjectContext{
+		Log:        logging.NewNoopLogger(t),
+		Workspace:  "default",
+		RepoRelDir: ".",
+		Terragrunt: true,
+	}
+	client := &DefaultClient{
+		defaultVersion:          v,
+		overrideTF:              "echo",
+		projectCmdOutputHandler: jobmocks.NewMockProjectCommandOutputHandler(),
+	}
+
+	out, err := client.RunCommandWithVersion(ctx, tmp, []string{"workspace", "show"}, map[string]string{}, nil, nil, "default")
+	Ok(t, err)
+	Equals(t, "echo workspace show\n", out)
+}
+
+// Test that terragrunt's logs on stderr are kept out of the output of
+// commands like `show -json`, but returned if the command fails.
+func TestDefaultClient_RunCommandWithVersion_TerragruntStderr(t *testing.T) {
+	v, err := version.NewVersion("0.11.11")
+	Ok(t, err)
+	tmp := t.TempDir()
+	binDir := t.TempDir()
+	script := "#!/bin/sh\necho \"INFO $TG_TF_FORWARD_STDOUT $TG_LOG_FORMAT\" >&2\necho '{\"format_version\":\"1.2\"}'\n[ \"$1\" = show ]\n"
+	err = os.WriteFile(filepath.Join(binDir, "terragrunt"), []byte(script), 0700) // nolint: gosec
+	Ok(t, err)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ctx := command.ProjectContext{
+		Log:        logging.NewNoopLogger(t),
+		Workspace:  "default",
+		RepoRelDir: ".",
+		Terragrunt: true,
+	}
+	client := &DefaultClient{
+		defaultVersion:          v,
+		overrideTF:              "echo",
+		projectCmdOutputHandler: jobmocks.NewMockProjectCommandOutputHandler(),
+	}
+
+	out, err := client.RunCommandWithVersion(ctx, tmp, []string{"show", "-json", "plan.tfplan"}, map[string]string{}, nil, nil, "default")
+	Ok(t, err)
+	Equals(t, "{\"format_version\":\"1.2\"}\n", out)
+
+	out, err = client.RunCommandWithVersion(ctx, tmp, []string{"version"}, map[string]string{}, nil, nil, "default")
+	Assert(t, err != nil, "expected error")
+	Equals(t, "{\"format_version\":\"1.2\"}\nINFO true bare\n", out)
+}
+
 // Test that it returns an error on error.
 func TestDefaultClient_RunCommandWithVersion_Error(t *testing.T) {
 	v, err := version.NewVersion("0.11.11")
@@ -230,6 +294,58 @@ func TestDefaultClient_RunCommandAsync_Success(t *testing.T) {
 	logger.VerifyWasCalledOnce().With(Eq("duration"), Any[any]())
 }
 
+// Test that init populates and collects the project's provider cache dir.
+func TestDefaultClient_RunCommandAsync_ProviderCache(t *testing.T) {
+	RegisterMockTestingT(t)
+	v, err := version.NewVersion("0.11.11")
+	Ok(t, err)
+	tmp := t.TempDir()
+	cache, err := providercache.New(t.TempDir())
+	Ok(t, err)
+
+	// A fake terraform that "downloads" the null provider into the cache dir.
+	pkg := filepath.Join(t.TempDir(), "null")
+	Ok(t, os.MkdirAll(pkg, 0700))
+	Ok(t, os.WriteFile(filepath.Join(pkg, "terraform-provider-null"), []byte("null provider"), 0600))
+	hash, err := dirhash.HashDir(pkg, "", dirhash.Hash1)
+	Ok(t, err)
+	Ok(t, os.WriteFile(filepath.Join(tmp, providercache.LockFileName), fmt.Appendf(nil, `provider "registry.terraform.io/hashicorp/null" {
+  version = "3.2.0"
+  hashes  = [%q]
+}
+`, hash), 0600))
+	installed := filepath.Join("registry.terraform.io", "hashicorp", "null", "3.2.0", runtime.GOOS+"_"+runtime.GOARCH)
+	fakeTF := filepath.Join(t.TempDir(), "terraform")
+	script := fmt.Sprintf("#!/bin/sh\nmkdir -p \"$TF_PLUGIN_CACHE_DIR/%s\"\ncp %s/* \"$TF_PLUGIN_CACHE_DIR/%s\"\necho \"$TF_PLUGIN_CACHE_DIR\"\n", installed, pkg, installed)
+	Ok(t, os.WriteFile(fakeTF, []byte(script), 0700)) // nolint: gosec
+
+	ctx := command.ProjectContext{
+		Log:        logging.NewNoopLogger(t),
+		Workspace:  "default",
+		RepoRelDir: ".",
+	}
+	client := &DefaultClient{
+		defaultVersion:          v,
+		overrideTF:              fakeTF,
+		usePluginCache:          true,
+		providerCache:           cache,
+		projectCmdOutputHandler: jobmocks.NewMockProjectCommandOutputHandler(),
+	}
+
+	_, outCh := client.RunCommandAsync(ctx, tmp, []string{"init"}, map[string]string{}, nil, nil, "default")
+	out, err := waitCh(outCh)
+	Ok(t, err)
+	projectDir, err := cache.ProjectDir(tmp)
+	Ok(t, err)
+	Equals(t, projectDir, out)
+
+	stats, err := cache.Stats()
+	Ok(t, err)
+	Equals(t, int64(1), stats.Misses)
+	Equals(t, int64(1), stats.Added)
+	Equals(t, 1, stats.Entries)
+}
+
 func TestDefaultClient_RunCommandAsyncSuppressesProjectOutputHandler(t *testing.T) {
 	RegisterMockTestingT(t)
 	v, err := version.NewVersion("0.11.11")
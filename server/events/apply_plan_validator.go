@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -116,6 +117,14 @@ func (v *DefaultApplyPlanValidator) ValidateProjectPlan(ctx command.ProjectConte
 		return fmt.Errorf("checking plan file for dir %q workspace %q project %q: %w", ctx.RepoRelDir, ctx.Workspace, ctx.ProjectName, err)
 	}
 
+	currentHead := ctx.Pull.HeadCommit
+	if livePull.HeadCommit != "" {
+		currentHead = livePull.HeadCommit
+	}
+	if err := validatePlanIntegrity(ctx, proj, absPath, planPath, currentHead); err != nil {
+		return err
+	}
+
 	if ctx.ExpectedPlanHash != "" {
 		actualHash, err := hashFile(absPath, planPath)
 		if err != nil {
@@ -132,6 +141,53 @@ func (v *DefaultApplyPlanValidator) ValidateProjectPlan(ctx command.ProjectConte
 	return nil
 }
 
+// validatePlanIntegrity checks the plan file on disk against the digest and
+// commit recorded when it was planned. Statuses recorded before digests were
+// tracked have neither and are not checked.
+func validatePlanIntegrity(ctx command.ProjectContext, proj *models.ProjectStatus, absPath, planPath, currentHead string) error {
+	if proj.PlanCommit != "" && currentHead != "" && proj.PlanCommit != currentHead {
+		return rejectProjectPlan(planPath,
+			"plan for dir %q workspace %q project %q was generated from commit %s but current head is %s; run `atlantis plan` before apply",
+			ctx.RepoRelDir, ctx.Workspace, ctx.ProjectName, shortSHA(proj.PlanCommit), shortSHA(currentHead),
+		)
+	}
+	if proj.PlanDigest == "" {
+		return nil
+	}
+	actualDigest, err := hashFile(absPath, planPath)
+	if err != nil {
+		return fmt.Errorf("hashing plan file for dir %q workspace %q project %q: %w", ctx.RepoRelDir, ctx.Workspace, ctx.ProjectName, err)
+	}
+	if actualDigest != proj.PlanDigest {
+		return rejectProjectPlan(planPath,
+			"plan file for dir %q workspace %q project %q does not match the plan Atlantis generated (SHA-256 %s, expected %s); run `atlantis plan` before apply",
+			ctx.RepoRelDir, ctx.Workspace, ctx.ProjectName, shortDigest(actualDigest), shortDigest(proj.PlanDigest),
+		)
+	}
+	return nil
+}
+
+// planFileDigest returns the SHA-256 digest of the project's plan file, or an
+// empty string if the workflow didn't write one.
+func planFileDigest(ctx command.ProjectContext, absPath string) (string, error) {
+	planPath, err := safePlanFilePath(ctx, absPath)
+	if err != nil {
+		return "", err
+	}
+	digest, err := hashFile(absPath, planPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return digest, err
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
 func validateCommandStartIdentity(ctx command.ProjectContext, livePull models.PullRequest) error {
 	if livePull.HeadCommit != "" && ctx.Pull.HeadCommit != "" && looksLikeCommitSHA(ctx.Pull.HeadCommit) && ctx.Pull.HeadCommit != livePull.HeadCommit {
 		return fmt.Errorf(
@@ -0,0 +1,142 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// AuditedProjectCommandRunner implements ProjectCommandRunner.
+// It records an audit event for each project plan, apply, approve_policies,
+// import and state rm.
+type AuditedProjectCommandRunner struct {
+	ProjectCommandRunner
+	AuditLog *audit.Log
+	// PullStatusFetcher looks up the digest of the plan being applied.
+	PullStatusFetcher PullStatusFetcher
+}
+
+func (a *AuditedProjectCommandRunner) Plan(ctx command.ProjectContext) command.ProjectCommandOutput {
+	out := a.ProjectCommandRunner.Plan(ctx)
+	event := projectAuditEvent(ctx, audit.ActionPlan, out)
+	if out.PlanSuccess != nil {
+		event.PlanDigest = out.PlanSuccess.PlanDigest
+	}
+	a.AuditLog.Record(event)
+	return out
+}
+
+func (a *AuditedProjectCommandRunner) Apply(ctx command.ProjectContext) command.ProjectCommandOutput {
+	// The plan's status is replaced once it's applied, so look up its digest
+	// first.
+	planDigest := a.planDigest(ctx)
+	out := a.ProjectCommandRunner.Apply(ctx)
+	event := projectAuditEvent(ctx, audit.ActionApply, out)
+	event.PlanDigest = planDigest
+	a.AuditLog.Record(event)
+	return out
+}
+
+func (a *AuditedProjectCommandRunner) ApprovePolicies(ctx command.ProjectContext) command.ProjectCommandOutput {
+	out := a.ProjectCommandRunner.ApprovePolicies(ctx)
+	event := projectAuditEvent(ctx, audit.ActionApprovePolicies, out)
+	event.Details = map[string]string{}
+	if ctx.PolicySetTarget != "" {
+		event.Details["policy_set"] = ctx.PolicySetTarget
+	}
+	if ctx.ClearPolicyApproval {
+		event.Details["clear"] = "true"
+	}
+	if ctx.PolicyExceptionExpiry > 0 {
+		event.Details["exception_expires_in"] = ctx.PolicyExceptionExpiry.String()
+		event.Details["exception_reason"] = ctx.PolicyExceptionReason
+	}
+	a.AuditLog.Record(event)
+	return out
+}
+
+func (a *AuditedProjectCommandRunner) Import(ctx command.ProjectContext) command.ProjectCommandOutput {
+	out := a.ProjectCommandRunner.Import(ctx)
+	a.AuditLog.Record(projectAuditEvent(ctx, audit.ActionImport, out))
+	return out
+}
+
+func (a *AuditedProjectCommandRunner) StateRm(ctx command.ProjectContext) command.ProjectCommandOutput {
+	out := a.ProjectCommandRunner.StateRm(ctx)
+	a.AuditLog.Record(projectAuditEvent(ctx, audit.ActionStateRm, out))
+	return out
+}
+
+func (a *AuditedProjectCommandRunner) PublishDeferredApplyStatuses(projectCmds []command.ProjectContext, result command.Result, status models.CommitStatus) {
+	publisher, ok := a.ProjectCommandRunner.(DeferredApplyStatusPublisher)
+	if !ok {
+		return
+	}
+	publisher.PublishDeferredApplyStatuses(projectCmds, result, status)
+}
+
+// planDigest returns the digest recorded when the project was planned, or ""
+// if it can't be found.
+func (a *AuditedProjectCommandRunner) planDigest(ctx command.ProjectContext) string {
+	pullStatus := ctx.PullStatus
+	if !ctx.API || pullStatus == nil {
+		if a.PullStatusFetcher == nil {
+			return ""
+		}
+		var err error
+		pullStatus, err = a.PullStatusFetcher.GetPullStatus(ctx.Pull)
+		if err != nil {
+			ctx.Log.Warn("unable to look up plan digest for audit log: %s", err)
+			return ""
+		}
+	}
+	if pullStatus == nil {
+		return ""
+	}
+	proj := findProjectInPullStatus(pullStatus, ctx.Workspace, ctx.RepoRelDir, ctx.ProjectName)
+	if proj == nil {
+		return ""
+	}
+	return proj.PlanDigest
+}
+
+func projectAuditEvent(ctx command.ProjectContext, action audit.Action, out command.ProjectCommandOutput) audit.Event {
+	event := audit.Event{
+		Action:    action,
+		Actor:     ctx.User.Username,
+		Source:    projectAuditSource(ctx),
+		Repo:      ctx.BaseRepo.FullName,
+		Project:   ctx.ProjectName,
+		Dir:       ctx.RepoRelDir,
+		Workspace: ctx.Workspace,
+		CommitSHA: ctx.Pull.HeadCommit,
+		Outcome:   audit.OutcomeSuccess,
+	}
+	// API commands that aren't for a pull request have negative numbers.
+	if ctx.Pull.Num > 0 {
+		event.Pull = ctx.Pull.Num
+	}
+	switch {
+	case out.Error != nil:
+		event.Outcome = audit.OutcomeError
+		event.Error = out.Error.Error()
+	case out.Failure != "":
+		event.Outcome = audit.OutcomeFailure
+		event.Error = out.Failure
+	}
+	return event
+}
+
+func projectAuditSource(ctx command.ProjectContext) string {
+	switch {
+	case ctx.API:
+		return audit.SourceAPI
+	case ctx.Trigger == command.CommentTrigger:
+		return audit.SourceComment
+	default:
+		return audit.SourceAutoplan
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	gogithub "github.com/google/go-github/v88/github"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs/github"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// Identifiers of the requested actions offered on check runs. GitHub sends
+// them back in check_run webhook events when a user clicks the button.
+const (
+	CheckRunPlanAction  = "plan"
+	CheckRunApplyAction = "apply"
+)
+
+// External ID keys identifying the project of a check run.
+const (
+	checkRunDirKey       = "dir"
+	checkRunWorkspaceKey = "workspace"
+	checkRunProjectKey   = "project"
+)
+
+//go:generate go tool pegomock generate github.com/runatlantis/atlantis/server/events --package mocks -o mocks/mock_check_run_updater.go CheckRunUpdater
+
+// CheckRunUpdater creates and updates GitHub check runs.
+type CheckRunUpdater interface {
+	UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, req github.CheckRunRequest) error
+}
+
+// ParseCheckRunAction returns the command to run for a requested action on a
+// check run created by Atlantis.
+func ParseCheckRunAction(identifier string, externalID string) (*CommentCommand, error) {
+	var name command.Name
+	switch identifier {
+	case CheckRunPlanAction:
+		name = command.Plan
+	case CheckRunApplyAction:
+		name = command.Apply
+	default:
+		return nil, fmt.Errorf("unknown check run action %q", identifier)
+	}
+	project, err := url.ParseQuery(externalID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing check run external id %q: %w", externalID, err)
+	}
+	if project.Get(checkRunProjectKey) == "" && project.Get(checkRunDirKey) == "" {
+		return nil, fmt.Errorf("check run external id %q does not identify a project", externalID)
+	}
+	return NewCommentCommand(project.Get(checkRunDirKey), nil, name, "", false, false, "", project.Get(checkRunWorkspaceKey), project.Get(checkRunProjectKey), "", false, "", 0), nil
+}
+
+// checkRunExternalID identifies the project of ctx so ParseCheckRunAction can
+// target it. Projects with a name are identified by name only because
+// commands can't combine a project with a dir or workspace.
+func checkRunExternalID(ctx command.ProjectContext) string {
+	if ctx.ProjectName != "" {
+		return url.Values{checkRunProjectKey: {ctx.ProjectName}}.Encode()
+	}
+	return url.Values{checkRunDirKey: {ctx.RepoRelDir}, checkRunWorkspaceKey: {ctx.Workspace}}.Encode()
+}
+
+// newCheckRunRequest returns the check run for a project command. projectDir
+// is the project's directory in the working dir, used to locate the source of
+// policy failures. It's empty if the working dir isn't available.
+func newCheckRunRequest(ctx command.ProjectContext, cmdName command.Name, status models.CommitStatus, name string, title string, detailsURL string, projectDir string, result *command.ProjectCommandOutput) github.CheckRunRequest {
+	req := github.CheckRunRequest{
+		Name:       name,
+		ExternalID: checkRunExternalID(ctx),
+		State:      status,
+		DetailsURL: detailsURL,
+		Title:      title,
+	}
+
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Ran %s for dir: `%s` workspace: `%s`", cmdName.String(), ctx.RepoRelDir, ctx.Workspace)
+	if ctx.ProjectName != "" {
+		fmt.Fprintf(&summary, " project: `%s`", ctx.ProjectName)
+	}
+	summary.WriteString("\n")
+	if status == models.PendingCommitStatus || result == nil {
+		req.Summary = summary.String()
+		return req
+	}
+
+	switch {
+	case result.Error != nil:
+		fmt.Fprintf(&summary, "\n**Error**\n```\n%s\n```\n", result.Error)
+	case result.Failure != "":
+		fmt.Fprintf(&summary, "\n**Failed**: %s\n", result.Failure)
+	}
+	if result.PlanSuccess != nil {
+		stats := result.PlanSuccess.Stats()
+		fmt.Fprintf(&summary, "\n**%s**\n\n", result.PlanSuccess.DiffSummary())
+		summary.WriteString("| Import | Add | Change | Destroy |\n|---|---|---|---|\n")
+		fmt.Fprintf(&summary, "| %d | %d | %d | %d |\n", stats.Import, stats.Add, stats.Change, stats.Destroy)
+		if stats.ChangesOutside {
+			summary.WriteString("\nTerraform detected changes made outside of Terraform.\n")
+		}
+		req.Text = fmt.Sprintf("```diff\n%s\n```", result.PlanSuccess.TerraformOutput)
+	}
+	if result.PolicyCheckResults != nil {
+		sources := findTerraformBlocks(projectDir)
+		summary.WriteString("\n| Policy set | Result |\n|---|---|\n")
+		for _, policySet := range result.PolicyCheckResults.PolicySetResults {
+			outcome := "passed"
+			if !policySet.Passed {
+				outcome = "failed"
+				req.Annotations = append(req.Annotations, policyFailureAnnotations(ctx.RepoRelDir, sources, policySet)...)
+			}
+			fmt.Fprintf(&summary, "| `%s` | %s |\n", policySet.PolicySetName, outcome)
+		}
+	}
+	req.Summary = summary.String()
+	req.Actions = checkRunActions(cmdName, status, result)
+	return req
+}
+
+// terraformBlockRegex matches the first line of a resource or data block.
+var terraformBlockRegex = regexp.MustCompile(`^\s*(resource|data)\s+"([^"]+)"\s+"([^"]+)"`)
+
+// resourceAddressRegex matches resource and data source addresses, ex.
+// aws_s3_bucket.logs or data.aws_iam_policy_document.logs, in policy output.
+var resourceAddressRegex = regexp.MustCompile(`\b(?:data\.)?[a-z][a-z0-9_]*\.[A-Za-z_][A-Za-z0-9_-]*`)
+
+// terraformBlock is where a resource or data source is declared.
+type terraformBlock struct {
+	// file is the name of the .tf file in the project dir.
+	file string
+	line int
+}
+
+// terraformSource is the .tf files of a project.
+type terraformSource struct {
+	// files are the names of the project's .tf files, sorted.
+	files []string
+	// blocks maps resource and data source addresses to where they're
+	// declared.
+	blocks map[string]terraformBlock
+}
+
+// findTerraformBlocks indexes the resource and data blocks declared in the
+// .tf files of dir. Modules aren't followed.
+func findTerraformBlocks(dir string) terraformSource {
+	source := terraformSource{blocks: map[string]terraformBlock{}}
+	if dir == "" {
+		return source
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.tf"))
+	for _, match := range matches {
+		file := filepath.Base(match)
+		source.files = append(source.files, file)
+		f, err := os.Open(match) // nolint: gosec
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			m := terraformBlockRegex.FindStringSubmatch(scanner.Text())
+			if m == nil {
+				continue
+			}
+			address := m[2] + "." + m[3]
+			if m[1] == "data" {
+				address = "data." + address
+			}
+			if _, ok := source.blocks[address]; !ok {
+				source.blocks[address] = terraformBlock{file: file, line: line}
+			}
+		}
+		f.Close() // nolint: errcheck
+	}
+	slices.Sort(source.files)
+	return source
+}
+
+// fallbackFile returns the file policy failures that can't be traced to a
+// resource are reported on: main.tf if there is one, else the first .tf file.
+func (s terraformSource) fallbackFile() (string, bool) {
+	if len(s.files) == 0 {
+		return "", false
+	}
+	if slices.Contains(s.files, "main.tf") {
+		return "main.tf", true
+	}
+	return s.files[0], true
+}
+
+// policyFailureAnnotations returns annotations for a failed policy set.
+// Annotations must be on a file, so each failure that names a resource
+// declared in the project is reported on that resource's block, and the rest
+// of the output on the project's main .tf file. Nothing is returned if the
+// project's files can't be found.
+func policyFailureAnnotations(repoRelDir string, source terraformSource, policySet models.PolicySetResult) []*gogithub.CheckRunAnnotation {
+	title := fmt.Sprintf("Policy set %s failed", policySet.PolicySetName)
+	annotation := func(file string, line int, message string) *gogithub.CheckRunAnnotation {
+		return &gogithub.CheckRunAnnotation{
+			Path:            gogithub.Ptr(path.Join(filepath.ToSlash(repoRelDir), file)),
+			StartLine:       gogithub.Ptr(line),
+			EndLine:         gogithub.Ptr(line),
+			AnnotationLevel: gogithub.Ptr("failure"),
+			Title:           gogithub.Ptr(title),
+			Message:         gogithub.Ptr(message),
+		}
+	}
+
+	var annotations []*gogithub.CheckRunAnnotation
+	for line := range strings.Lines(policySet.PolicyOutput) {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "FAIL") {
+			continue
+		}
+		for _, address := range resourceAddressRegex.FindAllString(line, -1) {
+			if block, ok := source.blocks[address]; ok {
+				annotations = append(annotations, annotation(block.file, block.line, line))
+				break
+			}
+		}
+	}
+	if len(annotations) > 0 {
+		return annotations
+	}
+	if file, ok := source.fallbackFile(); ok {
+		return []*gogithub.CheckRunAnnotation{annotation(file, 1, policySet.PolicyOutput)}
+	}
+	return nil
+}
+
+// checkRunActions returns the buttons offered on a completed check run: a
+// re-plan for plans, policy checks and failed applies, and an apply once there
+// is a plan with changes to apply.
+func checkRunActions(cmdName command.Name, status models.CommitStatus, result *command.ProjectCommandOutput) []*gogithub.CheckRunAction {
+	replan := &gogithub.CheckRunAction{
+		Label:       "Re-plan",
+		Description: "Run atlantis plan for this project",
+		Identifier:  CheckRunPlanAction,
+	}
+	apply := &gogithub.CheckRunAction{
+		Label:       "Apply",
+		Description: "Run atlantis apply for this project",
+		Identifier:  CheckRunApplyAction,
+	}
+	switch cmdName {
+	case command.Plan:
+		if status == models.SuccessCommitStatus && result.PlanSuccess != nil && !result.PlanSuccess.NoChanges() {
+			return []*gogithub.CheckRunAction{replan, apply}
+		}
+		return []*gogithub.CheckRunAction{replan}
+	case command.PolicyCheck:
+		if status == models.SuccessCommitStatus {
+			return []*gogithub.CheckRunAction{replan, apply}
+		}
+		return []*gogithub.CheckRunAction{replan}
+	case command.Apply:
+		if status == models.FailedCommitStatus {
+			return []*gogithub.CheckRunAction{replan}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v88/github"
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs/github"
+	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+var checkRunRepo = models.Repo{
+	FullName: "owner/repo",
+	VCSHost:  models.VCSHost{Type: models.Github, Hostname: "github.com"},
+}
+
+func updateProjectCheckRun(t *testing.T, ctx command.ProjectContext, cmdName command.Name, status models.CommitStatus, result *command.ProjectCommandOutput) github.CheckRunRequest {
+	t.Helper()
+	return updateProjectCheckRunInDir(t, "", ctx, cmdName, status, result)
+}
+
+// updateProjectCheckRunInDir is updateProjectCheckRun with the pull's working
+// dir at repoDir, if it's not empty.
+func updateProjectCheckRunInDir(t *testing.T, repoDir string, ctx command.ProjectContext, cmdName command.Name, status models.CommitStatus, result *command.ProjectCommandOutput) github.CheckRunRequest {
+	t.Helper()
+	RegisterMockTestingT(t)
+	client := vcsmocks.NewMockClient()
+	checkRuns := mocks.NewMockCheckRunUpdater()
+	s := events.DefaultCommitStatusUpdater{Client: client, StatusName: "atlantis", CheckRuns: checkRuns}
+	if repoDir != "" {
+		workingDir := mocks.NewMockWorkingDir()
+		When(workingDir.GetWorkingDir(Any[models.Repo](), Any[models.PullRequest](), Eq(ctx.Workspace))).ThenReturn(repoDir, nil)
+		s.WorkingDir = workingDir
+	}
+	ctx.Log = logging.NewNoopLogger(t)
+
+	Ok(t, s.UpdateProject(ctx, cmdName, status, "https://atlantis/jobs/1", result))
+
+	client.VerifyWasCalled(Never()).UpdateStatus(
+		Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[models.CommitStatus](), Any[string](), Any[string](), Any[string]())
+	_, _, _, req := checkRuns.VerifyWasCalledOnce().UpdateCheckRun(
+		Any[logging.SimpleLogging](), Eq(checkRunRepo), Any[models.PullRequest](), Any[github.CheckRunRequest]()).GetCapturedArguments()
+	return req
+}
+
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunPending(t *testing.T) {
+	req := updateProjectCheckRun(t, command.ProjectContext{
+		BaseRepo:   checkRunRepo,
+		RepoRelDir: "dir",
+		Workspace:  "default",
+	}, command.Plan, models.PendingCommitStatus, nil)
+
+	Equals(t, github.CheckRunRequest{
+		Name:       "atlantis/plan: dir/default",
+		ExternalID: "dir=dir&workspace=default",
+		State:      models.PendingCommitStatus,
+		DetailsURL: "https://atlantis/jobs/1",
+		Title:      "Plan in progress...",
+		Summary:    "Ran plan for dir: `dir` workspace: `default`\n",
+	}, req)
+}
+
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunPlan(t *testing.T) {
+	output := "Terraform will perform the following actions:\n\nPlan: 1 to add, 2 to change, 3 to destroy."
+	req := updateProjectCheckRun(t, command.ProjectContext{
+		BaseRepo:    checkRunRepo,
+		ProjectName: "app",
+		RepoRelDir:  "dir",
+		Workspace:   "default",
+	}, command.Plan, models.SuccessCommitStatus, &command.ProjectCommandOutput{
+		PlanSuccess: &models.PlanSuccess{TerraformOutput: output},
+	})
+
+	Equals(t, "atlantis/plan: app", req.Name)
+	Equals(t, "project=app", req.ExternalID)
+	Equals(t, "Plan: 1 to add, 2 to change, 3 to destroy.", req.Title)
+	Equals(t, "Ran plan for dir: `dir` workspace: `default` project: `app`\n"+
+		"\n**Plan: 1 to add, 2 to change, 3 to destroy.**\n\n"+
+		"| Import | Add | Change | Destroy |\n|---|---|---|---|\n"+
+		"| 0 | 1 | 2 | 3 |\n", req.Summary)
+	Equals(t, "```diff\n"+output+"\n```", req.Text)
+	Equals(t, []string{events.CheckRunPlanAction, events.CheckRunApplyAction}, checkRunActionIdentifiers(req.Actions))
+}
+
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunPolicyFailure(t *testing.T) {
+	repoDir := t.TempDir()
+	Ok(t, os.MkdirAll(filepath.Join(repoDir, "dir"), 0700))
+	Ok(t, os.WriteFile(filepath.Join(repoDir, "dir", "main.tf"), []byte("terraform {}\n"), 0600))
+	Ok(t, os.WriteFile(filepath.Join(repoDir, "dir", "s3.tf"), []byte("# Buckets\n\nresource \"aws_s3_bucket\" \"logs\" {\n}\n"), 0600))
+
+	req := updateProjectCheckRunInDir(t, repoDir, command.ProjectContext{
+		BaseRepo:   checkRunRepo,
+		RepoRelDir: "dir",
+		Workspace:  "default",
+	}, command.PolicyCheck, models.FailedCommitStatus, &command.ProjectCommandOutput{
+		Failure: "Some policy sets did not pass.",
+		PolicyCheckResults: &models.PolicyCheckResults{
+			PolicySetResults: []models.PolicySetResult{
+				{PolicySetName: "tags", Passed: true},
+				{PolicySetName: "encryption", PolicyOutput: "FAIL - bucket must be encrypted"},
+				{PolicySetName: "logging", PolicyOutput: "WARN - consider versioning\nFAIL - main - aws_s3_bucket.logs must have logging"},
+			},
+		},
+	})
+
+	Equals(t, "Policy_check failed.", req.Title)
+	Equals(t, "Ran policy_check for dir: `dir` workspace: `default`\n"+
+		"\n**Failed**: Some policy sets did not pass.\n"+
+		"\n| Policy set | Result |\n|---|---|\n"+
+		"| `tags` | passed |\n"+
+		"| `encryption` | failed |\n"+
+		"| `logging` | failed |\n", req.Summary)
+	Equals(t, []*gogithub.CheckRunAnnotation{{
+		// Failures that don't name a resource are reported on main.tf.
+		Path:            gogithub.Ptr("dir/main.tf"),
+		StartLine:       gogithub.Ptr(1),
+		EndLine:         gogithub.Ptr(1),
+		AnnotationLevel: gogithub.Ptr("failure"),
+		Title:           gogithub.Ptr("Policy set encryption failed"),
+		Message:         gogithub.Ptr("FAIL - bucket must be encrypted"),
+	}, {
+		Path:            gogithub.Ptr("dir/s3.tf"),
+		StartLine:       gogithub.Ptr(3),
+		EndLine:         gogithub.Ptr(3),
+		AnnotationLevel: gogithub.Ptr("failure"),
+		Title:           gogithub.Ptr("Policy set logging failed"),
+		Message:         gogithub.Ptr("FAIL - main - aws_s3_bucket.logs must have logging"),
+	}}, req.Annotations)
+	Equals(t, []string{events.CheckRunPlanAction}, checkRunActionIdentifiers(req.Actions))
+}
+
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunApplyError(t *testing.T) {
+	req := updateProjectCheckRun(t, command.ProjectContext{
+		BaseRepo:   checkRunRepo,
+		RepoRelDir: "dir",
+		Workspace:  "default",
+	}, command.Apply, models.FailedCommitStatus, &command.ProjectCommandOutput{
+		Error: errors.New("apply failed"),
+	})
+
+	Equals(t, "Ran apply for dir: `dir` workspace: `default`\n\n**Error**\n```\napply failed\n```\n", req.Summary)
+	Equals(t, []string{events.CheckRunPlanAction}, checkRunActionIdentifiers(req.Actions))
+}
+
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunsOnlyForGithub(t *testing.T) {
+	RegisterMockTestingT(t)
+	client := vcsmocks.NewMockClient()
+	checkRuns := mocks.NewMockCheckRunUpdater()
+	s := events.DefaultCommitStatusUpdater{Client: client, StatusName: "atlantis", CheckRuns: checkRuns}
+	repo := models.Repo{VCSHost: models.VCSHost{Type: models.Gitlab}}
+
+	err := s.UpdateProject(command.ProjectContext{BaseRepo: repo, RepoRelDir: "dir", Workspace: "default"}, command.Plan, models.PendingCommitStatus, "url", nil)
+
+	Ok(t, err)
+	client.VerifyWasCalledOnce().UpdateStatus(
+		Any[logging.SimpleLogging](), Eq(repo), Eq(models.PullRequest{}), Eq(models.PendingCommitStatus), Eq("atlantis/plan: dir/default"),
+		Eq("Plan in progress..."), Eq("url"))
+	checkRuns.VerifyWasCalled(Never()).UpdateCheckRun(
+		Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[github.CheckRunRequest]())
+}
+
+func TestParseCheckRunAction(t *testing.T) {
+	cases := []struct {
+		identifier string
+		externalID string
+		exp        *events.CommentCommand
+		expErr     string
+	}{
+		{
+			identifier: events.CheckRunPlanAction,
+			externalID: "dir=modules%2Fvpc&workspace=staging",
+			exp:        &events.CommentCommand{Name: command.Plan, RepoRelDir: "modules/vpc", Workspace: "staging"},
+		},
+		{
+			identifier: events.CheckRunApplyAction,
+			externalID: "project=app",
+			exp:        &events.CommentCommand{Name: command.Apply, ProjectName: "app"},
+		},
+		{
+			identifier: "unlock",
+			externalID: "project=app",
+			expErr:     `unknown check run action "unlock"`,
+		},
+		{
+			identifier: events.CheckRunPlanAction,
+			externalID: "other-app-id",
+			expErr:     `check run external id "other-app-id" does not identify a project`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.identifier+" "+c.externalID, func(t *testing.T) {
+			cmd, err := events.ParseCheckRunAction(c.identifier, c.externalID)
+			if c.expErr != "" {
+				ErrEquals(t, c.expErr, err)
+				return
+			}
+			Ok(t, err)
+			Equals(t, c.exp, cmd)
+		})
+	}
+}
+
+func checkRunActionIdentifiers(actions []*gogithub.CheckRunAction) []string {
+	var identifiers []string
+	for _, a := range actions {
+		identifiers = append(identifiers, a.Identifier)
+	}
+	return identifiers
+}
+
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunPolicyFailureWithoutWorkingDir(t *testing.T) {
+	req := updateProjectCheckRun(t, command.ProjectContext{
+		BaseRepo:   checkRunRepo,
+		RepoRelDir: "dir",
+		Workspace:  "default",
+	}, command.PolicyCheck, models.FailedCommitStatus, &command.ProjectCommandOutput{
+		Failure: "Some policy sets did not pass.",
+		PolicyCheckResults: &models.PolicyCheckResults{
+			PolicySetResults: []models.PolicySetResult{
+				{PolicySetName: "encryption", PolicyOutput: "FAIL - bucket must be encrypted"},
+			},
+		},
+	})
+
+	// Annotations must be on a file, so there are none if no file is found.
+	Equals(t, 0, len(req.Annotations))
+	Assert(t, strings.Contains(req.Summary, "| `encryption` | failed |"), "expected the failure in the summary")
+}
@@ -4,6 +4,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/logging"
 	tally "github.com/uber-go/tally/v4"
@@ -45,6 +47,11 @@ type Context struct {
 	// ClearPolicyApproval is true if approval should be cleared on specified policies.
 	ClearPolicyApproval bool
 
+	// PolicyExceptionReason and PolicyExceptionExpiry are set if approve_policies
+	// should approve failing policies as a policy exception.
+	PolicyExceptionReason string
+	PolicyExceptionExpiry time.Duration
+
 	Trigger Trigger
 
 	// API is true if plan/apply by API endpoints
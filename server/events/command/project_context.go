@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -109,6 +110,8 @@ type ProjectContext struct {
 	// executing commands for this project. This can be set to nil in which case
 	// we will use the default Atlantis terraform distribution.
 	TerraformDistribution *string
+	// Terragrunt is true if the project's commands run through terragrunt.
+	Terragrunt bool
 	// TerraformVersion is the version of terraform we should use when executing
 	// commands for this project. This can be set to nil in which case we will
 	// use the default Atlantis terraform version.
@@ -127,6 +130,13 @@ type ProjectContext struct {
 	PolicySetTarget string
 	// ClearPolicyApproval determines whether policy counts will be incremented or cleared.
 	ClearPolicyApproval bool
+	// PolicyExceptionReason is the justification for a policy exception
+	// granted by approve_policies.
+	PolicyExceptionReason string
+	// PolicyExceptionExpiry is how long a policy exception granted by
+	// approve_policies lasts. If it's 0, approve_policies grants ordinary
+	// approvals.
+	PolicyExceptionExpiry time.Duration
 	// DeleteSourceBranchOnMerge will attempt to allow a branch to be deleted when merged (AzureDevOps & GitLab Support Only)
 	DeleteSourceBranchOnMerge bool
 	// Repo locks mode: disabled, on plan or on apply
@@ -142,6 +152,12 @@ type ProjectContext struct {
 	// Allows custom policy check tools outside of Conftest to run in checks
 	CustomPolicyCheck bool
 	SilencePRComments []string
+	// CostBudget is the most the project's monthly cost may grow by before the
+	// cost_under_budget requirement blocks apply. Nil means no budget is set.
+	CostBudget *float64
+	// TFECredentials are the Terraform Cloud and Terraform Enterprise tokens
+	// that the project's commands authenticate with.
+	TFECredentials []valid.TFECredential
 
 	// TeamAllowlistChecker is used to check authorization on a project-level
 	TeamAllowlistChecker TeamAllowlistChecker
@@ -150,6 +166,10 @@ type ProjectContext struct {
 	// a PR comment.
 	API bool
 
+	// Trigger is whether the command was run automatically or by a comment.
+	// It's AutoTrigger for API commands.
+	Trigger Trigger
+
 	// SkipPRRequirements allows explicitly opted-in non-PR API workflows to skip
 	// PR-only requirements like approved and mergeable.
 	SkipPRRequirements bool
@@ -223,6 +243,16 @@ func (p ProjectContext) GetPolicyCheckResultFileName() string {
 	return fmt.Sprintf("%s-%s-policyout.json", projName, p.Workspace)
 }
 
+// GetCostEstimateFileName returns the filename (not the path) to store the
+// result of the cost_estimate step.
+func (p ProjectContext) GetCostEstimateFileName() string {
+	if p.ProjectName == "" {
+		return fmt.Sprintf("%s-cost.json", p.Workspace)
+	}
+	projName := strings.ReplaceAll(p.ProjectName, "/", planfileSlashReplace)
+	return fmt.Sprintf("%s-%s-cost.json", projName, p.Workspace)
+}
+
 // Gets a unique identifier for the current pull request as a single string
 func (p ProjectContext) PullInfo() string {
 	normalizedOwner := strings.ReplaceAll(p.BaseRepo.Owner, "/", "-")
@@ -263,3 +293,19 @@ func (p ProjectContext) PolicyCleared() bool {
 	}
 	return passing
 }
+
+// TFECredentialEnvVars returns a TF_TOKEN_* environment variable for each of
+// the project's TFE credentials. Terraform prefers these to the credentials in
+// ~/.terraformrc, so repos can use different tokens, and different hosts, than
+// the one set with --tfe-token.
+func (p ProjectContext) TFECredentialEnvVars() (map[string]string, error) {
+	envVars := make(map[string]string)
+	for _, c := range p.TFECredentials {
+		token, err := c.Token()
+		if err != nil {
+			return nil, fmt.Errorf("loading TFE credentials: %w", err)
+		}
+		envVars[c.EnvVar()] = token
+	}
+	return envVars, nil
+}
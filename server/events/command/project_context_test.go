@@ -4,6 +4,8 @@
 package command_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -162,3 +164,26 @@ func TestPolicyCheckResults_PolicyFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectContext_TFECredentialEnvVars(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tfe-token")
+	Ok(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+	t.Setenv("ATLANTIS_TEST_TFC_TOKEN", "env-token")
+
+	ctx := command.ProjectContext{
+		TFECredentials: []valid.TFECredential{
+			{Hostname: "app.terraform.io", TokenEnv: "ATLANTIS_TEST_TFC_TOKEN"},
+			{Hostname: "tfe.my-company.com", TokenFile: tokenFile},
+		},
+	}
+	envVars, err := ctx.TFECredentialEnvVars()
+	Ok(t, err)
+	Equals(t, map[string]string{
+		"TF_TOKEN_app_terraform_io":    "env-token",
+		"TF_TOKEN_tfe_my__company_com": "file-token",
+	}, envVars)
+
+	ctx.TFECredentials = []valid.TFECredential{{Hostname: "app.terraform.io", TokenFile: tokenFile + "-missing"}}
+	_, err = ctx.TFECredentialEnvVars()
+	ErrContains(t, "loading TFE credentials: reading token for app.terraform.io", err)
+}
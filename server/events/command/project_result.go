@@ -97,6 +97,24 @@ func (p ProjectResult) PlanStatus() models.ProjectPlanStatus {
 	panic("PlanStatus() missing a combination")
 }
 
+// PlanIntegrity returns the plan file digest and head commit recorded by a
+// successful plan. Both are empty for other commands and failed plans.
+func (p ProjectResult) PlanIntegrity() (digest string, commit string) {
+	if p.Command != Plan || p.PlanSuccess == nil {
+		return "", ""
+	}
+	return p.PlanSuccess.PlanDigest, p.PlanSuccess.PlanCommit
+}
+
+// PlanCostEstimate returns the cost estimate recorded by a successful plan. It's
+// nil for other commands, failed plans and plans without a cost_estimate step.
+func (p ProjectResult) PlanCostEstimate() *models.CostEstimate {
+	if p.Command != Plan || p.PlanSuccess == nil {
+		return nil
+	}
+	return p.PlanSuccess.CostEstimate
+}
+
 // IsSuccessful returns true if this project result had no errors.
 func (p ProjectResult) IsSuccessful() bool {
 	return p.PlanSuccess != nil || (p.PolicyCheckResults != nil && p.Error == nil && p.Failure == "") || p.ApplySuccess != ""
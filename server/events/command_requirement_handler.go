@@ -107,6 +107,10 @@ func (a *DefaultCommandRequirementHandler) validateCommandRequirement(repoDir st
 				}
 				return fmt.Sprintf("Pull request must be mergeable before running %s%s.", cmd, suffix), nil
 			}
+		case raw.CostUnderBudgetRequirement:
+			if failure := costUnderBudget(ctx, cmd); failure != "" {
+				return failure, nil
+			}
 		case raw.UnDivergedRequirement:
 			diverged, err := a.hasUndivergedImpact(repoDir, ctx, cmd)
 			if err != nil {
@@ -122,6 +126,35 @@ func (a *DefaultCommandRequirementHandler) validateCommandRequirement(repoDir st
 	return "", nil
 }
 
+// costUnderBudget returns a failure if the project has no cost budget or
+// estimate, if the estimate has resources it couldn't price, or if the
+// estimated monthly cost delta is over budget. The estimate is the one
+// recorded in the pull request's status by the project's last plan, so it
+// doesn't depend on the working directory still holding the plan's files.
+func costUnderBudget(ctx command.ProjectContext, cmd command.Name) string {
+	if ctx.CostBudget == nil {
+		return fmt.Sprintf("Project must have a cost_budget configured before running %s.", cmd)
+	}
+	var estimate *models.CostEstimate
+	if ctx.PullStatus != nil {
+		if proj := findProjectInPullStatus(ctx.PullStatus, ctx.Workspace, ctx.RepoRelDir, ctx.ProjectName); proj != nil {
+			estimate = proj.CostEstimate
+		}
+	}
+	if estimate == nil {
+		return fmt.Sprintf("Project must have a cost estimate before running %s, add the cost_estimate step to its plan workflow and plan again.", cmd)
+	}
+	if len(estimate.Unpriced) > 0 {
+		return fmt.Sprintf("Can't check the project's cost budget before running %s because the pricing table has no price for: %s.",
+			cmd, strings.Join(estimate.Unpriced, ", "))
+	}
+	if estimate.MonthlyDelta > *ctx.CostBudget {
+		return fmt.Sprintf("Estimated monthly cost increase of %.2f %s is over the project's budget of %.2f %s, can't run %s.",
+			estimate.MonthlyDelta, estimate.Currency, *ctx.CostBudget, estimate.Currency, cmd)
+	}
+	return ""
+}
+
 // mergeableIgnoringOtherProjectPlans reports whether the merge request should be
 // treated as mergeable for THIS project's apply even though the MR-wide
 // mergeable check failed, because every blocking commit status is either an
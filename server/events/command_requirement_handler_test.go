@@ -384,6 +384,72 @@ func TestAggregateApplyRequirements_ValidateApplyProject(t *testing.T) {
 	}
 }
 
+func TestAggregateApplyRequirements_CostUnderBudget(t *testing.T) {
+	budget := 100.0
+	tests := []struct {
+		name        string
+		budget      *float64
+		estimate    *models.CostEstimate
+		wantFailure string
+	}{
+		{
+			name:     "under budget",
+			budget:   &budget,
+			estimate: &models.CostEstimate{Currency: "USD", MonthlyDelta: 99.5},
+		},
+		{
+			name:     "cost reduction",
+			budget:   &budget,
+			estimate: &models.CostEstimate{Currency: "USD", MonthlyDelta: -250},
+		},
+		{
+			name:        "over budget",
+			budget:      &budget,
+			estimate:    &models.CostEstimate{Currency: "USD", MonthlyDelta: 100.01},
+			wantFailure: "Estimated monthly cost increase of 100.01 USD is over the project's budget of 100.00 USD, can't run apply.",
+		},
+		{
+			name:        "unpriced resources",
+			budget:      &budget,
+			estimate:    &models.CostEstimate{Currency: "USD", MonthlyDelta: 10, Unpriced: []string{"aws_nat_gateway.main", "aws_eip.nat"}},
+			wantFailure: "Can't check the project's cost budget before running apply because the pricing table has no price for: aws_nat_gateway.main, aws_eip.nat.",
+		},
+		{
+			name:        "no estimate",
+			budget:      &budget,
+			wantFailure: "Project must have a cost estimate before running apply, add the cost_estimate step to its plan workflow and plan again.",
+		},
+		{
+			name:        "no budget",
+			estimate:    &models.CostEstimate{Currency: "USD", MonthlyDelta: 1},
+			wantFailure: "Project must have a cost_budget configured before running apply.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The estimate comes from the pull request's status, not the
+			// working directory, which may have been re-cloned since plan.
+			ctx := command.ProjectContext{
+				RepoRelDir:        "project",
+				Workspace:         "default",
+				ApplyRequirements: []string{raw.CostUnderBudgetRequirement},
+				CostBudget:        tt.budget,
+				PullStatus: &models.PullStatus{
+					Projects: []models.ProjectStatus{
+						{RepoRelDir: "other", Workspace: "default", CostEstimate: &models.CostEstimate{MonthlyDelta: -1}},
+						{RepoRelDir: "project", Workspace: "default", Status: models.PlannedPlanStatus, CostEstimate: tt.estimate},
+					},
+				},
+			}
+			a := &events.DefaultCommandRequirementHandler{}
+
+			gotFailure, err := a.ValidateApplyProject(t.TempDir(), ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFailure, gotFailure)
+		})
+	}
+}
+
 // TestAggregateApplyRequirements_MergeableScopedToProject verifies that the
 // mergeable apply requirement is evaluated per project: a failing Atlantis plan
 // status for a different project in the same pull request must not block
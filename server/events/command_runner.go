@@ -5,6 +5,7 @@
 package events
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -21,8 +22,11 @@ import (
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/metrics"
 	"github.com/runatlantis/atlantis/server/recovery"
+	"github.com/runatlantis/atlantis/server/tracing"
 	"github.com/uber-go/tally/v4"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -36,8 +40,9 @@ type CommandRunner interface {
 	// RunCommentCommand is the first step after a command request has been parsed.
 	// It handles gathering additional information needed to execute the command
 	// and then calling the appropriate services to finish executing the command.
-	RunCommentCommand(baseRepo models.Repo, maybeHeadRepo *models.Repo, maybePull *models.PullRequest, user models.User, pullNum int, cmd *CommentCommand)
-	RunAutoplanCommand(baseRepo models.Repo, headRepo models.Repo, pull models.PullRequest, user models.User)
+	// The command's trace spans are created under the span in reqCtx.
+	RunCommentCommand(reqCtx context.Context, baseRepo models.Repo, maybeHeadRepo *models.Repo, maybePull *models.PullRequest, user models.User, pullNum int, cmd *CommentCommand)
+	RunAutoplanCommand(reqCtx context.Context, baseRepo models.Repo, headRepo models.Repo, pull models.PullRequest, user models.User)
 }
 
 //go:generate go tool pegomock generate github.com/runatlantis/atlantis/server/events --package mocks -o mocks/mock_github_pull_getter.go GithubPullGetter
@@ -142,7 +147,7 @@ type DefaultCommandRunner struct {
 }
 
 // RunAutoplanCommand runs plan and policy_checks when a pull request is opened or updated.
-func (c *DefaultCommandRunner) RunAutoplanCommand(baseRepo models.Repo, headRepo models.Repo, pull models.PullRequest, user models.User) {
+func (c *DefaultCommandRunner) RunAutoplanCommand(reqCtx context.Context, baseRepo models.Repo, headRepo models.Repo, pull models.PullRequest, user models.User) {
 	if opStarted := c.Drainer.StartOp(); !opStarted {
 		if commentErr := c.VCSClient.CreateComment(c.Logger, baseRepo, pull.Num, ShutdownComment, command.Plan.String()); commentErr != nil {
 			c.Logger.Log(logging.Error, "unable to comment that Atlantis is shutting down: %s", commentErr)
@@ -151,7 +156,13 @@ func (c *DefaultCommandRunner) RunAutoplanCommand(baseRepo models.Repo, headRepo
 	}
 	defer c.Drainer.OpDone()
 
-	log := c.buildLogger(baseRepo.FullName, pull.Num)
+	traceCtx, span := tracing.Start(reqCtx, "DefaultCommandRunner.RunAutoplanCommand",
+		attribute.String("vcs.repo", baseRepo.FullName),
+		attribute.Int("vcs.pull", pull.Num),
+	)
+	defer span.End()
+
+	log := c.buildLogger(traceCtx, baseRepo.FullName, pull.Num)
 	defer c.logPanics(baseRepo, pull.Num, log)
 	status, err := c.PullStatusFetcher.GetPullStatus(pull)
 
@@ -461,7 +472,7 @@ func (c *DefaultCommandRunner) validateCommentCommand(ctx *command.Context, base
 // enough data to construct the Repo model and callers might want to wait until
 // the event is further validated before making an additional (potentially
 // wasteful) call to get the necessary data.
-func (c *DefaultCommandRunner) RunCommentCommand(baseRepo models.Repo, maybeHeadRepo *models.Repo, maybePull *models.PullRequest, user models.User, pullNum int, cmd *CommentCommand) {
+func (c *DefaultCommandRunner) RunCommentCommand(reqCtx context.Context, baseRepo models.Repo, maybeHeadRepo *models.Repo, maybePull *models.PullRequest, user models.User, pullNum int, cmd *CommentCommand) {
 	if opStarted := c.Drainer.StartOp(); !opStarted {
 		if commentErr := c.VCSClient.CreateComment(c.Logger, baseRepo, pullNum, ShutdownComment, ""); commentErr != nil {
 			c.Logger.Log(logging.Error, "unable to comment that Atlantis is shutting down: %s", commentErr)
@@ -470,7 +481,16 @@ func (c *DefaultCommandRunner) RunCommentCommand(baseRepo models.Repo, maybeHead
 	}
 	defer c.Drainer.OpDone()
 
-	log := c.buildLogger(baseRepo.FullName, pullNum)
+	traceCtx, span := tracing.Start(reqCtx, "DefaultCommandRunner.RunCommentCommand",
+		attribute.String("vcs.repo", baseRepo.FullName),
+		attribute.Int("vcs.pull", pullNum),
+	)
+	if cmd != nil {
+		span.SetAttributes(attribute.String("atlantis.command", cmd.Name.String()))
+	}
+	defer span.End()
+
+	log := c.buildLogger(traceCtx, baseRepo.FullName, pullNum)
 	defer c.logPanics(baseRepo, pullNum, log)
 
 	scope := c.StatsScope.SubScope("comment")
@@ -493,16 +513,18 @@ func (c *DefaultCommandRunner) RunCommentCommand(baseRepo models.Repo, maybeHead
 	}
 
 	ctx := &command.Context{
-		User:                 user,
-		Log:                  log,
-		Pull:                 pull,
-		PullStatus:           status,
-		HeadRepo:             headRepo,
-		Scope:                scope,
-		Trigger:              command.CommentTrigger,
-		PolicySet:            cmd.PolicySet,
-		ClearPolicyApproval:  cmd.ClearPolicyApproval,
-		TeamAllowlistChecker: c.TeamAllowlistChecker,
+		User:                  user,
+		Log:                   log,
+		Pull:                  pull,
+		PullStatus:            status,
+		HeadRepo:              headRepo,
+		Scope:                 scope,
+		Trigger:               command.CommentTrigger,
+		PolicySet:             cmd.PolicySet,
+		ClearPolicyApproval:   cmd.ClearPolicyApproval,
+		PolicyExceptionReason: cmd.PolicyExceptionReason,
+		PolicyExceptionExpiry: cmd.PolicyExceptionExpiry,
+		TeamAllowlistChecker:  c.TeamAllowlistChecker,
 	}
 
 	if !c.validateCtxAndComment(ctx, cmd.Name, true) {
@@ -624,12 +646,17 @@ func (c *DefaultCommandRunner) getAzureDevopsData(logger logging.SimpleLogging,
 	return pull, headRepo, nil
 }
 
-func (c *DefaultCommandRunner) buildLogger(repoFullName string, pullNum int) logging.SimpleLogging {
-
-	return c.Logger.WithHistory(
+func (c *DefaultCommandRunner) buildLogger(traceCtx context.Context, repoFullName string, pullNum int) logging.SimpleLogging {
+	fields := []any{
 		"repo", repoFullName,
 		"pull", strconv.Itoa(pullNum),
-	)
+	}
+	sc := trace.SpanContextFromContext(traceCtx)
+	if !sc.IsValid() {
+		return c.Logger.WithHistory(fields...)
+	}
+	fields = append(fields, tracing.TraceIDKey, sc.TraceID().String())
+	return logging.WithContext(c.Logger.WithHistory(fields...), traceCtx)
 }
 
 func (c *DefaultCommandRunner) ensureValidRepoMetadata(
@@ -12,8 +12,10 @@ import (
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/google/shlex"
@@ -40,6 +42,10 @@ const (
 	verboseFlagShort             = ""
 	clearPolicyApprovalFlagLong  = "clear-policy-approval"
 	clearPolicyApprovalFlagShort = ""
+	reasonFlagLong               = "reason"
+	reasonFlagShort              = ""
+	expiresFlagLong              = "expires"
+	expiresFlagShort             = ""
 )
 
 // DefaultBlockedExtraArgs is the default set of Terraform CLI flag prefixes
@@ -239,6 +245,8 @@ func (e *CommentParser) Parse(rawComment string, vcsHost models.VCSHostType) Com
 	var project string
 	var policySet string
 	var clearPolicyApproval bool
+	var exceptionReason string
+	var exceptionExpires string
 	var verbose bool
 	var autoMergeDisabled bool
 	var autoMergeMethod string
@@ -274,6 +282,8 @@ func (e *CommentParser) Parse(rawComment string, vcsHost models.VCSHostType) Com
 		flagSet.StringVarP(&project, projectFlagLong, projectFlagShort, "", "Approve policies for this project. Refers to the name of the project configured in a repo config file. Cannot be used at same time as workspace or dir flags.")
 		flagSet.StringVarP(&policySet, policySetFlagLong, policySetFlagShort, "", "Approve policies for this project. Refers to the name of the project configured in a repo config file. Cannot be used at same time as workspace or dir flags.")
 		flagSet.BoolVarP(&clearPolicyApproval, clearPolicyApprovalFlagLong, clearPolicyApprovalFlagShort, false, "Clear any existing policy approvals.")
+		flagSet.StringVarP(&exceptionReason, reasonFlagLong, reasonFlagShort, "", "Approve the failing policies as a policy exception with this justification. Requires --expires.")
+		flagSet.StringVarP(&exceptionExpires, expiresFlagLong, expiresFlagShort, "", "How long the policy exception lasts, ex. '14d' or '36h'. It's re-applied when the project is re-planned until it expires. Requires --reason.")
 		flagSet.BoolVarP(&verbose, verboseFlagLong, verboseFlagShort, false, "Append Atlantis log to comment.")
 	case command.Unlock.String():
 		name = command.Unlock
@@ -353,9 +363,48 @@ func (e *CommentParser) Parse(rawComment string, vcsHost models.VCSHostType) Com
 		}
 	}
 
+	var exceptionExpiry time.Duration
+	if exceptionReason != "" || exceptionExpires != "" {
+		if exceptionReason == "" || exceptionExpires == "" {
+			err := fmt.Sprintf("--%s and --%s must be used together", reasonFlagLong, expiresFlagLong)
+			return CommentParseResult{CommentResponse: e.errMarkdown(err, cmd, flagSet)}
+		}
+		if clearPolicyApproval {
+			err := fmt.Sprintf("cannot use --%s at the same time as --%s", expiresFlagLong, clearPolicyApprovalFlagLong)
+			return CommentParseResult{CommentResponse: e.errMarkdown(err, cmd, flagSet)}
+		}
+		exceptionExpiry, err = parseExceptionExpiry(exceptionExpires)
+		if err != nil {
+			return CommentParseResult{CommentResponse: e.errMarkdown(err.Error(), cmd, flagSet)}
+		}
+	}
+
 	return CommentParseResult{
-		Command: NewCommentCommand(dir, extraArgs, name, subName, verbose, autoMergeDisabled, autoMergeMethod, workspace, project, policySet, clearPolicyApproval),
+		Command: NewCommentCommand(dir, extraArgs, name, subName, verbose, autoMergeDisabled, autoMergeMethod, workspace, project, policySet, clearPolicyApproval, exceptionReason, exceptionExpiry),
+	}
+}
+
+// parseExceptionExpiry parses the --expires flag. As well as Go durations it
+// accepts a number of days, ex. "14d".
+func parseExceptionExpiry(expires string) (time.Duration, error) {
+	var expiry time.Duration
+	if days, ok := strings.CutSuffix(expires, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --%s %q", expiresFlagLong, expires)
+		}
+		expiry = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		expiry, err = time.ParseDuration(expires)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --%s %q", expiresFlagLong, expires)
+		}
+	}
+	if expiry <= 0 {
+		return 0, fmt.Errorf("--%s must be positive", expiresFlagLong)
 	}
+	return expiry, nil
 }
 
 func (e *CommentParser) parseArgs(name command.Name, args []string, flagSet *pflag.FlagSet) (string, []string, string) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/events"
 	"github.com/runatlantis/atlantis/server/events/command"
@@ -646,6 +647,63 @@ func TestParse_UsingProjectAtSameTimeAsWorkspaceOrDir(t *testing.T) {
 	}
 }
 
+func TestParse_PolicyException(t *testing.T) {
+	cases := []struct {
+		comment   string
+		expReason string
+		expExpiry time.Duration
+		expErr    string
+	}{
+		{
+			comment:   `atlantis approve_policies --reason "accepted risk" --expires 14d`,
+			expReason: "accepted risk",
+			expExpiry: 14 * 24 * time.Hour,
+		},
+		{
+			comment:   `atlantis approve_policies --policy-set policy1 --reason "accepted risk" --expires 36h`,
+			expReason: "accepted risk",
+			expExpiry: 36 * time.Hour,
+		},
+		{
+			comment: `atlantis approve_policies --reason "accepted risk"`,
+			expErr:  "Error: --reason and --expires must be used together",
+		},
+		{
+			comment: "atlantis approve_policies --expires 14d",
+			expErr:  "Error: --reason and --expires must be used together",
+		},
+		{
+			comment: `atlantis approve_policies --reason "accepted risk" --expires 14d --clear-policy-approval`,
+			expErr:  "Error: cannot use --expires at the same time as --clear-policy-approval",
+		},
+		{
+			comment: `atlantis approve_policies --reason "accepted risk" --expires two-weeks`,
+			expErr:  `Error: invalid --expires "two-weeks"`,
+		},
+		{
+			comment: `atlantis approve_policies --reason "accepted risk" --expires 0d`,
+			expErr:  "Error: --expires must be positive",
+		},
+		{
+			comment: `atlantis plan --reason "accepted risk"`,
+			expErr:  "Error: unknown flag: --reason",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.comment, func(t *testing.T) {
+			r := commentParser.Parse(c.comment, models.Github)
+			if c.expErr != "" {
+				Assert(t, strings.Contains(r.CommentResponse, c.expErr),
+					"expected CommentResponse %q to contain %q", r.CommentResponse, c.expErr)
+				return
+			}
+			Equals(t, "", r.CommentResponse)
+			Equals(t, c.expReason, r.Command.PolicyExceptionReason)
+			Equals(t, c.expExpiry, r.Command.PolicyExceptionExpiry)
+		})
+	}
+}
+
 func TestParse_Parsing(t *testing.T) {
 	cases := []struct {
 		flags        string
@@ -1237,6 +1295,9 @@ var ApprovePolicyUsage = `Usage of approve_policies:
       --clear-policy-approval   Clear any existing policy approvals.
   -d, --dir string              Approve policies for this directory, relative to
                                 root of repo, ex. 'child/dir'.
+      --expires string          How long the policy exception lasts, ex. '14d' or
+                                '36h'. It's re-applied when the project is
+                                re-planned until it expires. Requires --reason.
       --policy-set string       Approve policies for this project. Refers to the
                                 name of the project configured in a repo config
                                 file. Cannot be used at same time as workspace or
@@ -1245,6 +1306,8 @@ var ApprovePolicyUsage = `Usage of approve_policies:
                                 name of the project configured in a repo config
                                 file. Cannot be used at same time as workspace or
                                 dir flags.
+      --reason string           Approve the failing policies as a policy exception
+                                with this justification. Requires --expires.
       --verbose                 Append Atlantis log to comment.
   -w, --workspace string        Approve policies for this Terraform workspace.
 `
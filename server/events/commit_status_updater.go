@@ -7,6 +7,7 @@ package events
 import (
 	"crypto/sha256"
 	"fmt"
+	"path/filepath"
 	"unicode/utf8"
 
 	"github.com/runatlantis/atlantis/server/core/runtime"
@@ -39,6 +40,12 @@ type DefaultCommitStatusUpdater struct {
 	Client vcs.Client
 	// StatusName is the name used to identify Atlantis when creating PR statuses.
 	StatusName string
+	// CheckRuns, if set, reports project commands on GitHub pull requests as
+	// check runs instead of commit statuses.
+	CheckRuns CheckRunUpdater
+	// WorkingDir, if set, is used to find the files check run annotations
+	// point at.
+	WorkingDir WorkingDir
 }
 
 // ensure DefaultCommitStatusUpdater implements runtime.StatusUpdater interface
@@ -134,9 +141,25 @@ func (d *DefaultCommitStatusUpdater) UpdateProject(ctx command.ProjectContext, c
 			descripWords = genProjectStatusDescription(cmdName.String(), "succeeded.")
 		}
 	}
+	if d.CheckRuns != nil && ctx.BaseRepo.VCSHost.Type == models.Github {
+		return d.CheckRuns.UpdateCheckRun(ctx.Log, ctx.BaseRepo, ctx.Pull, newCheckRunRequest(ctx, cmdName, status, src, descripWords, url, d.projectDir(ctx), result))
+	}
 	return d.Client.UpdateStatus(ctx.Log, ctx.BaseRepo, ctx.Pull, status, src, descripWords, url)
 }
 
+// projectDir returns the directory of ctx's project in the working dir, or
+// an empty string if it isn't known.
+func (d *DefaultCommitStatusUpdater) projectDir(ctx command.ProjectContext) string {
+	if d.WorkingDir == nil {
+		return ""
+	}
+	repoDir, err := d.WorkingDir.GetWorkingDir(ctx.Pull.BaseRepo, ctx.Pull, ctx.Workspace)
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(repoDir, ctx.RepoRelDir)
+}
+
 func genProjectStatusDescription(cmdName, description string) string {
 	return fmt.Sprintf("%s %s", cases.Title(language.English).String(cmdName), description)
 }
@@ -19,6 +19,8 @@ import (
 type DeleteLockCommand interface {
 	DeleteLock(logger logging.SimpleLogging, id string) (*models.ProjectLock, error)
 	DeleteLocksByPull(logger logging.SimpleLogging, repoFullName string, pullNum int) (int, error)
+	ReleaseLocksByPull(logger logging.SimpleLogging, repoFullName string, pullNum int) ([]models.ProjectLock, error)
+	ReleaseLock(logger logging.SimpleLogging, lock models.ProjectLock) (*models.ProjectLock, error)
 }
 
 // DefaultDeleteLockCommand deletes a specific lock after a request from the LocksController.
@@ -28,6 +30,8 @@ type DefaultDeleteLockCommand struct {
 	WorkingDirLocker WorkingDirLocker
 	Database         db.Database
 	PlanStore        planstore.PlanStore
+	// LockQueueRunner is nil unless --enable-lock-queue is set.
+	LockQueueRunner *LockQueueRunner
 }
 
 // DeleteLock handles deleting the lock at id
@@ -40,10 +44,27 @@ func (l *DefaultDeleteLockCommand) DeleteLock(logger logging.SimpleLogging, id s
 		return nil, nil
 	}
 
+	if err := l.deletePlan(logger, *lock); err != nil {
+		return nil, err
+	}
+	l.promoteQueued(logger, *lock)
+	return lock, nil
+}
+
+// promoteQueued passes released locks on to the pull requests queued for them.
+func (l *DefaultDeleteLockCommand) promoteQueued(logger logging.SimpleLogging, released ...models.ProjectLock) {
+	if l.LockQueueRunner != nil {
+		l.LockQueueRunner.Promote(logger, released...)
+	}
+}
+
+// deletePlan removes the plan of the released lock from the working dir and
+// the external plan store.
+func (l *DefaultDeleteLockCommand) deletePlan(logger logging.SimpleLogging, lock models.ProjectLock) error {
 	removeErr := l.WorkingDir.DeletePlan(logger, lock.Pull.BaseRepo, lock.Pull, lock.Workspace, lock.Project.Path, lock.Project.ProjectName)
 	if removeErr != nil {
 		logger.Warn("Failed to delete plan: %s", removeErr)
-		return nil, removeErr
+		return removeErr
 	}
 
 	if l.PlanStore != nil {
@@ -58,8 +79,7 @@ func (l *DefaultDeleteLockCommand) DeleteLock(logger logging.SimpleLogging, id s
 			logger.Warn("Failed to delete plan from external store: %s", err)
 		}
 	}
-
-	return lock, nil
+	return nil
 }
 
 // DeleteLocksByPull handles deleting all locks for the pull request
@@ -69,6 +89,10 @@ func (l *DefaultDeleteLockCommand) DeleteLocksByPull(logger logging.SimpleLoggin
 	if err != nil {
 		return numLocks, err
 	}
+	// Unlocking a pull request also gives up its place in any lock queues.
+	if l.LockQueueRunner != nil {
+		l.LockQueueRunner.RemovePull(logger, repoFullName, pullNum)
+	}
 
 	for i := range numLocks {
 		lock := locks[i]
@@ -95,6 +119,39 @@ func (l *DefaultDeleteLockCommand) DeleteLocksByPull(logger logging.SimpleLoggin
 	if numLocks == 0 {
 		logger.Debug("No locks found for repo '%v', pull request: %v", repoFullName, pullNum)
 	}
+	l.promoteQueued(logger, locks...)
 
 	return numLocks, nil
 }
+
+// ReleaseLocksByPull deletes all locks for the pull request and the plans of
+// their projects, returning the released locks. Unlike DeleteLocksByPull it
+// only removes the plans of projects that were locked.
+func (l *DefaultDeleteLockCommand) ReleaseLocksByPull(logger logging.SimpleLogging, repoFullName string, pullNum int) ([]models.ProjectLock, error) {
+	locks, err := l.Locker.UnlockByPull(repoFullName, pullNum)
+	if err != nil {
+		return nil, err
+	}
+	for _, lock := range locks {
+		if err := l.deletePlan(logger, lock); err != nil {
+			return locks, err
+		}
+	}
+	l.promoteQueued(logger, locks...)
+	return locks, nil
+}
+
+// ReleaseLock deletes lock and its project's plan, unless the lock has since
+// been released and taken by another pull request. It returns nil if nothing
+// was released.
+func (l *DefaultDeleteLockCommand) ReleaseLock(logger logging.SimpleLogging, lock models.ProjectLock) (*models.ProjectLock, error) {
+	released, err := l.Locker.UnlockIfOwnedByPull(lock.Project, lock.Workspace, lock.Pull.Num)
+	if err != nil || released == nil {
+		return nil, err
+	}
+	if err := l.deletePlan(logger, *released); err != nil {
+		return nil, err
+	}
+	l.promoteQueued(logger, *released)
+	return released, nil
+}
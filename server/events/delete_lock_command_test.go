@@ -206,3 +206,95 @@ func TestDeleteLocksByPull_MultipleSuccess(t *testing.T) {
 	workingDir.VerifyWasCalled(Once()).DeletePlan(logger, pull.BaseRepo, pull, workspace, path1, projectName)
 	workingDir.VerifyWasCalled(Once()).DeletePlan(logger, pull.BaseRepo, pull, workspace, path2, projectName)
 }
+
+func TestReleaseLock(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	RegisterMockTestingT(t) // needed for pegomock WorkingDir mock
+	pull := models.PullRequest{
+		BaseRepo: models.Repo{FullName: "owner/repo"},
+		Num:      2,
+	}
+	lock := models.ProjectLock{
+		Pull:      pull,
+		Workspace: "default",
+		Project:   models.NewProject("owner/repo", "path", ""),
+	}
+
+	t.Run("owned by pull", func(t *testing.T) {
+		l := lockmocks.NewMockLocker(gomock.NewController(t))
+		workingDir := events.NewMockWorkingDir()
+		l.EXPECT().UnlockIfOwnedByPull(lock.Project, "default", 2).Return(&lock, nil)
+		dlc := events.DefaultDeleteLockCommand{Locker: l, WorkingDir: workingDir}
+
+		released, err := dlc.ReleaseLock(logger, lock)
+
+		Ok(t, err)
+		Equals(t, &lock, released)
+		workingDir.VerifyWasCalledOnce().DeletePlan(Any[logging.SimpleLogging](), Eq(pull.BaseRepo), Eq(pull), Eq("default"),
+			Eq("path"), Eq(""))
+	})
+
+	t.Run("taken by another pull", func(t *testing.T) {
+		l := lockmocks.NewMockLocker(gomock.NewController(t))
+		workingDir := events.NewMockWorkingDir()
+		l.EXPECT().UnlockIfOwnedByPull(lock.Project, "default", 2).Return(nil, nil)
+		dlc := events.DefaultDeleteLockCommand{Locker: l, WorkingDir: workingDir}
+
+		released, err := dlc.ReleaseLock(logger, lock)
+
+		Ok(t, err)
+		Assert(t, released == nil, "expected no lock to be released")
+		workingDir.VerifyWasCalled(Never()).DeletePlan(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+			Any[string](), Any[string](), Any[string]())
+	})
+}
+
+func TestDeleteLock_PromotesQueuedPull(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	RegisterMockTestingT(t)
+	ctrl := gomock.NewController(t)
+	lock := models.ProjectLock{
+		Pull:      models.PullRequest{Num: 1, BaseRepo: models.Repo{FullName: "owner/repo"}},
+		Workspace: "default",
+		Project:   models.NewProject("owner/repo", "infra", ""),
+	}
+	l := lockmocks.NewMockLocker(ctrl)
+	l.EXPECT().Unlock("id").Return(&lock, nil)
+	lockQueue := lockmocks.NewMockLockQueue(ctrl)
+	lockQueue.EXPECT().LockNext(lock.Project, "default").Return(nil, nil)
+	dlc := events.DefaultDeleteLockCommand{
+		Locker:          l,
+		WorkingDir:      events.NewMockWorkingDir(),
+		LockQueueRunner: &events.LockQueueRunner{LockQueue: lockQueue},
+	}
+
+	_, err := dlc.DeleteLock(logger, "id")
+	Ok(t, err)
+}
+
+func TestDeleteLocksByPull_LeavesLockQueues(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	RegisterMockTestingT(t)
+	ctrl := gomock.NewController(t)
+	lock := models.ProjectLock{
+		Pull:      models.PullRequest{Num: 1, BaseRepo: models.Repo{FullName: "owner/repo"}},
+		Workspace: "default",
+		Project:   models.NewProject("owner/repo", "infra", ""),
+	}
+	l := lockmocks.NewMockLocker(ctrl)
+	l.EXPECT().UnlockByPull("owner/repo", 1).Return([]models.ProjectLock{lock}, nil)
+	lockQueue := lockmocks.NewMockLockQueue(ctrl)
+	gomock.InOrder(
+		lockQueue.EXPECT().RemovePull("owner/repo", 1).Return(nil),
+		lockQueue.EXPECT().LockNext(lock.Project, "default").Return(nil, nil),
+	)
+	dlc := events.DefaultDeleteLockCommand{
+		Locker:          l,
+		WorkingDir:      events.NewMockWorkingDir(),
+		LockQueueRunner: &events.LockQueueRunner{LockQueue: lockQueue},
+	}
+
+	numLocks, err := dlc.DeleteLocksByPull(logger, "owner/repo", 1)
+	Ok(t, err)
+	Equals(t, 1, numLocks)
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	giteasdk "code.gitea.io/sdk/gitea"
 
@@ -138,6 +139,12 @@ type CommentCommand struct {
 	PolicySet string
 	// ClearPolicyApproval is true if approvals should be cleared out for specified policies.
 	ClearPolicyApproval bool
+	// PolicyExceptionReason is the justification for approving policies as a
+	// policy exception. It's only set along with PolicyExceptionExpiry.
+	PolicyExceptionReason string
+	// PolicyExceptionExpiry is how long a policy exception lasts. If it's 0,
+	// approvals are ordinary approvals rather than exceptions.
+	PolicyExceptionExpiry time.Duration
 }
 
 // IsForSpecificProject returns true if the command is for a specific dir, workspace
@@ -178,7 +185,7 @@ func (c CommentCommand) String() string {
 }
 
 // NewCommentCommand constructs a CommentCommand, setting all missing fields to defaults.
-func NewCommentCommand(repoRelDir string, flags []string, name command.Name, subName string, verbose, autoMergeDisabled bool, autoMergeMethod string, workspace string, project string, policySet string, clearPolicyApproval bool) *CommentCommand {
+func NewCommentCommand(repoRelDir string, flags []string, name command.Name, subName string, verbose, autoMergeDisabled bool, autoMergeMethod string, workspace string, project string, policySet string, clearPolicyApproval bool, policyExceptionReason string, policyExceptionExpiry time.Duration) *CommentCommand {
 	// If repoRelDir was empty we want to keep it that way to indicate that it
 	// wasn't specified in the comment.
 	if repoRelDir != "" {
@@ -188,17 +195,19 @@ func NewCommentCommand(repoRelDir string, flags []string, name command.Name, sub
 		}
 	}
 	return &CommentCommand{
-		RepoRelDir:          repoRelDir,
-		Flags:               flags,
-		Name:                name,
-		SubName:             subName,
-		Verbose:             verbose,
-		Workspace:           workspace,
-		AutoMergeDisabled:   autoMergeDisabled,
-		AutoMergeMethod:     autoMergeMethod,
-		ProjectName:         project,
-		PolicySet:           policySet,
-		ClearPolicyApproval: clearPolicyApproval,
+		RepoRelDir:            repoRelDir,
+		Flags:                 flags,
+		Name:                  name,
+		SubName:               subName,
+		Verbose:               verbose,
+		Workspace:             workspace,
+		AutoMergeDisabled:     autoMergeDisabled,
+		AutoMergeMethod:       autoMergeMethod,
+		ProjectName:           project,
+		PolicySet:             policySet,
+		ClearPolicyApproval:   clearPolicyApproval,
+		PolicyExceptionReason: policyExceptionReason,
+		PolicyExceptionExpiry: policyExceptionExpiry,
 	}
 }
 
@@ -7,7 +7,9 @@ import (
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/metrics"
+	"github.com/runatlantis/atlantis/server/tracing"
 	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type InstrumentedProjectCommandBuilder struct {
@@ -18,6 +20,7 @@ type InstrumentedProjectCommandBuilder struct {
 
 func (b *InstrumentedProjectCommandBuilder) BuildApplyCommands(ctx *command.Context, comment *CommentCommand) ([]command.ProjectContext, error) {
 	return b.buildAndEmitStats(
+		ctx,
 		"apply",
 		func() ([]command.ProjectContext, error) {
 			return b.ProjectCommandBuilder.BuildApplyCommands(ctx, comment)
@@ -27,6 +30,7 @@ func (b *InstrumentedProjectCommandBuilder) BuildApplyCommands(ctx *command.Cont
 
 func (b *InstrumentedProjectCommandBuilder) BuildAutoplanCommands(ctx *command.Context) ([]command.ProjectContext, error) {
 	return b.buildAndEmitStats(
+		ctx,
 		"auto plan",
 		func() ([]command.ProjectContext, error) {
 			return b.ProjectCommandBuilder.BuildAutoplanCommands(ctx)
@@ -36,6 +40,7 @@ func (b *InstrumentedProjectCommandBuilder) BuildAutoplanCommands(ctx *command.C
 
 func (b *InstrumentedProjectCommandBuilder) BuildPlanCommands(ctx *command.Context, comment *CommentCommand) ([]command.ProjectContext, error) {
 	return b.buildAndEmitStats(
+		ctx,
 		"plan",
 		func() ([]command.ProjectContext, error) {
 			return b.ProjectCommandBuilder.BuildPlanCommands(ctx, comment)
@@ -45,6 +50,7 @@ func (b *InstrumentedProjectCommandBuilder) BuildPlanCommands(ctx *command.Conte
 
 func (b *InstrumentedProjectCommandBuilder) BuildImportCommands(ctx *command.Context, comment *CommentCommand) ([]command.ProjectContext, error) {
 	return b.buildAndEmitStats(
+		ctx,
 		"import",
 		func() ([]command.ProjectContext, error) {
 			return b.ProjectCommandBuilder.BuildImportCommands(ctx, comment)
@@ -54,6 +60,7 @@ func (b *InstrumentedProjectCommandBuilder) BuildImportCommands(ctx *command.Con
 
 func (b *InstrumentedProjectCommandBuilder) BuildStateRmCommands(ctx *command.Context, comment *CommentCommand) ([]command.ProjectContext, error) {
 	return b.buildAndEmitStats(
+		ctx,
 		"state rm",
 		func() ([]command.ProjectContext, error) {
 			return b.ProjectCommandBuilder.BuildStateRmCommands(ctx, comment)
@@ -62,6 +69,7 @@ func (b *InstrumentedProjectCommandBuilder) BuildStateRmCommands(ctx *command.Co
 }
 
 func (b *InstrumentedProjectCommandBuilder) buildAndEmitStats(
+	ctx *command.Context,
 	command string,
 	execute func() ([]command.ProjectContext, error),
 ) ([]command.ProjectContext, error) {
@@ -71,7 +79,23 @@ func (b *InstrumentedProjectCommandBuilder) buildAndEmitStats(
 	executionSuccess := b.scope.Counter(metrics.ExecutionSuccessMetric)
 	executionError := b.scope.Counter(metrics.ExecutionErrorMetric)
 
+	cmdLog := ctx.Log
+	log, span := tracing.StartLog(cmdLog, "ProjectCommandBuilder.Build", attribute.String("atlantis.command", command))
+	ctx.Log = log
 	projectCmds, err := execute()
+	ctx.Log = cmdLog
+	if IsIgnoredTargetedDir(err) {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
+	}
+	// The projects run after the build span has ended so they belong under
+	// the command's span instead.
+	if log != cmdLog {
+		for i := range projectCmds {
+			projectCmds[i].Log = logging.WithContext(projectCmds[i].Log, logging.Context(cmdLog))
+		}
+	}
 
 	if err != nil {
 		if IsIgnoredTargetedDir(err) {
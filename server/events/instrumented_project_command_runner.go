@@ -7,7 +7,10 @@ import (
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/metrics"
+	"github.com/runatlantis/atlantis/server/tracing"
 	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type IntrumentedCommandRunner interface {
@@ -70,10 +73,23 @@ func (p *InstrumentedProjectCommandRunner) StateRm(ctx command.ProjectContext) c
 	return RunAndEmitStats(ctx, p.projectCommandRunner.StateRm, p.scope)
 }
 
-func RunAndEmitStats(ctx command.ProjectContext, execute func(ctx command.ProjectContext) command.ProjectCommandOutput, scope tally.Scope) command.ProjectCommandOutput {
+func RunAndEmitStats(ctx command.ProjectContext, execute func(ctx command.ProjectContext) command.ProjectCommandOutput, scope tally.Scope) (result command.ProjectCommandOutput) {
 	commandName := ctx.CommandName.String()
 	// ensures we are differentiating between project level command and overall command
 	scope = ctx.SetProjectScopeTags(scope).SubScope(commandName)
+
+	log, span := tracing.StartLog(ctx.Log, "project."+commandName,
+		attribute.String("atlantis.project", ctx.ProjectName),
+		attribute.String("atlantis.dir", ctx.RepoRelDir),
+		attribute.String("atlantis.workspace", ctx.Workspace),
+	)
+	ctx.Log = log
+	defer func() {
+		if result.Failure != "" {
+			span.SetStatus(codes.Error, result.Failure)
+		}
+		tracing.End(span, result.Error)
+	}()
 	logger := ctx.Log
 
 	executionTime := scope.Timer(metrics.ExecutionTimeMetric).Start()
@@ -83,7 +99,7 @@ func RunAndEmitStats(ctx command.ProjectContext, execute func(ctx command.Projec
 	executionError := scope.Counter(metrics.ExecutionErrorMetric)
 	executionFailure := scope.Counter(metrics.ExecutionFailureMetric)
 
-	result := execute(ctx)
+	result = execute(ctx)
 
 	if result.Error != nil {
 		executionError.Inc(1)
@@ -0,0 +1,109 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/core/db"
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// LockExpiryCheckPeriod is how often LockExpiryJob looks for expired locks.
+const LockExpiryCheckPeriod = 5 * time.Minute
+
+// LockExpiryJob releases project locks that have been held longer than their
+// repo's lock TTL without the project being planned, so locks left behind by
+// abandoned pull requests don't block other pull requests indefinitely.
+type LockExpiryJob struct {
+	Locker            locking.Locker
+	DeleteLockCommand DeleteLockCommand
+	Database          db.Database
+	VCSClient         vcs.Client
+	GlobalCfg         valid.GlobalCfg
+	Logger            logging.SimpleLogging
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Run releases every expired lock. It implements scheduled.Job.
+func (j *LockExpiryJob) Run() {
+	locks, err := j.Locker.List()
+	if err != nil {
+		j.Logger.Err("listing locks to expire: %s", err)
+		return
+	}
+	now := time.Now()
+	if j.Now != nil {
+		now = j.Now()
+	}
+	for key, lock := range locks {
+		// Locks written before BaseRepo was added to PullRequest don't say
+		// which repo config applies to them.
+		if lock.Pull.BaseRepo == (models.Repo{}) {
+			continue
+		}
+		ttl := j.GlobalCfg.LockTTL(lock.Pull.BaseRepo.ID())
+		if ttl == 0 {
+			continue
+		}
+		lastActivity := j.lastActivity(lock)
+		if now.Sub(lastActivity) < ttl {
+			continue
+		}
+		j.expire(key, lock, ttl, lastActivity)
+	}
+}
+
+// lastActivity returns when lock was acquired or its project was last
+// planned, whichever is later.
+func (j *LockExpiryJob) lastActivity(lock models.ProjectLock) time.Time {
+	lastActivity := lock.Time
+	status, err := j.Database.GetPullStatus(lock.Pull)
+	if err != nil {
+		j.Logger.Warn("getting status of %s#%d to check lock %s: %s", lock.Pull.BaseRepo.FullName, lock.Pull.Num,
+			models.GenerateLockKey(lock.Project, lock.Workspace), err)
+		return lastActivity
+	}
+	if status == nil {
+		return lastActivity
+	}
+	for _, p := range status.Projects {
+		if p.Workspace == lock.Workspace && p.RepoRelDir == lock.Project.Path && p.ProjectName == lock.Project.ProjectName &&
+			p.PlannedAt.After(lastActivity) {
+			lastActivity = p.PlannedAt
+		}
+	}
+	return lastActivity
+}
+
+func (j *LockExpiryJob) expire(key string, lock models.ProjectLock, ttl time.Duration, lastActivity time.Time) {
+	released, err := j.DeleteLockCommand.ReleaseLock(j.Logger, lock)
+	if err != nil {
+		j.Logger.Err("releasing expired lock %s: %s", key, err)
+		return
+	}
+	if released == nil {
+		// The lock was released, and possibly taken by another pull
+		// request, since it was listed.
+		return
+	}
+	j.Logger.Info("released lock %s held by %s#%d, which had no activity since %s", key,
+		lock.Pull.BaseRepo.FullName, lock.Pull.Num, lastActivity.Format(time.RFC3339))
+
+	if err := j.Database.UpdateProjectStatus(lock.Pull, lock.Workspace, lock.Project.Path, models.DiscardedPlanStatus); err != nil {
+		j.Logger.Err("unable to update project status: %s", err)
+	}
+	comment := fmt.Sprintf("**Warning**: The lock on dir: `%s` workspace: `%s` expired because the project wasn't planned for %s, "+
+		"so it was released and the plan was **discarded**.\n\n"+
+		"To `apply` this project you must run `plan` again.", lock.Project.Path, lock.Workspace, ttl)
+	if err := j.VCSClient.CreateComment(j.Logger, lock.Pull.BaseRepo, lock.Pull.Num, comment, ""); err != nil {
+		j.Logger.Warn("failed commenting on pull request: %s", err)
+	}
+}
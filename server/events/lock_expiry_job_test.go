@@ -0,0 +1,124 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	dbmocks "github.com/runatlantis/atlantis/server/core/db/mocks"
+	lockmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
+)
+
+var lockExpiryNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func lockExpiryTestLock(repoFullName string, age time.Duration) models.ProjectLock {
+	return models.ProjectLock{
+		Project:   models.NewProject(repoFullName, "infra", ""),
+		Workspace: "default",
+		Time:      lockExpiryNow.Add(-age),
+		Pull: models.PullRequest{
+			Num:      3,
+			BaseRepo: models.Repo{FullName: repoFullName, VCSHost: models.VCSHost{Hostname: "github.com"}},
+		},
+	}
+}
+
+func newTestLockExpiryJob(t *testing.T, locks map[string]models.ProjectLock) (*events.LockExpiryJob, *mocks.MockDeleteLockCommand, *dbmocks.MockDatabase, *vcsmocks.MockClient) {
+	RegisterMockTestingT(t)
+	ctrl := gomock.NewController(t)
+	locker := lockmocks.NewMockLocker(ctrl)
+	locker.EXPECT().List().Return(locks, nil)
+	deleteLockCommand := mocks.NewMockDeleteLockCommand()
+	database := dbmocks.NewMockDatabase(ctrl)
+	vcsClient := vcsmocks.NewMockClient()
+	job := &events.LockExpiryJob{
+		Locker:            locker,
+		DeleteLockCommand: deleteLockCommand,
+		Database:          database,
+		VCSClient:         vcsClient,
+		GlobalCfg: valid.GlobalCfg{Repos: []valid.Repo{
+			{IDRegex: regexp.MustCompile(`github\.com/owner/(repo|short)`), LockTTL: 72 * time.Hour},
+			{ID: "github.com/owner/no-ttl"},
+			{ID: "github.com/owner/short", RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnPlanMode, TTL: time.Hour}},
+		}},
+		Logger: logging.NewNoopLogger(t),
+		Now:    func() time.Time { return lockExpiryNow },
+	}
+	return job, deleteLockCommand, database, vcsClient
+}
+
+func TestLockExpiryJob_ReleasesExpiredLock(t *testing.T) {
+	lock := lockExpiryTestLock("owner/repo", 73*time.Hour)
+	job, deleteLockCommand, database, vcsClient := newTestLockExpiryJob(t, map[string]models.ProjectLock{"owner/repo/infra/default": lock})
+	database.EXPECT().GetPullStatus(lock.Pull).Return(nil, nil)
+	When(deleteLockCommand.ReleaseLock(Any[logging.SimpleLogging](), Eq(lock))).ThenReturn(&lock, nil)
+	database.EXPECT().UpdateProjectStatus(lock.Pull, "default", "infra", models.DiscardedPlanStatus).Return(nil)
+
+	job.Run()
+
+	_, _, _, comment, _ := vcsClient.VerifyWasCalledOnce().CreateComment(Any[logging.SimpleLogging](), Eq(lock.Pull.BaseRepo), Eq(3), Any[string](), Eq("")).GetCapturedArguments()
+	Assert(t, strings.Contains(comment, "The lock on dir: `infra` workspace: `default` expired because the project wasn't planned for 72h0m0s"),
+		"unexpected comment: %s", comment)
+}
+
+func TestLockExpiryJob_KeepsLocks(t *testing.T) {
+	recentlyPlanned := lockExpiryTestLock("owner/repo", 100*time.Hour)
+	cases := []struct {
+		description string
+		lock        models.ProjectLock
+		status      *models.PullStatus
+	}{
+		{
+			description: "within ttl",
+			lock:        lockExpiryTestLock("owner/repo", 71*time.Hour),
+		},
+		{
+			description: "recently planned",
+			lock:        recentlyPlanned,
+			status: &models.PullStatus{Projects: []models.ProjectStatus{{
+				Workspace:  "default",
+				RepoRelDir: "infra",
+				PlannedAt:  lockExpiryNow.Add(-time.Hour),
+			}}},
+		},
+		{
+			description: "repo without ttl",
+			lock:        lockExpiryTestLock("owner/no-ttl", 100*time.Hour),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			job, deleteLockCommand, database, vcsClient := newTestLockExpiryJob(t, map[string]models.ProjectLock{"key": c.lock})
+			database.EXPECT().GetPullStatus(c.lock.Pull).Return(c.status, nil).AnyTimes()
+
+			job.Run()
+
+			deleteLockCommand.VerifyWasCalled(Never()).ReleaseLock(Any[logging.SimpleLogging](), Any[models.ProjectLock]())
+			vcsClient.VerifyWasCalled(Never()).CreateComment(Any[logging.SimpleLogging](), Any[models.Repo](), Any[int](), Any[string](), Any[string]())
+		})
+	}
+}
+
+func TestLockExpiryJob_RepoLocksTTLOverridesLockTTL(t *testing.T) {
+	lock := lockExpiryTestLock("owner/short", 2*time.Hour)
+	job, deleteLockCommand, database, _ := newTestLockExpiryJob(t, map[string]models.ProjectLock{"key": lock})
+	database.EXPECT().GetPullStatus(lock.Pull).Return(nil, nil)
+	When(deleteLockCommand.ReleaseLock(Any[logging.SimpleLogging](), Eq(lock))).ThenReturn(nil, nil)
+
+	job.Run()
+
+	deleteLockCommand.VerifyWasCalledOnce().ReleaseLock(Any[logging.SimpleLogging](), Eq(lock))
+}
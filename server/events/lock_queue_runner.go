@@ -0,0 +1,52 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// LockQueueRunner hands released project locks to the pull requests queued
+// for them and plans those pull requests again.
+type LockQueueRunner struct {
+	LockQueue     locking.LockQueue
+	CommandRunner CommandRunner
+}
+
+// Promote gives each released lock to the next pull request queued for it, if
+// any, and plans that pull request's project. Plans run in the background.
+func (r *LockQueueRunner) Promote(logger logging.SimpleLogging, released ...models.ProjectLock) {
+	for _, lock := range released {
+		next, err := r.LockQueue.LockNext(lock.Project, lock.Workspace)
+		if err != nil {
+			logger.Err("locking %s for the next queued pull request: %s", models.GenerateLockKey(lock.Project, lock.Workspace), err)
+			continue
+		}
+		if next == nil {
+			continue
+		}
+		logger.Info("lock %s passed from %s#%d to queued pull request #%d", models.GenerateLockKey(lock.Project, lock.Workspace),
+			lock.Project.RepoFullName, lock.Pull.Num, next.Pull.Num)
+		go r.CommandRunner.RunCommentCommand(logging.Context(logger), next.Pull.BaseRepo, &next.HeadRepo, &next.Pull, next.User, next.Pull.Num, queuedPlanCommand(*next))
+	}
+}
+
+// RemovePull takes pullNum out of every lock queue in the repo.
+func (r *LockQueueRunner) RemovePull(logger logging.SimpleLogging, repoFullName string, pullNum int) {
+	if err := r.LockQueue.RemovePull(repoFullName, pullNum); err != nil {
+		logger.Err("removing %s#%d from lock queues: %s", repoFullName, pullNum, err)
+	}
+}
+
+// queuedPlanCommand is the comment command that plans the project entry was
+// queued for, as if its author had commented to plan it.
+func queuedPlanCommand(entry models.LockQueueEntry) *CommentCommand {
+	if entry.Project.ProjectName != "" {
+		return &CommentCommand{Name: command.Plan, ProjectName: entry.Project.ProjectName}
+	}
+	return &CommentCommand{Name: command.Plan, RepoRelDir: entry.Project.Path, Workspace: entry.Workspace}
+}
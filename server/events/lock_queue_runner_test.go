@@ -0,0 +1,56 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	lockmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLockQueueRunner_Promote(t *testing.T) {
+	RegisterMockTestingT(t)
+	lockQueue := lockmocks.NewMockLockQueue(gomock.NewController(t))
+	commandRunner := mocks.NewMockCommandRunner()
+	runner := &events.LockQueueRunner{LockQueue: lockQueue, CommandRunner: commandRunner}
+
+	baseRepo := models.Repo{FullName: "owner/repo"}
+	infra := models.ProjectLock{Project: models.NewProject("owner/repo", "infra", ""), Workspace: "staging", Pull: models.PullRequest{Num: 1}}
+	app := models.ProjectLock{Project: models.NewProject("owner/repo", "app", "app"), Workspace: "default", Pull: models.PullRequest{Num: 1}}
+	unqueued := models.ProjectLock{Project: models.NewProject("owner/repo", "other", ""), Workspace: "default", Pull: models.PullRequest{Num: 1}}
+	failing := models.ProjectLock{Project: models.NewProject("owner/repo", "failing", ""), Workspace: "default", Pull: models.PullRequest{Num: 1}}
+	nextInfra := models.LockQueueEntry{Project: infra.Project, Workspace: "staging", Pull: models.PullRequest{Num: 2, BaseRepo: baseRepo},
+		HeadRepo: models.Repo{FullName: "fork/repo"}, User: models.User{Username: "alice"}}
+	nextApp := models.LockQueueEntry{Project: app.Project, Workspace: "default", Pull: models.PullRequest{Num: 3, BaseRepo: baseRepo}}
+	lockQueue.EXPECT().LockNext(infra.Project, "staging").Return(&nextInfra, nil)
+	lockQueue.EXPECT().LockNext(app.Project, "default").Return(&nextApp, nil)
+	lockQueue.EXPECT().LockNext(unqueued.Project, "default").Return(nil, nil)
+	lockQueue.EXPECT().LockNext(failing.Project, "default").Return(nil, errors.New("db error"))
+
+	runner.Promote(logging.NewNoopLogger(t), infra, app, unqueued, failing)
+
+	commandRunner.VerifyWasCalledEventually(Once(), time.Second).RunCommentCommand(Any[context.Context](), Eq(baseRepo), Eq(&nextInfra.HeadRepo), Eq(&nextInfra.Pull),
+		Eq(models.User{Username: "alice"}), Eq(2), Eq(&events.CommentCommand{Name: command.Plan, RepoRelDir: "infra", Workspace: "staging"}))
+	// Projects with names are planned by name, as `atlantis plan -p` would.
+	commandRunner.VerifyWasCalledEventually(Once(), time.Second).RunCommentCommand(Any[context.Context](), Eq(baseRepo), Any[*models.Repo](), Any[*models.PullRequest](),
+		Any[models.User](), Eq(3), Eq(&events.CommentCommand{Name: command.Plan, ProjectName: "app"}))
+}
+
+func TestLockQueueRunner_RemovePull(t *testing.T) {
+	lockQueue := lockmocks.NewMockLockQueue(gomock.NewController(t))
+	lockQueue.EXPECT().RemovePull("owner/repo", 2).Return(nil)
+	runner := &events.LockQueueRunner{LockQueue: lockQueue}
+
+	runner.RemovePull(logging.NewNoopLogger(t), "owner/repo", 2)
+}
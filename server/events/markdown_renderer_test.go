@@ -769,6 +769,70 @@ $$$
   $$$
 :twisted_rightwards_arrows: Upstream was modified, a new merge was performed.
 
+---
+* :fast_forward: To **apply** all unapplied plans from this Pull Request, comment:
+  $$$shell
+  atlantis apply
+  $$$
+* :put_litter_in_its_place: To **delete** all plans and locks from this Pull Request, comment:
+  $$$shell
+  atlantis unlock
+  $$$
+`,
+		},
+		{
+			"single successful plan with cost estimate",
+			command.Plan,
+			"",
+			[]command.ProjectResult{
+				{
+					ProjectCommandOutput: command.ProjectCommandOutput{
+						PlanSuccess: &models.PlanSuccess{
+							TerraformOutput: "terraform-output",
+							LockURL:         "lock-url",
+							RePlanCmd:       "atlantis plan -d path -w workspace",
+							ApplyCmd:        "atlantis apply -d path -w workspace",
+							CostEstimate: &models.CostEstimate{
+								Currency:     "USD",
+								MonthlyDelta: 62.49,
+								Resources: []models.ResourceCostEstimate{
+									{Address: "aws_instance.web", Action: "replace", MonthlyDelta: 62.49},
+									{Address: "aws_eip.old", Action: "delete", MonthlyDelta: 0},
+								},
+								Unpriced: []string{"aws_iam_role.web", "aws_s3_bucket.logs"},
+							},
+						},
+					},
+					Workspace:  "workspace",
+					RepoRelDir: "path",
+				},
+			},
+			models.Github,
+			`
+Ran Plan for dir: $path$ workspace: $workspace$
+
+$$$diff
+terraform-output
+$$$
+
+* :arrow_forward: To **apply** this plan, comment:
+  $$$shell
+  atlantis apply -d path -w workspace
+  $$$
+* :put_litter_in_its_place: To **delete** this plan and lock, click [here](lock-url)
+* :repeat: To **plan** this project again, comment:
+  $$$shell
+  atlantis plan -d path -w workspace
+  $$$
+:moneybag: Estimated monthly cost change: **+62.49 USD**
+
+| Resource | Action | Monthly cost change |
+|---|---|---|
+| $aws_instance.web$ | replace | +62.49 |
+| $aws_eip.old$ | delete | +0.00 |
+
+Not in the pricing table: $aws_iam_role.web$, $aws_s3_bucket.logs$
+
 ---
 * :fast_forward: To **apply** all unapplied plans from this Pull Request, comment:
   $$$shell
@@ -0,0 +1,127 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events (interfaces: CheckRunUpdater)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	models "github.com/runatlantis/atlantis/server/events/models"
+	github "github.com/runatlantis/atlantis/server/events/vcs/github"
+	logging "github.com/runatlantis/atlantis/server/logging"
+	"reflect"
+	"time"
+)
+
+type MockCheckRunUpdater struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockCheckRunUpdater(options ...pegomock.Option) *MockCheckRunUpdater {
+	mock := &MockCheckRunUpdater{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockCheckRunUpdater) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockCheckRunUpdater) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockCheckRunUpdater) UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, req github.CheckRunRequest) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockCheckRunUpdater().")
+	}
+	_params := []pegomock.Param{logger, repo, pull, req}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("UpdateCheckRun", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockCheckRunUpdater) VerifyWasCalledOnce() *VerifierMockCheckRunUpdater {
+	return &VerifierMockCheckRunUpdater{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockCheckRunUpdater) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockCheckRunUpdater {
+	return &VerifierMockCheckRunUpdater{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockCheckRunUpdater) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockCheckRunUpdater {
+	return &VerifierMockCheckRunUpdater{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockCheckRunUpdater) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockCheckRunUpdater {
+	return &VerifierMockCheckRunUpdater{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockCheckRunUpdater struct {
+	mock                   *MockCheckRunUpdater
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockCheckRunUpdater) UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, req github.CheckRunRequest) *MockCheckRunUpdater_UpdateCheckRun_OngoingVerification {
+	_params := []pegomock.Param{logger, repo, pull, req}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UpdateCheckRun", _params, verifier.timeout)
+	return &MockCheckRunUpdater_UpdateCheckRun_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockCheckRunUpdater_UpdateCheckRun_OngoingVerification struct {
+	mock              *MockCheckRunUpdater
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockCheckRunUpdater_UpdateCheckRun_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, models.Repo, models.PullRequest, github.CheckRunRequest) {
+	logger, repo, pull, req := c.GetAllCapturedArguments()
+	return logger[len(logger)-1], repo[len(repo)-1], pull[len(pull)-1], req[len(req)-1]
+}
+
+func (c *MockCheckRunUpdater_UpdateCheckRun_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []models.Repo, _param2 []models.PullRequest, _param3 []github.CheckRunRequest) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]models.Repo, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(models.Repo)
+			}
+		}
+		if len(_params) > 2 {
+			_param2 = make([]models.PullRequest, len(c.methodInvocations))
+			for u, param := range _params[2] {
+				_param2[u] = param.(models.PullRequest)
+			}
+		}
+		if len(_params) > 3 {
+			_param3 = make([]github.CheckRunRequest, len(c.methodInvocations))
+			for u, param := range _params[3] {
+				_param3[u] = param.(github.CheckRunRequest)
+			}
+		}
+	}
+	return
+}
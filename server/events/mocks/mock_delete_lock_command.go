@@ -64,6 +64,44 @@ func (mock *MockDeleteLockCommand) DeleteLocksByPull(logger logging.SimpleLoggin
 	return _ret0, _ret1
 }
 
+func (mock *MockDeleteLockCommand) ReleaseLock(logger logging.SimpleLogging, lock models.ProjectLock) (*models.ProjectLock, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockDeleteLockCommand().")
+	}
+	_params := []pegomock.Param{logger, lock}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ReleaseLock", _params, []reflect.Type{reflect.TypeOf((**models.ProjectLock)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 *models.ProjectLock
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(*models.ProjectLock)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
+func (mock *MockDeleteLockCommand) ReleaseLocksByPull(logger logging.SimpleLogging, repoFullName string, pullNum int) ([]models.ProjectLock, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockDeleteLockCommand().")
+	}
+	_params := []pegomock.Param{logger, repoFullName, pullNum}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ReleaseLocksByPull", _params, []reflect.Type{reflect.TypeOf((*[]models.ProjectLock)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 []models.ProjectLock
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].([]models.ProjectLock)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
 func (mock *MockDeleteLockCommand) VerifyWasCalledOnce() *VerifierMockDeleteLockCommand {
 	return &VerifierMockDeleteLockCommand{
 		mock:                   mock,
@@ -176,3 +214,79 @@ func (c *MockDeleteLockCommand_DeleteLocksByPull_OngoingVerification) GetAllCapt
 	}
 	return
 }
+
+func (verifier *VerifierMockDeleteLockCommand) ReleaseLock(logger logging.SimpleLogging, lock models.ProjectLock) *MockDeleteLockCommand_ReleaseLock_OngoingVerification {
+	_params := []pegomock.Param{logger, lock}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ReleaseLock", _params, verifier.timeout)
+	return &MockDeleteLockCommand_ReleaseLock_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockDeleteLockCommand_ReleaseLock_OngoingVerification struct {
+	mock              *MockDeleteLockCommand
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockDeleteLockCommand_ReleaseLock_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, models.ProjectLock) {
+	logger, lock := c.GetAllCapturedArguments()
+	return logger[len(logger)-1], lock[len(lock)-1]
+}
+
+func (c *MockDeleteLockCommand_ReleaseLock_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []models.ProjectLock) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]models.ProjectLock, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(models.ProjectLock)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockDeleteLockCommand) ReleaseLocksByPull(logger logging.SimpleLogging, repoFullName string, pullNum int) *MockDeleteLockCommand_ReleaseLocksByPull_OngoingVerification {
+	_params := []pegomock.Param{logger, repoFullName, pullNum}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ReleaseLocksByPull", _params, verifier.timeout)
+	return &MockDeleteLockCommand_ReleaseLocksByPull_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockDeleteLockCommand_ReleaseLocksByPull_OngoingVerification struct {
+	mock              *MockDeleteLockCommand
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockDeleteLockCommand_ReleaseLocksByPull_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, string, int) {
+	logger, repoFullName, pullNum := c.GetAllCapturedArguments()
+	return logger[len(logger)-1], repoFullName[len(repoFullName)-1], pullNum[len(pullNum)-1]
+}
+
+func (c *MockDeleteLockCommand_ReleaseLocksByPull_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []string, _param2 []int) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(string)
+			}
+		}
+		if len(_params) > 2 {
+			_param2 = make([]int, len(c.methodInvocations))
+			for u, param := range _params[2] {
+				_param2[u] = param.(int)
+			}
+		}
+	}
+	return
+}
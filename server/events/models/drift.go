@@ -316,6 +316,11 @@ type ProjectDrift struct {
 	LastChecked time.Time `json:"last_checked"`
 	// Error contains any error message if drift detection failed for this project.
 	Error string `json:"error,omitempty"`
+	// VCSType is the VCS host type of the repository, e.g. "Github". It is
+	// empty for results stored by older versions of Atlantis.
+	VCSType string `json:"vcs_type,omitempty"`
+	// PlanOutput is the output of the plan that detected this drift.
+	PlanOutput string `json:"plan_output,omitempty"`
 }
 
 // DriftStatusResponse is the API response for GET /api/drift/status.
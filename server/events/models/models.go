@@ -301,6 +301,24 @@ type ProjectLock struct {
 	Time time.Time
 }
 
+// LockQueueEntry is a pull request waiting for the lock on a project held by
+// another pull request.
+type LockQueueEntry struct {
+	// Project is the project whose lock is being waited for.
+	Project Project
+	// Workspace is the Terraform workspace whose lock is being waited for.
+	Workspace string
+	// Pull is the pull request waiting for the lock.
+	Pull PullRequest
+	// HeadRepo is the repository Pull is merging from. It's needed to plan
+	// Pull again once it gets the lock.
+	HeadRepo Repo
+	// User is the user that ran the command that was queued.
+	User User
+	// Time is the time at which the pull request joined the queue.
+	Time time.Time
+}
+
 // Project represents a Terraform project. Since there may be multiple
 // Terraform projects in a single repo we also include Path to the project
 // root relative to the repo root.
@@ -441,6 +459,36 @@ type PlanSuccess struct {
 	// branch we're merging into had been updated, and we had to merge again
 	// before planning
 	MergedAgain bool
+	// PlanDigest is the hex-encoded SHA-256 digest of the plan file. It's
+	// empty if the workflow didn't produce a plan file.
+	PlanDigest string
+	// PlanCommit is the head commit the plan was generated from.
+	PlanCommit string
+	// CostEstimate is the estimated change in monthly cost from the
+	// cost_estimate step. It's nil if the workflow doesn't run that step.
+	CostEstimate *CostEstimate
+}
+
+// CostEstimate is the estimated change in monthly cost of applying a plan.
+type CostEstimate struct {
+	// Currency is the currency prices are in, ex. USD.
+	Currency string
+	// MonthlyDelta is the total change in monthly cost. It's negative if the
+	// plan makes the project cheaper.
+	MonthlyDelta float64
+	// Resources are the priced resources the plan changes.
+	Resources []ResourceCostEstimate
+	// Unpriced are the addresses of changed resources that aren't in the
+	// pricing table and so aren't included in MonthlyDelta.
+	Unpriced []string
+}
+
+// ResourceCostEstimate is the estimated change in monthly cost of a single
+// resource.
+type ResourceCostEstimate struct {
+	Address      string
+	Action       string
+	MonthlyDelta float64
 }
 
 func NewPolicySetResult(policySetName string, policyOutput string, passed bool, reqApprovalCount int, policyItemRegex string) (*PolicySetResult, error) {
@@ -472,6 +520,56 @@ type PolicySetResult struct {
 type PolicySetApproval struct {
 	Approver string
 	Hashes   []string
+	// Expires is set if the approval was granted as a policy exception. Such
+	// approvals are re-applied from the exception on each policy check rather
+	// than carried over, so they stop counting once it expires.
+	Expires time.Time `json:",omitzero"`
+}
+
+// Expired returns true if the approval was granted as a policy exception that
+// no longer applies at now. Other approvals never expire.
+func (a PolicySetApproval) Expired(now time.Time) bool {
+	return !a.Expires.IsZero() && !now.Before(a.Expires)
+}
+
+// PolicyException is an approval of specific failing policy items, granted
+// with approve_policies --expires. Unlike other approvals it's stored
+// separately from the pull status, so it survives re-plans until it expires.
+type PolicyException struct {
+	// Project is the project the exception applies to.
+	Project Project
+	// Workspace is the Terraform workspace the exception applies to.
+	Workspace string
+	// PullNum is the pull request the exception was granted on.
+	PullNum int
+	// PolicySetName is the policy set the exception applies to.
+	PolicySetName string
+	// Hashes are the hashes of the failing policy items the exception covers.
+	// If a re-plan produces items that aren't covered, the exception no longer
+	// counts as an approval.
+	Hashes []string
+	// Approver is the policy owner who granted the exception.
+	Approver string
+	// Reason is the justification given by Approver.
+	Reason string
+	// Time is when the exception was granted.
+	Time time.Time
+	// Expires is when the exception stops applying.
+	Expires time.Time
+}
+
+// Expired returns true if the exception no longer applies at now.
+func (e PolicyException) Expired(now time.Time) bool {
+	return !now.Before(e.Expires)
+}
+
+// Approval returns the approval the exception grants.
+func (e PolicyException) Approval() PolicySetApproval {
+	return PolicySetApproval{
+		Approver: e.Approver,
+		Hashes:   e.Hashes,
+		Expires:  e.Expires,
+	}
 }
 
 // ApprovalCoversAllHashes reports whether approvalHashes contains every element of required.
@@ -500,11 +598,12 @@ type PolicySetStatus struct {
 	PolicyItemRegex string
 }
 
-// GetCurApprovals returns the number of approvals that cover all hashes in this policy set.
+// GetCurApprovals returns the number of unexpired approvals that cover all hashes in this policy set.
 func (pss *PolicySetStatus) GetCurApprovals() int {
 	n := 0
+	now := time.Now()
 	for _, approval := range pss.Approvals {
-		if ApprovalCoversAllHashes(approval.Hashes, pss.Hashes) {
+		if !approval.Expired(now) && ApprovalCoversAllHashes(approval.Hashes, pss.Hashes) {
 			n++
 		}
 	}
@@ -512,8 +611,9 @@ func (pss *PolicySetStatus) GetCurApprovals() int {
 }
 
 func (pss *PolicySetStatus) OwnerHasFullyApproved(owner string) bool {
+	now := time.Now()
 	for _, approval := range pss.Approvals {
-		if approval.Approver == owner && ApprovalCoversAllHashes(approval.Hashes, pss.Hashes) {
+		if approval.Approver == owner && !approval.Expired(now) && ApprovalCoversAllHashes(approval.Hashes, pss.Hashes) {
 			return true
 		}
 	}
@@ -751,7 +851,11 @@ func (p *PolicyCheckResults) PolicySummary() string {
 		if policySetResult.Passed {
 			summary = append(summary, fmt.Sprintf("policy set: %s: passed.", policySetResult.PolicySetName))
 		} else if policySetResult.GetCurApprovals() >= policySetResult.ReqApprovalCount {
-			summary = append(summary, fmt.Sprintf("policy set: %s: approved.", policySetResult.PolicySetName))
+			if expires, ok := policySetResult.ExceptionExpires(); ok {
+				summary = append(summary, fmt.Sprintf("policy set: %s: approved by policy exception until %s.", policySetResult.PolicySetName, expires.UTC().Format(time.RFC3339)))
+			} else {
+				summary = append(summary, fmt.Sprintf("policy set: %s: approved.", policySetResult.PolicySetName))
+			}
 		} else {
 			summary = append(summary, fmt.Sprintf("policy set: %s: requires: %d approval(s), have: %d.", policySetResult.PolicySetName, policySetResult.ReqApprovalCount, policySetResult.GetCurApprovals()))
 		}
@@ -759,17 +863,34 @@ func (p *PolicyCheckResults) PolicySummary() string {
 	return strings.Join(summary, "\n")
 }
 
-// GetCurApprovals returns the number of approvals that cover all hashes in this policy set result.
+// GetCurApprovals returns the number of unexpired approvals that cover all hashes in this policy set result.
 func (p *PolicySetResult) GetCurApprovals() int {
 	n := 0
+	now := time.Now()
 	for _, approval := range p.Approvals {
-		if ApprovalCoversAllHashes(approval.Hashes, p.Hashes) {
+		if !approval.Expired(now) && ApprovalCoversAllHashes(approval.Hashes, p.Hashes) {
 			n++
 		}
 	}
 	return n
 }
 
+// ExceptionExpires returns when the earliest policy exception counting as an
+// approval expires, and false if no policy exceptions count.
+func (p *PolicySetResult) ExceptionExpires() (time.Time, bool) {
+	var expires time.Time
+	now := time.Now()
+	for _, approval := range p.Approvals {
+		if approval.Expires.IsZero() || approval.Expired(now) || !ApprovalCoversAllHashes(approval.Hashes, p.Hashes) {
+			continue
+		}
+		if expires.IsZero() || approval.Expires.Before(expires) {
+			expires = approval.Expires
+		}
+	}
+	return expires, !expires.IsZero()
+}
+
 // ApprovedHashes returns the deduplicated union of hashes across all approvals.
 func (p *PolicySetResult) ApprovedHashes() []string {
 	seen := make(map[string]struct{})
@@ -836,6 +957,17 @@ type ProjectStatus struct {
 	PolicyStatus []PolicySetStatus
 	// Status is the status of where this project is at in the planning cycle.
 	Status ProjectPlanStatus
+	// PlanDigest is the hex-encoded SHA-256 digest of the plan file recorded
+	// at plan time. Apply rejects plan files that don't match it.
+	PlanDigest string
+	// PlanCommit is the head commit the current plan was generated from.
+	PlanCommit string
+	// PlannedAt is when plan last ran for the project. It's zero if plan
+	// hasn't run since Atlantis started recording it.
+	PlannedAt time.Time
+	// CostEstimate is the cost estimate recorded by the current plan. It's nil
+	// if the plan's workflow doesn't run the cost_estimate step.
+	CostEstimate *CostEstimate
 }
 
 // ProjectPlanStatus is the status of where this project is at in the planning
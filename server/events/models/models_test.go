@@ -7,6 +7,7 @@ package models_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/vcs/azuredevops"
@@ -723,6 +724,22 @@ func TestPolicyCheckResults_PolicyFuncs(t *testing.T) {
 			policyClearedExp: true,
 			policySummaryExp: "policy set: policy1: approved.",
 		},
+		{
+			description: "single policy set, approved by policy exception",
+			policysetResults: []models.PolicySetResult{
+				{
+					PolicySetName:    "policy1",
+					Passed:           false,
+					ReqApprovalCount: 1,
+					Approvals: []models.PolicySetApproval{{
+						Approver: "approver1",
+						Expires:  time.Date(2099, 1, 15, 0, 0, 0, 0, time.UTC),
+					}},
+				},
+			},
+			policyClearedExp: true,
+			policySummaryExp: "policy set: policy1: approved by policy exception until 2099-01-15T00:00:00Z.",
+		},
 		{
 			description: "multiple policy sets, different states.",
 			policysetResults: []models.PolicySetResult{
@@ -881,6 +898,17 @@ func TestPolicySetStatus_GetCurApprovals(t *testing.T) {
 			},
 			expected: 1,
 		},
+		{
+			description: "expired policy exceptions not counted",
+			status: models.PolicySetStatus{
+				Hashes: []string{"h1"},
+				Approvals: []models.PolicySetApproval{
+					{Approver: "user1", Hashes: []string{"h1"}, Expires: time.Now().Add(-time.Minute)},
+					{Approver: "user2", Hashes: []string{"h1"}, Expires: time.Now().Add(time.Hour)},
+				},
+			},
+			expected: 1,
+		},
 		{
 			description: "nil hashes means all approvals count",
 			status: models.PolicySetStatus{
@@ -1093,6 +1121,14 @@ func TestPolicySetResult_GetCurApprovals(t *testing.T) {
 			},
 			expected: 0,
 		},
+		{
+			description: "expired policy exception not counted",
+			result: models.PolicySetResult{
+				Hashes:    []string{"h1"},
+				Approvals: []models.PolicySetApproval{{Approver: "u", Hashes: []string{"h1"}, Expires: time.Now().Add(-time.Minute)}},
+			},
+			expected: 0,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.description, func(t *testing.T) {
@@ -51,18 +51,31 @@ const (
 	RemediationStatusFailed RemediationStatus = "failed"
 	// RemediationStatusPartial indicates some projects succeeded, some failed.
 	RemediationStatusPartial RemediationStatus = "partial"
+	// RemediationStatusCancelled indicates remediation was cancelled before
+	// all projects were processed.
+	RemediationStatusCancelled RemediationStatus = "cancelled"
 )
 
 // IsTerminal returns true if the status represents a final state.
 func (s RemediationStatus) IsTerminal() bool {
 	switch s {
-	case RemediationStatusSuccess, RemediationStatusFailed, RemediationStatusPartial:
+	case RemediationStatusSuccess, RemediationStatusFailed, RemediationStatusPartial, RemediationStatusCancelled:
 		return true
 	default:
 		return false
 	}
 }
 
+// IsValid returns true if the status is a recognized value.
+func (s RemediationStatus) IsValid() bool {
+	switch s {
+	case RemediationStatusPending, RemediationStatusRunning:
+		return true
+	default:
+		return s.IsTerminal()
+	}
+}
+
 // RemediationRequest is the API request for POST /api/drift/remediate.
 type RemediationRequest struct {
 	// Repository is the full repository name (owner/repo). Required.
@@ -93,6 +106,20 @@ type RemediationRequest struct {
 	Workspaces []string `json:"workspaces,omitempty"`
 	// DriftOnly if true, only remediates projects that have detected drift.
 	DriftOnly bool `json:"drift_only"`
+	// RequestedBy identifies the API caller. It is populated by the API
+	// controller and recorded on the result.
+	RequestedBy *APICaller `json:"-"`
+}
+
+// APICaller identifies who made an API request.
+type APICaller struct {
+	// Name is the caller's identity. With the shared API secret this is the
+	// optional, self-reported X-Atlantis-User header.
+	Name string `json:"name"`
+	// AuthMethod is how the caller authenticated, e.g. "api_secret".
+	AuthMethod string `json:"auth_method"`
+	// RemoteAddr is the network address the request came from.
+	RemoteAddr string `json:"remote_addr,omitempty"`
 }
 
 // FieldError represents a validation error for a specific field.
@@ -224,6 +251,16 @@ type RemediationResult struct {
 	Projects []ProjectRemediationResult `json:"projects"`
 	// Error contains any top-level error message.
 	Error string `json:"error,omitempty"`
+	// RequestedBy identifies the API caller that requested the remediation.
+	RequestedBy *APICaller `json:"requested_by,omitempty"`
+	// Owner identifies the Atlantis process executing the remediation. It is
+	// omitted from API responses.
+	Owner string `json:"-"`
+	// HeartbeatAt is when Owner last stored the result. Owners refresh it
+	// while the remediation is pending or running, so results that stop
+	// being refreshed belong to a process that stopped. It is omitted from
+	// API responses.
+	HeartbeatAt time.Time `json:"-"`
 }
 
 // NewRemediationResult creates a new RemediationResult with initial values.
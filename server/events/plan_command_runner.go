@@ -107,6 +107,9 @@ type PlanCommandRunner struct {
 	pullReqStatusFetcher  vcs.PullReqStatusFetcher
 	SilencePRComments     []string
 	PendingApplyStatus    bool
+	// LockQueueRunner is nil unless --enable-lock-queue is set. When set, plan
+	// locks this runner releases are handed to the next queued pull request.
+	LockQueueRunner *LockQueueRunner
 }
 
 func (p *PlanCommandRunner) runAutoplan(ctx *command.Context) {
@@ -575,9 +578,13 @@ func (p *PlanCommandRunner) deletePlanLocksForPendingPlans(ctx *command.Context,
 }
 
 func (p *PlanCommandRunner) unlockPlanLockIfOwnedByPull(ctx *command.Context, project models.Project, workspace string, lockKey string) error {
-	if _, err := p.lockingLocker.UnlockIfOwnedByPull(project, workspace, ctx.Pull.Num); err != nil {
+	released, err := p.lockingLocker.UnlockIfOwnedByPull(project, workspace, ctx.Pull.Num)
+	if err != nil {
 		return fmt.Errorf("deleting lock %q for pull %d: %w", lockKey, ctx.Pull.Num, err)
 	}
+	if p.LockQueueRunner != nil && released != nil {
+		p.LockQueueRunner.Promote(ctx.Log, *released)
+	}
 	return nil
 }
 
@@ -9,9 +9,11 @@ import (
 
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 	"github.com/runatlantis/atlantis/server/core/locking"
+	lockmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/logging"
+	"go.uber.org/mock/gomock"
 )
 
 func TestPlanCommandRunner_DeletePlansAndPlanLocksByRepoLockMode(t *testing.T) {
@@ -287,6 +289,33 @@ func TestPlanCommandRunner_DeletePlanLocksForPendingPlansReleasesOnlyPendingPlan
 	}
 }
 
+func TestPlanCommandRunner_DeletePlanLocksForPendingPlansPromotesQueuedPull(t *testing.T) {
+	repo := models.Repo{FullName: "owner/repo"}
+	pull := models.PullRequest{BaseRepo: repo, Num: 1}
+	project := models.NewProject(repo.FullName, "terraform", "prod")
+	key := models.GenerateLockKey(project, "default")
+	locker := &recordingPlanCleanupLocker{
+		locksByKey: map[string]models.ProjectLock{
+			key: {Project: project, Workspace: "default", Pull: pull},
+		},
+	}
+	lockQueue := lockmocks.NewMockLockQueue(gomock.NewController(t))
+	runner := &PlanCommandRunner{
+		lockingLocker:   locker,
+		LockQueueRunner: &LockQueueRunner{LockQueue: lockQueue},
+	}
+	ctx := &command.Context{
+		Log:  logging.NewNoopLogger(t),
+		Pull: pull,
+	}
+	// The released lock is offered to the queue; nobody is waiting for it.
+	lockQueue.EXPECT().LockNext(project, "default").Return(nil, nil)
+
+	if err := runner.deletePlanLocksForPendingPlans(ctx, []PendingPlan{{RepoRelDir: "terraform", Workspace: "default", ProjectName: "prod"}}); err != nil {
+		t.Fatalf("deletePlanLocksForPendingPlans returned error: %s", err)
+	}
+}
+
 type recordingPendingPlanFinder struct {
 	PendingPlanFinder
 	findPullDirs []string
@@ -403,7 +403,7 @@ func (p *DefaultProjectCommandBuilder) shouldSkipClone(ctx *command.Context, mod
 	ctx.Log.Info("successfully parsed remote %s file", repoCfgFile)
 
 	// If auto discover is enabled, we never want to skip cloning
-	if p.autoDiscoverModeEnabled(ctx, repoCfg) {
+	if p.autoDiscoverModeEnabled(ctx, repoCfg) || p.autoDiscoverMode(ctx, repoCfg) == valid.AutoDiscoverTerragruntMode {
 		ctx.Log.Info("automatic project discovery enabled. Will resume automatic detection")
 		return false, nil
 	}
@@ -430,14 +430,19 @@ func (p *DefaultProjectCommandBuilder) shouldSkipClone(ctx *command.Context, mod
 
 // autoDiscoverModeEnabled determines whether to use autodiscover
 func (p *DefaultProjectCommandBuilder) autoDiscoverModeEnabled(ctx *command.Context, repoCfg valid.RepoCfg) bool {
-	// 1. If global defines autodiscover, evaluate it directly
+	return repoCfg.AutoDiscoverEnabled(p.autoDiscoverMode(ctx, repoCfg))
+}
+
+// autoDiscoverMode returns the autodiscover mode that applies to this repo.
+func (p *DefaultProjectCommandBuilder) autoDiscoverMode(ctx *command.Context, repoCfg valid.RepoCfg) valid.AutoDiscoverMode {
+	// 1. If global defines autodiscover, use it directly
 	if global := p.GlobalCfg.RepoAutoDiscoverCfg(ctx.Pull.BaseRepo.ID()); global != nil {
-		return repoCfg.AutoDiscoverEnabled(global.Mode)
+		return global.Mode
 	}
 
-	// 2. Otherwise if repo defines it, evaluate that
+	// 2. Otherwise if repo defines it, use that
 	if repoCfg.AutoDiscover != nil {
-		return repoCfg.AutoDiscoverEnabled(repoCfg.AutoDiscover.Mode)
+		return repoCfg.AutoDiscover.Mode
 	}
 
 	// 3. Otherwise use CLI/default
@@ -445,7 +450,36 @@ func (p *DefaultProjectCommandBuilder) autoDiscoverModeEnabled(ctx *command.Cont
 	if defaultAutoDiscoverMode == "" {
 		defaultAutoDiscoverMode = valid.AutoDiscoverAutoMode
 	}
-	return repoCfg.AutoDiscoverEnabled(defaultAutoDiscoverMode)
+	return defaultAutoDiscoverMode
+}
+
+// addTerragruntProjects adds a project for every terragrunt.hcl file in repoDir
+// whose dir isn't already configured in repoCfg, if the terragrunt autodiscover
+// mode applies to this repo. Configured projects take precedence.
+func (p *DefaultProjectCommandBuilder) addTerragruntProjects(ctx *command.Context, repoDir string, repoCfg valid.RepoCfg) (valid.RepoCfg, error) {
+	if p.autoDiscoverMode(ctx, repoCfg) != valid.AutoDiscoverTerragruntMode {
+		return repoCfg, nil
+	}
+	configuredProjDirs := make(map[string]bool)
+	for _, configProj := range repoCfg.Projects {
+		configuredProjDirs[filepath.Clean(configProj.Dir)] = true
+	}
+	discovered, err := FindTerragruntProjects(repoDir, func(dir string) bool {
+		return configuredProjDirs[dir] || p.isAutoDiscoverPathIgnored(ctx, repoCfg, dir)
+	})
+	if err != nil {
+		ctx.Log.Warn("error(s) parsing terragrunt configuration: %s", err)
+	}
+	for i := range discovered {
+		// A dependency on a configured project can't be expressed by dir,
+		// so only keep dependencies on other discovered projects.
+		discovered[i].DependsOn = slices.DeleteFunc(discovered[i].DependsOn, func(dep string) bool {
+			return configuredProjDirs[dep]
+		})
+	}
+	ctx.Log.Info("automatically discovered %d terragrunt project(s)", len(discovered))
+	repoCfg.Projects = append(slices.Clone(repoCfg.Projects), discovered...)
+	return repoCfg, nil
 }
 
 // parseRepoCfg parses the repo config file from repoDir if it exists. Returns the
@@ -459,14 +493,16 @@ func (p *DefaultProjectCommandBuilder) parseRepoCfg(ctx *command.Context, repoDi
 	}
 	if !hasRepoCfg {
 		ctx.Log.Info("repo config file %s is absent, using global defaults", repoCfgFile)
-		return valid.RepoCfg{}, false, nil
+		repoCfg, err := p.addTerragruntProjects(ctx, repoDir, valid.RepoCfg{})
+		return repoCfg, false, err
 	}
 	repoCfg, err := p.ParserValidator.ParseRepoCfg(repoDir, p.GlobalCfg, ctx.Pull.BaseRepo.ID(), ctx.Pull.BaseBranch)
 	if err != nil {
 		return valid.RepoCfg{}, false, fmt.Errorf("parsing %s: %w", repoCfgFile, err)
 	}
 	ctx.Log.Info("successfully parsed %s file", repoCfgFile)
-	return repoCfg, true, nil
+	repoCfg, err = p.addTerragruntProjects(ctx, repoDir, repoCfg)
+	return repoCfg, true, err
 }
 
 // shouldIgnoreTargetedDir checks whether a targeted -d command should be
@@ -1032,6 +1068,10 @@ func (p *DefaultProjectCommandBuilder) buildProjectPlanCommand(ctx *command.Cont
 			if err != nil {
 				return pcc, err
 			}
+			repoConfig, err = p.addTerragruntProjects(ctx, defaultRepoDir, repoConfig)
+			if err != nil {
+				return pcc, err
+			}
 			exactAPIProjectIdentity := ctx.API && (cmd.RepoRelDir != "" || cmd.Workspace != "")
 			repoCfgProjects := repoConfig.FindProjectsByName(cmd.ProjectName)
 			if exactAPIProjectIdentity {
@@ -1098,16 +1138,20 @@ func (p *DefaultProjectCommandBuilder) getCfg(ctx *command.Context, projectName
 		err = fmt.Errorf("looking for '%s' file in '%s': %w", repoCfgFile, repoDir, err)
 		return
 	}
-	if !hasRepoCfg {
+	var repoConfig valid.RepoCfg
+	if hasRepoCfg {
+		repoConfig, err = p.ParserValidator.ParseRepoCfg(repoDir, p.GlobalCfg, ctx.Pull.BaseRepo.ID(), ctx.Pull.BaseBranch)
+		if err != nil {
+			return
+		}
+	} else if p.autoDiscoverMode(ctx, repoConfig) != valid.AutoDiscoverTerragruntMode {
 		if projectName != "" {
 			err = fmt.Errorf("cannot specify a project name unless an %s file exists to configure projects", repoCfgFile)
 			return
 		}
 		return
 	}
-
-	var repoConfig valid.RepoCfg
-	repoConfig, err = p.ParserValidator.ParseRepoCfg(repoDir, p.GlobalCfg, ctx.Pull.BaseRepo.ID(), ctx.Pull.BaseBranch)
+	repoConfig, err = p.addTerragruntProjects(ctx, repoDir, repoConfig)
 	if err != nil {
 		return
 	}
@@ -418,6 +418,78 @@ func TestDefaultProjectCommandBuilder_OpenTofuWorkspaceDetection(t *testing.T) {
 }
 
 // Test building a plan and apply command for one project.
+func TestDefaultProjectCommandBuilder_BuildAutoplanCommands_Terragrunt(t *testing.T) {
+	RegisterMockTestingT(t)
+	logger := logging.NewNoopLogger(t)
+	scope := metricstest.NewLoggingScope(t, logger, "atlantis")
+	tmpDir := DirStructure(t, map[string]any{
+		"live": map[string]any{
+			"app": map[string]any{
+				"terragrunt.hcl": nil,
+			},
+			"vpc": map[string]any{
+				"terragrunt.hcl": nil,
+			},
+		},
+		"plain": map[string]any{
+			"main.tf": nil,
+		},
+	})
+	Ok(t, os.WriteFile(filepath.Join(tmpDir, "live", "app", "terragrunt.hcl"), []byte("dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n"), 0600))
+
+	workingDir := mocks.NewMockWorkingDir()
+	When(workingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[string]())).
+		ThenReturn(tmpDir, nil)
+	vcsClient := vcsmocks.NewMockClient()
+	When(vcsClient.GetModifiedFiles(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest]())).
+		ThenReturn([]string{"live/app/terragrunt.hcl", "live/vpc/terragrunt.hcl", "plain/main.tf"}, nil)
+
+	builder := events.NewProjectCommandBuilder(
+		false,
+		&config.ParserValidator{},
+		&events.DefaultProjectFinder{},
+		vcsClient,
+		workingDir,
+		events.NewDefaultWorkingDirLocker(),
+		valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{}),
+		&events.DefaultPendingPlanFinder{},
+		&events.CommentParser{ExecutableName: "atlantis"},
+		defaultUserConfig.SkipCloneNoChanges,
+		defaultUserConfig.EnableRegExpCmd,
+		defaultUserConfig.EnableAutoMerge,
+		defaultUserConfig.EnableParallelPlan,
+		defaultUserConfig.EnableParallelApply,
+		defaultUserConfig.AutoDetectModuleFiles,
+		defaultUserConfig.AutoplanFileList,
+		defaultUserConfig.RestrictFileList,
+		defaultUserConfig.DefaultTFDistribution,
+		defaultUserConfig.SilenceNoProjects,
+		defaultUserConfig.IncludeGitUntrackedFiles,
+		string(valid.AutoDiscoverTerragruntMode),
+		scope,
+		tfclientmocks.NewMockClient(), &runtime.LocalPlanStore{},
+	)
+
+	ctxs, err := builder.BuildAutoplanCommands(&command.Context{
+		PullRequestStatus: models.PullReqStatus{
+			MergeableStatus: models.MergeableStatus{IsMergeable: true},
+		},
+		Log:   logger,
+		Scope: scope,
+	})
+	Ok(t, err)
+	sort.Slice(ctxs, func(i, j int) bool {
+		return ctxs[i].RepoRelDir < ctxs[j].RepoRelDir
+	})
+	Equals(t, 2, len(ctxs))
+	Equals(t, "live/app", ctxs[0].RepoRelDir)
+	Equals(t, "live/app", ctxs[0].ProjectName)
+	Equals(t, true, ctxs[0].Terragrunt)
+	Equals(t, []string{"live/vpc"}, ctxs[0].DependsOn)
+	Equals(t, "live/vpc", ctxs[1].RepoRelDir)
+	Equals(t, true, ctxs[1].Terragrunt)
+}
+
 func TestDefaultProjectCommandBuilder_BuildSinglePlanApplyCommand(t *testing.T) {
 	cases := []struct {
 		Description                string
@@ -315,20 +315,26 @@ func newProjectCommandContext(ctx *command.Context,
 		RepoConfigVersion:          projCfg.RepoCfgVersion,
 		TerraformDistribution:      projCfg.TerraformDistribution,
 		TerraformVersion:           projCfg.TerraformVersion,
+		Terragrunt:                 projCfg.Terragrunt,
 		User:                       ctx.User,
 		Verbose:                    verbose,
 		Workspace:                  projCfg.Workspace,
 		PolicySets:                 policySets,
 		PolicySetTarget:            ctx.PolicySet,
 		ClearPolicyApproval:        ctx.ClearPolicyApproval,
+		PolicyExceptionReason:      ctx.PolicyExceptionReason,
+		PolicyExceptionExpiry:      ctx.PolicyExceptionExpiry,
 		PullReqStatus:              pullReqStatus,
 		PullStatus:                 pullStatus,
 		JobID:                      uuid.New().String(),
 		ExecutionOrderGroup:        projCfg.ExecutionOrderGroup,
 		AbortOnExecutionOrderFail:  abortOnExecutionOrderFail,
 		SilencePRComments:          projCfg.SilencePRComments,
+		CostBudget:                 projCfg.CostBudget,
+		TFECredentials:             projCfg.TFECredentials,
 		TeamAllowlistChecker:       teamAllowlistChecker,
 		API:                        ctx.API,
+		Trigger:                    ctx.Trigger,
 		SkipPRRequirements:         ctx.SkipPRRequirements,
 		RunPolicyChecks:            ctx.RunPolicyChecks,
 		SuppressVCSStatus:          ctx.SuppressVCSStatus,
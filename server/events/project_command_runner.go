@@ -11,9 +11,13 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/core/db"
+	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/core/runtime"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
@@ -21,7 +25,9 @@ import (
 	"github.com/runatlantis/atlantis/server/events/webhooks"
 	"github.com/runatlantis/atlantis/server/jobs"
 	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
 	"github.com/runatlantis/atlantis/server/utils"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const OperationComplete = true
@@ -330,6 +336,7 @@ type DefaultProjectCommandRunner struct {
 	ApplyStepRunner           StepRunner
 	CancelStepRunner          StepRunner
 	PolicyCheckStepRunner     StepRunner
+	CostEstimateStepRunner    StepRunner
 	VersionStepRunner         StepRunner
 	ImportStepRunner          StepRunner
 	StateRmStepRunner         StepRunner
@@ -345,6 +352,10 @@ type DefaultProjectCommandRunner struct {
 	CancellationTracker       CancellationTracker
 	ApplyPlanValidator        ApplyPlanValidator
 	PlanStore                 runtime.PlanStore
+	// LockQueue is nil unless --enable-lock-queue is set.
+	LockQueue locking.LockQueue
+	// Database stores the policy exceptions granted by approve_policies.
+	Database db.Database
 }
 
 func (p *DefaultProjectCommandRunner) workingDirLockMetadata(ctx command.ProjectContext) WorkingDirLockMetadata {
@@ -493,10 +504,12 @@ func (p *DefaultProjectCommandRunner) doApprovePolicies(ctx command.ProjectConte
 					}
 					if !alreadyFullyApproved {
 						if !ctx.ClearPolicyApproval {
-							prjPolicyStatus[i].Approvals = append(prjPolicyStatus[i].Approvals, models.PolicySetApproval{
-								Approver: ctx.User.Username,
-								Hashes:   policyStatus.Hashes,
-							})
+							approval, err := p.policySetApproval(ctx, policySet.Name, policyStatus.Hashes)
+							if err != nil {
+								prjErr = errors.Join(prjErr, err)
+							} else {
+								prjPolicyStatus[i].Approvals = append(prjPolicyStatus[i].Approvals, approval)
+							}
 						} else {
 							prjPolicyStatus[i].Approvals = []models.PolicySetApproval{}
 						}
@@ -537,6 +550,68 @@ func (p *DefaultProjectCommandRunner) doApprovePolicies(ctx command.ProjectConte
 	}, failure, prjErr
 }
 
+// policySetApproval returns ctx.User's approval of the policy items in hashes.
+// If approve_policies was run with --expires, the approval is recorded as a
+// policy exception so that it's re-applied after re-plans until it expires.
+func (p *DefaultProjectCommandRunner) policySetApproval(ctx command.ProjectContext, policySetName string, hashes []string) (models.PolicySetApproval, error) {
+	if ctx.PolicyExceptionExpiry <= 0 {
+		return models.PolicySetApproval{Approver: ctx.User.Username, Hashes: hashes}, nil
+	}
+	now := time.Now()
+	exception := models.PolicyException{
+		Project:       models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName),
+		Workspace:     ctx.Workspace,
+		PullNum:       ctx.Pull.Num,
+		PolicySetName: policySetName,
+		Hashes:        hashes,
+		Approver:      ctx.User.Username,
+		Reason:        ctx.PolicyExceptionReason,
+		Time:          now,
+		Expires:       now.Add(ctx.PolicyExceptionExpiry),
+	}
+	if p.Database == nil {
+		ctx.Log.Warn("policy set %s: not recording policy exception, no database is configured; the approval won't survive a re-plan", policySetName)
+		return exception.Approval(), nil
+	}
+	if err := p.Database.AddPolicyException(exception); err != nil {
+		return models.PolicySetApproval{}, fmt.Errorf("policy set: %s: recording policy exception: %w", policySetName, err)
+	}
+	ctx.Log.Info("policy set %s approved by %s as a policy exception until %s: %s", policySetName, exception.Approver, exception.Expires.Format(time.RFC3339), exception.Reason)
+	return exception.Approval(), nil
+}
+
+// applyPolicyExceptions adds the approvals from the project's unexpired policy
+// exceptions to results. Approvals from exceptions that were carried over from
+// the previous policy check are dropped first, so an exception stops counting
+// once it expires.
+func (p *DefaultProjectCommandRunner) applyPolicyExceptions(ctx command.ProjectContext, results []models.PolicySetResult) error {
+	for i := range results {
+		results[i].Approvals = slices.DeleteFunc(results[i].Approvals, func(a models.PolicySetApproval) bool {
+			return !a.Expires.IsZero()
+		})
+	}
+	if p.Database == nil {
+		return nil
+	}
+	exceptions, err := p.Database.GetPolicyExceptions(ctx.Pull.BaseRepo.FullName, ctx.Pull.Num)
+	if err != nil {
+		return fmt.Errorf("getting policy exceptions: %w", err)
+	}
+	now := time.Now()
+	project := models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName)
+	for _, exception := range exceptions {
+		if exception.Project != project || exception.Workspace != ctx.Workspace || exception.Expired(now) {
+			continue
+		}
+		for i := range results {
+			if results[i].PolicySetName == exception.PolicySetName && !results[i].Passed {
+				results[i].Approvals = append(results[i].Approvals, exception.Approval())
+			}
+		}
+	}
+	return nil
+}
+
 func (p *DefaultProjectCommandRunner) doPolicyCheck(ctx command.ProjectContext) (*models.PolicyCheckResults, string, error) {
 	// Acquire Atlantis lock for this repo/dir/workspace.
 	// This should already be acquired from the prior plan operation.
@@ -749,6 +824,9 @@ func (p *DefaultProjectCommandRunner) doPolicyCheck(ctx command.ProjectContext)
 		// stale ones at read time.
 		result.Approvals = status.Approvals
 	}
+	if err := p.applyPolicyExceptions(ctx, policySetResults); err != nil {
+		return nil, "", err
+	}
 
 	// Check if we have any policy check results
 	// For non-custom policy checks (conftest), empty results means JSON parsing failed
@@ -794,6 +872,52 @@ func (p *DefaultProjectCommandRunner) doPolicyCheck(ctx command.ProjectContext)
 	return result, failure, nil
 }
 
+// queueForLock adds the pull request in ctx to the queue for the project lock
+// it couldn't acquire, and tells its author where it is in the queue.
+func (p *DefaultProjectCommandRunner) queueForLock(ctx command.ProjectContext, lockAttempt *TryLockResponse) string {
+	failure := lockAttempt.LockFailureReason
+	// API requests don't have a pull request that could be planned later.
+	if p.LockQueue == nil || ctx.API {
+		return failure
+	}
+	position, err := p.LockQueue.Enqueue(models.LockQueueEntry{
+		Project:   models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName),
+		Workspace: ctx.Workspace,
+		Pull:      ctx.Pull,
+		HeadRepo:  ctx.HeadRepo,
+		User:      ctx.User,
+	})
+	if err != nil {
+		ctx.Log.Err("queueing for lock: %s", err)
+		return failure
+	}
+	// Queued pull requests are planned automatically, so drop any advice to
+	// re-plan by hand.
+	if lockAttempt.QueuedFailureReason != "" {
+		failure = lockAttempt.QueuedFailureReason
+	}
+	return fmt.Sprintf("%s\n\nThis pull request is **#%d** in the queue for this lock. "+
+		"When it reaches the front of the queue and the lock is released, it will take the lock and be planned again automatically.",
+		failure, position)
+}
+
+// readCostEstimate reads the estimate written by the cost_estimate step for
+// the project at absPath. It returns nil if the step didn't run.
+func readCostEstimate(ctx command.ProjectContext, absPath string) (*models.CostEstimate, error) {
+	data, err := os.ReadFile(filepath.Join(absPath, ctx.GetCostEstimateFileName()))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cost estimate: %w", err)
+	}
+	var estimate models.CostEstimate
+	if err := json.Unmarshal(data, &estimate); err != nil {
+		return nil, fmt.Errorf("parsing cost estimate: %w", err)
+	}
+	return &estimate, nil
+}
+
 func (p *DefaultProjectCommandRunner) doPlan(ctx command.ProjectContext) (*models.PlanSuccess, string, error) {
 	// Acquire Atlantis lock for this repo/dir/workspace.
 	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), ctx.RepoLocksMode == valid.RepoLocksOnPlanMode)
@@ -801,7 +925,7 @@ func (p *DefaultProjectCommandRunner) doPlan(ctx command.ProjectContext) (*model
 		return nil, "", fmt.Errorf("acquiring lock: %w", err)
 	}
 	if !lockAttempt.LockAcquired {
-		return nil, lockAttempt.LockFailureReason, nil
+		return nil, p.queueForLock(ctx, lockAttempt), nil
 	}
 	ctx.Log.Debug("acquired lock for project")
 
@@ -860,6 +984,15 @@ func (p *DefaultProjectCommandRunner) doPlan(ctx command.ProjectContext) (*model
 		return nil, failure, err
 	}
 
+	// Remove the previous plan's cost estimate so it can't outlive a workflow
+	// change that drops the cost_estimate step.
+	if err := os.Remove(filepath.Join(projAbsPath, ctx.GetCostEstimateFileName())); err != nil && !os.IsNotExist(err) {
+		if unlockErr := lockAttempt.UnlockFn(); unlockErr != nil {
+			ctx.Log.Err("error unlocking state after plan error: %v", unlockErr)
+		}
+		return nil, "", fmt.Errorf("removing stale cost estimate: %w", err)
+	}
+
 	outputs, err := p.runSteps(ctx.Steps, ctx, projAbsPath)
 
 	if err != nil {
@@ -869,12 +1002,33 @@ func (p *DefaultProjectCommandRunner) doPlan(ctx command.ProjectContext) (*model
 		return nil, "", errorWithStepOutput(err, outputs)
 	}
 
+	// Record the plan file's digest so apply can verify that the plan it
+	// restores is the one generated here.
+	planDigest, err := planFileDigest(ctx, projAbsPath)
+	if err != nil {
+		if unlockErr := lockAttempt.UnlockFn(); unlockErr != nil {
+			ctx.Log.Err("error unlocking state after plan error: %v", unlockErr)
+		}
+		return nil, "", fmt.Errorf("hashing plan file: %w", err)
+	}
+
+	costEstimate, err := readCostEstimate(ctx, projAbsPath)
+	if err != nil {
+		if unlockErr := lockAttempt.UnlockFn(); unlockErr != nil {
+			ctx.Log.Err("error unlocking state after plan error: %v", unlockErr)
+		}
+		return nil, "", err
+	}
+
 	return &models.PlanSuccess{
 		LockURL:         p.LockURLGenerator.GenerateLockURL(lockAttempt.LockKey),
 		TerraformOutput: strings.Join(outputs, "\n"),
 		RePlanCmd:       ctx.RePlanCmd,
 		ApplyCmd:        ctx.ApplyCmd,
 		MergedAgain:     mergedAgain,
+		PlanDigest:      planDigest,
+		PlanCommit:      ctx.Pull.HeadCommit,
+		CostEstimate:    costEstimate,
 	}, "", nil
 }
 
@@ -1140,46 +1294,13 @@ func (p *DefaultProjectCommandRunner) runSteps(steps []valid.Step, ctx command.P
 	unlock := p.WorkingDir.GitReadLock(ctx.Pull.BaseRepo, ctx.Pull, ctx.Workspace)
 	defer unlock()
 
-	envs := make(map[string]string)
+	// Every step, including run steps, gets the project's TFE tokens.
+	envs, err := ctx.TFECredentialEnvVars()
+	if err != nil {
+		return nil, err
+	}
 	for _, step := range steps {
-		var out string
-		var err error
-		switch step.StepName {
-		case "init":
-			out, err = p.InitStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "plan":
-			out, err = p.PlanStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "show":
-			_, err = p.ShowStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "policy_check":
-			out, err = p.PolicyCheckStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "apply":
-			if err = ValidateNonPRAPIRefUnchanged(ctx, absPath); err != nil {
-				return outputs, err
-			}
-			if ctx.CommandName == command.Apply && p.ApplyPlanValidator != nil {
-				if err = p.ApplyPlanValidator.ValidateProjectPlan(ctx, absPath); err != nil {
-					return outputs, err
-				}
-			}
-			out, err = p.ApplyStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "version":
-			out, err = p.VersionStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "import":
-			out, err = p.ImportStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "state_rm":
-			out, err = p.StateRmStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
-		case "run":
-			out, err = p.RunStepRunner.Run(ctx, step.RunShell, step.RunCommand, absPath, envs, !ctx.SuppressJobOutput, step.Output, step.FilterRegexes)
-		case "env":
-			out, err = p.EnvStepRunner.Run(ctx, step.RunShell, step.RunCommand, step.EnvVarValue, absPath, envs)
-			envs[step.EnvVarName] = out
-			// We reset out to the empty string because we don't want it to
-			// be printed to the PR, it's solely to set the environment variable.
-			out = ""
-		case "multienv":
-			out, err = p.MultiEnvStepRunner.Run(ctx, step.RunShell, step.RunCommand, absPath, envs, step.Output)
-		}
+		out, err := p.runStep(step, ctx, absPath, envs)
 
 		// Keep all policy_check outputs for custom policy checks to maintain positional alignment with policy sets
 		// Empty outputs are still appended to prevent index mismatches
@@ -1193,6 +1314,53 @@ func (p *DefaultProjectCommandRunner) runSteps(steps []valid.Step, ctx command.P
 	return outputs, nil
 }
 
+// runStep runs a single workflow step in its own trace span.
+func (p *DefaultProjectCommandRunner) runStep(step valid.Step, ctx command.ProjectContext, absPath string, envs map[string]string) (out string, err error) {
+	var span trace.Span
+	ctx.Log, span = tracing.StartLog(ctx.Log, "step."+step.StepName)
+	defer func() { tracing.End(span, err) }()
+
+	switch step.StepName {
+	case "init":
+		out, err = p.InitStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "plan":
+		out, err = p.PlanStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "show":
+		_, err = p.ShowStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "policy_check":
+		out, err = p.PolicyCheckStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "cost_estimate":
+		out, err = p.CostEstimateStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "apply":
+		if err = ValidateNonPRAPIRefUnchanged(ctx, absPath); err != nil {
+			return "", err
+		}
+		if ctx.CommandName == command.Apply && p.ApplyPlanValidator != nil {
+			if err = p.ApplyPlanValidator.ValidateProjectPlan(ctx, absPath); err != nil {
+				return "", err
+			}
+		}
+		out, err = p.ApplyStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "version":
+		out, err = p.VersionStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "import":
+		out, err = p.ImportStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "state_rm":
+		out, err = p.StateRmStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
+	case "run":
+		out, err = p.RunStepRunner.Run(ctx, step.RunShell, step.RunCommand, absPath, envs, !ctx.SuppressJobOutput, step.Output, step.FilterRegexes)
+	case "env":
+		out, err = p.EnvStepRunner.Run(ctx, step.RunShell, step.RunCommand, step.EnvVarValue, absPath, envs)
+		envs[step.EnvVarName] = out
+		// We reset out to the empty string because we don't want it to
+		// be printed to the PR, it's solely to set the environment variable.
+		out = ""
+	case "multienv":
+		out, err = p.MultiEnvStepRunner.Run(ctx, step.RunShell, step.RunCommand, absPath, envs, step.Output)
+	}
+	return out, err
+}
+
 // getMissingPolicySetNames returns the names of policy sets that don't have corresponding outputs
 func getMissingPolicySetNames(policySets []valid.PolicySet, receivedCount int) []string {
 	var missing []string
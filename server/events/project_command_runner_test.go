@@ -15,11 +15,13 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	. "github.com/petergtz/pegomock/v4"
 	"github.com/runatlantis/atlantis/server/core/boltdb"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
+	lockingmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
 	"github.com/runatlantis/atlantis/server/core/runtime"
 	"github.com/runatlantis/atlantis/server/core/terraform"
 	tmocks "github.com/runatlantis/atlantis/server/core/terraform/mocks"
@@ -34,6 +36,7 @@ import (
 	jobmocks "github.com/runatlantis/atlantis/server/jobs/mocks"
 	"github.com/runatlantis/atlantis/server/logging"
 	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
 )
 
 // Test that it runs the expected plan steps.
@@ -70,12 +73,15 @@ func TestDefaultProjectCommandRunner_Plan(t *testing.T) {
 	When(mockLocker.TryLock(Any[logging.SimpleLogging](), Any[models.PullRequest](), Any[models.User](), Any[string](),
 		Any[models.Project](), AnyBool())).ThenReturn(&events.TryLockResponse{LockAcquired: true, LockKey: "lock-key"}, nil)
 
+	t.Setenv("ATLANTIS_TEST_TFC_TOKEN", "tfc-token")
 	expEnvs := map[string]string{
-		"name": "value",
+		"name":                      "value",
+		"TF_TOKEN_app_terraform_io": "tfc-token",
 	}
 
 	ctx := command.ProjectContext{
-		Log: logging.NewNoopLogger(t),
+		Log:            logging.NewNoopLogger(t),
+		TFECredentials: []valid.TFECredential{{Hostname: "app.terraform.io", TokenEnv: "ATLANTIS_TEST_TFC_TOKEN"}},
 		Steps: []valid.Step{
 			{
 				StepName:    "env",
@@ -97,7 +103,10 @@ func TestDefaultProjectCommandRunner_Plan(t *testing.T) {
 		},
 		Workspace:  "default",
 		RepoRelDir: ".",
+		Pull:       models.PullRequest{HeadCommit: "abc123"},
 	}
+	planContents := []byte("plan")
+	Ok(t, os.WriteFile(filepath.Join(repoDir, "default.tfplan"), planContents, 0600))
 
 	// Each step will output its step name.
 	When(mockInit.Run(ctx, nil, repoDir, expEnvs)).ThenReturn("init", nil)
@@ -110,6 +119,8 @@ func TestDefaultProjectCommandRunner_Plan(t *testing.T) {
 	Equals(t, "https://lock-key", res.PlanSuccess.LockURL)
 	t.Logf("output is %s", res.PlanSuccess.TerraformOutput)
 	Equals(t, "run\napply\nplan\ninit", res.PlanSuccess.TerraformOutput)
+	Equals(t, planHashForContent(planContents), res.PlanSuccess.PlanDigest)
+	Equals(t, "abc123", res.PlanSuccess.PlanCommit)
 	expSteps := []string{"run", "apply", "plan", "init", "env"}
 	for _, step := range expSteps {
 		switch step {
@@ -125,6 +136,62 @@ func TestDefaultProjectCommandRunner_Plan(t *testing.T) {
 	}
 }
 
+func TestDefaultProjectCommandRunner_PlanCostEstimate(t *testing.T) {
+	for _, withStep := range []bool{true, false} {
+		t.Run(fmt.Sprintf("cost_estimate step %t", withStep), func(t *testing.T) {
+			RegisterMockTestingT(t)
+			mockPlan := mocks.NewMockStepRunner()
+			mockCostEstimate := mocks.NewMockStepRunner()
+			mockWorkingDir := mocks.NewMockWorkingDir()
+			mockLocker := mocks.NewMockProjectLocker()
+			runner := events.DefaultProjectCommandRunner{
+				Locker:                    mockLocker,
+				LockURLGenerator:          mockURLGenerator{},
+				PlanStepRunner:            mockPlan,
+				CostEstimateStepRunner:    mockCostEstimate,
+				WorkingDir:                mockWorkingDir,
+				WorkingDirLocker:          events.NewDefaultWorkingDirLocker(),
+				CommandRequirementHandler: mocks.NewMockCommandRequirementHandler(),
+			}
+			repoDir := t.TempDir()
+			When(mockWorkingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+				Any[string]())).ThenReturn(repoDir, nil)
+			When(mockWorkingDir.GitReadLock(Any[models.Repo](), Any[models.PullRequest](), Any[string]())).ThenReturn(func() {})
+			When(mockLocker.TryLock(Any[logging.SimpleLogging](), Any[models.PullRequest](), Any[models.User](), Any[string](),
+				Any[models.Project](), AnyBool())).ThenReturn(&events.TryLockResponse{LockAcquired: true, LockKey: "lock-key"}, nil)
+
+			steps := []valid.Step{{StepName: "plan"}}
+			if withStep {
+				steps = append(steps, valid.Step{StepName: "cost_estimate"})
+			}
+			ctx := command.ProjectContext{
+				Log:        logging.NewNoopLogger(t),
+				Steps:      steps,
+				Workspace:  "default",
+				RepoRelDir: ".",
+			}
+			estimatePath := filepath.Join(repoDir, ctx.GetCostEstimateFileName())
+			// A previous plan's estimate must not be reported for this one.
+			Ok(t, os.WriteFile(estimatePath, []byte(`{"Currency": "USD", "MonthlyDelta": 999}`), 0600))
+			When(mockPlan.Run(ctx, nil, repoDir, map[string]string{})).ThenReturn("plan", nil)
+			When(mockCostEstimate.Run(ctx, nil, repoDir, map[string]string{})).Then(func(_ []Param) ReturnValues {
+				Ok(t, os.WriteFile(estimatePath, []byte(`{"Currency": "USD", "MonthlyDelta": 12.5}`), 0600))
+				return ReturnValues{"", nil}
+			})
+
+			res := runner.Plan(ctx)
+
+			Assert(t, res.PlanSuccess != nil, "exp plan success")
+			Equals(t, "plan", res.PlanSuccess.TerraformOutput)
+			if withStep {
+				Equals(t, &models.CostEstimate{Currency: "USD", MonthlyDelta: 12.5}, res.PlanSuccess.CostEstimate)
+			} else {
+				Assert(t, res.PlanSuccess.CostEstimate == nil, "exp no cost estimate, got %v", res.PlanSuccess.CostEstimate)
+			}
+		})
+	}
+}
+
 func TestDefaultProjectCommandRunner_ProjectLockJobURL(t *testing.T) {
 	const jobURL = "https://atlantis.example.com/jobs/job-id"
 	tests := []struct {
@@ -750,6 +817,41 @@ func TestDefaultProjectCommandRunner_PlanUndivergedBlocksAfterMergeAgain(t *test
 		Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[string]())
 }
 
+func TestDefaultProjectCommandRunner_PlanQueuesForLock(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockLocker := mocks.NewMockProjectLocker()
+	lockQueue := lockingmocks.NewMockLockQueue(gomock.NewController(t))
+	runner := &events.DefaultProjectCommandRunner{
+		Locker:           mockLocker,
+		LockURLGenerator: mockURLGenerator{},
+		LockQueue:        lockQueue,
+	}
+	pull := models.PullRequest{Num: 2, BaseRepo: models.Repo{FullName: "owner/repo"}}
+	ctx := command.ProjectContext{
+		Log:        logging.NewNoopLogger(t),
+		Pull:       pull,
+		HeadRepo:   models.Repo{FullName: "fork/repo"},
+		User:       models.User{Username: "alice"},
+		RepoRelDir: "infra",
+		Workspace:  "default",
+	}
+	When(mockLocker.TryLock(Any[logging.SimpleLogging](), Any[models.PullRequest](), Any[models.User](),
+		Any[string](), Any[models.Project](), AnyBool())).
+		ThenReturn(&events.TryLockResponse{LockAcquired: false, LockFailureReason: "locked by #1\n\ncomment to re-plan", QueuedFailureReason: "locked by #1"}, nil)
+	lockQueue.EXPECT().Enqueue(models.LockQueueEntry{
+		Project:   models.NewProject("owner/repo", "infra", ""),
+		Workspace: "default",
+		Pull:      pull,
+		HeadRepo:  models.Repo{FullName: "fork/repo"},
+		User:      models.User{Username: "alice"},
+	}).Return(3, nil)
+
+	res := runner.Plan(ctx)
+
+	Equals(t, "locked by #1\n\nThis pull request is **#3** in the queue for this lock. "+
+		"When it reaches the front of the queue and the lock is released, it will take the lock and be planned again automatically.", res.Failure)
+}
+
 func TestDefaultProjectCommandRunner_PlanChecksProjectPathAfterMergeAgain(t *testing.T) {
 	RegisterMockTestingT(t)
 	mockLocker := mocks.NewMockProjectLocker()
@@ -1613,6 +1715,84 @@ func TestApplyPlanValidator_UsesInMemoryPullStatusForAPIApply(t *testing.T) {
 	Ok(t, err)
 }
 
+func TestApplyPlanValidator_PlanIntegrity(t *testing.T) {
+	planContents := []byte("recorded plan")
+	cases := []struct {
+		description string
+		onDisk      []byte
+		planDigest  string
+		planCommit  string
+		expErr      string
+	}{
+		{
+			description: "matching digest and commit",
+			onDisk:      planContents,
+			planDigest:  planHashForContent(planContents),
+			planCommit:  "abc123",
+		},
+		{
+			description: "no recorded digest",
+			onDisk:      []byte("anything"),
+		},
+		{
+			description: "digest mismatch",
+			onDisk:      []byte("tampered plan"),
+			planDigest:  planHashForContent(planContents),
+			planCommit:  "abc123",
+			expErr:      "does not match the plan Atlantis generated",
+		},
+		{
+			description: "commit mismatch",
+			onDisk:      planContents,
+			planDigest:  planHashForContent(planContents),
+			planCommit:  "def456",
+			expErr:      "was generated from commit def456 but current head is abc123",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			repoDir := t.TempDir()
+			ctx := command.ProjectContext{
+				Log:         logging.NewNoopLogger(t),
+				CommandName: command.Apply,
+				API:         true,
+				Workspace:   "default",
+				RepoRelDir:  ".",
+				ProjectName: "projA",
+				Pull: models.PullRequest{
+					Num:        1,
+					HeadCommit: "abc123",
+					BaseRepo:   models.Repo{FullName: "runatlantis/atlantis"},
+				},
+			}
+			ctx.PullStatus = &models.PullStatus{
+				Pull: ctx.Pull,
+				Projects: []models.ProjectStatus{{
+					Workspace:   ctx.Workspace,
+					RepoRelDir:  ctx.RepoRelDir,
+					ProjectName: ctx.ProjectName,
+					Status:      models.PlannedPlanStatus,
+					PlanDigest:  c.planDigest,
+					PlanCommit:  c.planCommit,
+				}},
+			}
+			planPath := filepath.Join(repoDir, runtime.GetPlanFilename(ctx.Workspace, ctx.ProjectName))
+			Ok(t, os.WriteFile(planPath, c.onDisk, 0600))
+			validator := &events.DefaultApplyPlanValidator{PullStatusFetcher: newTestBoltDB(t)}
+
+			err := validator.ValidateProjectPlan(ctx, repoDir)
+
+			if c.expErr == "" {
+				Ok(t, err)
+				return
+			}
+			ErrContains(t, c.expErr, err)
+			_, statErr := os.Stat(planPath)
+			Assert(t, os.IsNotExist(statErr), "expected rejected plan file to be deleted")
+		})
+	}
+}
+
 func TestApplyPlanValidator_APIApplyWithBranchRefDoesNotCompareRefStringToLiveSHA(t *testing.T) {
 	db := newTestBoltDB(t)
 	repoDir := t.TempDir()
@@ -4765,6 +4945,78 @@ func TestDefaultProjectCommandRunner_ApprovePolicies_DuplicateApproval(t *testin
 	Equals(t, 1, result.GetCurApprovals())
 }
 
+// Test that a policy exception can be granted without a database, and that an
+// owner whose earlier exception expired can approve again.
+func TestDefaultProjectCommandRunner_ApprovePolicies_PolicyExceptionWithoutDatabase(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockVcsClient := vcsmocks.NewMockClient()
+	mockWorkingDir := mocks.NewMockWorkingDir()
+	mockLocker := mocks.NewMockProjectLocker()
+
+	runner := events.DefaultProjectCommandRunner{
+		Locker:           mockLocker,
+		VcsClient:        mockVcsClient,
+		LockURLGenerator: mockURLGenerator{},
+		WorkingDir:       mockWorkingDir,
+		WorkingDirLocker: events.NewDefaultWorkingDirLocker(),
+	}
+	When(mockWorkingDir.GetWorkingDir(
+		Any[models.Repo](),
+		Any[models.PullRequest](),
+		Any[string](),
+	)).ThenReturn(t.TempDir(), nil)
+	When(mockLocker.TryLock(
+		Any[logging.SimpleLogging](),
+		Any[models.PullRequest](),
+		Any[models.User](),
+		Any[string](),
+		Any[models.Project](),
+		AnyBool(),
+	)).ThenReturn(&events.TryLockResponse{
+		LockAcquired: true,
+		LockKey:      "lock-key",
+	}, nil)
+	When(runner.VcsClient.GetTeamNamesForUser(Any[logging.SimpleLogging](), Eq(testdata.GithubRepo), Eq(testdata.User))).ThenReturn(nil, nil)
+
+	ctx := command.ProjectContext{
+		User:                  testdata.User,
+		Log:                   logging.NewNoopLogger(t),
+		Workspace:             "default",
+		RepoRelDir:            ".",
+		PolicyExceptionExpiry: time.Hour,
+		PolicyExceptionReason: "accepted risk",
+		PolicySets: valid.PolicySets{
+			Owners: valid.PolicyOwners{
+				Users: []string{testdata.User.Username},
+			},
+			PolicySets: []valid.PolicySet{
+				{
+					Name:         "policy1",
+					ApproveCount: 1,
+				},
+			},
+		},
+		ProjectPolicyStatus: []models.PolicySetStatus{
+			{
+				PolicySetName: "policy1",
+				Hashes:        []string{"h1"},
+				Approvals: []models.PolicySetApproval{
+					{Approver: testdata.User.Username, Hashes: []string{"h1"}, Expires: time.Now().Add(-time.Minute)},
+				},
+			},
+		},
+		Pull: models.PullRequest{BaseRepo: testdata.GithubRepo, State: models.OpenPullState, Num: testdata.Pull.Num},
+	}
+
+	res := runner.ApprovePolicies(ctx)
+	Assert(t, res.Error == nil, "not expecting error: %v", res.Error)
+	result := res.PolicyCheckResults.PolicySetResults[0]
+	Equals(t, 2, len(result.Approvals))
+	Equals(t, 1, result.GetCurApprovals())
+	_, ok := result.ExceptionExpires()
+	Assert(t, ok, "expected the policy set to be approved by a policy exception")
+}
+
 // Test that sticky carry-over preserves all approvals (including dormant ones
 // for non-current hashes) so they can reactivate if code is reverted.
 func TestDefaultProjectCommandRunner_PolicyCheck_StickyCarryOverPreservesDormantApprovals(t *testing.T) {
@@ -4857,6 +5109,123 @@ func TestDefaultProjectCommandRunner_PolicyCheck_StickyCarryOverPreservesDormant
 	Equals(t, 0, status.GetCurApprovals())
 }
 
+// Test that unexpired policy exceptions are re-applied as approvals on each
+// policy check, and that exceptions carried over from the previous check
+// stop counting once they expire.
+func TestDefaultProjectCommandRunner_PolicyCheck_PolicyExceptions(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockPolicyCheck := mocks.NewMockStepRunner()
+	mockWorkingDir := mocks.NewMockWorkingDir()
+	mockLocker := mocks.NewMockProjectLocker()
+	database := newTestBoltDB(t)
+
+	runner := events.DefaultProjectCommandRunner{
+		Locker:                mockLocker,
+		LockURLGenerator:      mockURLGenerator{},
+		PolicyCheckStepRunner: mockPolicyCheck,
+		WorkingDir:            mockWorkingDir,
+		WorkingDirLocker:      events.NewDefaultWorkingDirLocker(),
+		Database:              database,
+	}
+
+	repoDir := t.TempDir()
+	When(mockWorkingDir.GetWorkingDir(
+		Any[models.Repo](),
+		Any[models.PullRequest](),
+		Any[string](),
+	)).ThenReturn(repoDir, nil)
+	When(mockWorkingDir.GitReadLock(Any[models.Repo](), Any[models.PullRequest](), Any[string]())).ThenReturn(func() {})
+	When(mockLocker.TryLock(
+		Any[logging.SimpleLogging](),
+		Any[models.PullRequest](),
+		Any[models.User](),
+		Any[string](),
+		Any[models.Project](),
+		AnyBool(),
+	)).ThenReturn(&events.TryLockResponse{
+		LockAcquired: true,
+		LockKey:      "lock-key",
+	}, nil)
+	When(mockPolicyCheck.Run(
+		Any[command.ProjectContext](),
+		Any[[]string](),
+		Any[string](),
+		Any[map[string]string](),
+	)).ThenReturn("1 failure found\nviolation-bucket", nil)
+
+	pull := models.PullRequest{Num: 7, BaseRepo: models.Repo{FullName: "owner/repo"}}
+	hashes := []string{models.HashPolicyItem("violation-bucket")}
+	now := time.Now()
+	exception := func(workspace string, expires time.Time, hashes []string) models.PolicyException {
+		return models.PolicyException{
+			Project:       models.NewProject("owner/repo", ".", ""),
+			Workspace:     workspace,
+			PullNum:       7,
+			PolicySetName: "policy1",
+			Hashes:        hashes,
+			Approver:      "boss",
+			Reason:        "accepted risk",
+			Time:          now.Add(-time.Hour),
+			Expires:       expires,
+		}
+	}
+	for _, e := range []models.PolicyException{
+		exception("default", now.Add(-time.Minute), hashes),
+		exception("staging", now.Add(time.Hour), hashes),
+		exception("default", now.Add(time.Hour), []string{models.HashPolicyItem("other")}),
+	} {
+		Ok(t, database.AddPolicyException(e))
+	}
+
+	ctx := command.ProjectContext{
+		Log:               logging.NewNoopLogger(t),
+		Pull:              pull,
+		Workspace:         "default",
+		RepoRelDir:        ".",
+		CustomPolicyCheck: true,
+		PolicySets: valid.PolicySets{
+			PolicySets: []valid.PolicySet{
+				{
+					Name:            "policy1",
+					ApproveCount:    1,
+					StickyApprovals: true,
+					PolicyItemRegex: "violation-[a-z]+",
+				},
+			},
+		},
+		// The exception that has since expired counted at the previous check.
+		ProjectPolicyStatus: []models.PolicySetStatus{
+			{
+				PolicySetName: "policy1",
+				Hashes:        hashes,
+				Approvals: []models.PolicySetApproval{
+					{Approver: "boss", Hashes: hashes, Expires: now.Add(-time.Minute)},
+				},
+			},
+		},
+		Steps: []valid.Step{{StepName: "policy_check"}},
+	}
+
+	res := runner.PolicyCheck(ctx)
+	Assert(t, res.Error == nil, "not expecting error: %v", res.Error)
+	result := res.PolicyCheckResults.PolicySetResults[0]
+	Equals(t, 1, len(result.Approvals))
+	Equals(t, 0, result.GetCurApprovals())
+	Equals(t, false, res.PolicyCheckResults.PolicyCleared())
+
+	active := exception("default", now.Add(time.Hour), hashes)
+	Ok(t, database.AddPolicyException(active))
+
+	res = runner.PolicyCheck(ctx)
+	Assert(t, res.Error == nil, "not expecting error: %v", res.Error)
+	result = res.PolicyCheckResults.PolicySetResults[0]
+	Equals(t, 1, result.GetCurApprovals())
+	Equals(t, true, res.PolicyCheckResults.PolicyCleared())
+	expires, ok := result.ExceptionExpires()
+	Assert(t, ok, "expected the policy set to be approved by a policy exception")
+	Equals(t, active.Expires.Unix(), expires.Unix())
+}
+
 func TestDefaultProjectCommandRunner_PolicyCheck_StickyCarryOverBehavior(t *testing.T) {
 	cases := []struct {
 		description       string
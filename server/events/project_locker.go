@@ -33,6 +33,9 @@ type DefaultProjectLocker struct {
 	NoOpLocker     locking.Locker
 	VCSClient      vcs.Client
 	ExecutableName string
+	// LockQueueRunner is nil unless --enable-lock-queue is set. When set, locks
+	// released through UnlockFn are handed to the next queued pull request.
+	LockQueueRunner *LockQueueRunner
 }
 
 // TryLockResponse is the result of trying to lock a project.
@@ -42,6 +45,10 @@ type TryLockResponse struct {
 	// LockFailureReason is the reason why the lock was not acquired. It will
 	// only be set if LockAcquired is false.
 	LockFailureReason string
+	// QueuedFailureReason is LockFailureReason without the advice to re-plan
+	// once the lock is released, for callers that queue the pull request for
+	// the lock instead. It will only be set if LockAcquired is false.
+	QueuedFailureReason string
 	// UnlockFn will unlock the lock created by the caller. This might be called
 	// if there is an error later and the caller doesn't want to continue to
 	// hold the lock.
@@ -67,21 +74,27 @@ func (p *DefaultProjectLocker) TryLock(log logging.SimpleLogging, pull models.Pu
 			return nil, err
 		}
 		failureMsg := fmt.Sprintf(
-			"This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nOnce the lock is released, comment `%s plan` here to re-plan.",
+			"This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.",
 			link,
-			link,
-			p.ExecutableName)
+			link)
 		return &TryLockResponse{
-			LockAcquired:      false,
-			LockFailureReason: failureMsg,
+			LockAcquired:        false,
+			LockFailureReason:   fmt.Sprintf("%s\n\nOnce the lock is released, comment `%s plan` here to re-plan.", failureMsg, p.ExecutableName),
+			QueuedFailureReason: failureMsg,
 		}, nil
 	}
 	log.Info("Acquired lock with id '%s'", lockAttempt.LockKey)
 	return &TryLockResponse{
 		LockAcquired: true,
 		UnlockFn: func() error {
-			_, err := locker.UnlockIfOwnedByPull(project, workspace, pull.Num)
-			return err
+			released, err := locker.UnlockIfOwnedByPull(project, workspace, pull.Num)
+			if err != nil {
+				return err
+			}
+			if p.LockQueueRunner != nil && released != nil {
+				p.LockQueueRunner.Promote(log, *released)
+			}
+			return nil
 		},
 		LockKey: lockAttempt.LockKey,
 	}, nil
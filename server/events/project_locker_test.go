@@ -51,8 +51,9 @@ func TestDefaultProjectLocker_TryLockWhenLocked(t *testing.T) {
 	link, _ := mockClient.MarkdownPullLink(lockingPull)
 	Ok(t, err)
 	Equals(t, &events.TryLockResponse{
-		LockAcquired:      false,
-		LockFailureReason: fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nOnce the lock is released, comment `atlantis plan` here to re-plan.", link, link),
+		LockAcquired:        false,
+		LockFailureReason:   fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nOnce the lock is released, comment `atlantis plan` here to re-plan.", link, link),
+		QueuedFailureReason: fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.", link, link),
 	}, res)
 }
 
@@ -89,8 +90,9 @@ func TestDefaultProjectLocker_TryLockWhenLockedCustomExecutableName(t *testing.T
 	link, _ := mockClient.MarkdownPullLink(lockingPull)
 	Ok(t, err)
 	Equals(t, &events.TryLockResponse{
-		LockAcquired:      false,
-		LockFailureReason: fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nOnce the lock is released, comment `%s plan` here to re-plan.", link, link, customExecutableName),
+		LockAcquired:        false,
+		LockFailureReason:   fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nOnce the lock is released, comment `%s plan` here to re-plan.", link, link, customExecutableName),
+		QueuedFailureReason: fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.", link, link),
 	}, res)
 }
 
@@ -172,6 +174,30 @@ func TestDefaultProjectLocker_TryLockUnlocked(t *testing.T) {
 	Ok(t, err)
 }
 
+func TestDefaultProjectLocker_UnlockFnPromotesQueuedPull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocker := mocks.NewMockLocker(ctrl)
+	lockQueue := mocks.NewMockLockQueue(ctrl)
+	locker := events.DefaultProjectLocker{
+		Locker:          mockLocker,
+		ExecutableName:  "atlantis",
+		LockQueueRunner: &events.LockQueueRunner{LockQueue: lockQueue},
+	}
+	expProject := models.NewProject("owner/repo", "infra", "")
+	expPull := models.PullRequest{Num: 2}
+	released := models.ProjectLock{Project: expProject, Workspace: "default", Pull: expPull}
+
+	mockLocker.EXPECT().TryLock(expProject, "default", expPull, models.User{}).Return(
+		locking.TryLockResponse{LockAcquired: true, CurrLock: released, LockKey: "key"}, nil)
+	mockLocker.EXPECT().UnlockIfOwnedByPull(expProject, "default", expPull.Num).Return(&released, nil)
+	// Nobody is queued, so no plan is started.
+	lockQueue.EXPECT().LockNext(expProject, "default").Return(nil, nil)
+
+	res, err := locker.TryLock(logging.NewNoopLogger(t), expPull, models.User{}, "default", expProject, true)
+	Ok(t, err)
+	Ok(t, res.UnlockFn())
+}
+
 func TestDefaultProjectLocker_TryLockRepoLockingDisabledUnlockUsesNoOpLocker(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	var githubClient *github.Client
@@ -49,6 +49,8 @@ type PullClosedExecutor struct {
 	LogStreamResourceCleaner ResourceCleaner
 	CancellationTracker      CancellationTracker
 	PlanStore                runtime.PlanStore
+	// LockQueueRunner is nil unless --enable-lock-queue is set.
+	LockQueueRunner *LockQueueRunner
 }
 
 type templatedProject struct {
@@ -117,6 +119,10 @@ func (p *PullClosedExecutor) CleanUpPull(logger logging.SimpleLogging, repo mode
 	if err != nil {
 		return fmt.Errorf("cleaning up locks: %w", err)
 	}
+	if p.LockQueueRunner != nil {
+		p.LockQueueRunner.RemovePull(logger, repo.FullName, pull.Num)
+		p.LockQueueRunner.Promote(logger, locks...)
+	}
 
 	// Delete pull from DB.
 	if err := p.Database.DeletePullStatus(pull); err != nil {
@@ -126,6 +126,36 @@ func TestCleanUpPullNoLocks(t *testing.T) {
 	cp.VerifyWasCalled(Never()).CreateComment(Any[logging.SimpleLogging](), Any[models.Repo](), Any[int](), Any[string](), Any[string]())
 }
 
+func TestCleanUpPullPromotesQueuedPulls(t *testing.T) {
+	t.Log("closing a pull request removes it from lock queues and hands its locks to the next queued pull requests")
+	RegisterMockTestingT(t)
+	logger := logging.NewNoopLogger(t)
+	ctrl := gomock.NewController(t)
+	l := lockmocks.NewMockLocker(ctrl)
+	lockQueue := lockmocks.NewMockLockQueue(ctrl)
+	db, err := boltdb.New(t.TempDir())
+	t.Cleanup(func() {
+		db.Close()
+	})
+	Ok(t, err)
+	pce := events.PullClosedExecutor{
+		Locker:             l,
+		VCSClient:          vcsmocks.NewMockClient(),
+		WorkingDir:         mocks.NewMockWorkingDir(),
+		Database:           db,
+		PullClosedTemplate: &events.PullClosedEventTemplate{},
+		LockQueueRunner:    &events.LockQueueRunner{LockQueue: lockQueue},
+	}
+	lock := models.ProjectLock{Project: models.NewProject(testdata.GithubRepo.FullName, "infra", ""), Workspace: "default"}
+	l.EXPECT().UnlockByPull(testdata.GithubRepo.FullName, testdata.Pull.Num).Return([]models.ProjectLock{lock}, nil)
+	gomock.InOrder(
+		lockQueue.EXPECT().RemovePull(testdata.GithubRepo.FullName, testdata.Pull.Num).Return(nil),
+		lockQueue.EXPECT().LockNext(lock.Project, "default").Return(nil, nil),
+	)
+
+	Ok(t, pce.CleanUpPull(logger, testdata.GithubRepo, testdata.Pull))
+}
+
 func TestCleanUpPullComments(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	t.Log("should comment correctly")
@@ -242,7 +272,7 @@ func TestCleanUpLogStreaming(t *testing.T) {
 
 		// Create Log streaming resources
 		prjCmdOutput := make(chan *jobs.ProjectCmdOutputLine)
-		prjCmdOutHandler := jobs.NewAsyncProjectCommandOutputHandler(prjCmdOutput, logger)
+		prjCmdOutHandler := jobs.NewAsyncProjectCommandOutputHandler(prjCmdOutput, logger, nil)
 		ctx := command.ProjectContext{
 			BaseRepo:    testdata.GithubRepo,
 			Pull:        testdata.Pull,
@@ -0,0 +1,314 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// TerragruntConfigFile is the file that makes a directory a Terragrunt project.
+const TerragruntConfigFile = "terragrunt.hcl"
+
+// terragruntWhenModified are the when_modified patterns every discovered
+// Terragrunt project gets. They're not recursive so that a change in a nested
+// Terragrunt project doesn't also plan its parent.
+var terragruntWhenModified = []string{
+	"*.hcl",
+	"*.tf*",
+	"*.tofu",
+	"*.tofu.json",
+}
+
+// terragruntConfig is what we need to know about a single terragrunt.hcl file.
+// All paths are relative to the repo root.
+type terragruntConfig struct {
+	dir          string
+	includes     []string
+	dependencies []string
+	sources      []string
+}
+
+// FindTerragruntProjects discovers a project for every terragrunt.hcl file in
+// absRepoDir that isn't included by another terragrunt.hcl file. Each project's
+// when_modified covers its own directory, the files it includes and any local
+// module source, and its depends_on lists the projects it has a dependency
+// block for. isIgnored is called with each project's repo-relative dir and
+// can be nil.
+func FindTerragruntProjects(absRepoDir string, isIgnored func(dir string) bool) ([]valid.Project, error) {
+	var configs []terragruntConfig
+	var errs []error
+	err := filepath.WalkDir(absRepoDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" || entry.Name() == ".terraform" || entry.Name() == ".terragrunt-cache" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != TerragruntConfigFile {
+			return nil
+		}
+		cfg, err := parseTerragruntConfig(absRepoDir, path)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		configs = append(configs, cfg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	included := make(map[string]bool)
+	for _, cfg := range configs {
+		for _, inc := range cfg.includes {
+			included[inc] = true
+		}
+	}
+	projectDirs := make(map[string]bool)
+	var projCfgs []terragruntConfig
+	for _, cfg := range configs {
+		if included[filepath.Join(cfg.dir, TerragruntConfigFile)] {
+			continue
+		}
+		if isIgnored != nil && isIgnored(cfg.dir) {
+			continue
+		}
+		projectDirs[cfg.dir] = true
+		projCfgs = append(projCfgs, cfg)
+	}
+
+	projects := make([]valid.Project, 0, len(projCfgs))
+	for _, cfg := range projCfgs {
+		whenModified := append([]string{}, terragruntWhenModified...)
+		for _, inc := range cfg.includes {
+			whenModified = append(whenModified, repoRelToProject(cfg.dir, inc))
+		}
+		for _, src := range cfg.sources {
+			whenModified = append(whenModified, repoRelToProject(cfg.dir, filepath.Join(src, "**", "*.tf*")))
+		}
+
+		var dependsOn []string
+		for _, dep := range cfg.dependencies {
+			if projectDirs[dep] && dep != cfg.dir && !slices.Contains(dependsOn, dep) {
+				dependsOn = append(dependsOn, dep)
+			}
+		}
+
+		name := cfg.dir
+		projects = append(projects, valid.Project{
+			Dir:       cfg.dir,
+			Workspace: DefaultWorkspace,
+			Name:      &name,
+			Autoplan: valid.Autoplan{
+				WhenModified: whenModified,
+				Enabled:      valid.DefaultAutoPlanEnabled,
+			},
+			DependsOn:  dependsOn,
+			Terragrunt: true,
+		})
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Dir < projects[j].Dir
+	})
+	return projects, errors.Join(errs...)
+}
+
+// parseTerragruntConfig reads the include, dependency, dependencies and
+// terraform blocks of the terragrunt.hcl file at absPath. Expressions we can't
+// evaluate without running terragrunt, e.g. ones that use locals, are skipped.
+func parseTerragruntConfig(absRepoDir string, absPath string) (terragruntConfig, error) {
+	absDir := filepath.Dir(absPath)
+	relDir, err := filepath.Rel(absRepoDir, absDir)
+	if err != nil {
+		return terragruntConfig{}, err
+	}
+	cfg := terragruntConfig{dir: relDir}
+
+	file, diags := hclparse.NewParser().ParseHCLFile(absPath)
+	if diags.HasErrors() {
+		return cfg, fmt.Errorf("parsing %s: %w", filepath.Join(relDir, TerragruntConfigFile), diags)
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return cfg, nil
+	}
+
+	evalCtx := terragruntEvalContext(absRepoDir, absDir)
+	toRepoRel := func(p string) (string, bool) {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(absDir, p)
+		}
+		rel, err := filepath.Rel(absRepoDir, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", false
+		}
+		return rel, true
+	}
+
+	for _, block := range body.Blocks {
+		var attrName string
+		switch block.Type {
+		case "include":
+			attrName = "path"
+		case "dependency":
+			attrName = "config_path"
+		case "dependencies":
+			attrName = "paths"
+		case "terraform":
+			attrName = "source"
+		default:
+			continue
+		}
+		attr, ok := block.Body.Attributes[attrName]
+		if !ok {
+			continue
+		}
+		for _, value := range evalTerragruntStrings(attr.Expr, evalCtx) {
+			switch block.Type {
+			case "include":
+				if rel, ok := toRepoRel(value); ok {
+					cfg.includes = append(cfg.includes, rel)
+				}
+			case "dependency", "dependencies":
+				// config_path can point at the dependency's directory or at
+				// its terragrunt.hcl file.
+				if filepath.Base(value) == TerragruntConfigFile {
+					value = filepath.Dir(value)
+				}
+				if rel, ok := toRepoRel(value); ok {
+					cfg.dependencies = append(cfg.dependencies, rel)
+				}
+			case "terraform":
+				src, ok := localTerragruntSource(value)
+				if !ok {
+					continue
+				}
+				if rel, ok := toRepoRel(src); ok {
+					cfg.sources = append(cfg.sources, rel)
+				}
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// evalTerragruntStrings evaluates expr and returns its string value, or the
+// string elements if it's a list. It returns nil if expr can't be evaluated.
+func evalTerragruntStrings(expr hcl.Expression, evalCtx *hcl.EvalContext) []string {
+	val, diags := expr.Value(evalCtx)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return nil
+	}
+	if val.Type() == cty.String {
+		return []string{val.AsString()}
+	}
+	if !val.CanIterateElements() {
+		return nil
+	}
+	var values []string
+	for it := val.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		if !elem.IsNull() && elem.Type() == cty.String {
+			values = append(values, elem.AsString())
+		}
+	}
+	return values
+}
+
+// localTerragruntSource returns the root of a terraform.source that points at
+// the local filesystem, dropping any "//subdir" and "?ref" suffixes. Remote
+// sources return false.
+func localTerragruntSource(source string) (string, bool) {
+	if strings.Contains(source, "::") || !(strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/")) {
+		return "", false
+	}
+	if i := strings.Index(source, "?"); i >= 0 {
+		source = source[:i]
+	}
+	if i := strings.Index(source[1:], "//"); i >= 0 {
+		source = source[:i+1]
+	}
+	return source, source != ""
+}
+
+// repoRelToProject turns a repo-relative path into a when_modified pattern
+// relative to the project dir.
+func repoRelToProject(projectDir string, repoRelPath string) string {
+	rel, err := filepath.Rel(projectDir, repoRelPath)
+	if err != nil {
+		return repoRelPath
+	}
+	return filepath.ToSlash(rel)
+}
+
+// terragruntEvalContext implements the Terragrunt built-in functions that are
+// commonly used to build include, dependency and source paths.
+func terragruntEvalContext(absRepoDir string, absDir string) *hcl.EvalContext {
+	stringFn := func(fn func() (string, error)) function.Function {
+		return function.New(&function.Spec{
+			Type: function.StaticReturnType(cty.String),
+			Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+				s, err := fn()
+				if err != nil {
+					return cty.NilVal, err
+				}
+				return cty.StringVal(s), nil
+			},
+		})
+	}
+	return &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"find_in_parent_folders": function.New(&function.Spec{
+				VarParam: &function.Parameter{Name: "args", Type: cty.String},
+				Type:     function.StaticReturnType(cty.String),
+				Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+					name := TerragruntConfigFile
+					if len(args) > 0 {
+						name = args[0].AsString()
+					}
+					for dir := filepath.Dir(absDir); strings.HasPrefix(dir, absRepoDir); dir = filepath.Dir(dir) {
+						candidate := filepath.Join(dir, name)
+						if _, err := os.Stat(candidate); err == nil {
+							return cty.StringVal(candidate), nil
+						}
+						if dir == absRepoDir {
+							break
+						}
+					}
+					if len(args) > 1 {
+						return args[1], nil
+					}
+					return cty.NilVal, fmt.Errorf("could not find %s in any parent folder of %s", name, absDir)
+				},
+			}),
+			"get_terragrunt_dir": stringFn(func() (string, error) {
+				return absDir, nil
+			}),
+			"get_repo_root": stringFn(func() (string, error) {
+				return absRepoDir, nil
+			}),
+			"get_path_to_repo_root": stringFn(func() (string, error) {
+				return filepath.Rel(absDir, absRepoDir)
+			}),
+		},
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/events"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func writeTerragruntRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	repoDir := t.TempDir()
+	for path, contents := range files {
+		absPath := filepath.Join(repoDir, path)
+		Ok(t, os.MkdirAll(filepath.Dir(absPath), 0700))
+		Ok(t, os.WriteFile(absPath, []byte(contents), 0600))
+	}
+	return repoDir
+}
+
+func TestFindTerragruntProjects(t *testing.T) {
+	repoDir := writeTerragruntRepo(t, map[string]string{
+		"terragrunt.hcl": `remote_state {
+  backend = "s3"
+}
+`,
+		"prod/vpc/terragrunt.hcl": `include "root" {
+  path = find_in_parent_folders()
+}
+
+terraform {
+  source = "../../modules//vpc?ref=v1"
+}
+`,
+		"prod/app/terragrunt.hcl": `include {
+  path = find_in_parent_folders()
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+dependencies {
+  paths = ["../db", "${get_repo_root()}/outside/../prod/vpc"]
+}
+
+terraform {
+  source = "git::https://example.com/modules.git//app?ref=v1"
+}
+`,
+		"prod/db/terragrunt.hcl": `locals {
+  env = "prod"
+}
+
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+
+terraform {
+  source = "${get_repo_root()}/modules/${local.env}"
+}
+`,
+		"modules/vpc/main.tf": `resource "null_resource" "this" {}`,
+	})
+
+	projects, err := events.FindTerragruntProjects(repoDir, nil)
+	Ok(t, err)
+	Equals(t, 3, len(projects))
+
+	app, db, vpc := projects[0], projects[1], projects[2]
+	Equals(t, "prod/app", app.Dir)
+	Equals(t, "prod/app", *app.Name)
+	Equals(t, events.DefaultWorkspace, app.Workspace)
+	Equals(t, true, app.Terragrunt)
+	Equals(t, true, app.Autoplan.Enabled)
+	Equals(t, []string{"prod/vpc", "prod/db"}, app.DependsOn)
+	Equals(t, []string{"*.hcl", "*.tf*", "*.tofu", "*.tofu.json", "../../terragrunt.hcl"}, app.Autoplan.WhenModified)
+
+	// The source uses a local so it can't be evaluated without terragrunt.
+	Equals(t, "prod/db", db.Dir)
+	Equals(t, []string{"*.hcl", "*.tf*", "*.tofu", "*.tofu.json", "../../terragrunt.hcl"}, db.Autoplan.WhenModified)
+
+	Equals(t, "prod/vpc", vpc.Dir)
+	Equals(t, 0, len(vpc.DependsOn))
+	Equals(t, []string{"*.hcl", "*.tf*", "*.tofu", "*.tofu.json", "../../terragrunt.hcl", "../../modules/**/*.tf*"}, vpc.Autoplan.WhenModified)
+}
+
+func TestFindTerragruntProjects_Ignored(t *testing.T) {
+	repoDir := writeTerragruntRepo(t, map[string]string{
+		"dev/app/terragrunt.hcl": `dependency "vpc" {
+  config_path = "../vpc"
+}
+`,
+		"dev/vpc/terragrunt.hcl":                        ``,
+		"dev/app/.terragrunt-cache/abc/terragrunt.hcl":  ``,
+		"dev/app/.terraform/modules/mod/terragrunt.hcl": ``,
+	})
+
+	projects, err := events.FindTerragruntProjects(repoDir, func(dir string) bool {
+		return dir == "dev/vpc"
+	})
+	Ok(t, err)
+	Equals(t, []valid.Project{{
+		Dir:       "dev/app",
+		Name:      projects[0].Name,
+		Workspace: events.DefaultWorkspace,
+		Autoplan: valid.Autoplan{
+			WhenModified: []string{"*.hcl", "*.tf*", "*.tofu", "*.tofu.json"},
+			Enabled:      true,
+		},
+		Terragrunt: true,
+	}}, projects)
+}
+
+func TestFindTerragruntProjects_ParseError(t *testing.T) {
+	repoDir := writeTerragruntRepo(t, map[string]string{
+		"good/terragrunt.hcl": ``,
+		"bad/terragrunt.hcl":  `include {`,
+	})
+
+	projects, err := events.FindTerragruntProjects(repoDir, nil)
+	ErrContains(t, "parsing bad/terragrunt.hcl", err)
+	Equals(t, 1, len(projects))
+	Equals(t, "good", projects[0].Dir)
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TracedWorkingDir implements WorkingDir.
+// It creates a trace span for clones and merges, which can be slow for big
+// repos, under the span carried by the call's logger.
+type TracedWorkingDir struct {
+	WorkingDir
+}
+
+func (t *TracedWorkingDir) Clone(logger logging.SimpleLogging, headRepo models.Repo, p models.PullRequest, workspace string) (dir string, err error) {
+	logger, span := tracing.StartLog(logger, "working_dir.Clone",
+		attribute.String("vcs.repo", headRepo.FullName),
+		attribute.String("atlantis.workspace", workspace),
+	)
+	defer func() { tracing.End(span, err) }()
+	return t.WorkingDir.Clone(logger, headRepo, p, workspace)
+}
+
+func (t *TracedWorkingDir) MergeAgain(logger logging.SimpleLogging, headRepo models.Repo, p models.PullRequest, workspace string) (mergedAgain bool, err error) {
+	logger, span := tracing.StartLog(logger, "working_dir.MergeAgain",
+		attribute.String("vcs.repo", headRepo.FullName),
+		attribute.String("atlantis.workspace", workspace),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Bool("atlantis.merged_again", mergedAgain))
+		tracing.End(span, err)
+	}()
+	return t.WorkingDir.MergeAgain(logger, headRepo, p, workspace)
+}
+
+func (t *TracedWorkingDir) CheckoutMergeEnabled() bool {
+	checkoutMerge, ok := t.WorkingDir.(interface {
+		CheckoutMergeEnabled() bool
+	})
+	return ok && checkoutMerge.CheckoutMergeEnabled()
+}
@@ -5,7 +5,9 @@ package events
 
 import (
 	"slices"
+	"strconv"
 
+	"github.com/runatlantis/atlantis/server/audit"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/vcs"
 )
@@ -31,6 +33,8 @@ type UnlockCommandRunner struct {
 	// are found
 	SilenceNoProjects  bool
 	DisableUnlockLabel string
+	// AuditLog records each unlock. It may be nil.
+	AuditLog *audit.Log
 }
 
 func (u *UnlockCommandRunner) Run(ctx *command.Context, _ *CommentCommand) {
@@ -54,6 +58,10 @@ func (u *UnlockCommandRunner) Run(ctx *command.Context, _ *CommentCommand) {
 		if hasLabel {
 			vcsMessage = "Not allowed to unlock PR with " + disableUnlockLabel + " label"
 			ctx.Log.Info("Not allowed to unlock PR with %v label", disableUnlockLabel)
+			event := unlockAuditEvent(ctx)
+			event.Outcome = audit.OutcomeDenied
+			event.Error = vcsMessage
+			u.AuditLog.Record(event)
 		}
 	}
 
@@ -64,6 +72,7 @@ func (u *UnlockCommandRunner) Run(ctx *command.Context, _ *CommentCommand) {
 			vcsMessage = "Failed to delete PR locks"
 			ctx.Log.Err("failed to delete locks by pull %s", err.Error())
 		}
+		u.recordUnlock(ctx, numLocks, err)
 	}
 
 	// if there are no locks to delete, no errors, and SilenceNoProjects is enabled, don't comment
@@ -78,3 +87,25 @@ func (u *UnlockCommandRunner) Run(ctx *command.Context, _ *CommentCommand) {
 		ctx.Log.Err("unable to comment: %s", commentErr)
 	}
 }
+
+func (u *UnlockCommandRunner) recordUnlock(ctx *command.Context, numLocks int, err error) {
+	event := unlockAuditEvent(ctx)
+	event.Details = map[string]string{"locks": strconv.Itoa(numLocks)}
+	if err != nil {
+		event.Outcome = audit.OutcomeError
+		event.Error = err.Error()
+	}
+	u.AuditLog.Record(event)
+}
+
+func unlockAuditEvent(ctx *command.Context) audit.Event {
+	return audit.Event{
+		Action:    audit.ActionUnlock,
+		Actor:     ctx.User.Username,
+		Source:    audit.SourceComment,
+		Repo:      ctx.Pull.BaseRepo.FullName,
+		Pull:      ctx.Pull.Num,
+		CommitSHA: ctx.Pull.HeadCommit,
+		Outcome:   audit.OutcomeSuccess,
+	}
+}
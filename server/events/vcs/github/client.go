@@ -5,6 +5,7 @@
 package github
 
 import (
+	"cmp"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -1002,6 +1003,119 @@ func (g *Client) UpdateStatus(logger logging.SimpleLogging, repo models.Repo, pu
 	return err
 }
 
+// maxCheckRunOutputLength is the maximum number of chars GitHub accepts in the
+// summary and text of a check run.
+const maxCheckRunOutputLength = 65535
+
+// maxCheckRunAnnotations is the maximum number of annotations GitHub accepts
+// in a single check run request.
+const maxCheckRunAnnotations = 50
+
+// CheckRunRequest describes the check run Atlantis reports for a project
+// command.
+type CheckRunRequest struct {
+	// Name identifies the check run on the head commit. Requests with the same
+	// name and ExternalID update the same check run.
+	Name       string
+	ExternalID string
+	State      models.CommitStatus
+	DetailsURL string
+	Title      string
+	Summary    string
+	Text       string
+	// Annotations are truncated to the first 50.
+	Annotations []*github.CheckRunAnnotation
+	// Actions are shown as buttons once the check run has completed.
+	Actions []*github.CheckRunAction
+}
+
+// UpdateCheckRun creates the check run described by req on the head commit of
+// pull, or updates it if Atlantis already created it.
+// See https://docs.github.com/en/rest/checks/runs.
+func (g *Client) UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, req CheckRunRequest) error {
+	status, conclusion := "in_progress", ""
+	switch req.State {
+	case models.SuccessCommitStatus:
+		status, conclusion = "completed", "success"
+	case models.FailedCommitStatus:
+		status, conclusion = "completed", "failure"
+	}
+	output := &github.CheckRunOutput{
+		Title:   github.Ptr(req.Title),
+		Summary: github.Ptr(truncateCheckRunOutput(req.Summary)),
+	}
+	if req.Text != "" {
+		output.Text = github.Ptr(truncateCheckRunOutput(req.Text))
+	}
+	output.Annotations = req.Annotations[:min(len(req.Annotations), maxCheckRunAnnotations)]
+	var completedAt *github.Timestamp
+	if conclusion != "" {
+		completedAt = &github.Timestamp{Time: time.Now()}
+	}
+
+	logger.Info("Updating GitHub check run '%s' to '%s'", req.Name, cmp.Or(conclusion, status))
+
+	existing, resp, err := g.client.Checks.ListCheckRunsForRef(g.ctx, repo.Owner, repo.Name, pull.HeadCommit, &github.ListCheckRunsOptions{
+		CheckName: github.Ptr(req.Name),
+	})
+	if resp != nil {
+		logger.Debug("GET /repos/%v/%v/commits/%s/check-runs returned: %v", repo.Owner, repo.Name, pull.HeadCommit, resp.StatusCode)
+	}
+	if err != nil {
+		return fmt.Errorf("listing check runs: %w", err)
+	}
+	for _, run := range existing.CheckRuns {
+		if run.GetExternalID() != req.ExternalID {
+			continue
+		}
+		_, resp, err = g.client.Checks.UpdateCheckRun(g.ctx, repo.Owner, repo.Name, run.GetID(), github.UpdateCheckRunOptions{
+			Name:        req.Name,
+			DetailsURL:  github.Ptr(req.DetailsURL),
+			ExternalID:  github.Ptr(req.ExternalID),
+			Status:      github.Ptr(status),
+			Conclusion:  optionalString(conclusion),
+			CompletedAt: completedAt,
+			Output:      output,
+			Actions:     req.Actions,
+		})
+		if resp != nil {
+			logger.Debug("PATCH /repos/%v/%v/check-runs/%d returned: %v", repo.Owner, repo.Name, run.GetID(), resp.StatusCode)
+		}
+		return err
+	}
+
+	_, resp, err = g.client.Checks.CreateCheckRun(g.ctx, repo.Owner, repo.Name, github.CreateCheckRunOptions{
+		Name:        req.Name,
+		HeadSHA:     pull.HeadCommit,
+		DetailsURL:  github.Ptr(req.DetailsURL),
+		ExternalID:  github.Ptr(req.ExternalID),
+		Status:      github.Ptr(status),
+		Conclusion:  optionalString(conclusion),
+		CompletedAt: completedAt,
+		Output:      output,
+		Actions:     req.Actions,
+	})
+	if resp != nil {
+		logger.Debug("POST /repos/%v/%v/check-runs returned: %v", repo.Owner, repo.Name, resp.StatusCode)
+	}
+	return err
+}
+
+func truncateCheckRunOutput(s string) string {
+	if len(s) <= maxCheckRunOutputLength {
+		return s
+	}
+	const suffix = "\n\n...truncated"
+	return strings.ToValidUTF8(s[:maxCheckRunOutputLength-len(suffix)], "") + suffix
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // MergePull merges the pull request.
 func (g *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest, pullOptions models.PullRequestOptions) error {
 	logger.Debug("Merging GitHub pull request %d", pull.Num)
@@ -13,10 +13,12 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	gogithub "github.com/google/go-github/v88/github"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/vcs/github"
@@ -458,6 +460,82 @@ func TestClient_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestClient_UpdateCheckRun(t *testing.T) {
+	cases := []struct {
+		description string
+		existing    string
+		expMethod   string
+		expURI      string
+		expBody     string
+	}{
+		{
+			description: "creates check run",
+			existing:    `{"total_count":1,"check_runs":[{"id":7,"external_id":"other"}]}`,
+			expMethod:   "POST",
+			expURI:      "/api/v3/repos/owner/repo/check-runs",
+			expBody:     `{"name":"atlantis/plan: dir/default","head_sha":"sha","details_url":"https://example.com/jobs/1","external_id":"dir=dir","status":"completed","conclusion":"failure","completed_at":"<ts>","output":{"title":"Plan failed","summary":"summary","annotations":[{"path":"dir","start_line":1,"end_line":1,"annotation_level":"failure","message":"denied"}]},"actions":[{"label":"Re-plan","description":"Run plan again","identifier":"plan"}]}`,
+		},
+		{
+			description: "updates check run",
+			existing:    `{"total_count":1,"check_runs":[{"id":7,"external_id":"dir=dir"}]}`,
+			expMethod:   "PATCH",
+			expURI:      "/api/v3/repos/owner/repo/check-runs/7",
+			expBody:     `{"name":"atlantis/plan: dir/default","details_url":"https://example.com/jobs/1","external_id":"dir=dir","status":"completed","conclusion":"failure","completed_at":"<ts>","output":{"title":"Plan failed","summary":"summary","annotations":[{"path":"dir","start_line":1,"end_line":1,"annotation_level":"failure","message":"denied"}]},"actions":[{"label":"Re-plan","description":"Run plan again","identifier":"plan"}]}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			var gotBody string
+			testServer := httptest.NewTLSServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case r.Method == "GET" && r.RequestURI == "/api/v3/repos/owner/repo/commits/sha/check-runs?check_name=atlantis%2Fplan%3A+dir%2Fdefault":
+						w.Write([]byte(c.existing)) // nolint: errcheck
+					case r.Method == c.expMethod && r.RequestURI == c.expURI:
+						body, err := io.ReadAll(r.Body)
+						Ok(t, err)
+						gotBody = string(body)
+						w.Write([]byte(`{"id":7}`)) // nolint: errcheck
+					default:
+						t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+						http.Error(w, "not found", http.StatusNotFound)
+					}
+				}))
+			defer testServer.Close()
+
+			testServerURL, err := url.Parse(testServer.URL)
+			Ok(t, err)
+			client, err := github.New(testServerURL.Host, &github.UserCredentials{"user", "pass", ""}, github.Config{}, 0, logging.NewNoopLogger(t))
+			Ok(t, err)
+			defer disableSSLVerification()()
+
+			err = client.UpdateCheckRun(
+				logging.NewNoopLogger(t),
+				models.Repo{Owner: "owner", Name: "repo", FullName: "owner/repo"},
+				models.PullRequest{Num: 1, HeadCommit: "sha"},
+				github.CheckRunRequest{
+					Name:       "atlantis/plan: dir/default",
+					ExternalID: "dir=dir",
+					State:      models.FailedCommitStatus,
+					DetailsURL: "https://example.com/jobs/1",
+					Title:      "Plan failed",
+					Summary:    "summary",
+					Annotations: []*gogithub.CheckRunAnnotation{{
+						Path:            gogithub.Ptr("dir"),
+						StartLine:       gogithub.Ptr(1),
+						EndLine:         gogithub.Ptr(1),
+						AnnotationLevel: gogithub.Ptr("failure"),
+						Message:         gogithub.Ptr("denied"),
+					}},
+					Actions: []*gogithub.CheckRunAction{{Label: "Re-plan", Description: "Run plan again", Identifier: "plan"}},
+				})
+			Ok(t, err)
+			gotBody = regexp.MustCompile(`"completed_at":"[^"]+"`).ReplaceAllString(gotBody, `"completed_at":"<ts>"`)
+			Equals(t, c.expBody+"\n", gotBody)
+		})
+	}
+}
+
 func TestClient_PullIsApproved(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	respTemplate := `[
@@ -26,6 +26,7 @@ func NewInstrumentedGithubClient(client *Client, statsScope tally.Scope, logger
 	return &InstrumentedGithubClient{
 		InstrumentedClient: instrumentedGHClient,
 		PullRequestGetter:  client,
+		CheckRunUpdater:    client,
 		StatsScope:         scope,
 		Logger:             logger,
 	}
@@ -37,11 +38,17 @@ type GithubPullRequestGetter interface {
 	GetPullRequest(logger logging.SimpleLogging, repo models.Repo, pullNum int) (*github.PullRequest, error)
 }
 
+// GithubCheckRunUpdater creates and updates check runs.
+type GithubCheckRunUpdater interface {
+	UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, req CheckRunRequest) error
+}
+
 // IGithubClient exists to bridge the gap between GithubPullRequestGetter and Client interface to allow
 // for a single instrumented client
 type IGithubClient interface {
 	vcs.Client
 	GithubPullRequestGetter
+	GithubCheckRunUpdater
 }
 
 // InstrumentedGithubClient should delegate to the underlying InstrumentedClient for vcs provider-agnostic
@@ -49,6 +56,7 @@ type IGithubClient interface {
 type InstrumentedGithubClient struct {
 	*common.InstrumentedClient
 	PullRequestGetter GithubPullRequestGetter
+	CheckRunUpdater   GithubCheckRunUpdater
 	StatsScope        tally.Scope
 	Logger            logging.SimpleLogging
 }
@@ -75,3 +83,23 @@ func (c *InstrumentedGithubClient) GetPullRequest(logger logging.SimpleLogging,
 	return pull, err
 
 }
+
+func (c *InstrumentedGithubClient) UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, req CheckRunRequest) error {
+	scope := c.StatsScope.SubScope("update_check_run")
+	scope = common.SetGitScopeTags(scope, repo.FullName, pull.Num)
+
+	executionTime := scope.Timer(metrics.ExecutionTimeMetric).Start()
+	defer executionTime.Stop()
+
+	executionSuccess := scope.Counter(metrics.ExecutionSuccessMetric)
+	executionError := scope.Counter(metrics.ExecutionErrorMetric)
+
+	if err := c.CheckRunUpdater.UpdateCheckRun(logger, repo, pull, req); err != nil {
+		executionError.Inc(1)
+		logger.Err("Unable to update check run %s, error: %s", req.Name, err.Error())
+		return err
+	}
+
+	executionSuccess.Inc(1)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vcs
+
+import (
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracedClient creates a trace span for each call to the VCS host that is a
+// child of the span carried by the call's logger.
+type TracedClient struct {
+	Client
+}
+
+func NewTracedClient(client Client) *TracedClient {
+	return &TracedClient{Client: client}
+}
+
+func startSpan(logger logging.SimpleLogging, method string, repo models.Repo, pullNum int) (logging.SimpleLogging, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("vcs.host", repo.VCSHost.Type.String()),
+		attribute.String("vcs.repo", repo.FullName),
+	}
+	if pullNum > 0 {
+		attrs = append(attrs, attribute.Int("vcs.pull", pullNum))
+	}
+	return tracing.StartLog(logger, "vcs."+method, attrs...)
+}
+
+func (c *TracedClient) GetModifiedFiles(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (files []string, err error) {
+	logger, span := startSpan(logger, "GetModifiedFiles", repo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.GetModifiedFiles(logger, repo, pull)
+}
+
+func (c *TracedClient) CreateComment(logger logging.SimpleLogging, repo models.Repo, pullNum int, comment string, command string) (err error) {
+	logger, span := startSpan(logger, "CreateComment", repo, pullNum)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.CreateComment(logger, repo, pullNum, comment, command)
+}
+
+func (c *TracedClient) ReactToComment(logger logging.SimpleLogging, repo models.Repo, pullNum int, commentID int64, reaction string) (err error) {
+	logger, span := startSpan(logger, "ReactToComment", repo, pullNum)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.ReactToComment(logger, repo, pullNum, commentID, reaction)
+}
+
+func (c *TracedClient) HidePrevCommandComments(logger logging.SimpleLogging, repo models.Repo, pullNum int, command string, dir string) (err error) {
+	logger, span := startSpan(logger, "HidePrevCommandComments", repo, pullNum)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.HidePrevCommandComments(logger, repo, pullNum, command, dir)
+}
+
+func (c *TracedClient) PullIsApproved(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (status models.ApprovalStatus, err error) {
+	logger, span := startSpan(logger, "PullIsApproved", repo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.PullIsApproved(logger, repo, pull)
+}
+
+func (c *TracedClient) PullIsMergeable(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, vcsstatusname string, ignoreVCSStatusNames []string) (status models.MergeableStatus, err error) {
+	logger, span := startSpan(logger, "PullIsMergeable", repo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.PullIsMergeable(logger, repo, pull, vcsstatusname, ignoreVCSStatusNames)
+}
+
+func (c *TracedClient) UpdateStatus(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, state models.CommitStatus, src string, description string, url string) (err error) {
+	logger, span := startSpan(logger, "UpdateStatus", repo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.UpdateStatus(logger, repo, pull, state, src, description, url)
+}
+
+func (c *TracedClient) DiscardReviews(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (err error) {
+	logger, span := startSpan(logger, "DiscardReviews", repo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.DiscardReviews(logger, repo, pull)
+}
+
+func (c *TracedClient) MergePull(logger logging.SimpleLogging, pull models.PullRequest, pullOptions models.PullRequestOptions) (err error) {
+	logger, span := startSpan(logger, "MergePull", pull.BaseRepo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.MergePull(logger, pull, pullOptions)
+}
+
+func (c *TracedClient) GetTeamNamesForUser(logger logging.SimpleLogging, repo models.Repo, user models.User) (teams []string, err error) {
+	logger, span := startSpan(logger, "GetTeamNamesForUser", repo, 0)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.GetTeamNamesForUser(logger, repo, user)
+}
+
+func (c *TracedClient) GetFileContent(logger logging.SimpleLogging, repo models.Repo, branch string, fileName string) (found bool, content []byte, err error) {
+	logger, span := startSpan(logger, "GetFileContent", repo, 0)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.GetFileContent(logger, repo, branch, fileName)
+}
+
+func (c *TracedClient) GetCloneURL(logger logging.SimpleLogging, VCSHostType models.VCSHostType, repo string) (url string, err error) {
+	logger, span := startSpan(logger, "GetCloneURL", models.Repo{FullName: repo, VCSHost: models.VCSHost{Type: VCSHostType}}, 0)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.GetCloneURL(logger, VCSHostType, repo)
+}
+
+func (c *TracedClient) GetPullLabels(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (labels []string, err error) {
+	logger, span := startSpan(logger, "GetPullLabels", repo, pull.Num)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.GetPullLabels(logger, repo, pull)
+}
+
+func (c *TracedClient) GetChildTeams(logger logging.SimpleLogging, repo models.Repo, teamSlug string) (teams []string, err error) {
+	logger, span := startSpan(logger, "GetChildTeams", repo, 0)
+	defer func() { tracing.End(span, err) }()
+	return c.Client.GetChildTeams(logger, repo, teamSlug)
+}
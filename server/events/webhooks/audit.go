@@ -0,0 +1,73 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/runatlantis/atlantis/server/audit"
+)
+
+const AuditEvent = "audit"
+
+// AuditHttpWebhook is an audit.Sink that posts each event to an HTTP
+// endpoint as JSON.
+type AuditHttpWebhook struct {
+	Client *HttpClient
+	URL    string
+}
+
+func (h *AuditHttpWebhook) Write(event audit.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", h.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("creating audit webhook request for %q: %s", sanitizeDriftWebhookURL(h.URL), sanitizeDriftWebhookError(err.Error()))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for header, values := range h.Client.Headers {
+		for _, value := range values {
+			req.Header.Add(header, value)
+		}
+	}
+	resp, err := h.Client.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending audit webhook to %q: %s", sanitizeDriftWebhookURL(h.URL), sanitizeDriftWebhookError(err.Error()))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("audit webhook to %q returned status %d: %s", sanitizeDriftWebhookURL(h.URL), resp.StatusCode, sanitizeDriftWebhookError(string(respBody)))
+	}
+	return nil
+}
+
+// NewAuditWebhookSinks returns a sink for each webhook config with
+// event: audit. Only kind: http is supported.
+func NewAuditWebhookSinks(configs []Config, clients Clients) ([]audit.Sink, error) {
+	var sinks []audit.Sink
+	for _, c := range configs {
+		if c.Event != AuditEvent {
+			continue
+		}
+		if c.Kind != HttpKind {
+			return nil, fmt.Errorf("\"kind: %s\" not supported for audit webhooks. Only \"kind: %s\" is supported", c.Kind, HttpKind)
+		}
+		if c.URL == "" {
+			return nil, errors.New("must specify \"url\" for audit webhook of \"kind: http\"")
+		}
+		sinks = append(sinks, &AuditHttpWebhook{
+			Client: clients.Http,
+			URL:    c.URL,
+		})
+	}
+	return sinks, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/audit"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestAuditHttpWebhook(t *testing.T) {
+	var got audit.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Equals(t, "application/json", r.Header.Get("Content-Type"))
+		Equals(t, "Bearer token", r.Header.Get("Authorization"))
+		Ok(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	webhook := webhooks.AuditHttpWebhook{
+		Client: &webhooks.HttpClient{Client: http.DefaultClient, Headers: map[string][]string{"Authorization": {"Bearer token"}}},
+		URL:    server.URL,
+	}
+	err := webhook.Write(audit.Event{
+		Action:  audit.ActionApply,
+		Actor:   "lkysow",
+		Repo:    "runatlantis/atlantis",
+		Pull:    1,
+		Outcome: audit.OutcomeSuccess,
+	})
+	Ok(t, err)
+	Equals(t, audit.ActionApply, got.Action)
+	Equals(t, "lkysow", got.Actor)
+	Equals(t, 1, got.Pull)
+}
+
+func TestAuditHttpWebhook500(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := webhooks.AuditHttpWebhook{
+		Client: &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:    server.URL,
+	}
+	err := webhook.Write(audit.Event{Action: audit.ActionApply})
+	ErrContains(t, "returned status 500", err)
+}
+
+func TestNewAuditWebhookSinks(t *testing.T) {
+	clients := webhooks.Clients{Http: &webhooks.HttpClient{Client: http.DefaultClient}}
+
+	sinks, err := webhooks.NewAuditWebhookSinks([]webhooks.Config{
+		{Event: webhooks.ApplyEvent, Kind: webhooks.HttpKind, URL: "https://example.com/apply"},
+		{Event: webhooks.AuditEvent, Kind: webhooks.HttpKind, URL: "https://example.com/audit"},
+	}, clients)
+	Ok(t, err)
+	Equals(t, 1, len(sinks))
+	Equals(t, "https://example.com/audit", sinks[0].(*webhooks.AuditHttpWebhook).URL)
+
+	_, err = webhooks.NewAuditWebhookSinks([]webhooks.Config{
+		{Event: webhooks.AuditEvent, Kind: webhooks.SlackKind, Channel: "audit"},
+	}, clients)
+	ErrContains(t, "not supported for audit webhooks", err)
+
+	_, err = webhooks.NewAuditWebhookSinks([]webhooks.Config{
+		{Event: webhooks.AuditEvent, Kind: webhooks.HttpKind},
+	}, clients)
+	ErrContains(t, "must specify \"url\"", err)
+}
@@ -16,6 +16,8 @@ const DriftEvent = "drift"
 type DriftResult struct {
 	Repository        string               `json:"repository"`
 	Ref               string               `json:"ref"`
+	BaseBranch        string               `json:"base_branch,omitempty"`
+	VCSType           string               `json:"vcs_type,omitempty"`
 	DetectionID       string               `json:"detection_id"`
 	ProjectsWithDrift int                  `json:"projects_with_drift"`
 	TotalProjects     int                  `json:"total_projects"`
@@ -63,7 +65,9 @@ func (d *DriftWebhookSender) Send(log logging.SimpleLogging, result DriftResult)
 // NewDriftWebhookSender creates a DriftWebhookSender from webhook configs.
 // It filters configs to only those with event: drift and validates kind/channel/url.
 // Returns a sender with no webhooks (no-op) if no drift configs are found.
-func NewDriftWebhookSender(configs []Config, clients Clients) (*DriftWebhookSender, error) {
+// slackInteractive adds remediation buttons to Slack drift messages, and
+// slackApply adds the Apply fix button among them.
+func NewDriftWebhookSender(configs []Config, clients Clients, slackInteractive bool, slackApply bool) (*DriftWebhookSender, error) {
 	var senders []DriftSender
 	for _, c := range configs {
 		if c.Event != DriftEvent {
@@ -81,8 +85,10 @@ func NewDriftWebhookSender(configs []Config, clients Clients) (*DriftWebhookSend
 				return nil, errors.New("must specify \"channel\" for drift webhook of \"kind: slack\"")
 			}
 			senders = append(senders, &DriftSlackWebhook{
-				Client:  clients.Slack,
-				Channel: c.Channel,
+				Client:      clients.Slack,
+				Channel:     c.Channel,
+				Interactive: slackInteractive,
+				AllowApply:  slackApply,
 			})
 		case HttpKind:
 			if c.URL == "" {
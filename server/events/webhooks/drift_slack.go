@@ -11,9 +11,18 @@ import (
 type DriftSlackWebhook struct {
 	Client  SlackClient
 	Channel string
+	// Interactive adds Plan fix and Apply fix buttons to messages. It
+	// requires Slack interactivity to be configured.
+	Interactive bool
+	// AllowApply adds the Apply fix button to interactive messages. Set it
+	// when drift remediation apply is enabled.
+	AllowApply bool
 }
 
 // Send sends the drift result to Slack.
 func (s *DriftSlackWebhook) Send(_ logging.SimpleLogging, result DriftResult) error {
+	if s.Interactive && result.ProjectsWithDrift > 0 {
+		return s.Client.PostInteractiveDriftMessage(s.Channel, result, s.AllowApply)
+	}
 	return s.Client.PostDriftMessage(s.Channel, result)
 }
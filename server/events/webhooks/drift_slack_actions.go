@@ -0,0 +1,155 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// DriftPlanActionID is the action ID of the "Plan fix" button on drift
+	// messages.
+	DriftPlanActionID = "atlantis_drift_plan"
+	// DriftApplyActionID is the action ID of the "Apply fix" button on drift
+	// messages.
+	DriftApplyActionID = "atlantis_drift_apply"
+
+	driftActionsBlockPrefix = "atlantis_drift_actions/"
+	driftStatusBlockPrefix  = "atlantis_drift_status/"
+	driftProjectBlockPrefix = "atlantis_drift_project/"
+	// maxDriftActionProjects keeps interactive drift messages under Slack's
+	// limit of 50 blocks per message. Each project uses up to three blocks.
+	maxDriftActionProjects = 15
+)
+
+// DriftActionValue identifies the project a drift message button remediates.
+// It is JSON encoded in the button's value.
+type DriftActionValue struct {
+	Repository  string `json:"repository"`
+	Ref         string `json:"ref"`
+	BaseBranch  string `json:"base_branch,omitempty"`
+	VCSType     string `json:"vcs_type"`
+	ProjectName string `json:"project_name,omitempty"`
+	Path        string `json:"path"`
+	Workspace   string `json:"workspace"`
+}
+
+// ParseDriftActionValue decodes the value of a drift message button.
+func ParseDriftActionValue(value string) (DriftActionValue, error) {
+	var v DriftActionValue
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return DriftActionValue{}, fmt.Errorf("parsing drift action value: %w", err)
+	}
+	if v.Repository == "" || v.Ref == "" || v.VCSType == "" || v.Path == "" {
+		return DriftActionValue{}, fmt.Errorf("drift action value is missing repository, ref, vcs_type or path")
+	}
+	return v, nil
+}
+
+// DriftActionProjectKey returns the key of the project whose buttons are in
+// the actions block blockID, or false if blockID isn't a drift actions block.
+func DriftActionProjectKey(blockID string) (string, bool) {
+	key, ok := strings.CutPrefix(blockID, driftActionsBlockPrefix)
+	return key, ok && key != ""
+}
+
+// driftActionBlocks returns a section and Plan fix / Apply fix buttons for each
+// drifted project in result. The Apply fix button is only added if allowApply
+// is true.
+func driftActionBlocks(result DriftResult, allowApply bool) []slack.Block {
+	var blocks []slack.Block
+	shown := 0
+	for i, p := range result.Projects {
+		if !p.HasDrift || p.Error != "" {
+			continue
+		}
+		if shown == maxDriftActionProjects {
+			blocks = append(blocks, slack.NewContextBlock("",
+				slack.NewTextBlockObject(slack.MarkdownType, "More projects have drift than can be shown; remediate them through the API.", false, false)))
+			break
+		}
+		shown++
+		key := strconv.Itoa(i)
+		value := DriftActionValue{
+			Repository:  result.Repository,
+			Ref:         result.Ref,
+			BaseBranch:  result.BaseBranch,
+			VCSType:     result.VCSType,
+			ProjectName: p.ProjectName,
+			Path:        p.Path,
+			Workspace:   p.Workspace,
+		}
+		blocks = append(blocks,
+			slack.NewSectionBlock(
+				slack.NewTextBlockObject(slack.MarkdownType, driftProjectText(p), false, false),
+				nil, nil, slack.SectionBlockOptionBlockID(driftProjectBlockPrefix+key)),
+			DriftActionsBlock(key, value, allowApply),
+		)
+	}
+	return blocks
+}
+
+// DriftActionsBlock returns the Plan fix / Apply fix buttons for a project.
+// The Apply fix button is only added if allowApply is true.
+func DriftActionsBlock(key string, value DriftActionValue, allowApply bool) *slack.ActionBlock {
+	encoded, _ := json.Marshal(value)
+	plan := slack.NewButtonBlockElement(DriftPlanActionID, string(encoded),
+		slack.NewTextBlockObject(slack.PlainTextType, "Plan fix", false, false))
+	if !allowApply {
+		return slack.NewActionBlock(driftActionsBlockPrefix+key, plan)
+	}
+	apply := slack.NewButtonBlockElement(DriftApplyActionID, string(encoded),
+		slack.NewTextBlockObject(slack.PlainTextType, "Apply fix", false, false)).
+		WithStyle(slack.StyleDanger).
+		WithConfirm(slack.NewConfirmationBlockObject(
+			slack.NewTextBlockObject(slack.PlainTextType, "Apply fix?", false, false),
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("This plans and applies `%s` in workspace `%s` at `%s`.", value.Path, value.Workspace, value.Ref), false, false),
+			slack.NewTextBlockObject(slack.PlainTextType, "Apply", false, false),
+			slack.NewTextBlockObject(slack.PlainTextType, "Cancel", false, false),
+		))
+	return slack.NewActionBlock(driftActionsBlockPrefix+key, plan, apply)
+}
+
+// SetDriftProjectStatus returns a copy of a drift message's blocks with the
+// status line of the project key set to status. The project's buttons are
+// kept below the status when showActions is true and removed otherwise. The
+// Apply fix button is only kept if allowApply is true.
+func SetDriftProjectStatus(blocks []slack.Block, key string, value DriftActionValue, status string, showActions bool, allowApply bool) []slack.Block {
+	statusBlock := slack.NewContextBlock(driftStatusBlockPrefix+key,
+		slack.NewTextBlockObject(slack.MarkdownType, status, false, false))
+	updated := make([]slack.Block, 0, len(blocks)+2)
+	inserted := false
+	for _, b := range blocks {
+		switch b.ID() {
+		case driftStatusBlockPrefix + key, driftActionsBlockPrefix + key:
+			continue
+		}
+		updated = append(updated, b)
+		if b.ID() == driftProjectBlockPrefix+key {
+			updated = append(updated, statusBlock)
+			if showActions {
+				updated = append(updated, DriftActionsBlock(key, value, allowApply))
+			}
+			inserted = true
+		}
+	}
+	if !inserted {
+		updated = append(updated, statusBlock)
+	}
+	return updated
+}
+
+func driftProjectText(p DriftProjectResult) string {
+	name := p.ProjectName
+	if name == "" {
+		name = p.Path
+	}
+	return fmt.Sprintf("*%s* (`%s`, workspace `%s`): %d to add, %d to change, %d to destroy",
+		name, p.Path, p.Workspace, p.ToAdd, p.ToChange, p.ToDestroy)
+}
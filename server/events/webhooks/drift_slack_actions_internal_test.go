@@ -0,0 +1,78 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/runatlantis/atlantis/testing"
+	"github.com/slack-go/slack"
+)
+
+func TestDriftActionBlocks(t *testing.T) {
+	result := DriftResult{
+		Repository: "owner/repo",
+		Ref:        "main",
+		VCSType:    "Github",
+		Projects: []DriftProjectResult{
+			{ProjectName: "clean", Path: "clean", Workspace: "default"},
+			{ProjectName: "vpc", Path: "modules/vpc", Workspace: "default", HasDrift: true, ToChange: 2},
+			{ProjectName: "broken", Path: "broken", Workspace: "default", HasDrift: true, Error: "plan failed"},
+		},
+	}
+
+	blocks := driftActionBlocks(result, true)
+
+	Equals(t, 2, len(blocks))
+	Equals(t, "atlantis_drift_project/1", blocks[0].ID())
+	section := blocks[0].(*slack.SectionBlock)
+	Equals(t, "*vpc* (`modules/vpc`, workspace `default`): 0 to add, 2 to change, 0 to destroy", section.Text.Text)
+
+	actions := blocks[1].(*slack.ActionBlock)
+	Equals(t, "atlantis_drift_actions/1", actions.BlockID)
+	Equals(t, 2, len(actions.Elements.ElementSet))
+	plan := actions.Elements.ElementSet[0].(*slack.ButtonBlockElement)
+	Equals(t, DriftPlanActionID, plan.ActionID)
+	value, err := ParseDriftActionValue(plan.Value)
+	Ok(t, err)
+	Equals(t, DriftActionValue{
+		Repository:  "owner/repo",
+		Ref:         "main",
+		VCSType:     "Github",
+		ProjectName: "vpc",
+		Path:        "modules/vpc",
+		Workspace:   "default",
+	}, value)
+	apply := actions.Elements.ElementSet[1].(*slack.ButtonBlockElement)
+	Equals(t, DriftApplyActionID, apply.ActionID)
+	Assert(t, apply.Confirm != nil, "expected Apply fix to ask for confirmation")
+}
+
+func TestDriftActionBlocks_WithoutApply(t *testing.T) {
+	result := DriftResult{
+		Repository: "owner/repo",
+		Ref:        "main",
+		VCSType:    "Github",
+		Projects:   []DriftProjectResult{{Path: "vpc", Workspace: "default", HasDrift: true}},
+	}
+
+	blocks := driftActionBlocks(result, false)
+
+	actions := blocks[1].(*slack.ActionBlock)
+	Equals(t, 1, len(actions.Elements.ElementSet))
+	Equals(t, DriftPlanActionID, actions.Elements.ElementSet[0].(*slack.ButtonBlockElement).ActionID)
+}
+
+func TestDriftActionBlocks_LimitsProjects(t *testing.T) {
+	result := DriftResult{Repository: "owner/repo", Ref: "main", VCSType: "Github"}
+	for range maxDriftActionProjects + 5 {
+		result.Projects = append(result.Projects, DriftProjectResult{Path: "p", Workspace: "default", HasDrift: true})
+	}
+
+	blocks := driftActionBlocks(result, true)
+
+	// Two blocks per project plus a note about the projects not shown.
+	Equals(t, 2*maxDriftActionProjects+1, len(blocks))
+	Assert(t, 3*maxDriftActionProjects <= 50, "project blocks and status lines must fit in a Slack message")
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	. "github.com/runatlantis/atlantis/testing"
+	"github.com/slack-go/slack"
+)
+
+func blockIDs(blocks []slack.Block) []string {
+	var ids []string
+	for _, b := range blocks {
+		ids = append(ids, b.ID())
+	}
+	return ids
+}
+
+func TestSetDriftProjectStatus(t *testing.T) {
+	value := webhooks.DriftActionValue{Repository: "owner/repo", Ref: "main", VCSType: "Github", Path: "vpc", Workspace: "default"}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "vpc", false, false), nil, nil, slack.SectionBlockOptionBlockID("atlantis_drift_project/0")),
+		webhooks.DriftActionsBlock("0", value, true),
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "db", false, false), nil, nil, slack.SectionBlockOptionBlockID("atlantis_drift_project/1")),
+		webhooks.DriftActionsBlock("1", value, true),
+	}
+
+	running := webhooks.SetDriftProjectStatus(blocks, "0", value, "running", false, true)
+	Equals(t, []string{
+		"atlantis_drift_project/0",
+		"atlantis_drift_status/0",
+		"atlantis_drift_project/1",
+		"atlantis_drift_actions/1",
+	}, blockIDs(running))
+
+	done := webhooks.SetDriftProjectStatus(running, "0", value, "done", true, true)
+	Equals(t, []string{
+		"atlantis_drift_project/0",
+		"atlantis_drift_status/0",
+		"atlantis_drift_actions/0",
+		"atlantis_drift_project/1",
+		"atlantis_drift_actions/1",
+	}, blockIDs(done))
+	status := done[1].(*slack.ContextBlock)
+	Equals(t, "done", status.ContextElements.Elements[0].(*slack.TextBlockObject).Text)
+	// The original blocks are left alone.
+	Equals(t, 4, len(blocks))
+}
+
+func TestParseDriftActionValue(t *testing.T) {
+	_, err := webhooks.ParseDriftActionValue("not json")
+	ErrContains(t, "parsing drift action value", err)
+
+	_, err = webhooks.ParseDriftActionValue(`{"repository":"owner/repo","ref":"main"}`)
+	ErrEquals(t, "drift action value is missing repository, ref, vcs_type or path", err)
+}
+
+func TestDriftActionProjectKey(t *testing.T) {
+	key, ok := webhooks.DriftActionProjectKey("atlantis_drift_actions/3")
+	Assert(t, ok, "expected a drift actions block")
+	Equals(t, "3", key)
+
+	_, ok = webhooks.DriftActionProjectKey("atlantis_drift_project/3")
+	Assert(t, !ok, "expected project block not to be an actions block")
+}
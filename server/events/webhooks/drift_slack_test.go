@@ -55,3 +55,23 @@ func TestDriftSlackWebhook_SendNoDrift(t *testing.T) {
 	Ok(t, err)
 	client.VerifyWasCalledOnce().PostDriftMessage(channel, result)
 }
+
+func TestDriftSlackWebhook_SendInteractive(t *testing.T) {
+	RegisterMockTestingT(t)
+	client := mocks.NewMockSlackClient()
+	hook := webhooks.DriftSlackWebhook{
+		Client:      client,
+		Channel:     "drift-alerts",
+		Interactive: true,
+		AllowApply:  true,
+	}
+
+	drifted := webhooks.DriftResult{Repository: "owner/repo", Ref: "main", ProjectsWithDrift: 1, TotalProjects: 1}
+	Ok(t, hook.Send(logging.NewNoopLogger(t), drifted))
+	client.VerifyWasCalledOnce().PostInteractiveDriftMessage("drift-alerts", drifted, true)
+
+	// Messages without drift have nothing to remediate.
+	clean := webhooks.DriftResult{Repository: "owner/repo", Ref: "main", TotalProjects: 1}
+	Ok(t, hook.Send(logging.NewNoopLogger(t), clean))
+	client.VerifyWasCalledOnce().PostDriftMessage("drift-alerts", clean)
+}
@@ -94,7 +94,7 @@ func TestDriftWebhookSender_Send_NoWebhooksNoOp(t *testing.T) {
 }
 
 func TestNewDriftWebhookSender_NoConfigs(t *testing.T) {
-	sender, err := webhooks.NewDriftWebhookSender(nil, webhooks.Clients{})
+	sender, err := webhooks.NewDriftWebhookSender(nil, webhooks.Clients{}, false, false)
 	Ok(t, err)
 	Equals(t, 0, len(sender.Webhooks))
 }
@@ -103,7 +103,7 @@ func TestNewDriftWebhookSender_SkipsApplyConfigs(t *testing.T) {
 	configs := []webhooks.Config{
 		{Event: webhooks.ApplyEvent, Kind: webhooks.SlackKind, Channel: "ch"},
 	}
-	sender, err := webhooks.NewDriftWebhookSender(configs, webhooks.Clients{})
+	sender, err := webhooks.NewDriftWebhookSender(configs, webhooks.Clients{}, false, false)
 	Ok(t, err)
 	Equals(t, 0, len(sender.Webhooks))
 }
@@ -117,9 +117,19 @@ func TestNewDriftWebhookSender_SlackSuccess(t *testing.T) {
 		{Event: webhooks.DriftEvent, Kind: webhooks.SlackKind, Channel: "drift-alerts"},
 	}
 	clients := webhooks.Clients{Slack: slackClient}
-	sender, err := webhooks.NewDriftWebhookSender(configs, clients)
+	sender, err := webhooks.NewDriftWebhookSender(configs, clients, false, false)
 	Ok(t, err)
 	Equals(t, 1, len(sender.Webhooks))
+	Equals(t, false, sender.Webhooks[0].(*webhooks.DriftSlackWebhook).Interactive)
+
+	sender, err = webhooks.NewDriftWebhookSender(configs, clients, true, false)
+	Ok(t, err)
+	Equals(t, true, sender.Webhooks[0].(*webhooks.DriftSlackWebhook).Interactive)
+	Equals(t, false, sender.Webhooks[0].(*webhooks.DriftSlackWebhook).AllowApply)
+
+	sender, err = webhooks.NewDriftWebhookSender(configs, clients, true, true)
+	Ok(t, err)
+	Equals(t, true, sender.Webhooks[0].(*webhooks.DriftSlackWebhook).AllowApply)
 }
 
 func TestNewDriftWebhookSender_SlackNoToken(t *testing.T) {
@@ -131,7 +141,7 @@ func TestNewDriftWebhookSender_SlackNoToken(t *testing.T) {
 		{Event: webhooks.DriftEvent, Kind: webhooks.SlackKind, Channel: "drift-alerts"},
 	}
 	clients := webhooks.Clients{Slack: slackClient}
-	_, err := webhooks.NewDriftWebhookSender(configs, clients)
+	_, err := webhooks.NewDriftWebhookSender(configs, clients, false, false)
 	Assert(t, err != nil, "expected error when slack token is not set")
 	ErrContains(t, "slack-token", err)
 }
@@ -145,7 +155,7 @@ func TestNewDriftWebhookSender_SlackNoChannel(t *testing.T) {
 		{Event: webhooks.DriftEvent, Kind: webhooks.SlackKind, Channel: ""},
 	}
 	clients := webhooks.Clients{Slack: slackClient}
-	_, err := webhooks.NewDriftWebhookSender(configs, clients)
+	_, err := webhooks.NewDriftWebhookSender(configs, clients, false, false)
 	Assert(t, err != nil, "expected error when channel is empty")
 	ErrContains(t, "channel", err)
 }
@@ -157,7 +167,7 @@ func TestNewDriftWebhookSender_HttpSuccess(t *testing.T) {
 	clients := webhooks.Clients{
 		Http: &webhooks.HttpClient{Client: http.DefaultClient},
 	}
-	sender, err := webhooks.NewDriftWebhookSender(configs, clients)
+	sender, err := webhooks.NewDriftWebhookSender(configs, clients, false, false)
 	Ok(t, err)
 	Equals(t, 1, len(sender.Webhooks))
 }
@@ -169,7 +179,7 @@ func TestNewDriftWebhookSender_HttpNoURL(t *testing.T) {
 	clients := webhooks.Clients{
 		Http: &webhooks.HttpClient{Client: http.DefaultClient},
 	}
-	_, err := webhooks.NewDriftWebhookSender(configs, clients)
+	_, err := webhooks.NewDriftWebhookSender(configs, clients, false, false)
 	Assert(t, err != nil, "expected error when URL is empty")
 	ErrContains(t, "url", err)
 }
@@ -178,7 +188,7 @@ func TestNewDriftWebhookSender_UnsupportedKind(t *testing.T) {
 	configs := []webhooks.Config{
 		{Event: webhooks.DriftEvent, Kind: "unsupported"},
 	}
-	_, err := webhooks.NewDriftWebhookSender(configs, webhooks.Clients{})
+	_, err := webhooks.NewDriftWebhookSender(configs, webhooks.Clients{}, false, false)
 	Assert(t, err != nil, "expected error for unsupported kind")
 	ErrContains(t, "unsupported", err)
 }
@@ -187,7 +197,7 @@ func TestNewDriftWebhookSender_NoKind(t *testing.T) {
 	configs := []webhooks.Config{
 		{Event: webhooks.DriftEvent, Kind: ""},
 	}
-	_, err := webhooks.NewDriftWebhookSender(configs, webhooks.Clients{})
+	_, err := webhooks.NewDriftWebhookSender(configs, webhooks.Clients{}, false, false)
 	Assert(t, err != nil, "expected error when kind is empty")
 	ErrContains(t, "kind", err)
 }
@@ -206,7 +216,7 @@ func TestNewDriftWebhookSender_MixedConfigs(t *testing.T) {
 		Slack: slackClient,
 		Http:  &webhooks.HttpClient{Client: http.DefaultClient},
 	}
-	sender, err := webhooks.NewDriftWebhookSender(configs, clients)
+	sender, err := webhooks.NewDriftWebhookSender(configs, clients, false, false)
 	Ok(t, err)
 	Equals(t, 2, len(sender.Webhooks))
 }
@@ -6,6 +6,7 @@ package mocks
 import (
 	pegomock "github.com/petergtz/pegomock/v4"
 	webhooks "github.com/runatlantis/atlantis/server/events/webhooks"
+	slack "github.com/slack-go/slack"
 	"reflect"
 	"time"
 )
@@ -55,6 +56,36 @@ func (mock *MockSlackClient) PostDriftMessage(channel string, driftResult webhoo
 	return _ret0
 }
 
+func (mock *MockSlackClient) PostEphemeral(channel string, user string, text string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
+	}
+	_params := []pegomock.Param{channel, user, text}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("PostEphemeral", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockSlackClient) PostInteractiveDriftMessage(channel string, driftResult webhooks.DriftResult, allowApply bool) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
+	}
+	_params := []pegomock.Param{channel, driftResult, allowApply}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("PostInteractiveDriftMessage", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
 func (mock *MockSlackClient) PostMessage(channel string, applyResult webhooks.ApplyResult) error {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
@@ -85,6 +116,21 @@ func (mock *MockSlackClient) TokenIsSet() bool {
 	return _ret0
 }
 
+func (mock *MockSlackClient) UpdateMessage(channel string, timestamp string, attachments []slack.Attachment, blocks []slack.Block) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
+	}
+	_params := []pegomock.Param{channel, timestamp, attachments, blocks}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("UpdateMessage", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
 func (mock *MockSlackClient) VerifyWasCalledOnce() *VerifierMockSlackClient {
 	return &VerifierMockSlackClient{
 		mock:                   mock,
@@ -174,6 +220,88 @@ func (c *MockSlackClient_PostDriftMessage_OngoingVerification) GetAllCapturedArg
 	return
 }
 
+func (verifier *VerifierMockSlackClient) PostEphemeral(channel string, user string, text string) *MockSlackClient_PostEphemeral_OngoingVerification {
+	_params := []pegomock.Param{channel, user, text}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostEphemeral", _params, verifier.timeout)
+	return &MockSlackClient_PostEphemeral_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockSlackClient_PostEphemeral_OngoingVerification struct {
+	mock              *MockSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockSlackClient_PostEphemeral_OngoingVerification) GetCapturedArguments() (string, string, string) {
+	channel, user, text := c.GetAllCapturedArguments()
+	return channel[len(channel)-1], user[len(user)-1], text[len(text)-1]
+}
+
+func (c *MockSlackClient_PostEphemeral_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 []string) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(string)
+			}
+		}
+		if len(_params) > 2 {
+			_param2 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[2] {
+				_param2[u] = param.(string)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockSlackClient) PostInteractiveDriftMessage(channel string, driftResult webhooks.DriftResult, allowApply bool) *MockSlackClient_PostInteractiveDriftMessage_OngoingVerification {
+	_params := []pegomock.Param{channel, driftResult, allowApply}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostInteractiveDriftMessage", _params, verifier.timeout)
+	return &MockSlackClient_PostInteractiveDriftMessage_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockSlackClient_PostInteractiveDriftMessage_OngoingVerification struct {
+	mock              *MockSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockSlackClient_PostInteractiveDriftMessage_OngoingVerification) GetCapturedArguments() (string, webhooks.DriftResult, bool) {
+	channel, driftResult, allowApply := c.GetAllCapturedArguments()
+	return channel[len(channel)-1], driftResult[len(driftResult)-1], allowApply[len(allowApply)-1]
+}
+
+func (c *MockSlackClient_PostInteractiveDriftMessage_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []webhooks.DriftResult, _param2 []bool) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]webhooks.DriftResult, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(webhooks.DriftResult)
+			}
+		}
+		if len(_params) > 2 {
+			_param2 = make([]bool, len(c.methodInvocations))
+			for u, param := range _params[2] {
+				_param2[u] = param.(bool)
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockSlackClient) PostMessage(channel string, applyResult webhooks.ApplyResult) *MockSlackClient_PostMessage_OngoingVerification {
 	_params := []pegomock.Param{channel, applyResult}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostMessage", _params, verifier.timeout)
@@ -225,3 +353,50 @@ func (c *MockSlackClient_TokenIsSet_OngoingVerification) GetCapturedArguments()
 
 func (c *MockSlackClient_TokenIsSet_OngoingVerification) GetAllCapturedArguments() {
 }
+
+func (verifier *VerifierMockSlackClient) UpdateMessage(channel string, timestamp string, attachments []slack.Attachment, blocks []slack.Block) *MockSlackClient_UpdateMessage_OngoingVerification {
+	_params := []pegomock.Param{channel, timestamp, attachments, blocks}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UpdateMessage", _params, verifier.timeout)
+	return &MockSlackClient_UpdateMessage_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockSlackClient_UpdateMessage_OngoingVerification struct {
+	mock              *MockSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockSlackClient_UpdateMessage_OngoingVerification) GetCapturedArguments() (string, string, []slack.Attachment, []slack.Block) {
+	channel, timestamp, attachments, blocks := c.GetAllCapturedArguments()
+	return channel[len(channel)-1], timestamp[len(timestamp)-1], attachments[len(attachments)-1], blocks[len(blocks)-1]
+}
+
+func (c *MockSlackClient_UpdateMessage_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 [][]slack.Attachment, _param3 [][]slack.Block) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(string)
+			}
+		}
+		if len(_params) > 2 {
+			_param2 = make([][]slack.Attachment, len(c.methodInvocations))
+			for u, param := range _params[2] {
+				_param2[u] = param.([]slack.Attachment)
+			}
+		}
+		if len(_params) > 3 {
+			_param3 = make([][]slack.Block, len(c.methodInvocations))
+			for u, param := range _params[3] {
+				_param3[u] = param.([]slack.Block)
+			}
+		}
+	}
+	return
+}
@@ -67,6 +67,28 @@ func (mock *MockUnderlyingSlackClient) GetConversations(conversationParams *slac
 	return _ret0, _ret1, _ret2
 }
 
+func (mock *MockUnderlyingSlackClient) PostEphemeral(channelID string, userID string, options ...slack.MsgOption) (string, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockUnderlyingSlackClient().")
+	}
+	_params := []pegomock.Param{channelID, userID}
+	for _, param := range options {
+		_params = append(_params, param)
+	}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("PostEphemeral", _params, []reflect.Type{reflect.TypeOf((*string)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 string
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(string)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
 func (mock *MockUnderlyingSlackClient) PostMessage(channelID string, options ...slack.MsgOption) (string, string, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockUnderlyingSlackClient().")
@@ -93,6 +115,36 @@ func (mock *MockUnderlyingSlackClient) PostMessage(channelID string, options ...
 	return _ret0, _ret1, _ret2
 }
 
+func (mock *MockUnderlyingSlackClient) UpdateMessage(channelID string, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockUnderlyingSlackClient().")
+	}
+	_params := []pegomock.Param{channelID, timestamp}
+	for _, param := range options {
+		_params = append(_params, param)
+	}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("UpdateMessage", _params, []reflect.Type{reflect.TypeOf((*string)(nil)).Elem(), reflect.TypeOf((*string)(nil)).Elem(), reflect.TypeOf((*string)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 string
+	var _ret1 string
+	var _ret2 string
+	var _ret3 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(string)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(string)
+		}
+		if _result[2] != nil {
+			_ret2 = _result[2].(string)
+		}
+		if _result[3] != nil {
+			_ret3 = _result[3].(error)
+		}
+	}
+	return _ret0, _ret1, _ret2, _ret3
+}
+
 func (mock *MockUnderlyingSlackClient) VerifyWasCalledOnce() *VerifierMockUnderlyingSlackClient {
 	return &VerifierMockUnderlyingSlackClient{
 		mock:                   mock,
@@ -176,6 +228,53 @@ func (c *MockUnderlyingSlackClient_GetConversations_OngoingVerification) GetAllC
 	return
 }
 
+func (verifier *VerifierMockUnderlyingSlackClient) PostEphemeral(channelID string, userID string, options ...slack.MsgOption) *MockUnderlyingSlackClient_PostEphemeral_OngoingVerification {
+	_params := []pegomock.Param{channelID, userID}
+	for _, param := range options {
+		_params = append(_params, param)
+	}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostEphemeral", _params, verifier.timeout)
+	return &MockUnderlyingSlackClient_PostEphemeral_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockUnderlyingSlackClient_PostEphemeral_OngoingVerification struct {
+	mock              *MockUnderlyingSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockUnderlyingSlackClient_PostEphemeral_OngoingVerification) GetCapturedArguments() (string, string, []slack.MsgOption) {
+	channelID, userID, options := c.GetAllCapturedArguments()
+	return channelID[len(channelID)-1], userID[len(userID)-1], options[len(options)-1]
+}
+
+func (c *MockUnderlyingSlackClient_PostEphemeral_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 [][]slack.MsgOption) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(string)
+			}
+		}
+		_param2 = make([][]slack.MsgOption, len(c.methodInvocations))
+		for u := 0; u < len(c.methodInvocations); u++ {
+			_param2[u] = make([]slack.MsgOption, len(_params)-2)
+			for x := 2; x < len(_params); x++ {
+				if _params[x][u] != nil {
+					_param2[u][x-2] = _params[x][u].(slack.MsgOption)
+				}
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockUnderlyingSlackClient) PostMessage(channelID string, options ...slack.MsgOption) *MockUnderlyingSlackClient_PostMessage_OngoingVerification {
 	_params := []pegomock.Param{channelID}
 	for _, param := range options {
@@ -216,3 +315,50 @@ func (c *MockUnderlyingSlackClient_PostMessage_OngoingVerification) GetAllCaptur
 	}
 	return
 }
+
+func (verifier *VerifierMockUnderlyingSlackClient) UpdateMessage(channelID string, timestamp string, options ...slack.MsgOption) *MockUnderlyingSlackClient_UpdateMessage_OngoingVerification {
+	_params := []pegomock.Param{channelID, timestamp}
+	for _, param := range options {
+		_params = append(_params, param)
+	}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UpdateMessage", _params, verifier.timeout)
+	return &MockUnderlyingSlackClient_UpdateMessage_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockUnderlyingSlackClient_UpdateMessage_OngoingVerification struct {
+	mock              *MockUnderlyingSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockUnderlyingSlackClient_UpdateMessage_OngoingVerification) GetCapturedArguments() (string, string, []slack.MsgOption) {
+	channelID, timestamp, options := c.GetAllCapturedArguments()
+	return channelID[len(channelID)-1], timestamp[len(timestamp)-1], options[len(options)-1]
+}
+
+func (c *MockUnderlyingSlackClient_UpdateMessage_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 [][]slack.MsgOption) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(string)
+			}
+		}
+		_param2 = make([][]slack.MsgOption, len(c.methodInvocations))
+		for u := 0; u < len(c.methodInvocations); u++ {
+			_param2[u] = make([]slack.MsgOption, len(_params)-2)
+			for x := 2; x < len(_params); x++ {
+				if _params[x][u] != nil {
+					_param2[u][x-2] = _params[x][u].(slack.MsgOption)
+				}
+			}
+		}
+	}
+	return
+}
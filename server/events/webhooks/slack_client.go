@@ -28,6 +28,14 @@ type SlackClient interface {
 	TokenIsSet() bool
 	PostMessage(channel string, applyResult ApplyResult) error
 	PostDriftMessage(channel string, driftResult DriftResult) error
+	// PostInteractiveDriftMessage posts a drift message with Plan fix and,
+	// if allowApply is true, Apply fix buttons for each drifted project.
+	PostInteractiveDriftMessage(channel string, driftResult DriftResult, allowApply bool) error
+	// UpdateMessage replaces the blocks of the message at timestamp, keeping
+	// its attachments.
+	UpdateMessage(channel, timestamp string, attachments []slack.Attachment, blocks []slack.Block) error
+	// PostEphemeral posts a message only user can see.
+	PostEphemeral(channel, user, text string) error
 }
 
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_underlying_slack_client.go UnderlyingSlackClient
@@ -38,6 +46,8 @@ type UnderlyingSlackClient interface {
 	AuthTest() (response *slack.AuthTestResponse, error error)
 	GetConversations(conversationParams *slack.GetConversationsParameters) (channels []slack.Channel, nextCursor string, err error)
 	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessage(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+	PostEphemeral(channelID, userID string, options ...slack.MsgOption) (string, error)
 }
 
 type DefaultSlackClient struct {
@@ -83,6 +93,33 @@ func (d *DefaultSlackClient) PostDriftMessage(channel string, driftResult DriftR
 	return err
 }
 
+func (d *DefaultSlackClient) PostInteractiveDriftMessage(channel string, driftResult DriftResult, allowApply bool) error {
+	attachment := d.createDriftAttachment(driftResult)
+	_, _, err := d.Slack.PostMessage(
+		channel,
+		slack.MsgOptionAsUser(true),
+		slack.MsgOptionText(attachment.Text, false),
+		slack.MsgOptionAttachments(attachment),
+		slack.MsgOptionBlocks(driftActionBlocks(driftResult, allowApply)...),
+	)
+	return err
+}
+
+func (d *DefaultSlackClient) UpdateMessage(channel, timestamp string, attachments []slack.Attachment, blocks []slack.Block) error {
+	_, _, _, err := d.Slack.UpdateMessage(
+		channel,
+		timestamp,
+		slack.MsgOptionAttachments(attachments...),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	return err
+}
+
+func (d *DefaultSlackClient) PostEphemeral(channel, user, text string) error {
+	_, err := d.Slack.PostEphemeral(channel, user, slack.MsgOptionText(text, false))
+	return err
+}
+
 func (d *DefaultSlackClient) createDriftAttachment(result DriftResult) slack.Attachment {
 	var colour string
 	var text string
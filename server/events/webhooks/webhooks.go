@@ -65,8 +65,11 @@ func NewMultiWebhookSender(configs []Config, clients Clients) (*MultiWebhookSend
 		if c.Event == DriftEvent {
 			continue // drift events are handled by DriftWebhookSender
 		}
+		if c.Event == AuditEvent {
+			continue // audit events are handled by NewAuditWebhookSinks
+		}
 		if c.Event != ApplyEvent {
-			return nil, fmt.Errorf("\"event: %s\" not supported. Only \"event: %s\" and \"event: %s\" are supported", c.Event, ApplyEvent, DriftEvent)
+			return nil, fmt.Errorf("\"event: %s\" not supported. Only \"event: %s\", \"event: %s\" and \"event: %s\" are supported", c.Event, ApplyEvent, DriftEvent, AuditEvent)
 		}
 		wr, err := regexp.Compile(c.WorkspaceRegex)
 		if err != nil {
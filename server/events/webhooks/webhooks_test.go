@@ -119,7 +119,7 @@ func TestNewWebhooksManager_UnsupportedEvent(t *testing.T) {
 	configs[0].Event = unsupportedEvent
 	_, err := webhooks.NewMultiWebhookSender(configs, clients)
 	Assert(t, err != nil, "expected error")
-	Equals(t, "\"event: badevent\" not supported. Only \"event: apply\" and \"event: drift\" are supported", err.Error())
+	Equals(t, "\"event: badevent\" not supported. Only \"event: apply\", \"event: drift\" and \"event: audit\" are supported", err.Error())
 }
 
 func TestNewWebhooksManager_NoKind(t *testing.T) {
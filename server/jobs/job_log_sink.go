@@ -0,0 +1,381 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// JobLogRetentionPeriod is how often archived job logs are checked against
+// the retention setting.
+const JobLogRetentionPeriod = time.Hour
+
+// s3OpTimeout is the per-operation timeout for S3 API calls.
+const s3OpTimeout = 30 * time.Second
+
+func s3Ctx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), s3OpTimeout)
+}
+
+// JobLog is the output of a completed job along with the metadata needed to
+// show it once the job is no longer held in memory.
+type JobLog struct {
+	JobID        string    `json:"job_id"`
+	RepoFullName string    `json:"repo_full_name"`
+	PullNum      int       `json:"pull_num"`
+	ProjectName  string    `json:"project_name"`
+	Path         string    `json:"path"`
+	Workspace    string    `json:"workspace"`
+	Command      string    `json:"command"`
+	User         string    `json:"user"`
+	HeadCommit   string    `json:"head_commit"`
+	TraceID      string    `json:"trace_id,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	CompletedAt  time.Time `json:"completed_at"`
+	Lines        []string  `json:"lines"`
+}
+
+// JobLogSink archives the output of completed jobs so that job links keep
+// working after a pull request is closed or Atlantis restarts.
+type JobLogSink interface {
+	// Archive stores log, replacing any archived log with the same job ID.
+	Archive(log JobLog) error
+	// Get returns the archived log for jobID, or nil if there isn't one.
+	Get(jobID string) (*JobLog, error)
+	// TraceID returns the trace ID in the archived log for jobID, or "" if
+	// there isn't one. It doesn't load the job's output.
+	TraceID(jobID string) (string, error)
+	// DeleteBefore removes the logs of jobs that completed before cutoff and
+	// returns how many were removed.
+	DeleteBefore(cutoff time.Time) (int, error)
+}
+
+// validJobID returns an error if jobID can't safely be used as a file name
+// or object key.
+func validJobID(jobID string) error {
+	if jobID == "" || jobID == "." || jobID == ".." || strings.ContainsAny(jobID, `/\`) {
+		return fmt.Errorf("invalid job ID %q", jobID)
+	}
+	return nil
+}
+
+// decodeTraceID reads the trace ID of an encoded JobLog. JobLog encodes its
+// metadata before its lines, so decoding stops before the job's output.
+func decodeTraceID(r io.Reader) (string, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return "", err
+	} else if tok != json.Delim('{') {
+		return "", errors.New("not a job log")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch tok {
+		case "trace_id":
+			var traceID string
+			err := dec.Decode(&traceID)
+			return traceID, err
+		case "lines":
+			return "", nil
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// LocalJobLogSink archives job logs as JSON files in a local directory.
+type LocalJobLogSink struct {
+	dir string
+}
+
+// NewLocalJobLogSink creates dir if it doesn't exist and returns a sink that
+// archives into it.
+func NewLocalJobLogSink(dir string) (*LocalJobLogSink, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating job log directory: %w", err)
+	}
+	return &LocalJobLogSink{dir: dir}, nil
+}
+
+func (l *LocalJobLogSink) path(jobID string) string {
+	return filepath.Join(l.dir, jobID+".json")
+}
+
+func (l *LocalJobLogSink) Archive(log JobLog) error {
+	if err := validJobID(log.JobID); err != nil {
+		return err
+	}
+	data, err := json.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("encoding job log: %w", err)
+	}
+	// Write to a temp file and rename so readers never see a partial log.
+	tmp, err := os.CreateTemp(l.dir, log.JobID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing job log: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()           // nolint: errcheck
+		os.Remove(tmp.Name()) // nolint: errcheck
+		return fmt.Errorf("writing job log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name()) // nolint: errcheck
+		return fmt.Errorf("writing job log: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.path(log.JobID)); err != nil {
+		os.Remove(tmp.Name()) // nolint: errcheck
+		return fmt.Errorf("writing job log: %w", err)
+	}
+	return nil
+}
+
+func (l *LocalJobLogSink) Get(jobID string) (*JobLog, error) {
+	if err := validJobID(jobID); err != nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(l.path(jobID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading job log: %w", err)
+	}
+	var log JobLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("decoding job log %s: %w", jobID, err)
+	}
+	return &log, nil
+}
+
+func (l *LocalJobLogSink) TraceID(jobID string) (string, error) {
+	if err := validJobID(jobID); err != nil {
+		return "", nil
+	}
+	f, err := os.Open(l.path(jobID))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading job log: %w", err)
+	}
+	defer f.Close()
+	traceID, err := decodeTraceID(f)
+	if err != nil {
+		return "", fmt.Errorf("decoding job log %s: %w", jobID, err)
+	}
+	return traceID, nil
+}
+
+// DeleteBefore uses each file's modification time, which is when the job was
+// archived, so it doesn't have to decode every log.
+func (l *LocalJobLogSink) DeleteBefore(cutoff time.Time) (int, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return 0, fmt.Errorf("listing job logs: %w", err)
+	}
+	var deleted int
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(l.dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// S3JobLogSink archives job logs as JSON objects in an S3-compatible bucket.
+type S3JobLogSink struct {
+	client planstore.S3Client
+	bucket string
+	prefix string
+}
+
+// NewS3JobLogSink returns a sink that archives into bucket under prefix using
+// client, which is usually created with planstore.NewS3Client.
+func NewS3JobLogSink(client planstore.S3Client, bucket string, prefix string) *S3JobLogSink {
+	return &S3JobLogSink{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+func (s *S3JobLogSink) key(jobID string) string {
+	if s.prefix == "" {
+		return jobID + ".json"
+	}
+	return s.prefix + "/" + jobID + ".json"
+}
+
+func (s *S3JobLogSink) Archive(log JobLog) error {
+	if err := validJobID(log.JobID); err != nil {
+		return err
+	}
+	data, err := json.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("encoding job log: %w", err)
+	}
+	ctx, cancel := s3Ctx()
+	defer cancel()
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.key(log.JobID)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("uploading job log to S3 (key=%s): %w", s.key(log.JobID), err)
+	}
+	return nil
+}
+
+func (s *S3JobLogSink) Get(jobID string) (*JobLog, error) {
+	if err := validJobID(jobID); err != nil {
+		return nil, nil
+	}
+	ctx, cancel := s3Ctx()
+	defer cancel()
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(jobID)),
+	})
+	var noSuchKey *s3types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("downloading job log from S3 (key=%s): %w", s.key(jobID), err)
+	}
+	defer out.Body.Close()
+	var log JobLog
+	if err := json.NewDecoder(out.Body).Decode(&log); err != nil {
+		return nil, fmt.Errorf("decoding job log %s: %w", jobID, err)
+	}
+	return &log, nil
+}
+
+// TraceID closes the object's body once the trace ID is decoded, so only the
+// start of the log is downloaded.
+func (s *S3JobLogSink) TraceID(jobID string) (string, error) {
+	if err := validJobID(jobID); err != nil {
+		return "", nil
+	}
+	ctx, cancel := s3Ctx()
+	defer cancel()
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(jobID)),
+	})
+	var noSuchKey *s3types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("downloading job log from S3 (key=%s): %w", s.key(jobID), err)
+	}
+	defer out.Body.Close()
+	traceID, err := decodeTraceID(out.Body)
+	if err != nil {
+		return "", fmt.Errorf("decoding job log %s: %w", jobID, err)
+	}
+	return traceID, nil
+}
+
+// DeleteBefore uses each object's last modified time, which is when the job
+// was archived.
+func (s *S3JobLogSink) DeleteBefore(cutoff time.Time) (int, error) {
+	listPrefix := ""
+	if s.prefix != "" {
+		listPrefix = s.prefix + "/"
+	}
+	var deleted int
+	var errs []error
+	var continuationToken *string
+	for {
+		listCtx, listCancel := s3Ctx()
+		resp, err := s.client.ListObjectsV2(listCtx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(s.bucket),
+			Prefix:            aws.String(listPrefix),
+			ContinuationToken: continuationToken,
+		})
+		listCancel()
+		if err != nil {
+			return deleted, fmt.Errorf("listing job logs (prefix=%s): %w", listPrefix, err)
+		}
+		for _, obj := range resp.Contents {
+			key := aws.ToString(obj.Key)
+			if !strings.HasSuffix(key, ".json") || obj.LastModified == nil || !obj.LastModified.Before(cutoff) {
+				continue
+			}
+			delCtx, delCancel := s3Ctx()
+			_, err := s.client.DeleteObject(delCtx, &s3.DeleteObjectInput{
+				Bucket: aws.String(s.bucket),
+				Key:    aws.String(key),
+			})
+			delCancel()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("deleting job log from S3 (key=%s): %w", key, err))
+				continue
+			}
+			deleted++
+		}
+		if !aws.ToBool(resp.IsTruncated) {
+			break
+		}
+		continuationToken = resp.NextContinuationToken
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// JobLogRetentionJob is a scheduled job that removes archived job logs older
+// than Retention.
+type JobLogRetentionJob struct {
+	Sink      JobLogSink
+	Retention time.Duration
+	Logger    logging.SimpleLogging
+}
+
+func (j *JobLogRetentionJob) Run() {
+	deleted, err := j.Sink.DeleteBefore(time.Now().Add(-j.Retention))
+	if err != nil {
+		j.Logger.Err("removing expired job logs: %s", err)
+	}
+	if deleted > 0 {
+		j.Logger.Info("removed %d job log(s) older than %s", deleted, j.Retention)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/runatlantis/atlantis/server/jobs"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func testJobLog(jobID string) jobs.JobLog {
+	return jobs.JobLog{
+		JobID:        jobID,
+		RepoFullName: "owner/repo",
+		PullNum:      1,
+		ProjectName:  "test-project",
+		Path:         "test-dir",
+		Workspace:    "default",
+		Command:      "plan",
+		User:         "test-user",
+		HeadCommit:   "234r232432",
+		StartedAt:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		CompletedAt:  time.Date(2025, 1, 2, 3, 5, 5, 0, time.UTC),
+		Lines:        []string{"Initializing...", "Plan: 1 to add, 0 to change, 0 to destroy."},
+	}
+}
+
+func TestLocalJobLogSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "job-logs")
+	sink, err := jobs.NewLocalJobLogSink(dir)
+	Ok(t, err)
+
+	log, err := sink.Get("1234")
+	Ok(t, err)
+	Assert(t, log == nil, "expected no log before archiving")
+
+	Ok(t, sink.Archive(testJobLog("1234")))
+	log, err = sink.Get("1234")
+	Ok(t, err)
+	Equals(t, testJobLog("1234"), *log)
+
+	traceID, err := sink.TraceID("1234")
+	Ok(t, err)
+	Equals(t, "", traceID)
+
+	traced := testJobLog("traced")
+	traced.TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	Ok(t, sink.Archive(traced))
+	traceID, err = sink.TraceID("traced")
+	Ok(t, err)
+	Equals(t, traced.TraceID, traceID)
+	traceID, err = sink.TraceID("missing")
+	Ok(t, err)
+	Equals(t, "", traceID)
+
+	ErrContains(t, "invalid job ID", sink.Archive(testJobLog("../1234")))
+	log, err = sink.Get("../1234")
+	Ok(t, err)
+	Assert(t, log == nil, "expected no log for an invalid job ID")
+}
+
+func TestLocalJobLogSink_DeleteBefore(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := jobs.NewLocalJobLogSink(dir)
+	Ok(t, err)
+	Ok(t, sink.Archive(testJobLog("old")))
+	Ok(t, sink.Archive(testJobLog("new")))
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	Ok(t, os.Chtimes(filepath.Join(dir, "old.json"), lastWeek, lastWeek))
+
+	deleted, err := sink.DeleteBefore(time.Now().Add(-24 * time.Hour))
+	Ok(t, err)
+	Equals(t, 1, deleted)
+
+	log, err := sink.Get("old")
+	Ok(t, err)
+	Assert(t, log == nil, "expected old log to be deleted")
+	log, err = sink.Get("new")
+	Ok(t, err)
+	Assert(t, log != nil, "expected new log to be kept")
+}
+
+// fakeS3Client stores objects in memory.
+type fakeS3Client struct {
+	objects  map[string][]byte
+	modified map[string]time.Time
+}
+
+func newFakeS3Client() *fakeS3Client {
+	return &fakeS3Client{objects: map[string][]byte{}, modified: map[string]time.Time{}}
+}
+
+func (f *fakeS3Client) HeadBucket(_ context.Context, _ *s3.HeadBucketInput, _ ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	return &s3.HeadBucketOutput{}, nil
+}
+
+func (f *fakeS3Client) PutObject(_ context.Context, input *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	body, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects[aws.ToString(input.Key)] = body
+	f.modified[aws.ToString(input.Key)] = time.Now()
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3Client) GetObject(_ context.Context, input *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	body, ok := f.objects[aws.ToString(input.Key)]
+	if !ok {
+		return nil, &s3types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func (f *fakeS3Client) DeleteObject(_ context.Context, input *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	delete(f.objects, aws.ToString(input.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (f *fakeS3Client) ListObjectsV2(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	out := &s3.ListObjectsV2Output{}
+	for key := range f.objects {
+		out.Contents = append(out.Contents, s3types.Object{Key: aws.String(key), LastModified: aws.Time(f.modified[key])})
+	}
+	return out, nil
+}
+
+func TestS3JobLogSink(t *testing.T) {
+	client := newFakeS3Client()
+	sink := jobs.NewS3JobLogSink(client, "bucket", "/atlantis/jobs/")
+
+	log, err := sink.Get("1234")
+	Ok(t, err)
+	Assert(t, log == nil, "expected no log before archiving")
+
+	Ok(t, sink.Archive(testJobLog("1234")))
+	_, ok := client.objects["atlantis/jobs/1234.json"]
+	Assert(t, ok, "expected log to be archived under the prefix")
+	log, err = sink.Get("1234")
+	Ok(t, err)
+	Equals(t, testJobLog("1234"), *log)
+
+	traced := testJobLog("traced")
+	traced.TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	Ok(t, sink.Archive(traced))
+	traceID, err := sink.TraceID("traced")
+	Ok(t, err)
+	Equals(t, traced.TraceID, traceID)
+	traceID, err = sink.TraceID("missing")
+	Ok(t, err)
+	Equals(t, "", traceID)
+
+	Ok(t, sink.Archive(testJobLog("5678")))
+	client.modified["atlantis/jobs/1234.json"] = time.Now().Add(-48 * time.Hour)
+	deleted, err := sink.DeleteBefore(time.Now().Add(-24 * time.Hour))
+	Ok(t, err)
+	Equals(t, 1, deleted)
+	_, ok = client.objects["atlantis/jobs/1234.json"]
+	Assert(t, !ok, "expected expired log to be deleted")
+}
+
+// Test that the trace ID is read without decoding the job's output.
+func TestLocalJobLogSink_TraceIDSkipsOutput(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := jobs.NewLocalJobLogSink(dir)
+	Ok(t, err)
+	// Output that isn't valid JSON would fail to decode if it were read.
+	data := `{"job_id":"1234","trace_id":"abc","started_at":"2025-01-02T03:04:05Z","lines":[not json`
+	Ok(t, os.WriteFile(filepath.Join(dir, "1234.json"), []byte(data), 0600))
+
+	traceID, err := sink.TraceID("1234")
+	Ok(t, err)
+	Equals(t, "abc", traceID)
+}
+
+type errJobLogSink struct {
+	cutoff time.Time
+}
+
+func (e *errJobLogSink) Archive(_ jobs.JobLog) error { return nil }
+
+func (e *errJobLogSink) Get(_ string) (*jobs.JobLog, error) { return nil, nil }
+
+func (e *errJobLogSink) TraceID(_ string) (string, error) { return "", nil }
+
+func (e *errJobLogSink) DeleteBefore(cutoff time.Time) (int, error) {
+	e.cutoff = cutoff
+	return 0, errors.New("bucket unavailable")
+}
+
+func TestJobLogRetentionJob(t *testing.T) {
+	sink := &errJobLogSink{}
+	job := &jobs.JobLogRetentionJob{
+		Sink:      sink,
+		Retention: 24 * time.Hour,
+		Logger:    logging.NewNoopLogger(t),
+	}
+
+	job.Run()
+
+	expCutoff := time.Now().Add(-24 * time.Hour)
+	Assert(t, sink.cutoff.Sub(expCutoff).Abs() < time.Minute, "expected cutoff %s, got %s", expCutoff, sink.cutoff)
+}
@@ -43,6 +43,21 @@ func (mock *MockProjectCommandOutputHandler) Deregister(jobID string, receiver c
 	pegomock.GetGenericMockFrom(mock).Invoke("Deregister", _params, []reflect.Type{})
 }
 
+func (mock *MockProjectCommandOutputHandler) GetJobTraceID(jobID string) string {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockProjectCommandOutputHandler().")
+	}
+	_params := []pegomock.Param{jobID}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("GetJobTraceID", _params, []reflect.Type{reflect.TypeOf((*string)(nil)).Elem()})
+	var _ret0 string
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(string)
+		}
+	}
+	return _ret0
+}
+
 func (mock *MockProjectCommandOutputHandler) GetPullToJobMapping() []jobs.PullInfoWithJobIDs {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockProjectCommandOutputHandler().")
@@ -206,6 +221,35 @@ func (c *MockProjectCommandOutputHandler_Deregister_OngoingVerification) GetAllC
 	return
 }
 
+func (verifier *VerifierMockProjectCommandOutputHandler) GetJobTraceID(jobID string) *MockProjectCommandOutputHandler_GetJobTraceID_OngoingVerification {
+	_params := []pegomock.Param{jobID}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetJobTraceID", _params, verifier.timeout)
+	return &MockProjectCommandOutputHandler_GetJobTraceID_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockProjectCommandOutputHandler_GetJobTraceID_OngoingVerification struct {
+	mock              *MockProjectCommandOutputHandler
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockProjectCommandOutputHandler_GetJobTraceID_OngoingVerification) GetCapturedArguments() string {
+	jobID := c.GetAllCapturedArguments()
+	return jobID[len(jobID)-1]
+}
+
+func (c *MockProjectCommandOutputHandler_GetJobTraceID_OngoingVerification) GetAllCapturedArguments() (_param0 []string) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockProjectCommandOutputHandler) GetPullToJobMapping() *MockProjectCommandOutputHandler_GetPullToJobMapping_OngoingVerification {
 	_params := []pegomock.Param{}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "GetPullToJobMapping", _params, verifier.timeout)
@@ -4,17 +4,21 @@
 package jobs
 
 import (
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
 )
 
 type OutputBuffer struct {
 	OperationComplete bool
 	Buffer            []string
+	// TraceID is the ID of the trace the job ran in, if tracing is enabled.
+	TraceID string
 }
 
 type PullInfo struct {
@@ -45,6 +49,8 @@ type JobInfo struct {
 	HeadCommit     string
 	JobDescription string
 	JobStep        string
+	User           string
+	TraceID        string
 }
 
 type ProjectCmdOutputLine struct {
@@ -69,6 +75,17 @@ type AsyncProjectCommandOutputHandler struct {
 
 	// Tracks all the jobs for a pull request which is used for clean up after a pull request is closed.
 	pullToJobMapping sync.Map
+
+	// jobLogSink archives completed jobs. It's nil if archiving is disabled.
+	jobLogSink JobLogSink
+	// Tracks when running jobs started and what they're for, so they can be
+	// archived when they complete. Guarded by projectOutputBuffersLock.
+	runningJobs map[string]runningJob
+}
+
+type runningJob struct {
+	info      JobInfo
+	startedAt time.Time
 }
 
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_project_command_output_handler.go ProjectCommandOutputHandler
@@ -96,11 +113,19 @@ type ProjectCommandOutputHandler interface {
 
 	// Returns a map from Pull Requests to Jobs
 	GetPullToJobMapping() []PullInfoWithJobIDs
+
+	// GetJobTraceID returns the ID of the trace the job ran in, or "" if
+	// the job isn't in memory or wasn't traced.
+	GetJobTraceID(jobID string) string
 }
 
+// NewAsyncProjectCommandOutputHandler returns a handler that keeps job output
+// in memory. If jobLogSink isn't nil, each job's output is also archived to it
+// when the job completes.
 func NewAsyncProjectCommandOutputHandler(
 	projectCmdOutput chan *ProjectCmdOutputLine,
 	logger logging.SimpleLogging,
+	jobLogSink JobLogSink,
 ) ProjectCommandOutputHandler {
 	return &AsyncProjectCommandOutputHandler{
 		projectCmdOutput:     projectCmdOutput,
@@ -108,6 +133,8 @@ func NewAsyncProjectCommandOutputHandler(
 		receiverBuffers:      map[string]map[chan string]bool{},
 		projectOutputBuffers: map[string]OutputBuffer{},
 		pullToJobMapping:     sync.Map{},
+		jobLogSink:           jobLogSink,
+		runningJobs:          map[string]runningJob{},
 	}
 }
 
@@ -155,6 +182,8 @@ func (p *AsyncProjectCommandOutputHandler) Send(ctx command.ProjectContext, msg
 				Workspace:    ctx.Workspace,
 			},
 			JobStep: ctx.CommandName.String(),
+			User:    ctx.User.Username,
+			TraceID: tracing.TraceID(ctx.Log),
 		},
 		Line:              msg,
 		OperationComplete: operationComplete,
@@ -173,6 +202,8 @@ func (p *AsyncProjectCommandOutputHandler) SendWorkflowHook(ctx models.WorkflowH
 			},
 			JobDescription: ctx.HookDescription,
 			JobStep:        ctx.HookStepName,
+			User:           ctx.User.Username,
+			TraceID:        tracing.TraceID(ctx.Log),
 		},
 		Line:              msg,
 		OperationComplete: operationComplete,
@@ -204,7 +235,7 @@ func (p *AsyncProjectCommandOutputHandler) Handle() {
 		})
 
 		// Forward new message to all receiver channels and output buffer
-		p.writeLogLine(msg.JobID, msg.Line)
+		p.writeLogLine(msg.JobID, msg.JobInfo, msg.Line)
 	}
 }
 
@@ -220,7 +251,28 @@ func (p *AsyncProjectCommandOutputHandler) completeJob(jobID string) {
 	if outputBuffer, ok := p.projectOutputBuffers[jobID]; ok {
 		outputBuffer.OperationComplete = true
 		p.projectOutputBuffers[jobID] = outputBuffer
+
+		if job, ok := p.runningJobs[jobID]; ok && p.jobLogSink != nil {
+			// Archive in the background so a slow sink doesn't hold up
+			// the output of other jobs.
+			go p.archiveJob(JobLog{
+				JobID:        jobID,
+				RepoFullName: job.info.RepoFullName,
+				PullNum:      job.info.PullNum,
+				ProjectName:  job.info.ProjectName,
+				Path:         job.info.Path,
+				Workspace:    job.info.Workspace,
+				Command:      job.info.JobStep,
+				User:         job.info.User,
+				HeadCommit:   job.info.HeadCommit,
+				TraceID:      job.info.TraceID,
+				StartedAt:    job.startedAt,
+				CompletedAt:  time.Now(),
+				Lines:        slices.Clone(outputBuffer.Buffer),
+			})
+		}
 	}
+	delete(p.runningJobs, jobID)
 
 	// Close active receiver channels
 	if openChannels, ok := p.receiverBuffers[jobID]; ok {
@@ -231,6 +283,12 @@ func (p *AsyncProjectCommandOutputHandler) completeJob(jobID string) {
 
 }
 
+func (p *AsyncProjectCommandOutputHandler) archiveJob(log JobLog) {
+	if err := p.jobLogSink.Archive(log); err != nil {
+		p.logger.Err("archiving output of job %s: %s", log.JobID, err)
+	}
+}
+
 func (p *AsyncProjectCommandOutputHandler) addChan(ch chan string, jobID string) {
 	p.projectOutputBuffersLock.RLock()
 	outputBuffer := p.projectOutputBuffers[jobID]
@@ -257,7 +315,7 @@ func (p *AsyncProjectCommandOutputHandler) addChan(ch chan string, jobID string)
 }
 
 // Add log line to buffer and send to all current channels
-func (p *AsyncProjectCommandOutputHandler) writeLogLine(jobID string, line string) {
+func (p *AsyncProjectCommandOutputHandler) writeLogLine(jobID string, info JobInfo, line string) {
 	p.receiverBuffersLock.Lock()
 	for ch := range p.receiverBuffers[jobID] {
 		select {
@@ -272,12 +330,16 @@ func (p *AsyncProjectCommandOutputHandler) writeLogLine(jobID string, line strin
 	p.projectOutputBuffersLock.Lock()
 	if _, ok := p.projectOutputBuffers[jobID]; !ok {
 		p.projectOutputBuffers[jobID] = OutputBuffer{
-			Buffer: []string{},
+			Buffer:  []string{},
+			TraceID: info.TraceID,
 		}
 	}
 	outputBuffer := p.projectOutputBuffers[jobID]
 	outputBuffer.Buffer = append(outputBuffer.Buffer, line)
 	p.projectOutputBuffers[jobID] = outputBuffer
+	if _, ok := p.runningJobs[jobID]; !ok && !outputBuffer.OperationComplete {
+		p.runningJobs[jobID] = runningJob{info: info, startedAt: time.Now()}
+	}
 
 	p.projectOutputBuffersLock.Unlock()
 }
@@ -302,6 +364,12 @@ func (p *AsyncProjectCommandOutputHandler) GetProjectOutputBuffer(jobID string)
 	return p.projectOutputBuffers[jobID]
 }
 
+func (p *AsyncProjectCommandOutputHandler) GetJobTraceID(jobID string) string {
+	p.projectOutputBuffersLock.RLock()
+	defer p.projectOutputBuffersLock.RUnlock()
+	return p.projectOutputBuffers[jobID].TraceID
+}
+
 func (p *AsyncProjectCommandOutputHandler) GetJobIDMapForPull(pullInfo PullInfo) map[string]JobIDInfo {
 	result := make(map[string]JobIDInfo)
 	if value, ok := p.pullToJobMapping.Load(pullInfo); ok {
@@ -322,6 +390,7 @@ func (p *AsyncProjectCommandOutputHandler) CleanUp(pullInfo PullInfo) {
 			jobID := k.(string)
 			p.projectOutputBuffersLock.Lock()
 			delete(p.projectOutputBuffers, jobID)
+			delete(p.runningJobs, jobID)
 			p.projectOutputBuffersLock.Unlock()
 
 			p.receiverBuffersLock.Lock()
@@ -360,3 +429,7 @@ func (p *NoopProjectOutputHandler) IsKeyExists(_ string) bool {
 func (p *NoopProjectOutputHandler) GetPullToJobMapping() []PullInfoWithJobIDs {
 	return []PullInfoWithJobIDs{}
 }
+
+func (p *NoopProjectOutputHandler) GetJobTraceID(_ string) string {
+	return ""
+}
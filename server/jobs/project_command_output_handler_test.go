@@ -52,6 +52,7 @@ func createProjectCommandOutputHandler(t *testing.T) jobs.ProjectCommandOutputHa
 	prjCmdOutputHandler := jobs.NewAsyncProjectCommandOutputHandler(
 		prjCmdOutputChan,
 		logger,
+		nil,
 	)
 
 	go func() {
@@ -257,11 +258,53 @@ func TestProjectCommandOutputHandler(t *testing.T) {
 	})
 }
 
+// chanJobLogSink sends each archived log on a channel.
+type chanJobLogSink struct {
+	archived chan jobs.JobLog
+}
+
+func (c *chanJobLogSink) Archive(log jobs.JobLog) error {
+	c.archived <- log
+	return nil
+}
+
+func (c *chanJobLogSink) Get(_ string) (*jobs.JobLog, error) { return nil, nil }
+
+func (c *chanJobLogSink) TraceID(_ string) (string, error) { return "", nil }
+
+func (c *chanJobLogSink) DeleteBefore(_ time.Time) (int, error) { return 0, nil }
+
+func TestProjectCommandOutputHandler_ArchivesCompletedJobs(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	sink := &chanJobLogSink{archived: make(chan jobs.JobLog, 1)}
+	handler := jobs.NewAsyncProjectCommandOutputHandler(make(chan *jobs.ProjectCmdOutputLine), logger, sink)
+	go handler.Handle()
+
+	ctx := createTestProjectCmdContext(t)
+	ctx.CommandName = command.Plan
+	handler.Send(ctx, "line 1", false)
+	handler.Send(ctx, "line 2", false)
+	handler.Send(ctx, "", true)
+
+	log := <-sink.archived
+	Equals(t, "1234", log.JobID)
+	Equals(t, ctx.BaseRepo.FullName, log.RepoFullName)
+	Equals(t, 1, log.PullNum)
+	Equals(t, "test-project", log.ProjectName)
+	Equals(t, "test-dir", log.Path)
+	Equals(t, "myworkspace", log.Workspace)
+	Equals(t, "plan", log.Command)
+	Equals(t, "test-user", log.User)
+	Equals(t, "234r232432", log.HeadCommit)
+	Equals(t, []string{"line 1", "line 2"}, log.Lines)
+	Assert(t, !log.CompletedAt.Before(log.StartedAt), "expected job to complete after it started")
+}
+
 // TestRaceConditionPrevention tests that our fixes prevent the specific race conditions
 func TestRaceConditionPrevention(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	prjCmdOutputChan := make(chan *jobs.ProjectCmdOutputLine)
-	handler := jobs.NewAsyncProjectCommandOutputHandler(prjCmdOutputChan, logger)
+	handler := jobs.NewAsyncProjectCommandOutputHandler(prjCmdOutputChan, logger, nil)
 
 	// Start the handler
 	go handler.Handle()
@@ -360,7 +403,7 @@ func TestHighConcurrencyStress(t *testing.T) {
 
 	logger := logging.NewNoopLogger(t)
 	prjCmdOutputChan := make(chan *jobs.ProjectCmdOutputLine)
-	handler := jobs.NewAsyncProjectCommandOutputHandler(prjCmdOutputChan, logger)
+	handler := jobs.NewAsyncProjectCommandOutputHandler(prjCmdOutputChan, logger, nil)
 
 	// Start the handler
 	go handler.Handle()
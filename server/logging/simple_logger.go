@@ -7,6 +7,7 @@ package logging
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -57,7 +58,12 @@ type StructuredLogger struct {
 	// This doesn't really make sense to keep given that structured logging
 	// gives us the ability to query our logs across multiple dimensions
 	// I don't believe we should mix this in with atlantis commands and expose this to the user
-	history bytes.Buffer
+	// It's a pointer so that loggers derived with WithHistory and WithContext
+	// write to the same history.
+	history *bytes.Buffer
+	// ctx is carried along with the logger so that code which is only passed
+	// a logger can still start trace spans under the command it's running for.
+	ctx context.Context
 }
 
 func NewStructuredLoggerFromLevel(lvl LogLevel) (SimpleLogging, error) {
@@ -98,6 +104,7 @@ func (l *StructuredLogger) With(a ...any) SimpleLogging {
 	return &StructuredLogger{
 		z:     l.z.With(a...),
 		level: l.level,
+		ctx:   l.ctx,
 	}
 }
 
@@ -105,16 +112,23 @@ func (l *StructuredLogger) WithHistory(a ...any) SimpleLogging {
 	logger := &StructuredLogger{
 		z:     l.z.With(a...),
 		level: l.level,
+		ctx:   l.ctx,
 	}
 
 	// ensure that the history is kept across loggers.
 	logger.keepHistory = true
 	logger.history = l.history
+	if logger.history == nil {
+		logger.history = &bytes.Buffer{}
+	}
 
 	return logger
 }
 
 func (l *StructuredLogger) GetHistory() string {
+	if l.history == nil {
+		return ""
+	}
 	return l.history.String()
 }
 
@@ -166,7 +180,32 @@ func (l *StructuredLogger) saveToHistory(lvl LogLevel, format string, a ...any)
 		return
 	}
 	msg := fmt.Sprintf(format, a...)
-	fmt.Fprintf(&l.history, "[%s] %s\n", lvl.shortStr, msg)
+	fmt.Fprintf(l.history, "[%s] %s\n", lvl.shortStr, msg)
+}
+
+// WithContext returns a copy of logger that carries ctx. Loggers other than
+// StructuredLogger can't carry a context and are returned as is.
+func WithContext(logger SimpleLogging, ctx context.Context) SimpleLogging {
+	l, ok := logger.(*StructuredLogger)
+	if !ok || l == nil {
+		return logger
+	}
+	return &StructuredLogger{
+		z:           l.z,
+		level:       l.level,
+		keepHistory: l.keepHistory,
+		history:     l.history,
+		ctx:         ctx,
+	}
+}
+
+// Context returns the context carried by logger, or context.Background() if
+// it doesn't carry one.
+func Context(logger SimpleLogging) context.Context {
+	if l, ok := logger.(*StructuredLogger); ok && l != nil && l.ctx != nil {
+		return l.ctx
+	}
+	return context.Background()
 }
 
 // NewNoopLogger creates a logger instance that discards all logs and never
@@ -37,10 +37,12 @@ func (l *RequestLogger) ServeHTTP(rw http.ResponseWriter, r *http.Request, next
 	allowed := false
 	if !l.WebAuthentication ||
 		r.URL.Path == "/events" ||
+		r.URL.Path == "/slack/interactions" ||
 		r.URL.Path == "/healthz" ||
 		r.URL.Path == "/readyz" ||
 		r.URL.Path == "/status" ||
-		strings.HasPrefix(r.URL.Path, "/api/") {
+		strings.HasPrefix(r.URL.Path, "/api/") ||
+		strings.HasPrefix(r.URL.Path, "/providers/") {
 		allowed = true
 	} else {
 		user, pass, ok := r.BasicAuth()
@@ -34,6 +34,12 @@ func TestRequestLogger_WebAuthentication(t *testing.T) {
 			expStatus:      http.StatusNoContent,
 			expNextHandler: true,
 		},
+		{
+			name:           "provider mirror is public",
+			path:           "/providers/registry.terraform.io/hashicorp/null/index.json",
+			expStatus:      http.StatusNoContent,
+			expNextHandler: true,
+		},
 		{
 			name:           "root remains protected",
 			path:           "/",
@@ -0,0 +1,63 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduled_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/scheduled"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestParseCronSchedule_Next(t *testing.T) {
+	// Wednesday.
+	from := time.Date(2025, time.January, 15, 10, 17, 30, 0, time.UTC)
+	cases := []struct {
+		expr string
+		exp  time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.January, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2025, time.January, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,20 * *", time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match.
+		{"0 0 20 * mon", time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			schedule, err := scheduled.ParseCronSchedule(c.expr)
+			Ok(t, err)
+			Equals(t, c.expr, schedule.String())
+			Equals(t, c.exp, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"":              `cron expression "" must have 5 fields, got 0`,
+		"* * * *":       `cron expression "* * * *" must have 5 fields, got 4`,
+		"60 * * * *":    "value 60 out of range [0-59] in minute field",
+		"* * 0 * *":     "value 0 out of range [1-31] in day of month field",
+		"* * * foo *":   `invalid value "foo" in month field`,
+		"*/0 * * * *":   `invalid step "0" in minute field "*/0"`,
+		"* 5-2 * * *":   `invalid range "5-2" in hour field`,
+		"* * * * mon-x": `invalid value "x" in day of week field`,
+	}
+	for expr, expErr := range cases {
+		t.Run(expr, func(t *testing.T) {
+			_, err := scheduled.ParseCronSchedule(expr)
+			ErrEquals(t, expErr, err)
+		})
+	}
+}
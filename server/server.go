@@ -38,19 +38,23 @@ import (
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/planstore"
 	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/core/terraform/providercache"
 	"github.com/runatlantis/atlantis/server/core/terraform/tfclient"
 	"github.com/runatlantis/atlantis/server/jobs"
 	"github.com/runatlantis/atlantis/server/metrics"
 	"github.com/runatlantis/atlantis/server/scheduled"
 
 	"github.com/gorilla/mux"
+	"github.com/runatlantis/atlantis/server/audit"
 	"github.com/runatlantis/atlantis/server/controllers"
 	events_controllers "github.com/runatlantis/atlantis/server/controllers/events"
 	"github.com/runatlantis/atlantis/server/controllers/web_templates"
 	"github.com/runatlantis/atlantis/server/controllers/websocket"
 	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/core/runtime"
+	"github.com/runatlantis/atlantis/server/core/runtime/cost"
 	"github.com/runatlantis/atlantis/server/core/runtime/policy"
 	"github.com/runatlantis/atlantis/server/core/terraform"
 	"github.com/runatlantis/atlantis/server/events"
@@ -67,6 +71,7 @@ import (
 	"github.com/runatlantis/atlantis/server/events/webhooks"
 	"github.com/runatlantis/atlantis/server/i18n"
 	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
 )
 
 const (
@@ -86,6 +91,9 @@ const (
 	// terraformPluginCacheDir is the name of the dir inside our data dir
 	// where we tell terraform to cache plugins and modules.
 	TerraformPluginCacheDirName = "plugin-cache"
+	// JobLogsDirName is the name of the dir inside our data dir where job
+	// output is archived by default when the local job log store is used.
+	JobLogsDirName = "job-logs"
 )
 
 // Server runs the Atlantis web server.
@@ -101,6 +109,8 @@ type Server struct {
 	StatsScope                     tally.Scope
 	StatsReporter                  tally.BaseStatsReporter
 	StatsCloser                    io.Closer
+	TracingShutdown                func(context.Context) error
+	AuditLog                       *audit.Log
 	Locker                         locking.Locker
 	ApplyLocker                    locking.ApplyLocker
 	VCSEventsController            *events_controllers.VCSEventsController
@@ -109,6 +119,9 @@ type Server struct {
 	StatusController               *controllers.StatusController
 	JobsController                 *controllers.JobsController
 	APIController                  *controllers.APIController
+	SlackInteractionsController    *controllers.SlackInteractionsController
+	DriftDashboardController       *controllers.DriftDashboardController
+	ProviderMirror                 *providercache.Mirror
 	IndexTemplate                  web_templates.TemplateWriter
 	LockDetailTemplate             web_templates.TemplateWriter
 	ProjectJobsTemplate            web_templates.TemplateWriter
@@ -162,6 +175,56 @@ type WebhookConfig struct {
 	URL string `mapstructure:"url"`
 }
 
+// APITokenConfig is nested within UserConfig. It configures a named API token
+// with limited scopes, e.g. for a CI pipeline that only needs to plan.
+type APITokenConfig struct {
+	// Name identifies the token. It is recorded as the acting user on locks
+	// and remediation results.
+	Name string `mapstructure:"name"`
+	// TokenSHA256 is the hex encoded SHA-256 digest of the token. The token
+	// itself is never stored.
+	TokenSHA256 string `mapstructure:"token-sha256"`
+	// Scopes are the API permissions granted to the token, ex. plan or
+	// drift:read.
+	Scopes []string `mapstructure:"scopes"`
+	// Repos restricts the token to repositories matching this pattern, in the
+	// same format as --repo-allowlist.
+	Repos string `mapstructure:"repos"`
+}
+
+// APIOIDCIssuerConfig is nested within UserConfig. It configures an OIDC
+// issuer, ex. GitHub Actions, whose tokens are accepted by the API.
+type APIOIDCIssuerConfig struct {
+	// Issuer must match the iss claim of tokens, ex.
+	// https://token.actions.githubusercontent.com.
+	Issuer string `mapstructure:"issuer"`
+	// Audience must be one of the aud claims of tokens.
+	Audience string `mapstructure:"audience"`
+	// JWKSFile is a local file with the issuer's signing keys. Either it or
+	// JWKSURL must be set.
+	JWKSFile string `mapstructure:"jwks-file"`
+	// JWKSURL is where the issuer publishes its signing keys.
+	JWKSURL string `mapstructure:"jwks-url"`
+	// Rules map token claims to API access. The first matching rule is used.
+	Rules []APIOIDCRuleConfig `mapstructure:"rules"`
+}
+
+// APIOIDCRuleConfig is nested within APIOIDCIssuerConfig.
+type APIOIDCRuleConfig struct {
+	// Repository, Ref and Environment are regexes matched against the whole
+	// claim of the same name. Repository is required.
+	Repository  string `mapstructure:"repository"`
+	Ref         string `mapstructure:"ref"`
+	Environment string `mapstructure:"environment"`
+	// Scopes are the API permissions granted, ex. plan or apply.
+	Scopes []string `mapstructure:"scopes"`
+	// Repos restricts the repositories the caller may act on, in the same
+	// format as --repo-allowlist.
+	Repos string `mapstructure:"repos"`
+	// Refs is a regex restricting the refs the caller may plan and apply.
+	Refs string `mapstructure:"refs"`
+}
+
 //go:embed static
 var staticAssets embed.FS
 
@@ -182,6 +245,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 
 	var supportedVCSHosts []models.VCSHostType
 	var githubClient github.IGithubClient
+	var githubCheckRuns events.CheckRunUpdater
 	var githubAppEnabled bool
 	var githubConfig github.Config
 	var githubCredentials github.Credentials
@@ -227,6 +291,14 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		return nil, fmt.Errorf("instantiating metrics scope: %w", err)
 	}
 
+	tracingShutdown := func(context.Context) error { return nil }
+	if userConfig.TracingOTLPEndpoint != "" {
+		tracingShutdown, err = tracing.Setup(userConfig.TracingOTLPEndpoint, config.AtlantisVersion)
+		if err != nil {
+			return nil, fmt.Errorf("initializing tracing: %w", err)
+		}
+	}
+
 	if userConfig.GithubUser != "" || userConfig.GithubAppID != 0 {
 		if userConfig.GithubAllowMergeableBypassApply {
 			githubConfig = github.Config{
@@ -271,6 +343,9 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		}
 
 		githubClient = github.NewInstrumentedGithubClient(rawGithubClient, statsScope, logger)
+		if userConfig.GithubCheckRuns {
+			githubCheckRuns = githubClient
+		}
 	}
 	if userConfig.GitlabUser != "" {
 		supportedVCSHosts = append(supportedVCSHosts, models.Gitlab)
@@ -385,6 +460,37 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		userConfig.AutoplanModulesFromProjects = userConfig.AutoplanFileList
 	}
 
+	var apiTokens []*controllers.APIToken
+	for _, c := range userConfig.APITokens {
+		token, err := controllers.NewAPIToken(c.Name, c.TokenSHA256, c.Scopes, c.Repos)
+		if err != nil {
+			return nil, fmt.Errorf("parsing api-tokens: %w", err)
+		}
+		apiTokens = append(apiTokens, token)
+	}
+	if err := controllers.ValidateAPITokens(apiTokens); err != nil {
+		return nil, fmt.Errorf("parsing api-tokens: %w", err)
+	}
+	var oidcIssuers []*controllers.OIDCIssuer
+	for _, c := range userConfig.APIOIDCIssuers {
+		var rules []controllers.OIDCRule
+		for _, r := range c.Rules {
+			rules = append(rules, controllers.OIDCRule{
+				Repository:  r.Repository,
+				Ref:         r.Ref,
+				Environment: r.Environment,
+				Scopes:      r.Scopes,
+				Repos:       r.Repos,
+				Refs:        r.Refs,
+			})
+		}
+		issuer, err := controllers.NewOIDCIssuer(c.Issuer, c.Audience, c.JWKSFile, c.JWKSURL, rules)
+		if err != nil {
+			return nil, fmt.Errorf("parsing api-oidc-issuers: %w", err)
+		}
+		oidcIssuers = append(oidcIssuers, issuer)
+	}
+
 	var webhooksConfig []webhooks.Config
 	for _, c := range userConfig.Webhooks {
 		config := webhooks.Config{
@@ -409,8 +515,28 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("initializing webhooks: %w", err)
 	}
-	vcsClient := vcs.NewClientProxy(githubClient, gitlabClient, bitbucketCloudClient, bitbucketServerClient, azuredevopsClient, giteaClient)
-	commitStatusUpdater := &events.DefaultCommitStatusUpdater{Client: vcsClient, StatusName: userConfig.VCSStatusName}
+	// Audit webhooks are sent in the background so that they don't hold up
+	// the audited action. The timeout keeps a slow endpoint from backing up
+	// the queue.
+	auditWebhookClients := webhookClients
+	auditWebhookClients.Http = &webhooks.HttpClient{Client: &http.Client{Timeout: 10 * time.Second}, Headers: webhookHeaders}
+	auditSinks, err := webhooks.NewAuditWebhookSinks(webhooksConfig, auditWebhookClients)
+	if err != nil {
+		return nil, fmt.Errorf("initializing audit webhooks: %w", err)
+	}
+	for i, sink := range auditSinks {
+		auditSinks[i] = audit.NewAsyncSink(logger, sink, audit.AsyncSinkBufferSize)
+	}
+	if userConfig.AuditLogFile != "" {
+		auditFileSink, err := audit.NewFileSink(userConfig.AuditLogFile)
+		if err != nil {
+			return nil, fmt.Errorf("initializing audit log: %w", err)
+		}
+		auditSinks = append([]audit.Sink{auditFileSink}, auditSinks...)
+	}
+	auditLog := audit.NewLog(logger, auditSinks...)
+	vcsClient := vcs.NewTracedClient(vcs.NewClientProxy(githubClient, gitlabClient, bitbucketCloudClient, bitbucketServerClient, azuredevopsClient, giteaClient))
+	commitStatusUpdater := &events.DefaultCommitStatusUpdater{Client: vcsClient, StatusName: userConfig.VCSStatusName, CheckRuns: githubCheckRuns}
 
 	binDir, err := mkSubDir(userConfig.DataDir, BinDirName)
 
@@ -439,15 +565,23 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	}
 
 	var projectCmdOutputHandler jobs.ProjectCommandOutputHandler
+	var jobLogSink jobs.JobLogSink
 
 	if userConfig.TFEToken != "" && !userConfig.TFELocalExecutionMode {
 		// When TFE is enabled and using remote execution mode log streaming is not necessary.
 		projectCmdOutputHandler = &jobs.NoopProjectOutputHandler{}
 	} else {
+		if userConfig.EnableExternalStores {
+			jobLogSink, err = newJobLogSink(globalCfg.ExternalStores.JobLogs, userConfig.DataDir, logger)
+			if err != nil {
+				return nil, fmt.Errorf("initializing job log store: %w", err)
+			}
+		}
 		projectCmdOutput := make(chan *jobs.ProjectCmdOutputLine)
 		projectCmdOutputHandler = jobs.NewAsyncProjectCommandOutputHandler(
 			projectCmdOutput,
 			logger,
+			jobLogSink,
 		)
 	}
 
@@ -472,6 +606,23 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	if err != nil && flag.Lookup("test.v") == nil {
 		return nil, fmt.Errorf("initializing %s: %w", userConfig.DefaultTFDistribution, err)
 	}
+	var providerCache *providercache.Cache
+	if terraformClient != nil && terraformClient.ProviderCache() != nil {
+		providerCache = terraformClient.ProviderCache()
+		providerCache.SetMaxSize(int64(userConfig.TFPluginCacheMaxSizeMB) * 1024 * 1024)
+		// Providers from before each project got its own cache dir are outside
+		// the store, so move them in and apply the size limit to them.
+		if err := providerCache.MigrateLegacy(logger); err != nil {
+			logger.Warn("migrating provider cache: %s", err)
+		}
+		if err := providerCache.Evict(logger); err != nil {
+			logger.Warn("evicting providers from cache: %s", err)
+		}
+	}
+	var providerMirror *providercache.Mirror
+	if userConfig.TFProviderMirrorDir != "" {
+		providerMirror = providercache.NewMirror(userConfig.TFProviderMirrorDir)
+	}
 	markdownRenderer := events.NewMarkdownRenderer(
 		gitlabClient.SupportsCommonMark(),
 		userConfig.DisableApplyAll,
@@ -539,6 +690,13 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	} else {
 		lockingClient = locking.NewClient(database)
 	}
+	// lockQueueRunner's CommandRunner is set once the command runner exists.
+	var lockQueue locking.LockQueue
+	var lockQueueRunner *events.LockQueueRunner
+	if userConfig.EnableLockQueue {
+		lockQueue = locking.NewClient(database)
+		lockQueueRunner = &events.LockQueueRunner{LockQueue: lockQueue}
+	}
 	disableGlobalApplyLock := userConfig.DisableGlobalApplyLock
 
 	applyLockingClient = locking.NewApplyClient(database, disableApply, disableGlobalApplyLock)
@@ -574,6 +732,8 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		}
 		scheduledExecutorService.AddJob(tokenJd)
 	}
+	workingDir = &events.TracedWorkingDir{WorkingDir: workingDir}
+	commitStatusUpdater.WorkingDir = workingDir
 
 	if userConfig.GithubUser != "" && userConfig.GithubTokenFile != "" && userConfig.WriteGitCreds {
 		githubTokenRotator := github.NewTokenRotator(logger, githubCredentials, userConfig.GithubHostname, userConfig.GithubUser, home)
@@ -585,12 +745,14 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	}
 
 	projectLocker := &events.DefaultProjectLocker{
-		Locker:         lockingClient,
-		NoOpLocker:     noOpLocker,
-		VCSClient:      vcsClient,
-		ExecutableName: userConfig.ExecutableName,
+		Locker:          lockingClient,
+		NoOpLocker:      noOpLocker,
+		VCSClient:       vcsClient,
+		ExecutableName:  userConfig.ExecutableName,
+		LockQueueRunner: lockQueueRunner,
 	}
 	deleteLockCommand := &events.DefaultDeleteLockCommand{
+		LockQueueRunner:  lockQueueRunner,
 		Locker:           lockingClient,
 		WorkingDir:       workingDir,
 		WorkingDirLocker: workingDirLocker,
@@ -690,9 +852,38 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 			if err != nil {
 				return nil, fmt.Errorf("initializing S3 plan store: %w", err)
 			}
+		case "gcs":
+			logger.Info("initializing GCS plan store (bucket=%s)", psCfg.GCS.Bucket)
+			planStore, err = planstore.NewGCSPlanStore(planstore.GCSPlanStoreConfig{
+				Bucket:          psCfg.GCS.Bucket,
+				Prefix:          psCfg.GCS.Prefix,
+				Endpoint:        psCfg.GCS.Endpoint,
+				CredentialsFile: psCfg.GCS.CredentialsFile,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("initializing GCS plan store: %w", err)
+			}
+		case "azureblob":
+			logger.Info("initializing Azure Blob plan store (account=%s, container=%s)", psCfg.AzureBlob.AccountName, psCfg.AzureBlob.Container)
+			planStore, err = planstore.NewAzureBlobPlanStore(planstore.AzureBlobPlanStoreConfig{
+				AccountName: psCfg.AzureBlob.AccountName,
+				Container:   psCfg.AzureBlob.Container,
+				Prefix:      psCfg.AzureBlob.Prefix,
+				Endpoint:    psCfg.AzureBlob.Endpoint,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("initializing Azure Blob plan store: %w", err)
+			}
 		default:
 			return nil, fmt.Errorf("unsupported plan store type %q", psCfg.Type)
 		}
+		if psCfg.Encryption != nil {
+			planStore, err = newEncryptedPlanStore(planStore, *psCfg.Encryption)
+			if err != nil {
+				return nil, fmt.Errorf("initializing plan store encryption: %w", err)
+			}
+			logger.Info("plan store encryption is enabled")
+		}
 	} else {
 		planStore = &runtime.LocalPlanStore{}
 	}
@@ -710,6 +901,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 			LogStreamResourceCleaner: projectCmdOutputHandler,
 			VCSClient:                vcsClient,
 			PlanStore:                planStore,
+			LockQueueRunner:          lockQueueRunner,
 		},
 	)
 
@@ -758,6 +950,14 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		return nil, fmt.Errorf("initializing policy check step runner: %w", err)
 	}
 
+	var pricingTable *cost.PricingTable
+	if userConfig.CostPricingFile != "" {
+		pricingTable, err = cost.LoadPricingTable(userConfig.CostPricingFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	applyRequirementHandler := &events.DefaultCommandRequirementHandler{
 		WorkingDir:    workingDir,
 		VCSStatusName: userConfig.VCSStatusName,
@@ -776,6 +976,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	projectCommandRunner := &events.DefaultProjectCommandRunner{
 		VcsClient:        vcsClient,
 		Locker:           projectLocker,
+		LockQueue:        lockQueue,
 		LockURLGenerator: router,
 		Logger:           logger,
 		InitStepRunner: &runtime.InitStepRunner{
@@ -783,9 +984,10 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 			DefaultTFDistribution: defaultTfDistribution,
 			DefaultTFVersion:      defaultTfVersion,
 		},
-		PlanStepRunner:        runtime.NewPlanStepRunner(terraformClient, defaultTfDistribution, defaultTfVersion, commitStatusUpdater, terraformClient, planStore),
-		ShowStepRunner:        showStepRunner,
-		PolicyCheckStepRunner: policyCheckStepRunner,
+		PlanStepRunner:         runtime.NewPlanStepRunner(terraformClient, defaultTfDistribution, defaultTfVersion, commitStatusUpdater, terraformClient, planStore),
+		ShowStepRunner:         showStepRunner,
+		PolicyCheckStepRunner:  policyCheckStepRunner,
+		CostEstimateStepRunner: runtime.NewCostEstimateStepRunner(pricingTable),
 		ApplyStepRunner: &runtime.ApplyStepRunner{
 			TerraformExecutor:     terraformClient,
 			DefaultTFDistribution: defaultTfDistribution,
@@ -816,6 +1018,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		CancellationTracker:       cancellationTracker,
 		ApplyPlanValidator:        &events.DefaultApplyPlanValidator{PullStatusFetcher: database, LivePullHeadFetcher: livePullHeadFetcher},
 		PlanStore:                 planStore,
+		Database:                  database,
 	}
 
 	dbUpdater := &events.DBUpdater{
@@ -841,7 +1044,11 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	}
 	instrumentedProjectCmdRunner := events.NewInstrumentedProjectCommandRunner(
 		statsScope,
-		projectOutputWrapper,
+		&events.AuditedProjectCommandRunner{
+			ProjectCommandRunner: projectOutputWrapper,
+			AuditLog:             auditLog,
+			PullStatusFetcher:    database,
+		},
 	)
 
 	policyCheckCommandRunner := events.NewPolicyCheckCommandRunner(
@@ -878,6 +1085,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		pullReqStatusFetcher,
 		userConfig.PendingApplyStatus,
 	)
+	planCommandRunner.LockQueueRunner = lockQueueRunner
 
 	applyCommandRunner := events.NewApplyCommandRunner(
 		vcsClient,
@@ -917,6 +1125,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		userConfig.SilenceNoProjects,
 		userConfig.DisableUnlockLabel,
 	)
+	unlockCommandRunner.AuditLog = auditLog
 
 	versionCommandRunner := events.NewVersionCommandRunner(
 		pullUpdater,
@@ -1012,6 +1221,9 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		VarFileAllowlistChecker:        varFileAllowlistChecker,
 		CommitStatusUpdater:            commitStatusUpdater,
 	}
+	if lockQueueRunner != nil {
+		lockQueueRunner.CommandRunner = commandRunner
+	}
 	repoAllowlist, err := events.NewRepoAllowlistChecker(userConfig.RepoAllowlist)
 	if err != nil {
 		return nil, err
@@ -1028,6 +1240,8 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		WorkingDirLocker:   workingDirLocker,
 		Database:           database,
 		DeleteLockCommand:  deleteLockCommand,
+		LockQueue:          lockQueue,
+		AuditLog:           auditLog,
 	}
 
 	wsMux := websocket.NewMultiplexor(
@@ -1047,10 +1261,15 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		WsMux:                    wsMux,
 		KeyGenerator:             controllers.JobIDKeyGenerator{},
 		StatsScope:               statsScope.SubScope("api"),
+		JobRegistry:              projectCmdOutputHandler,
+		JobLogSink:               jobLogSink,
+		JobTraces:                projectCmdOutputHandler,
 	}
 
 	apiController := &controllers.APIController{
 		APISecret:                       []byte(userConfig.APISecret),
+		APITokens:                       apiTokens,
+		OIDCIssuers:                     oidcIssuers,
 		Locker:                          lockingClient,
 		Logger:                          logger,
 		Parser:                          eventParser,
@@ -1059,6 +1278,12 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		ProjectPolicyCheckCommandRunner: instrumentedProjectCmdRunner,
 		ProjectApplyCommandRunner:       instrumentedProjectCmdRunner,
 		ApplyLockChecker:                applyLockingClient,
+		ApplyLocker:                     applyLockingClient,
+		AuditLog:                        auditLog,
+		ProviderCache:                   providerCache,
+		ProviderMirror:                  providerMirror,
+		DeleteLockCommand:               deleteLockCommand,
+		Database:                        database,
 		EnableDriftRemediation:          userConfig.EnableDriftRemediation,
 		FailOnPreWorkflowHookError:      userConfig.FailOnPreWorkflowHookError,
 		PreWorkflowHooksCommandRunner:   preWorkflowHooksCommandRunner,
@@ -1075,17 +1300,101 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		SilenceVCSStatusNoProjects:      userConfig.SilenceVCSStatusNoProjects,
 	}
 
+	var slackInteractionsController *controllers.SlackInteractionsController
+	var driftDashboardController *controllers.DriftDashboardController
 	if userConfig.EnableDriftDetection {
 		logger.Info("Drift detection is enabled")
-		driftStorage := drift.NewInMemoryStorage()
+		driftStorage, err := newDriftStorage(database)
+		if err != nil {
+			return nil, fmt.Errorf("initializing drift storage: %w", err)
+		}
 		apiController.DriftStorage = driftStorage
-		apiController.RemediationService = drift.NewInMemoryRemediationService(driftStorage)
+		driftMetrics := drift.NewMetrics(statsScope)
+		apiController.DriftMetrics = driftMetrics
+		scheduledExecutorService.AddJob(scheduled.JobDefinition{
+			Job:    drift.NewMetricsPublisher(driftStorage, driftMetrics, logger),
+			Period: drift.MetricsPublishPeriod,
+		})
+		remediationStore, err := newRemediationStore(database)
+		if err != nil {
+			return nil, fmt.Errorf("initializing remediation store: %w", err)
+		}
+		remediationService := drift.NewRemediationService(driftStorage, remediationStore, userConfig.DriftRemediationWorkers, drift.RemediationRetention{
+			MaxAge:           time.Duration(userConfig.DriftRemediationRetentionDays) * 24 * time.Hour,
+			MaxPerRepository: userConfig.DriftRemediationMaxResults,
+		}, driftMetrics)
+		remediationRecoverer := drift.NewRemediationRecoverer(remediationService, logger)
+		remediationRecoverer.Run()
+		scheduledExecutorService.AddJob(scheduled.JobDefinition{
+			Job:    remediationRecoverer,
+			Period: drift.RemediationHeartbeatInterval,
+		})
+		apiController.RemediationService = remediationService
 
-		driftWebhookSender, err := webhooks.NewDriftWebhookSender(webhooksConfig, webhookClients)
+		slackInteractive := userConfig.SlackSigningSecret != ""
+		driftWebhookSender, err := webhooks.NewDriftWebhookSender(webhooksConfig, webhookClients, slackInteractive, userConfig.EnableDriftRemediation)
 		if err != nil {
 			return nil, fmt.Errorf("initializing drift webhooks: %w", err)
 		}
 		apiController.DriftWebhookSender = driftWebhookSender
+		if slackInteractive {
+			slackInteractionsController = &controllers.SlackInteractionsController{
+				Logger:             logger,
+				SigningSecret:      userConfig.SlackSigningSecret,
+				AllowedUsers:       userConfig.ToSlackRemediationAllowlist(),
+				AllowApply:         userConfig.EnableDriftRemediation,
+				Remediator:         apiController,
+				RemediationService: remediationService,
+				Slack:              webhookClients.Slack,
+			}
+		}
+
+		driftDashboardController = &controllers.DriftDashboardController{
+			AtlantisVersion:   config.AtlantisVersion,
+			AtlantisURL:       parsedURL,
+			Logger:            logger,
+			DriftStorage:      driftStorage,
+			DashboardTemplate: web_templates.DriftTemplate,
+			PlanTemplate:      web_templates.DriftPlanTemplate,
+		}
+		// Remediation buttons are only offered when the web UI requires
+		// authentication, since anyone who can reach it could use them.
+		if userConfig.EnableDriftRemediation && userConfig.WebBasicAuth {
+			driftDashboardController.Remediator = apiController
+		}
+
+		if err := addDriftDetectionJobs(scheduledExecutorService, apiController, globalCfg, userConfig, logger); err != nil {
+			return nil, err
+		}
+	} else if slices.ContainsFunc(globalCfg.Repos, func(repo valid.Repo) bool { return repo.DriftDetection != nil }) {
+		logger.Warn("drift_detection is configured in the server-side repo config but --enable-drift-detection is not set, scheduled drift detection is disabled")
+	}
+
+	if jobLogSink != nil && globalCfg.ExternalStores.JobLogs.Retention > 0 {
+		scheduledExecutorService.AddJob(scheduled.JobDefinition{
+			Job: &jobs.JobLogRetentionJob{
+				Sink:      jobLogSink,
+				Retention: globalCfg.ExternalStores.JobLogs.Retention,
+				Logger:    logger,
+			},
+			Period: jobs.JobLogRetentionPeriod,
+		})
+	}
+
+	if slices.ContainsFunc(globalCfg.Repos, func(repo valid.Repo) bool {
+		return repo.LockTTL > 0 || (repo.RepoLocks != nil && repo.RepoLocks.TTL > 0)
+	}) {
+		scheduledExecutorService.AddJob(scheduled.JobDefinition{
+			Job: &events.LockExpiryJob{
+				Locker:            lockingClient,
+				DeleteLockCommand: deleteLockCommand,
+				Database:          database,
+				VCSClient:         vcsClient,
+				GlobalCfg:         globalCfg,
+				Logger:            logger,
+			},
+			Period: events.LockExpiryCheckPeriod,
+		})
 	}
 
 	eventsController := &events_controllers.VCSEventsController{
@@ -1132,6 +1441,8 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		StatsScope:                     statsScope,
 		StatsReporter:                  statsReporter,
 		StatsCloser:                    closer,
+		TracingShutdown:                tracingShutdown,
+		AuditLog:                       auditLog,
 		Locker:                         lockingClient,
 		ApplyLocker:                    applyLockingClient,
 		VCSEventsController:            eventsController,
@@ -1140,6 +1451,9 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		JobsController:                 jobsController,
 		StatusController:               statusController,
 		APIController:                  apiController,
+		SlackInteractionsController:    slackInteractionsController,
+		DriftDashboardController:       driftDashboardController,
+		ProviderMirror:                 providerMirror,
 		IndexTemplate:                  web_templates.IndexTemplate,
 		LockDetailTemplate:             web_templates.LockTemplate,
 		ProjectJobsTemplate:            web_templates.ProjectJobsTemplate,
@@ -1181,11 +1495,32 @@ func (s *Server) SetupRoutes() {
 	s.Router.HandleFunc("/api/plan", s.APIController.Plan).Methods("POST")
 	s.Router.HandleFunc("/api/apply", s.APIController.Apply).Methods("POST")
 	s.Router.HandleFunc("/api/locks", s.APIController.ListLocks).Methods("GET")
+	s.Router.HandleFunc("/api/locks", s.APIController.ReleasePullLocks).Methods("DELETE")
+	s.Router.HandleFunc("/api/lock", s.APIController.GetLock).Methods("GET")
+	s.Router.HandleFunc("/api/lock", s.APIController.DeleteLock).Methods("DELETE")
+	s.Router.HandleFunc("/api/apply/lock", s.APIController.GetApplyLock).Methods("GET")
+	s.Router.HandleFunc("/api/policies/exceptions", s.APIController.ListPolicyExceptions).Methods("GET")
+	s.Router.HandleFunc("/api/audit", s.APIController.ListAuditEvents).Methods("GET")
+	s.Router.HandleFunc("/api/cache", s.APIController.GetCacheStats).Methods("GET")
 	s.Router.HandleFunc("/api/drift/status", s.APIController.DriftStatus).Methods("GET")
 	s.Router.HandleFunc("/api/drift/detect", s.APIController.DetectDrift).Methods("POST")
 	s.Router.HandleFunc("/api/drift/remediate/{id}", s.APIController.GetRemediationResult).Methods("GET")
+	s.Router.HandleFunc("/api/drift/remediate/{id}", s.APIController.CancelRemediation).Methods("DELETE")
 	s.Router.HandleFunc("/api/drift/remediate", s.APIController.ListRemediationResults).Methods("GET")
 	s.Router.HandleFunc("/api/drift/remediate", s.APIController.Remediate).Methods("POST")
+	if s.SlackInteractionsController != nil {
+		s.Router.HandleFunc("/slack/interactions", s.SlackInteractionsController.Post).Methods("POST")
+	}
+	if s.ProviderMirror != nil {
+		s.Router.PathPrefix("/providers/").Handler(http.StripPrefix("/providers", s.ProviderMirror))
+	}
+	if s.DriftDashboardController != nil {
+		s.Router.HandleFunc("/drift", s.DriftDashboardController.Get).Methods("GET")
+		s.Router.HandleFunc("/drift/plan", s.DriftDashboardController.GetPlan).Methods("GET")
+		if s.DriftDashboardController.Remediator != nil {
+			s.Router.HandleFunc("/drift/remediate", s.DriftDashboardController.Remediate).Methods("POST")
+		}
+	}
 	s.Router.HandleFunc("/github-app/exchange-code", s.GithubAppController.ExchangeCode).Methods("GET")
 	s.Router.HandleFunc("/github-app/setup", s.GithubAppController.New).Methods("GET")
 	s.Router.HandleFunc("/locks", s.LocksController.DeleteLock).Methods("DELETE").Queries("id", "{id:.*}")
@@ -1201,6 +1536,8 @@ func (s *Server) SetupRoutes() {
 	if !s.DisableGlobalApplyLock {
 		s.Router.HandleFunc("/apply/lock", s.LocksController.LockApply).Methods("POST").Queries()
 		s.Router.HandleFunc("/apply/unlock", s.LocksController.UnlockApply).Methods("DELETE").Queries()
+		s.Router.HandleFunc("/api/apply/lock", s.APIController.LockApply).Methods("POST")
+		s.Router.HandleFunc("/api/apply/lock", s.APIController.UnlockApply).Methods("DELETE")
 	}
 
 	if s.EnableProfilingAPI {
@@ -1268,6 +1605,20 @@ func (s *Server) Start() error {
 		s.Logger.Err("%s", err.Error())
 	}
 
+	// send queued audit events before shutdown
+	auditCtx, auditCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer auditCancel()
+	if err := s.AuditLog.Close(auditCtx); err != nil {
+		s.Logger.Err("while sending audit events: %v", err)
+	}
+
+	// flush traces before shutdown
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tracingCancel()
+	if err := s.TracingShutdown(tracingCtx); err != nil {
+		s.Logger.Err("while flushing traces: %v", err)
+	}
+
 	// Attempt to close the database
 	if err := s.closeDatabase(1 * time.Second); err != nil {
 		s.Logger.Err("while closing database: %v", err)
@@ -1361,11 +1712,12 @@ func (s *Server) Index(w http.ResponseWriter, _ *http.Request) {
 	sort.SliceStable(lockResults, func(i, j int) bool { return lockResults[i].Time.After(lockResults[j].Time) })
 
 	err = s.IndexTemplate.Execute(w, web_templates.IndexData{
-		Locks:            lockResults,
-		PullToJobMapping: preparePullToJobMappings(s),
-		ApplyLock:        applyLockData,
-		AtlantisVersion:  s.AtlantisVersion,
-		CleanedBasePath:  s.AtlantisURL.Path,
+		Locks:                 lockResults,
+		PullToJobMapping:      preparePullToJobMappings(s),
+		ApplyLock:             applyLockData,
+		AtlantisVersion:       s.AtlantisVersion,
+		CleanedBasePath:       s.AtlantisURL.Path,
+		DriftDashboardEnabled: s.DriftDashboardController != nil,
 	})
 	if err != nil {
 		s.Logger.Err("%s", err.Error())
@@ -1415,6 +1767,144 @@ func mkSubDir(parentDir string, subDir string) (string, error) {
 	return fullDir, nil
 }
 
+// newDriftStorage returns a drift storage backed by the locking database so
+// drift results survive restarts and are shared between replicas. It falls
+// back to in-memory storage for database types without a drift backend.
+func newDriftStorage(database db.Database) (drift.Storage, error) {
+	switch d := database.(type) {
+	case *boltdb.BoltDB:
+		return boltdb.NewDriftStorage(d)
+	case *redis.RedisDB:
+		return redis.NewDriftStorage(d), nil
+	default:
+		return drift.NewInMemoryStorage(), nil
+	}
+}
+
+// newJobLogSink returns the job log sink configured by cfg, or nil if job
+// output isn't archived.
+func newJobLogSink(cfg valid.JobLogStoreConfig, dataDir string, logger logging.SimpleLogging) (jobs.JobLogSink, error) {
+	switch cfg.Type {
+	case "local":
+		dir := cfg.Dir
+		if dir == "" {
+			dir = filepath.Join(dataDir, JobLogsDirName)
+		}
+		logger.Info("archiving job output to %s", dir)
+		sink, err := jobs.NewLocalJobLogSink(dir)
+		if err != nil {
+			return nil, err
+		}
+		return sink, nil
+	case "s3":
+		logger.Info("archiving job output to S3 (bucket=%s, region=%s)", cfg.S3.Bucket, cfg.S3.Region)
+		client, err := planstore.NewS3Client(planstore.S3PlanStoreConfig{
+			Bucket:         cfg.S3.Bucket,
+			Region:         cfg.S3.Region,
+			Prefix:         cfg.S3.Prefix,
+			Endpoint:       cfg.S3.Endpoint,
+			ForcePathStyle: cfg.S3.ForcePathStyle,
+			Profile:        cfg.S3.Profile,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return jobs.NewS3JobLogSink(client, cfg.S3.Bucket, cfg.S3.Prefix), nil
+	}
+	return nil, nil
+}
+
+// newEncryptedPlanStore wraps planStore so plans are encrypted with the key
+// configured in the server-side repo config.
+func newEncryptedPlanStore(planStore planstore.PlanStore, cfg valid.PlanStoreEncryption) (planstore.PlanStore, error) {
+	var key []byte
+	var err error
+	if cfg.KeyFile != "" {
+		key, err = planstore.LoadKeyFromFile(cfg.KeyFile)
+	} else {
+		key, err = planstore.LoadKeyFromEnv(cfg.KeyEnv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	keys, err := planstore.NewLocalKeyManager(key)
+	if err != nil {
+		return nil, err
+	}
+	return planstore.NewEncryptedPlanStore(planStore, keys), nil
+}
+
+// newRemediationStore returns a remediation store backed by the locking
+// database so remediation history survives restarts. It falls back to
+// in-memory storage for database types without a remediation backend.
+func newRemediationStore(database db.Database) (drift.RemediationStore, error) {
+	switch d := database.(type) {
+	case *boltdb.BoltDB:
+		return boltdb.NewRemediationStore(d)
+	case *redis.RedisDB:
+		return redis.NewRemediationStore(d), nil
+	default:
+		return drift.NewInMemoryRemediationStore(), nil
+	}
+}
+
+// addDriftDetectionJobs registers a scheduled job for every server-side repo
+// config with a drift_detection block.
+func addDriftDetectionJobs(executor *scheduled.ExecutorService, detector drift.Detector, globalCfg valid.GlobalCfg, userConfig UserConfig, logger logging.SimpleLogging) error {
+	for _, repo := range globalCfg.Repos {
+		if repo.DriftDetection == nil {
+			continue
+		}
+		vcsType, repository, err := driftDetectionRepo(repo.ID, userConfig)
+		if err != nil {
+			return fmt.Errorf("configuring drift_detection for %s: %w", repo.ID, err)
+		}
+		request := models.DriftDetectionRequest{
+			Repository: repository,
+			Ref:        repo.DriftDetection.Ref,
+			Type:       vcsType.String(),
+			Projects:   repo.DriftDetection.Projects,
+		}
+		for _, path := range repo.DriftDetection.Paths {
+			request.Paths = append(request.Paths, models.DriftDetectionPath{Directory: path.Directory, Workspace: path.Workspace})
+		}
+		if validationErrors := request.Validate(); len(validationErrors) > 0 {
+			return fmt.Errorf("configuring drift_detection for %s: %s: %s", repo.ID, validationErrors[0].Field, validationErrors[0].Message)
+		}
+
+		logger.Info("Scheduling drift detection for %s at ref %s with schedule %q", repo.ID, request.Ref, repo.DriftDetection.Schedule)
+		executor.AddJob(scheduled.JobDefinition{
+			Job:    drift.NewScheduledDetectionJob(detector, request, repo.DriftDetection.Schedule, repo.DriftDetection.Jitter, logger),
+			Period: drift.ScheduleCheckPeriod,
+		})
+	}
+	return nil
+}
+
+// driftDetectionRepo splits a server-side repo config id such as
+// github.com/owner/repo into the VCS host type and repository name by
+// matching its hostname against the configured VCS hosts.
+func driftDetectionRepo(repoID string, userConfig UserConfig) (models.VCSHostType, string, error) {
+	hostname, repository, ok := strings.Cut(repoID, "/")
+	if !ok || repository == "" {
+		return 0, "", errors.New("repo id must be of the form hostname/owner/repo")
+	}
+	if userConfig.GithubUser != "" || userConfig.GithubAppID != 0 {
+		if hostname == userConfig.GithubHostname {
+			return models.Github, repository, nil
+		}
+	}
+	if userConfig.GitlabUser != "" && hostname == userConfig.GitlabHostname {
+		return models.Gitlab, repository, nil
+	}
+	if userConfig.GiteaToken != "" {
+		if giteaURL, err := url.Parse(userConfig.GiteaBaseURL); err == nil && hostname == giteaURL.Host {
+			return models.Gitea, repository, nil
+		}
+	}
+	return 0, "", fmt.Errorf("no GitHub, GitLab or Gitea host is configured for hostname %q", hostname)
+}
+
 // Healthz returns the health check response. It always returns 200 if the
 // process is running. Use /readyz for dependency health checks.
 // Suitable for K8s liveness probes (should not depend on external services).
@@ -12,6 +12,7 @@ import (
 
 	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/db/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -91,3 +92,39 @@ func TestServer_CloseDatabase(t *testing.T) {
 		})
 	}
 }
+
+func TestDriftDetectionRepo(t *testing.T) {
+	userConfig := UserConfig{
+		GithubUser:     "user",
+		GithubHostname: "github.com",
+		GitlabUser:     "user",
+		GitlabHostname: "gitlab.example.com",
+		GiteaToken:     "token",
+		GiteaBaseURL:   "https://gitea.example.com",
+	}
+
+	cases := []struct {
+		repoID      string
+		expType     models.VCSHostType
+		expRepo     string
+		expectedErr string
+	}{
+		{repoID: "github.com/owner/repo", expType: models.Github, expRepo: "owner/repo"},
+		{repoID: "gitlab.example.com/group/sub/repo", expType: models.Gitlab, expRepo: "group/sub/repo"},
+		{repoID: "gitea.example.com/owner/repo", expType: models.Gitea, expRepo: "owner/repo"},
+		{repoID: "bitbucket.org/owner/repo", expectedErr: `no GitHub, GitLab or Gitea host is configured for hostname "bitbucket.org"`},
+		{repoID: "github.com", expectedErr: "repo id must be of the form hostname/owner/repo"},
+	}
+	for _, c := range cases {
+		t.Run(c.repoID, func(t *testing.T) {
+			vcsType, repository, err := driftDetectionRepo(c.repoID, userConfig)
+			if c.expectedErr != "" {
+				assert.EqualError(t, err, c.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expType, vcsType)
+			assert.Equal(t, c.expRepo, repository)
+		})
+	}
+}
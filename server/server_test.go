@@ -351,6 +351,65 @@ func TestParseAtlantisURL(t *testing.T) {
 	}
 }
 
+func TestSetupRoutes_SlackInteractionsRoute(t *testing.T) {
+	newServer := func(slack *controllers.SlackInteractionsController) *server.Server {
+		s := &server.Server{
+			Router:                      mux.NewRouter(),
+			APIController:               &controllers.APIController{},
+			StatusController:            &controllers.StatusController{},
+			LocksController:             &controllers.LocksController{},
+			GithubAppController:         &controllers.GithubAppController{},
+			JobsController:              &controllers.JobsController{},
+			VCSEventsController:         &events_controllers.VCSEventsController{},
+			SlackInteractionsController: slack,
+			Logger:                      logging.NewNoopLogger(t),
+		}
+		s.SetupRoutes()
+		return s
+	}
+	req, err := http.NewRequest("POST", "/slack/interactions", nil)
+	Ok(t, err)
+
+	var match mux.RouteMatch
+	Assert(t, !newServer(nil).Router.Match(req, &match), "route should not be registered without Slack interactivity")
+	Assert(t, newServer(&controllers.SlackInteractionsController{}).Router.Match(req, &match), "route should be registered")
+}
+
+func TestSetupRoutes_DriftDashboardRoutes(t *testing.T) {
+	newServer := func(dashboard *controllers.DriftDashboardController) *server.Server {
+		s := &server.Server{
+			Router:                   mux.NewRouter(),
+			APIController:            &controllers.APIController{},
+			StatusController:         &controllers.StatusController{},
+			LocksController:          &controllers.LocksController{},
+			GithubAppController:      &controllers.GithubAppController{},
+			JobsController:           &controllers.JobsController{},
+			VCSEventsController:      &events_controllers.VCSEventsController{},
+			DriftDashboardController: dashboard,
+			Logger:                   logging.NewNoopLogger(t),
+		}
+		s.SetupRoutes()
+		return s
+	}
+	matches := func(s *server.Server, method, path string) bool {
+		req, err := http.NewRequest(method, path, nil)
+		Ok(t, err)
+		var match mux.RouteMatch
+		return s.Router.Match(req, &match)
+	}
+
+	disabled := newServer(nil)
+	Assert(t, !matches(disabled, "GET", "/drift"), "dashboard should not be registered without drift detection")
+
+	readOnly := newServer(&controllers.DriftDashboardController{})
+	Assert(t, matches(readOnly, "GET", "/drift"), "dashboard should be registered")
+	Assert(t, matches(readOnly, "GET", "/drift/plan"), "plan view should be registered")
+	Assert(t, !matches(readOnly, "POST", "/drift/remediate"), "remediation should not be registered without a remediator")
+
+	withRemediation := newServer(&controllers.DriftDashboardController{Remediator: &controllers.APIController{}})
+	Assert(t, matches(withRemediation, "POST", "/drift/remediate"), "remediation should be registered")
+}
+
 func TestSetupRoutes_APIRoutesRegistered(t *testing.T) {
 	t.Log("All API routes should be registered after SetupRoutes()")
 
@@ -0,0 +1,119 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing creates OpenTelemetry spans for the work Atlantis does to
+// run a command and exports them over OTLP.
+//
+// Most of Atlantis passes a logging.SimpleLogging rather than a
+// context.Context, so spans are carried from one call to the next on the
+// logger. See StartLog.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDKey is the logger field that trace IDs are added under.
+const TraceIDKey = "trace_id"
+
+const tracerName = "github.com/runatlantis/atlantis"
+
+// Setup exports spans to the OTLP/HTTP endpoint, e.g.
+// http://localhost:4318. The exporter also reads the standard
+// OTEL_EXPORTER_OTLP_* environment variables, e.g. for headers. The returned
+// function flushes any buffered spans and should be called on shutdown.
+func Setup(endpoint string, atlantisVersion string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("atlantis"),
+		semconv.ServiceVersion(atlantisVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in ctx. Until Setup is called
+// spans aren't recorded.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartRequest starts a span for an incoming request, continuing the trace in
+// its traceparent header if it has one.
+func StartRequest(r *http.Request, name string) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return Start(ctx, name,
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+	)
+}
+
+// StartLog starts a span as a child of the span carried by logger and returns
+// a logger that carries the new span.
+func StartLog(logger logging.SimpleLogging, name string, attrs ...attribute.KeyValue) (logging.SimpleLogging, trace.Span) {
+	parent := logging.Context(logger)
+	ctx, span := Start(parent, name, attrs...)
+	if !span.SpanContext().IsValid() {
+		// Tracing isn't set up so there's nothing to carry.
+		return logger, span
+	}
+	if trace.SpanContextFromContext(parent).IsValid() {
+		// The logger already has this trace's ID.
+		return logging.WithContext(logger, ctx), span
+	}
+	return Logger(logger, ctx), span
+}
+
+// Logger returns a copy of logger that carries the span in ctx and has the
+// span's trace ID as a field. If ctx doesn't have a span, logger is returned
+// as is.
+func Logger(logger logging.SimpleLogging, ctx context.Context) logging.SimpleLogging {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return logger
+	}
+	return logging.WithContext(logger.With(TraceIDKey, sc.TraceID().String()), ctx)
+}
+
+// TraceID returns the ID of the trace carried by logger, or "" if it doesn't
+// carry one.
+func TraceID(logger logging.SimpleLogging) string {
+	sc := trace.SpanContextFromContext(logging.Context(logger))
+	if !sc.IsValid() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// End marks span as failed if err isn't nil and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/tracing"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartLog_Disabled(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	spanLogger, span := tracing.StartLog(logger, "test")
+	defer span.End()
+	Assert(t, spanLogger == logger, "expected logger to be returned as is")
+	Equals(t, "", tracing.TraceID(spanLogger))
+}
+
+func TestStartLog(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	logger := logging.NewNoopLogger(t)
+	parentLogger, parent := tracing.StartLog(logger, "parent")
+	traceID := parent.SpanContext().TraceID().String()
+	Equals(t, traceID, tracing.TraceID(parentLogger))
+
+	childLogger, child := tracing.StartLog(parentLogger, "child")
+	Equals(t, traceID, tracing.TraceID(childLogger))
+	tracing.End(child, errors.New("failed"))
+	tracing.End(parent, nil)
+
+	spans := recorder.Ended()
+	Equals(t, 2, len(spans))
+	Equals(t, "child", spans[0].Name())
+	Equals(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	Equals(t, codes.Error, spans[0].Status().Code)
+	Equals(t, codes.Unset, spans[1].Status().Code)
+}
+
+// Test that lines logged through a span's logger end up in the history of the
+// command's logger, which is rendered in --verbose comments.
+func TestStartLog_KeepsHistory(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	ctx, span := tracing.Start(context.Background(), "command")
+	defer span.End()
+	logger := logging.WithContext(logging.NewNoopLogger(t).WithHistory("repo", "owner/repo"), ctx)
+
+	logger.Info("before")
+	childLogger, child := tracing.StartLog(logger, "child")
+	childLogger.Info("inside")
+	child.End()
+	logger.Info("after")
+
+	Equals(t, "[INFO] before\n[INFO] inside\n[INFO] after\n", logger.GetHistory())
+}
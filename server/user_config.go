@@ -21,6 +21,7 @@ type UserConfig struct {
 	AllowCommands               string `mapstructure:"allow-commands"`
 	BlockedExtraArgs            string `mapstructure:"blocked-extra-args"`
 	AtlantisURL                 string `mapstructure:"atlantis-url"`
+	AuditLogFile                string `mapstructure:"audit-log-file"`
 	AutoDiscoverModeFlag        string `mapstructure:"autodiscover-mode"`
 	Automerge                   bool   `mapstructure:"automerge"`
 	AutomergeMethod             string `mapstructure:"automerge-method"`
@@ -39,6 +40,7 @@ type UserConfig struct {
 	BitbucketWebhookSecret      string `mapstructure:"bitbucket-webhook-secret"`
 	CheckoutDepth               int    `mapstructure:"checkout-depth"`
 	CheckoutStrategy            string `mapstructure:"checkout-strategy"`
+	CostPricingFile             string `mapstructure:"cost-pricing-file"`
 	DataDir                     string `mapstructure:"data-dir"`
 	DisableApplyAll             bool   `mapstructure:"disable-apply-all"`
 	DisableAutoplan             bool   `mapstructure:"disable-autoplan"`
@@ -52,15 +54,22 @@ type UserConfig struct {
 	EmojiReaction               string `mapstructure:"emoji-reaction"`
 	EnablePolicyChecksFlag      bool   `mapstructure:"enable-policy-checks"`
 	EnableRegExpCmd             bool   `mapstructure:"enable-regexp-cmd"`
+	EnableLockQueue             bool   `mapstructure:"enable-lock-queue"`
 	EnableProfilingAPI          bool   `mapstructure:"enable-profiling-api"`
 	EnableDiffMarkdownFormat    bool   `mapstructure:"enable-diff-markdown-format"`
 	EnableDriftDetection        bool   `mapstructure:"enable-drift-detection"`
 	EnableDriftRemediation      bool   `mapstructure:"enable-drift-remediation"`
-	ExecutableName              string `mapstructure:"executable-name"`
+	DriftRemediationWorkers     int    `mapstructure:"drift-remediation-workers"`
+	// DriftRemediationRetentionDays and DriftRemediationMaxResults limit how
+	// much finished remediation history is kept.
+	DriftRemediationRetentionDays int    `mapstructure:"drift-remediation-retention-days"`
+	DriftRemediationMaxResults    int    `mapstructure:"drift-remediation-max-results"`
+	ExecutableName                string `mapstructure:"executable-name"`
 	// Fail and do not run the Atlantis command request if any of the pre workflow hooks error.
 	FailOnPreWorkflowHookError      bool   `mapstructure:"fail-on-pre-workflow-hook-error"`
 	HideUnchangedPlanComments       bool   `mapstructure:"hide-unchanged-plan-comments"`
 	GithubAllowMergeableBypassApply bool   `mapstructure:"gh-allow-mergeable-bypass-apply"`
+	GithubCheckRuns                 bool   `mapstructure:"gh-check-runs"`
 	GithubHostname                  string `mapstructure:"gh-hostname"`
 	GithubToken                     string `mapstructure:"gh-token"`
 	GithubTokenFile                 string `mapstructure:"gh-token-file"`
@@ -99,6 +108,7 @@ type UserConfig struct {
 	ParallelApply                   bool   `mapstructure:"parallel-apply"`
 	PendingApplyStatus              bool   `mapstructure:"pending-apply-status"`
 	StatsNamespace                  string `mapstructure:"stats-namespace"`
+	TracingOTLPEndpoint             string `mapstructure:"tracing-otlp-endpoint"`
 	PlanDrafts                      bool   `mapstructure:"allow-draft-prs"`
 	EnableExternalStores            bool   `mapstructure:"enable-external-stores"`
 	Port                            int    `mapstructure:"port"`
@@ -123,31 +133,37 @@ type UserConfig struct {
 	SilenceVCSStatusNoPlans bool `mapstructure:"silence-vcs-status-no-plans"`
 	// SilenceVCSStatusNoProjects is whether autoplan should set commit status if no projects
 	// are found.
-	SilenceVCSStatusNoProjects bool            `mapstructure:"silence-vcs-status-no-projects"`
-	SilenceAllowlistErrors     bool            `mapstructure:"silence-allowlist-errors"`
-	SkipCloneNoChanges         bool            `mapstructure:"skip-clone-no-changes"`
-	SlackToken                 string          `mapstructure:"slack-token"`
-	SSLCertFile                string          `mapstructure:"ssl-cert-file"`
-	SSLKeyFile                 string          `mapstructure:"ssl-key-file"`
-	RestrictFileList           bool            `mapstructure:"restrict-file-list"`
-	TFDistribution             string          `mapstructure:"tf-distribution"` // deprecated in favor of DefaultTFDistribution
-	TFDownload                 bool            `mapstructure:"tf-download"`
-	TFDownloadURL              string          `mapstructure:"tf-download-url"`
-	TFEHostname                string          `mapstructure:"tfe-hostname"`
-	TFELocalExecutionMode      bool            `mapstructure:"tfe-local-execution-mode"`
-	TFEToken                   string          `mapstructure:"tfe-token"`
-	VarFileAllowlist           string          `mapstructure:"var-file-allowlist"`
-	VCSStatusName              string          `mapstructure:"vcs-status-name"`
-	DefaultTFDistribution      string          `mapstructure:"default-tf-distribution"`
-	DefaultTFVersion           string          `mapstructure:"default-tf-version"`
-	Webhooks                   []WebhookConfig `mapstructure:"webhooks" flag:"false"`
-	WebhookHttpHeaders         string          `mapstructure:"webhook-http-headers"`
-	WebBasicAuth               bool            `mapstructure:"web-basic-auth"`
-	WebUsername                string          `mapstructure:"web-username"`
-	WebPassword                string          `mapstructure:"web-password"`
-	WriteGitCreds              bool            `mapstructure:"write-git-creds"`
-	WebsocketCheckOrigin       bool            `mapstructure:"websocket-check-origin"`
-	UseTFPluginCache           bool            `mapstructure:"use-tf-plugin-cache"`
+	SilenceVCSStatusNoProjects bool                  `mapstructure:"silence-vcs-status-no-projects"`
+	SilenceAllowlistErrors     bool                  `mapstructure:"silence-allowlist-errors"`
+	SkipCloneNoChanges         bool                  `mapstructure:"skip-clone-no-changes"`
+	SlackRemediationAllowlist  string                `mapstructure:"slack-remediation-allowlist"`
+	SlackSigningSecret         string                `mapstructure:"slack-signing-secret"`
+	SlackToken                 string                `mapstructure:"slack-token"`
+	SSLCertFile                string                `mapstructure:"ssl-cert-file"`
+	SSLKeyFile                 string                `mapstructure:"ssl-key-file"`
+	RestrictFileList           bool                  `mapstructure:"restrict-file-list"`
+	TFDistribution             string                `mapstructure:"tf-distribution"` // deprecated in favor of DefaultTFDistribution
+	TFDownload                 bool                  `mapstructure:"tf-download"`
+	TFDownloadURL              string                `mapstructure:"tf-download-url"`
+	TFEHostname                string                `mapstructure:"tfe-hostname"`
+	TFELocalExecutionMode      bool                  `mapstructure:"tfe-local-execution-mode"`
+	TFEToken                   string                `mapstructure:"tfe-token"`
+	VarFileAllowlist           string                `mapstructure:"var-file-allowlist"`
+	VCSStatusName              string                `mapstructure:"vcs-status-name"`
+	DefaultTFDistribution      string                `mapstructure:"default-tf-distribution"`
+	DefaultTFVersion           string                `mapstructure:"default-tf-version"`
+	Webhooks                   []WebhookConfig       `mapstructure:"webhooks" flag:"false"`
+	APITokens                  []APITokenConfig      `mapstructure:"api-tokens" flag:"false"`
+	APIOIDCIssuers             []APIOIDCIssuerConfig `mapstructure:"api-oidc-issuers" flag:"false"`
+	WebhookHttpHeaders         string                `mapstructure:"webhook-http-headers"`
+	WebBasicAuth               bool                  `mapstructure:"web-basic-auth"`
+	WebUsername                string                `mapstructure:"web-username"`
+	WebPassword                string                `mapstructure:"web-password"`
+	WriteGitCreds              bool                  `mapstructure:"write-git-creds"`
+	WebsocketCheckOrigin       bool                  `mapstructure:"websocket-check-origin"`
+	UseTFPluginCache           bool                  `mapstructure:"use-tf-plugin-cache"`
+	TFPluginCacheMaxSizeMB     int                   `mapstructure:"tf-plugin-cache-max-size-mb"`
+	TFProviderMirrorDir        string                `mapstructure:"tf-provider-mirror-dir"`
 }
 
 // ToAllowCommandNames parse AllowCommands into a slice of CommandName
@@ -189,6 +205,18 @@ func (u UserConfig) ToBlockedExtraArgs() []string {
 	return args
 }
 
+// ToSlackRemediationAllowlist returns the Slack user IDs in
+// SlackRemediationAllowlist.
+func (u UserConfig) ToSlackRemediationAllowlist() []string {
+	var users []string
+	for user := range strings.SplitSeq(u.SlackRemediationAllowlist, ",") {
+		if trimmed := strings.TrimSpace(user); trimmed != "" {
+			users = append(users, trimmed)
+		}
+	}
+	return users
+}
+
 // ToWebhookHttpHeaders parses WebhookHttpHeaders into a map of HTTP headers.
 func (u UserConfig) ToWebhookHttpHeaders() (map[string][]string, error) {
 	if u.WebhookHttpHeaders == "" {
@@ -117,6 +117,12 @@ func TestUserConfig_ToBlockedExtraArgs(t *testing.T) {
 	}
 }
 
+func TestUserConfig_ToSlackRemediationAllowlist(t *testing.T) {
+	assert.Nil(t, server.UserConfig{}.ToSlackRemediationAllowlist())
+	u := server.UserConfig{SlackRemediationAllowlist: " U012AB3CD, ,U045EF6GH "}
+	assert.Equal(t, []string{"U012AB3CD", "U045EF6GH"}, u.ToSlackRemediationAllowlist())
+}
+
 func TestUserConfig_ToWebhookHttpHeaders(t *testing.T) {
 	tcs := []struct {
 		name  string